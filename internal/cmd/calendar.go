@@ -22,16 +22,22 @@ type CalendarCmd struct {
 	Delete          CalendarDeleteCmd          `cmd:"" name:"delete" aliases:"rm,del,remove" help:"Delete an event"`
 	FreeBusy        CalendarFreeBusyCmd        `cmd:"" name:"freebusy" help:"Get free/busy"`
 	Respond         CalendarRespondCmd         `cmd:"" name:"respond" aliases:"rsvp,reply" help:"Respond to an event invitation"`
+	Bulk            CalendarBulkCmd            `cmd:"" name:"bulk" help:"Update, delete, or respond to every event matching a query"`
 	ProposeTime     CalendarProposeTimeCmd     `cmd:"" name:"propose-time" help:"Generate URL to propose a new meeting time (browser-only feature)"`
 	Colors          CalendarColorsCmd          `cmd:"" name:"colors" help:"Show calendar colors"`
 	Conflicts       CalendarConflictsCmd       `cmd:"" name:"conflicts" help:"Find conflicts"`
 	Search          CalendarSearchCmd          `cmd:"" name:"search" aliases:"find,query" help:"Search events"`
 	Time            CalendarTimeCmd            `cmd:"" name:"time" help:"Show server time"`
 	Users           CalendarUsersCmd           `cmd:"" name:"users" help:"List workspace users (use their email as calendar ID)"`
+	Resources       CalendarResourcesCmd       `cmd:"" name:"resources" aliases:"rooms" help:"List, search, and check availability of rooms/resources (Workspace)"`
 	Team            CalendarTeamCmd            `cmd:"" name:"team" help:"Show events for all members of a Google Group"`
 	FocusTime       CalendarFocusTimeCmd       `cmd:"" name:"focus-time" aliases:"focus" help:"Create a Focus Time block"`
 	OOO             CalendarOOOCmd             `cmd:"" name:"out-of-office" aliases:"ooo" help:"Create an Out of Office event"`
 	WorkingLocation CalendarWorkingLocationCmd `cmd:"" name:"working-location" aliases:"wl" help:"Set working location (home/office/custom)"`
+	Report          CalendarReportCmd          `cmd:"" name:"report" aliases:"stats" help:"Summarize meeting load (hours, 1:1s, external) by week/attendee/domain/color/recurring"`
+	Changes         CalendarChangesCmd         `cmd:"" name:"changes" help:"Emit events changed since the last run (JSONL, uses stored sync tokens)"`
+	Watch           CalendarWatchCmd           `cmd:"" name:"watch" help:"Receive push notifications for calendar changes"`
+	Sync            CalendarSyncCmd            `cmd:"" name:"sync" aliases:"mirror" help:"Mirror events from one calendar (or account) into another"`
 }
 
 type CalendarCalendarsCmd struct {
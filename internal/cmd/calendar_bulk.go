@@ -0,0 +1,493 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	calendarBulkStatusOK      = "ok"
+	calendarBulkStatusSkipped = "skipped"
+	calendarBulkStatusFailed  = "failed"
+)
+
+type CalendarBulkCmd struct {
+	Update  CalendarBulkUpdateCmd  `cmd:"" name:"update" aliases:"edit,set" help:"Update every event matching the filters"`
+	Delete  CalendarBulkDeleteCmd  `cmd:"" name:"delete" aliases:"rm,del,remove" help:"Delete every event matching the filters"`
+	Respond CalendarBulkRespondCmd `cmd:"" name:"respond" aliases:"rsvp,reply" help:"Respond to every invitation matching the filters"`
+}
+
+// CalendarBulkSelector selects events with the same filters as `calendar events`.
+// Recurring events are expanded, so each matched occurrence is handled on its own.
+type CalendarBulkSelector struct {
+	Cal               []string `name:"cal" help:"Calendar ID or name (can be repeated; default: primary)"`
+	Calendars         string   `name:"calendars" help:"Comma-separated calendar IDs, names, or indices from 'calendar calendars'"`
+	AllCalendars      bool     `name:"all-calendars" help:"Match events from all calendars"`
+	Query             string   `name:"query" help:"Free text search"`
+	PrivatePropFilter string   `name:"private-prop-filter" help:"Filter by private extended property (key=value)"`
+	SharedPropFilter  string   `name:"shared-prop-filter" help:"Filter by shared extended property (key=value)"`
+	Max               int      `name:"max" aliases:"limit" help:"Refuse to act when more than N events match" default:"100"`
+	TimeRangeFlags
+}
+
+type calendarBulkTarget struct {
+	CalendarID string
+	Event      *calendar.Event
+}
+
+type calendarBulkResult struct {
+	CalendarID string `json:"calendarId"`
+	EventID    string `json:"eventId"`
+	Summary    string `json:"summary,omitempty"`
+	Start      string `json:"start,omitempty"`
+	Status     string `json:"status"`
+	Reason     string `json:"reason,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+func (s *CalendarBulkSelector) calendarIDs(ctx context.Context, svc *calendar.Service) ([]string, error) {
+	inputs := append([]string{}, s.Cal...)
+	if strings.TrimSpace(s.Calendars) != "" {
+		inputs = append(inputs, splitCSV(s.Calendars)...)
+	}
+	if s.AllCalendars {
+		if len(inputs) > 0 {
+			return nil, usage("--cal/--calendars not allowed with --all-calendars")
+		}
+		calendars, err := listCalendarList(ctx, svc)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]string, 0, len(calendars))
+		for _, cal := range calendars {
+			if cal != nil && strings.TrimSpace(cal.Id) != "" {
+				ids = append(ids, cal.Id)
+			}
+		}
+		return ids, nil
+	}
+	if len(inputs) == 0 {
+		return []string{primaryCalendarID}, nil
+	}
+	return resolveCalendarIDs(ctx, svc, inputs)
+}
+
+// selectEvents lists every matching, non-cancelled event and enforces --max.
+func (s *CalendarBulkSelector) selectEvents(ctx context.Context, svc *calendar.Service) ([]calendarBulkTarget, error) {
+	ids, err := s.calendarIDs(ctx, svc)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, usage("no calendars specified")
+	}
+
+	tr, err := ResolveTimeRange(ctx, svc, s.TimeRangeFlags)
+	if err != nil {
+		return nil, err
+	}
+	from, to := tr.FormatRFC3339()
+
+	var targets []calendarBulkTarget
+	for _, calID := range ids {
+		fetch := func(pageToken string) ([]*calendar.Event, string, error) {
+			resp, err := calendarEventsListCall(ctx, svc, calID, from, to, 250, s.Query, s.PrivatePropFilter, s.SharedPropFilter, "", pageToken).Do()
+			if err != nil {
+				return nil, "", err
+			}
+			return resp.Items, resp.NextPageToken, nil
+		}
+		events, err := collectAllPages("", fetch)
+		if err != nil {
+			return nil, fmt.Errorf("calendar %s: %w", calID, err)
+		}
+		for _, ev := range events {
+			if ev == nil || ev.Status == eventStatusCancelled {
+				continue
+			}
+			targets = append(targets, calendarBulkTarget{CalendarID: calID, Event: ev})
+		}
+	}
+
+	if s.Max > 0 && len(targets) > s.Max {
+		return nil, usagef("%d events match (more than --max %d); narrow the filters or raise --max", len(targets), s.Max)
+	}
+	return targets, nil
+}
+
+func calendarBulkPreview(targets []calendarBulkTarget) []calendarBulkResult {
+	out := make([]calendarBulkResult, 0, len(targets))
+	for _, t := range targets {
+		out = append(out, calendarBulkResult{
+			CalendarID: t.CalendarID,
+			EventID:    t.Event.Id,
+			Summary:    t.Event.Summary,
+			Start:      eventStart(t.Event),
+		})
+	}
+	return out
+}
+
+// confirmCalendarBulk shows the matched set, honours --dry-run, and asks for
+// confirmation before anything is changed.
+func confirmCalendarBulk(ctx context.Context, flags *RootFlags, op, action string, targets []calendarBulkTarget, request map[string]any) error {
+	preview := calendarBulkPreview(targets)
+	if !outfmt.IsJSON(ctx) {
+		u := ui.FromContext(ctx)
+		u.Err().Printf("Matched %d event(s):", len(preview))
+		for _, p := range preview {
+			u.Err().Printf("  %s\t%s\t%s\t%s", p.CalendarID, p.EventID, p.Start, orEmpty(p.Summary, "(no title)"))
+		}
+	}
+
+	if request == nil {
+		request = map[string]any{}
+	}
+	request["events"] = preview
+	if err := dryRunExit(ctx, flags, op, request); err != nil {
+		return err
+	}
+	return confirmDestructive(ctx, flags, fmt.Sprintf("%s %d event(s)", action, len(targets)))
+}
+
+func runCalendarBulk(targets []calendarBulkTarget, apply func(t calendarBulkTarget) (string, string, error)) []calendarBulkResult {
+	results := make([]calendarBulkResult, 0, len(targets))
+	for _, t := range targets {
+		r := calendarBulkResult{
+			CalendarID: t.CalendarID,
+			EventID:    t.Event.Id,
+			Summary:    t.Event.Summary,
+			Start:      eventStart(t.Event),
+		}
+		status, reason, err := apply(t)
+		if err != nil {
+			r.Status = calendarBulkStatusFailed
+			r.Error = err.Error()
+		} else {
+			r.Status = status
+			r.Reason = reason
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+func writeCalendarBulkResults(ctx context.Context, op string, results []calendarBulkResult) error {
+	u := ui.FromContext(ctx)
+	counts := map[string]int{}
+	for _, r := range results {
+		counts[r.Status]++
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"op":        op,
+			"matched":   len(results),
+			"results":   results,
+			"succeeded": counts[calendarBulkStatusOK],
+			"skipped":   counts[calendarBulkStatusSkipped],
+			"failed":    counts[calendarBulkStatusFailed],
+		}); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			switch r.Status {
+			case calendarBulkStatusFailed:
+				u.Err().Errorf("%s: %s", r.EventID, r.Error)
+			case calendarBulkStatusSkipped:
+				u.Out().Printf("%s\t%s\t%s", r.EventID, r.Status, r.Reason)
+			default:
+				u.Out().Printf("%s\t%s", r.EventID, r.Status)
+			}
+		}
+		u.Err().Printf("%d succeeded, %d skipped, %d failed", counts[calendarBulkStatusOK], counts[calendarBulkStatusSkipped], counts[calendarBulkStatusFailed])
+	}
+
+	if failed := counts[calendarBulkStatusFailed]; failed > 0 {
+		return fmt.Errorf("%d of %d event(s) failed", failed, len(results))
+	}
+	return nil
+}
+
+// fullCalendarBulkEvent refetches events whose attendee list was truncated by
+// the list call, so attendee edits don't drop guests.
+func fullCalendarBulkEvent(ctx context.Context, svc *calendar.Service, t calendarBulkTarget) (*calendar.Event, error) {
+	if !t.Event.AttendeesOmitted {
+		return t.Event, nil
+	}
+	return svc.Events.Get(t.CalendarID, t.Event.Id).Context(ctx).Do()
+}
+
+func bulkCalendarService(ctx context.Context, flags *RootFlags) (*calendar.Service, error) {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return nil, err
+	}
+	return newCalendarService(ctx, account)
+}
+
+type CalendarBulkUpdateCmd struct {
+	CalendarBulkSelector
+	Summary        string `name:"summary" help:"New summary/title (set empty to clear)"`
+	Description    string `name:"description" help:"New description (set empty to clear)"`
+	Location       string `name:"location" help:"New location (set empty to clear)"`
+	AddAttendee    string `name:"add-attendee" help:"Comma-separated attendee emails to add (preserves existing attendees)"`
+	RemoveAttendee string `name:"remove-attendee" help:"Comma-separated attendee emails to remove"`
+	ColorId        string `name:"event-color" help:"Event color ID (1-11)"`
+	Visibility     string `name:"visibility" help:"Event visibility: default, public, private, confidential"`
+	Transparency   string `name:"transparency" help:"Show as busy (opaque) or free (transparent). Aliases: busy, free"`
+	SendUpdates    string `name:"send-updates" help:"Notification mode: all, externalOnly, none (default: none)"`
+}
+
+func (c *CalendarBulkUpdateCmd) Run(ctx context.Context, kctx *kong.Context, flags *RootFlags) error {
+	patch, changed, err := c.buildPatch(kctx)
+	if err != nil {
+		return err
+	}
+	addCSV := strings.TrimSpace(c.AddAttendee)
+	removeCSV := strings.TrimSpace(c.RemoveAttendee)
+	if !changed && addCSV == "" && removeCSV == "" {
+		return usage("no updates provided")
+	}
+	sendUpdates, err := validateSendUpdates(c.SendUpdates)
+	if err != nil {
+		return err
+	}
+
+	svc, err := bulkCalendarService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	targets, err := c.selectEvents(ctx, svc)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		ui.FromContext(ctx).Err().Println("No matching events")
+		return nil
+	}
+
+	if err := confirmCalendarBulk(ctx, flags, "calendar.bulk.update", "update", targets, map[string]any{
+		"patch":           patch,
+		"add_attendee":    addCSV,
+		"remove_attendee": removeCSV,
+		"send_updates":    sendUpdates,
+	}); err != nil {
+		return err
+	}
+
+	results := runCalendarBulk(targets, func(t calendarBulkTarget) (string, string, error) {
+		eventPatch := *patch
+		if addCSV != "" || removeCSV != "" {
+			ev, err := fullCalendarBulkEvent(ctx, svc, t)
+			if err != nil {
+				return "", "", err
+			}
+			attendees, attendeesChanged := mergeAttendeesWithChange(ev.Attendees, addCSV)
+			attendees, removed := removeAttendees(attendees, removeCSV)
+			if attendeesChanged || removed {
+				eventPatch.Attendees = attendees
+				if len(attendees) == 0 {
+					eventPatch.NullFields = append(eventPatch.NullFields, "Attendees")
+				}
+			} else if !changed {
+				return calendarBulkStatusSkipped, "attendees already up to date", nil
+			}
+		}
+		call := svc.Events.Patch(t.CalendarID, t.Event.Id, &eventPatch).Context(ctx)
+		if sendUpdates != "" {
+			call = call.SendUpdates(sendUpdates)
+		}
+		if _, err := call.Do(); err != nil {
+			return "", "", err
+		}
+		return calendarBulkStatusOK, "", nil
+	})
+	return writeCalendarBulkResults(ctx, "update", results)
+}
+
+func (c *CalendarBulkUpdateCmd) buildPatch(kctx *kong.Context) (*calendar.Event, bool, error) {
+	patch := &calendar.Event{}
+	changed := false
+	if flagProvided(kctx, "summary") {
+		patch.Summary = strings.TrimSpace(c.Summary)
+		patch.ForceSendFields = append(patch.ForceSendFields, "Summary")
+		changed = true
+	}
+	if flagProvided(kctx, "description") {
+		patch.Description = strings.TrimSpace(c.Description)
+		patch.ForceSendFields = append(patch.ForceSendFields, "Description")
+		changed = true
+	}
+	if flagProvided(kctx, "location") {
+		patch.Location = strings.TrimSpace(c.Location)
+		patch.ForceSendFields = append(patch.ForceSendFields, "Location")
+		changed = true
+	}
+	if strings.TrimSpace(c.ColorId) != "" {
+		colorID, err := validateColorId(c.ColorId)
+		if err != nil {
+			return nil, false, err
+		}
+		patch.ColorId = colorID
+		changed = true
+	}
+	if strings.TrimSpace(c.Visibility) != "" {
+		visibility, err := validateVisibility(c.Visibility)
+		if err != nil {
+			return nil, false, err
+		}
+		patch.Visibility = visibility
+		changed = true
+	}
+	if strings.TrimSpace(c.Transparency) != "" {
+		transparency, err := validateTransparency(c.Transparency)
+		if err != nil {
+			return nil, false, err
+		}
+		patch.Transparency = transparency
+		changed = true
+	}
+	return patch, changed, nil
+}
+
+// removeAttendees drops attendees whose email appears in removeCSV and reports
+// whether anything was removed.
+func removeAttendees(existing []*calendar.EventAttendee, removeCSV string) ([]*calendar.EventAttendee, bool) {
+	emails := splitCSV(removeCSV)
+	if len(emails) == 0 {
+		return existing, false
+	}
+	drop := make(map[string]bool, len(emails))
+	for _, e := range emails {
+		drop[strings.ToLower(strings.TrimSpace(e))] = true
+	}
+	out := make([]*calendar.EventAttendee, 0, len(existing))
+	removed := false
+	for _, a := range existing {
+		if a != nil && drop[strings.ToLower(a.Email)] {
+			removed = true
+			continue
+		}
+		out = append(out, a)
+	}
+	return out, removed
+}
+
+type CalendarBulkDeleteCmd struct {
+	CalendarBulkSelector
+	SendUpdates string `name:"send-updates" help:"Notification mode: all, externalOnly, none (default: none)"`
+}
+
+func (c *CalendarBulkDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	sendUpdates, err := validateSendUpdates(c.SendUpdates)
+	if err != nil {
+		return err
+	}
+
+	svc, err := bulkCalendarService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	targets, err := c.selectEvents(ctx, svc)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		ui.FromContext(ctx).Err().Println("No matching events")
+		return nil
+	}
+
+	if err := confirmCalendarBulk(ctx, flags, "calendar.bulk.delete", "delete", targets, map[string]any{
+		"send_updates": sendUpdates,
+	}); err != nil {
+		return err
+	}
+
+	results := runCalendarBulk(targets, func(t calendarBulkTarget) (string, string, error) {
+		call := svc.Events.Delete(t.CalendarID, t.Event.Id).Context(ctx)
+		if sendUpdates != "" {
+			call = call.SendUpdates(sendUpdates)
+		}
+		if err := call.Do(); err != nil {
+			if isCalendarEventGoneError(err) {
+				return calendarBulkStatusSkipped, "already deleted", nil
+			}
+			return "", "", err
+		}
+		return calendarBulkStatusOK, "", nil
+	})
+	return writeCalendarBulkResults(ctx, "delete", results)
+}
+
+type CalendarBulkRespondCmd struct {
+	CalendarBulkSelector
+	Status  string `name:"status" required:"" help:"Response status" enum:"accepted,declined,tentative,needsAction"`
+	Comment string `name:"comment" help:"Optional comment/note to include with each response"`
+}
+
+func (c *CalendarBulkRespondCmd) Run(ctx context.Context, flags *RootFlags) error {
+	status := strings.TrimSpace(c.Status)
+	comment := strings.TrimSpace(c.Comment)
+
+	svc, err := bulkCalendarService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	targets, err := c.selectEvents(ctx, svc)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		ui.FromContext(ctx).Err().Println("No matching events")
+		return nil
+	}
+
+	if err := confirmCalendarBulk(ctx, flags, "calendar.bulk.respond", "respond "+status+" to", targets, map[string]any{
+		"status":  status,
+		"comment": comment,
+	}); err != nil {
+		return err
+	}
+
+	results := runCalendarBulk(targets, func(t calendarBulkTarget) (string, string, error) {
+		ev, err := fullCalendarBulkEvent(ctx, svc, t)
+		if err != nil {
+			return "", "", err
+		}
+		self := -1
+		for i, a := range ev.Attendees {
+			if a != nil && a.Self {
+				self = i
+				break
+			}
+		}
+		switch {
+		case self < 0:
+			return calendarBulkStatusSkipped, "not an attendee", nil
+		case ev.Attendees[self].Organizer:
+			return calendarBulkStatusSkipped, "you are the organizer", nil
+		case ev.Attendees[self].ResponseStatus == status && (comment == "" || ev.Attendees[self].Comment == comment):
+			return calendarBulkStatusSkipped, "already " + status, nil
+		}
+		ev.Attendees[self].ResponseStatus = status
+		if comment != "" {
+			ev.Attendees[self].Comment = comment
+		}
+		// Only patch attendees, matching `calendar respond`.
+		if _, err := svc.Events.Patch(t.CalendarID, ev.Id, &calendar.Event{Attendees: ev.Attendees}).Context(ctx).Do(); err != nil {
+			return "", "", err
+		}
+		return calendarBulkStatusOK, "", nil
+	})
+	return writeCalendarBulkResults(ctx, "respond", results)
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestRemoveAttendees(t *testing.T) {
+	existing := []*calendar.EventAttendee{
+		{Email: "a@example.com", ResponseStatus: "accepted"},
+		{Email: "B@example.com"},
+	}
+	out, removed := removeAttendees(existing, "b@example.com, c@example.com")
+	if !removed || len(out) != 1 || out[0].Email != "a@example.com" {
+		t.Fatalf("unexpected result: %#v removed=%v", out, removed)
+	}
+	if _, removed := removeAttendees(existing, "nobody@example.com"); removed {
+		t.Fatalf("expected no removal")
+	}
+}
+
+func bulkTestEvents() []map[string]any {
+	return []map[string]any{
+		{
+			"id": "e1", "status": "confirmed", "summary": "Sprint review",
+			"start": map[string]any{"dateTime": "2025-03-03T10:00:00Z"},
+			"end":   map[string]any{"dateTime": "2025-03-03T11:00:00Z"},
+			"attendees": []map[string]any{
+				{"email": "me@example.com", "self": true, "responseStatus": "needsAction"},
+				{"email": "lead@example.com", "organizer": true, "responseStatus": "accepted"},
+			},
+		},
+		{
+			"id": "e2", "status": "confirmed", "summary": "Sprint review (mine)",
+			"start": map[string]any{"dateTime": "2025-03-10T10:00:00Z"},
+			"end":   map[string]any{"dateTime": "2025-03-10T11:00:00Z"},
+			"attendees": []map[string]any{
+				{"email": "me@example.com", "self": true, "organizer": true, "responseStatus": "accepted"},
+			},
+		},
+	}
+}
+
+func runBulkTest(t *testing.T, handler http.HandlerFunc, args ...string) (string, error) {
+	t.Helper()
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+	svc := newCalendarChangesTestService(t, withPrimaryCalendar(handler).ServeHTTP)
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	var runErr error
+	out := captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			runErr = Execute(append([]string{"--json", "--force", "--account", "me@example.com", "calendar", "bulk"}, args...))
+		})
+	})
+	return out, runErr
+}
+
+func TestCalendarBulkRespond_DeclinesAndSkipsOwnEvents(t *testing.T) {
+	var patched []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/calendars/primary/events"):
+			if got := r.URL.Query().Get("q"); got != "Sprint review" {
+				t.Errorf("unexpected query %q", got)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": bulkTestEvents()})
+		case r.Method == http.MethodPatch:
+			body, _ := io.ReadAll(r.Body)
+			patched = append(patched, r.URL.Path)
+			if !strings.Contains(string(body), `"responseStatus":"declined"`) {
+				t.Errorf("expected declined in patch body: %s", body)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "e1"})
+		default:
+			http.NotFound(w, r)
+		}
+	}
+
+	out, err := runBulkTest(t, handler, "respond", "--query", "Sprint review",
+		"--from", "2025-03-01T00:00:00Z", "--to", "2025-04-01T00:00:00Z", "--status", "declined")
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	var parsed struct {
+		Matched   int                  `json:"matched"`
+		Succeeded int                  `json:"succeeded"`
+		Skipped   int                  `json:"skipped"`
+		Results   []calendarBulkResult `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json parse: %v\nout=%q", err, out)
+	}
+	if parsed.Matched != 2 || parsed.Succeeded != 1 || parsed.Skipped != 1 {
+		t.Fatalf("unexpected counts: %#v", parsed)
+	}
+	if parsed.Results[1].EventID != "e2" || parsed.Results[1].Reason != "you are the organizer" {
+		t.Fatalf("unexpected skip result: %#v", parsed.Results[1])
+	}
+	if len(patched) != 1 || !strings.HasSuffix(patched[0], "/events/e1") {
+		t.Fatalf("unexpected patches: %v", patched)
+	}
+}
+
+func TestCalendarBulkDelete_ReportsPerEventFailures(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/calendars/primary/events"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": bulkTestEvents()})
+		case r.Method == http.MethodDelete && strings.HasSuffix(r.URL.Path, "/events/e1"):
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 403, "message": "forbidden"}})
+		default:
+			http.NotFound(w, r)
+		}
+	}
+
+	out, err := runBulkTest(t, handler, "delete", "--query", "Sprint review",
+		"--from", "2025-03-01T00:00:00Z", "--to", "2025-04-01T00:00:00Z")
+	if err == nil || !strings.Contains(err.Error(), "1 of 2") {
+		t.Fatalf("expected partial failure error, got %v", err)
+	}
+	var parsed struct {
+		Failed  int                  `json:"failed"`
+		Results []calendarBulkResult `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json parse: %v\nout=%q", err, out)
+	}
+	if parsed.Failed != 1 || parsed.Results[0].Status != calendarBulkStatusOK || parsed.Results[1].Status != calendarBulkStatusFailed {
+		t.Fatalf("unexpected results: %#v", parsed)
+	}
+}
+
+func TestCalendarBulkDelete_MaxGuard(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodDelete {
+			t.Errorf("unexpected delete: %s", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"items": bulkTestEvents()})
+	}
+	_, err := runBulkTest(t, handler, "delete", "--max", "1",
+		"--from", "2025-03-01T00:00:00Z", "--to", "2025-04-01T00:00:00Z")
+	if err == nil || !strings.Contains(err.Error(), "--max") {
+		t.Fatalf("expected --max guard error, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	calendarChangeCreated   = "created"
+	calendarChangeUpdated   = "updated"
+	calendarChangeCancelled = "cancelled"
+)
+
+type CalendarChangesCmd struct {
+	CalendarID  string `arg:"" name:"calendarId" optional:"" help:"Calendar ID or name (default: primary)"`
+	Reset       bool   `name:"reset" help:"Discard the stored sync token and start over with a full listing"`
+	SkipInitial bool   `name:"skip-initial" aliases:"baseline" help:"When no sync token is stored, record one without emitting existing events"`
+}
+
+type calendarChange struct {
+	Type       string          `json:"type"`
+	CalendarID string          `json:"calendarId"`
+	EventID    string          `json:"eventId"`
+	Summary    string          `json:"summary,omitempty"`
+	Start      string          `json:"start,omitempty"`
+	Updated    string          `json:"updated,omitempty"`
+	Event      *calendar.Event `json:"event,omitempty"`
+}
+
+type calendarChangesResult struct {
+	Changes  []calendarChange
+	FullSync bool
+}
+
+func (c *CalendarChangesCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	calendarID := strings.TrimSpace(c.CalendarID)
+	if calendarID == "" {
+		calendarID = primaryCalendarID
+	}
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	calendarID, err = resolveCalendarID(ctx, svc, calendarID)
+	if err != nil {
+		return err
+	}
+
+	store, err := openCalendarWatchStore(account)
+	if err != nil {
+		return err
+	}
+	if c.Reset {
+		if err := store.Update(func(s *calendarWatchState) error {
+			s.calendar(calendarID).SyncToken = ""
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	baseline := c.SkipInitial && store.CalendarState(calendarID).SyncToken == ""
+
+	result, err := collectCalendarChanges(ctx, svc, store, calendarID)
+	if err != nil {
+		return err
+	}
+	changes := result.Changes
+	if baseline {
+		changes = []calendarChange{}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"calendarId": calendarID,
+			"fullSync":   result.FullSync,
+			"changes":    changes,
+		})
+	}
+
+	// Default output is JSONL: one change per line, suitable for piping.
+	enc := json.NewEncoder(os.Stdout)
+	for _, ch := range changes {
+		if err := enc.Encode(ch); err != nil {
+			return err
+		}
+	}
+	switch {
+	case baseline:
+		u.Err().Printf("Recorded sync token for %s", calendarID)
+	case len(changes) == 0:
+		u.Err().Println("No changes")
+	}
+	return nil
+}
+
+// collectCalendarChanges fetches changes since the stored sync token (or a
+// full listing when none is stored or it expired) and persists the new token.
+func collectCalendarChanges(ctx context.Context, svc *calendar.Service, store *calendarWatchStore, calendarID string) (calendarChangesResult, error) {
+	syncToken := store.CalendarState(calendarID).SyncToken
+	events, nextSyncToken, err := fetchCalendarSyncEvents(ctx, svc, calendarID, syncToken)
+	if err != nil && syncToken != "" && isCalendarSyncTokenExpired(err) {
+		syncToken = ""
+		events, nextSyncToken, err = fetchCalendarSyncEvents(ctx, svc, calendarID, "")
+	}
+	if err != nil {
+		return calendarChangesResult{}, err
+	}
+
+	changes := make([]calendarChange, 0, len(events))
+	for _, ev := range events {
+		if ev == nil {
+			continue
+		}
+		changes = append(changes, calendarChange{
+			Type:       classifyCalendarChange(ev),
+			CalendarID: calendarID,
+			EventID:    ev.Id,
+			Summary:    ev.Summary,
+			Start:      eventStart(ev),
+			Updated:    ev.Updated,
+			Event:      ev,
+		})
+	}
+
+	if nextSyncToken != "" {
+		if err := store.Update(func(s *calendarWatchState) error {
+			cal := s.calendar(calendarID)
+			cal.SyncToken = nextSyncToken
+			cal.UpdatedAtMs = time.Now().UnixMilli()
+			return nil
+		}); err != nil {
+			return calendarChangesResult{}, err
+		}
+	}
+	return calendarChangesResult{Changes: changes, FullSync: syncToken == ""}, nil
+}
+
+// classifyCalendarChange reports whether an event was cancelled, newly
+// created, or updated. The API has no explicit flag, so events whose updated
+// timestamp is within a second of creation count as created.
+func classifyCalendarChange(ev *calendar.Event) string {
+	if ev.Status == eventStatusCancelled {
+		return calendarChangeCancelled
+	}
+	created, createdErr := time.Parse(time.RFC3339, ev.Created)
+	updated, updatedErr := time.Parse(time.RFC3339, ev.Updated)
+	if createdErr == nil && updatedErr == nil && updated.Sub(created) < time.Second {
+		return calendarChangeCreated
+	}
+	return calendarChangeUpdated
+}
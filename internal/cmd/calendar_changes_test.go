@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+func TestClassifyCalendarChange(t *testing.T) {
+	tests := []struct {
+		name string
+		ev   *calendar.Event
+		want string
+	}{
+		{name: "cancelled", ev: &calendar.Event{Status: "cancelled"}, want: calendarChangeCancelled},
+		{name: "created", ev: &calendar.Event{Created: "2025-01-01T10:00:00.000Z", Updated: "2025-01-01T10:00:00.250Z"}, want: calendarChangeCreated},
+		{name: "updated", ev: &calendar.Event{Created: "2025-01-01T10:00:00Z", Updated: "2025-01-02T10:00:00Z"}, want: calendarChangeUpdated},
+		{name: "missing timestamps", ev: &calendar.Event{}, want: calendarChangeUpdated},
+	}
+	for _, tt := range tests {
+		if got := classifyCalendarChange(tt.ev); got != tt.want {
+			t.Fatalf("%s: got %q want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func newCalendarChangesTestService(t *testing.T, handler http.HandlerFunc) *calendar.Service {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	svc, err := calendar.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	return svc
+}
+
+func TestCalendarChangesCmd_JSONLAndSyncToken(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+
+	var seenTokens []string
+	svc := newCalendarChangesTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/calendars/changes@example.com/events") {
+			http.NotFound(w, r)
+			return
+		}
+		token := r.URL.Query().Get("syncToken")
+		seenTokens = append(seenTokens, token)
+		w.Header().Set("Content-Type", "application/json")
+		switch token {
+		case "":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"items": []map[string]any{
+					{"id": "e1", "status": "confirmed", "summary": "Old", "created": "2025-01-01T00:00:00Z", "updated": "2025-01-05T00:00:00Z"},
+				},
+				"nextSyncToken": "s1",
+			})
+		case "s1":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"items": []map[string]any{
+					{"id": "e2", "status": "confirmed", "summary": "New", "created": "2025-01-06T00:00:00Z", "updated": "2025-01-06T00:00:00Z"},
+					{"id": "e1", "status": "cancelled"},
+				},
+				"nextSyncToken": "s2",
+			})
+		default:
+			w.WriteHeader(http.StatusGone)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 410, "message": "Sync token is no longer valid"}})
+		}
+	})
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	run := func(extra ...string) string {
+		args := append([]string{"--account", "changes-user@example.com", "calendar", "changes", "changes@example.com"}, extra...)
+		return captureStdout(t, func() {
+			_ = captureStderr(t, func() {
+				if err := Execute(args); err != nil {
+					t.Fatalf("Execute: %v", err)
+				}
+			})
+		})
+	}
+
+	if out := run("--skip-initial"); strings.TrimSpace(out) != "" {
+		t.Fatalf("expected no output for baseline run, got %q", out)
+	}
+
+	out := run()
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 JSONL lines, got %q", out)
+	}
+	var first, second calendarChange
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("line 1: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("line 2: %v", err)
+	}
+	if first.Type != calendarChangeCreated || first.EventID != "e2" || first.CalendarID != "changes@example.com" {
+		t.Fatalf("unexpected first change: %#v", first)
+	}
+	if second.Type != calendarChangeCancelled || second.EventID != "e1" {
+		t.Fatalf("unexpected second change: %#v", second)
+	}
+
+	store, err := openCalendarWatchStore("changes-user@example.com")
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	if got := store.CalendarState("changes@example.com").SyncToken; got != "s2" {
+		t.Fatalf("expected stored token s2, got %q", got)
+	}
+
+	// An expired token falls back to a full listing.
+	_ = run()
+	if got := strings.Join(seenTokens, ","); got != ",s1,s2," {
+		t.Fatalf("unexpected sync token sequence: %q", got)
+	}
+	store, err = openCalendarWatchStore("changes-user@example.com")
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	if got := store.CalendarState("changes@example.com").SyncToken; got != "s1" {
+		t.Fatalf("expected token reset to s1 after full sync, got %q", got)
+	}
+}
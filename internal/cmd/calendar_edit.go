@@ -21,6 +21,10 @@ type CalendarCreateCmd struct {
 	Description           string   `name:"description" help:"Description"`
 	Location              string   `name:"location" help:"Location"`
 	Attendees             string   `name:"attendees" help:"Comma-separated attendee emails"`
+	Room                  string   `name:"room" help:"Room resource email to book, or 'auto' to book the smallest free room matching --capacity/--building/--room-feature"`
+	Capacity              int64    `name:"capacity" help:"Minimum room capacity (with --room auto)"`
+	Building              string   `name:"building" help:"Room building ID (with --room auto)"`
+	RoomFeatures          []string `name:"room-feature" help:"Required room feature (with --room auto; can be repeated)"`
 	AllDay                bool     `name:"all-day" help:"All-day event (use date-only in --from/--to)"`
 	Recurrence            []string `name:"rrule" help:"Recurrence rules (e.g., 'RRULE:FREQ=MONTHLY;BYMONTHDAY=11'). Can be repeated."`
 	Reminders             []string `name:"reminder" help:"Custom reminders as method:duration (e.g., popup:30m, email:1d). Can be repeated (max 5)."`
@@ -98,6 +102,11 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 	transparency = applyEventTypeTransparencyDefault(transparency, eventType)
 
+	room, err := c.resolveRoomRequest(allDay)
+	if err != nil {
+		return err
+	}
+
 	event := &calendar.Event{
 		Summary:            summary,
 		Description:        strings.TrimSpace(c.Description),
@@ -136,6 +145,7 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 
 	if dryRunErr := dryRunExit(ctx, flags, "calendar.create", map[string]any{
 		"calendar_id":          calendarID,
+		"room":                 room,
 		"send_updates":         sendUpdates,
 		"conference_version_1": c.WithMeet,
 		"supports_attachments": len(event.Attachments) > 0,
@@ -158,6 +168,12 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
+	if room != "" {
+		if err := c.addRoom(ctx, u, account, svc, event, room); err != nil {
+			return err
+		}
+	}
+
 	call := svc.Events.Insert(calendarID, event)
 	if sendUpdates != "" {
 		call = call.SendUpdates(sendUpdates)
@@ -213,6 +229,49 @@ func (c *CalendarCreateCmd) defaultSummaryForEventType(eventType string) string
 	}
 }
 
+// resolveRoomRequest validates --room and its auto-selection filters.
+func (c *CalendarCreateCmd) resolveRoomRequest(allDay bool) (string, error) {
+	room := strings.TrimSpace(c.Room)
+	autoFilters := c.Capacity > 0 || strings.TrimSpace(c.Building) != "" || len(c.RoomFeatures) > 0
+	if autoFilters && !strings.EqualFold(room, calendarRoomAuto) {
+		return "", usage("--capacity/--building/--room-feature require --room auto")
+	}
+	if strings.EqualFold(room, calendarRoomAuto) {
+		if allDay {
+			return "", usage("--room auto requires timed --from/--to (RFC3339)")
+		}
+		return calendarRoomAuto, nil
+	}
+	return room, nil
+}
+
+// addRoom adds the requested room (or the first free matching room for
+// --room auto) as a resource attendee.
+func (c *CalendarCreateCmd) addRoom(ctx context.Context, u *ui.UI, account string, svc *calendar.Service, event *calendar.Event, room string) error {
+	if room == calendarRoomAuto {
+		adminSvc, err := newAdminResourcesService(ctx, account)
+		if err != nil {
+			return wrapAdminResourcesError(err, account)
+		}
+		resources, err := listCalendarResources(ctx, adminSvc, account)
+		if err != nil {
+			return err
+		}
+		picked, err := pickAvailableRoom(ctx, svc, resources, calendarResourceFilter{
+			Building: c.Building,
+			Capacity: c.Capacity,
+			Features: c.RoomFeatures,
+		}, event.Start.DateTime, event.End.DateTime)
+		if err != nil {
+			return err
+		}
+		room = picked.Email
+		u.Err().Printf("Booking room %s (%s)", orEmpty(picked.DisplayName, picked.Name), picked.Email)
+	}
+	event.Attendees = append(event.Attendees, &calendar.EventAttendee{Email: room, Resource: true})
+	return nil
+}
+
 func resolveCreateAllDay(from, to string, allDay bool, eventType string) (bool, error) {
 	if eventType != eventTypeWorkingLocation {
 		return allDay, nil
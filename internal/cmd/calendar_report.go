@@ -0,0 +1,486 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	reportGroupByWeek      = "week"
+	reportGroupByAttendee  = "attendee"
+	reportGroupByDomain    = "domain"
+	reportGroupByColor     = "color"
+	reportGroupByRecurring = "recurring"
+)
+
+type CalendarReportCmd struct {
+	Cal       []string `name:"cal" help:"Calendar ID or name (can be repeated)"`
+	Calendars string   `name:"calendars" help:"Comma-separated calendar IDs or names"`
+	Group     []string `name:"group" help:"Google Group email; include each member's calendar (can be repeated; requires Cloud Identity API)"`
+	GroupBy   string   `name:"group-by" help:"Bucket results by: week|attendee|domain|color|recurring" enum:"week,attendee,domain,color,recurring" default:"week"`
+	Domain    string   `name:"domain" help:"Comma-separated internal domains (default: domain of each calendar, else of the account)"`
+	Format    string   `name:"format" help:"Text output format: table|csv (use --json for JSON)" enum:"table,csv" default:"table"`
+	TimeRangeFlags
+}
+
+// meetingStats aggregates person-hours: a meeting attended by two reported
+// calendars counts once for each of them.
+type meetingStats struct {
+	Meetings int     `json:"meetings"`
+	Minutes  int64   `json:"minutes"`
+	Hours    float64 `json:"hours"`
+	OneOnOne int     `json:"oneOnOne"`
+	Group    int     `json:"group"`
+	External int     `json:"external"`
+	Internal int     `json:"internal"`
+}
+
+func (s *meetingStats) add(m reportMeeting) {
+	s.Meetings++
+	s.Minutes += m.minutes
+	s.Hours = math.Round(float64(s.Minutes)/60*100) / 100
+	if m.oneOnOne {
+		s.OneOnOne++
+	} else {
+		s.Group++
+	}
+	if m.external {
+		s.External++
+	} else {
+		s.Internal++
+	}
+}
+
+type reportBucket struct {
+	Key string `json:"key"`
+	meetingStats
+}
+
+type reportPerson struct {
+	Calendar string `json:"calendar"`
+	meetingStats
+}
+
+// reportMeeting is an accepted, timed meeting with at least one other attendee.
+type reportMeeting struct {
+	calendarID string
+	start      time.Time
+	minutes    int64
+	attendees  []string
+	oneOnOne   bool
+	external   bool
+	colorID    string
+	recurring  bool
+}
+
+func (c *CalendarReportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	tr, err := ResolveTimeRange(ctx, svc, c.TimeRangeFlags)
+	if err != nil {
+		return err
+	}
+
+	calInputs := append([]string{}, c.Cal...)
+	calInputs = append(calInputs, splitCSV(c.Calendars)...)
+	calendarIDs := []string{}
+	if len(calInputs) > 0 {
+		calendarIDs, err = resolveCalendarIDs(ctx, svc, calInputs)
+		if err != nil {
+			return err
+		}
+	}
+	if len(c.Group) > 0 {
+		cloudSvc, cloudErr := newCloudIdentityService(ctx, account)
+		if cloudErr != nil {
+			return wrapCloudIdentityError(cloudErr, account)
+		}
+		for _, group := range c.Group {
+			members, memberErr := collectGroupMemberEmails(ctx, cloudSvc, strings.TrimSpace(group))
+			if memberErr != nil {
+				return fmt.Errorf("failed to list group members: %w", memberErr)
+			}
+			calendarIDs = append(calendarIDs, members...)
+		}
+	}
+	if len(calendarIDs) == 0 {
+		calendarIDs = []string{primaryCalendarID}
+	}
+	calendarIDs = dedupeStrings(calendarIDs)
+
+	internal := reportInternalDomains(c.Domain)
+
+	meetings, warnings := collectReportMeetings(ctx, svc, calendarIDs, tr, internal, emailDomain(account))
+	for _, w := range warnings {
+		u.Err().Printf("Warning: %s", w)
+	}
+
+	total, people, buckets := summarizeReportMeetings(meetings, calendarIDs, c.GroupBy, tr.Location)
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"timeMin":  tr.From.Format(time.RFC3339),
+			"timeMax":  tr.To.Format(time.RFC3339),
+			"timezone": tr.Location.String(),
+			"groupBy":  c.GroupBy,
+			"total":    total,
+			"people":   people,
+			"groups":   buckets,
+		})
+	}
+
+	if c.Format == "csv" {
+		return writeReportCSV(c.GroupBy, buckets)
+	}
+
+	if total.Meetings == 0 {
+		u.Err().Println("No meetings")
+		return nil
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintf(w, "%s\tMEETINGS\tHOURS\t1:1\tGROUP\tEXTERNAL\tINTERNAL\n", strings.ToUpper(c.GroupBy))
+	for _, b := range buckets {
+		fmt.Fprintf(w, "%s\t%d\t%.2f\t%d\t%d\t%d\t%d\n", sanitizeTab(b.Key), b.Meetings, b.Hours, b.OneOnOne, b.Group, b.External, b.Internal)
+	}
+	fmt.Fprintf(w, "TOTAL\t%d\t%.2f\t%d\t%d\t%d\t%d\n", total.Meetings, total.Hours, total.OneOnOne, total.Group, total.External, total.Internal)
+	return nil
+}
+
+func writeReportCSV(groupBy string, buckets []reportBucket) error {
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write([]string{groupBy, "meetings", "minutes", "hours", "one_on_one", "group", "external", "internal"}); err != nil {
+		return err
+	}
+	for _, b := range buckets {
+		if err := w.Write([]string{
+			b.Key,
+			strconv.Itoa(b.Meetings),
+			strconv.FormatInt(b.Minutes, 10),
+			strconv.FormatFloat(b.Hours, 'f', 2, 64),
+			strconv.Itoa(b.OneOnOne),
+			strconv.Itoa(b.Group),
+			strconv.Itoa(b.External),
+			strconv.Itoa(b.Internal),
+		}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func reportInternalDomains(raw string) map[string]bool {
+	out := map[string]bool{}
+	for _, d := range splitCSV(raw) {
+		out[strings.ToLower(strings.TrimPrefix(d, "@"))] = true
+	}
+	return out
+}
+
+func emailDomain(email string) string {
+	_, domain, ok := strings.Cut(strings.TrimSpace(email), "@")
+	if !ok {
+		return ""
+	}
+	return strings.ToLower(domain)
+}
+
+func dedupeStrings(items []string) []string {
+	seen := make(map[string]bool, len(items))
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		key := strings.ToLower(item)
+		if item == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, item)
+	}
+	return out
+}
+
+func collectReportMeetings(ctx context.Context, svc *calendar.Service, calendarIDs []string, tr *TimeRange, internal map[string]bool, accountDomain string) ([]reportMeeting, []string) {
+	var (
+		mu       sync.Mutex
+		meetings []reportMeeting
+		warnings []string
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, 10)
+	)
+	from, to := tr.FormatRFC3339()
+
+	for _, calID := range calendarIDs {
+		wg.Add(1)
+		go func(calID string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			fetch := func(pageToken string) ([]*calendar.Event, string, error) {
+				resp, err := calendarEventsListCall(ctx, svc, calID, from, to, 250, "", "", "", "", pageToken).Do()
+				if err != nil {
+					return nil, "", err
+				}
+				return resp.Items, resp.NextPageToken, nil
+			}
+			events, err := collectAllPages("", fetch)
+			if err != nil {
+				mu.Lock()
+				warnings = append(warnings, fmt.Sprintf("%s: %v", calID, err))
+				mu.Unlock()
+				return
+			}
+
+			domains := internal
+			if len(domains) == 0 {
+				domain := emailDomain(calID)
+				if domain == "" || strings.HasSuffix(domain, "calendar.google.com") {
+					domain = accountDomain
+				}
+				domains = map[string]bool{domain: true}
+			}
+			local := make([]reportMeeting, 0, len(events))
+			withGuests, owned := 0, 0
+			for _, ev := range events {
+				if ev != nil && len(ev.Attendees) > 0 {
+					withGuests++
+					if reportEventHasOwner(calID, ev) {
+						owned++
+					}
+				}
+				if m, ok := reportMeetingFromEvent(calID, ev, domains); ok {
+					local = append(local, m)
+				}
+			}
+			mu.Lock()
+			meetings = append(meetings, local...)
+			if withGuests > 0 && owned == 0 {
+				warnings = append(warnings, fmt.Sprintf("%s: no event lists the calendar's owner or you as a guest; meetings cannot be counted", calID))
+			}
+			mu.Unlock()
+		}(calID)
+	}
+	wg.Wait()
+	sort.Strings(warnings)
+	return meetings, warnings
+}
+
+// reportMeetingFromEvent keeps accepted, timed events with other human
+// attendees. Focus time, out-of-office and working-location blocks are not
+// meetings and are skipped.
+func reportMeetingFromEvent(calendarID string, ev *calendar.Event, internal map[string]bool) (reportMeeting, bool) {
+	if ev == nil || ev.Status == eventStatusCancelled || isAllDayEvent(ev) {
+		return reportMeeting{}, false
+	}
+	switch ev.EventType {
+	case eventTypeFocusTime, eventTypeOutOfOffice, eventTypeWorkingLocation:
+		return reportMeeting{}, false
+	}
+	if ev.Start == nil || ev.End == nil {
+		return reportMeeting{}, false
+	}
+	start, err := time.Parse(time.RFC3339, ev.Start.DateTime)
+	if err != nil {
+		return reportMeeting{}, false
+	}
+	end, err := time.Parse(time.RFC3339, ev.End.DateTime)
+	if err != nil || !end.After(start) {
+		return reportMeeting{}, false
+	}
+
+	// Acceptance and "others" are judged from the reported calendar's owner,
+	// not the caller.
+	isOwner := reportOwnerMatcher(calendarID, ev)
+	accepted := ev.Organizer != nil && isOwner(ev.Organizer.Email, ev.Organizer.Self)
+	humans := 0
+	ownerListed := false
+	others := []string{}
+	external := false
+	for _, a := range ev.Attendees {
+		if a == nil || a.Resource {
+			continue
+		}
+		humans++
+		if isOwner(a.Email, a.Self) {
+			ownerListed = true
+			accepted = a.ResponseStatus == "accepted" || (a.Organizer && a.ResponseStatus != "declined")
+			continue
+		}
+		email := strings.ToLower(strings.TrimSpace(a.Email))
+		if email == "" {
+			continue
+		}
+		others = append(others, email)
+		if len(internal) > 0 && !internal[emailDomain(email)] {
+			external = true
+		}
+	}
+	if accepted && !ownerListed {
+		humans++ // organizer missing from the attendee list
+	}
+	if !accepted || len(others) == 0 {
+		return reportMeeting{}, false
+	}
+
+	colorID := ev.ColorId
+	if colorID == "" {
+		colorID = "default"
+	}
+	return reportMeeting{
+		calendarID: calendarID,
+		start:      start,
+		minutes:    int64(end.Sub(start) / time.Minute),
+		attendees:  others,
+		oneOnOne:   humans == 2,
+		external:   external,
+		colorID:    colorID,
+		recurring:  ev.RecurringEventId != "",
+	}, true
+}
+
+// reportOwnerMatcher identifies the reported calendar's owner among the
+// organizer and attendees of ev. A user calendar's owner is found by email;
+// "primary" and calendars whose ID is not a participant address (groups,
+// resources, imported calendars) fall back to the caller's Self entry.
+func reportOwnerMatcher(calendarID string, ev *calendar.Event) func(email string, self bool) bool {
+	bySelf := func(_ string, self bool) bool { return self }
+	byEmail := func(email string, _ bool) bool { return strings.EqualFold(strings.TrimSpace(email), calendarID) }
+	if strings.EqualFold(calendarID, primaryCalendarID) {
+		return bySelf
+	}
+	if ev.Organizer != nil && byEmail(ev.Organizer.Email, false) {
+		return byEmail
+	}
+	for _, a := range ev.Attendees {
+		if a != nil && byEmail(a.Email, false) {
+			return byEmail
+		}
+	}
+	return bySelf
+}
+
+// reportEventHasOwner reports whether an event with guests lists the
+// reported calendar's owner, so its acceptance can be judged.
+func reportEventHasOwner(calendarID string, ev *calendar.Event) bool {
+	isOwner := reportOwnerMatcher(calendarID, ev)
+	if ev.Organizer != nil && isOwner(ev.Organizer.Email, ev.Organizer.Self) {
+		return true
+	}
+	for _, a := range ev.Attendees {
+		if a != nil && isOwner(a.Email, a.Self) {
+			return true
+		}
+	}
+	return false
+}
+
+func summarizeReportMeetings(meetings []reportMeeting, calendarIDs []string, groupBy string, loc *time.Location) (meetingStats, []reportPerson, []reportBucket) {
+	var total meetingStats
+	perPerson := map[string]*meetingStats{}
+	perBucket := map[string]*meetingStats{}
+
+	addBucket := func(key string, m reportMeeting) {
+		stats := perBucket[key]
+		if stats == nil {
+			stats = &meetingStats{}
+			perBucket[key] = stats
+		}
+		stats.add(m)
+	}
+
+	for _, m := range meetings {
+		total.add(m)
+		person := perPerson[m.calendarID]
+		if person == nil {
+			person = &meetingStats{}
+			perPerson[m.calendarID] = person
+		}
+		person.add(m)
+
+		for _, key := range reportBucketKeys(m, groupBy, loc) {
+			addBucket(key, m)
+		}
+	}
+
+	people := make([]reportPerson, 0, len(calendarIDs))
+	for _, id := range calendarIDs {
+		p := reportPerson{Calendar: id}
+		if stats := perPerson[id]; stats != nil {
+			p.meetingStats = *stats
+		}
+		people = append(people, p)
+	}
+
+	buckets := make([]reportBucket, 0, len(perBucket))
+	for key, stats := range perBucket {
+		buckets = append(buckets, reportBucket{Key: key, meetingStats: *stats})
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		// Weeks read chronologically; everything else by load.
+		if groupBy == reportGroupByWeek || buckets[i].Minutes == buckets[j].Minutes {
+			return buckets[i].Key < buckets[j].Key
+		}
+		return buckets[i].Minutes > buckets[j].Minutes
+	})
+	return total, people, buckets
+}
+
+func reportBucketKeys(m reportMeeting, groupBy string, loc *time.Location) []string {
+	switch groupBy {
+	case reportGroupByAttendee:
+		return m.attendees
+	case reportGroupByDomain:
+		seen := map[string]bool{}
+		keys := []string{}
+		for _, a := range m.attendees {
+			d := emailDomain(a)
+			if d == "" || seen[d] {
+				continue
+			}
+			seen[d] = true
+			keys = append(keys, d)
+		}
+		return keys
+	case reportGroupByColor:
+		return []string{m.colorID}
+	case reportGroupByRecurring:
+		if m.recurring {
+			return []string{"recurring"}
+		}
+		return []string{"single"}
+	default:
+		return []string{reportWeekStart(m.start, loc)}
+	}
+}
+
+// reportWeekStart returns the Monday (YYYY-MM-DD) of the week containing t.
+func reportWeekStart(t time.Time, loc *time.Location) string {
+	if loc != nil {
+		t = t.In(loc)
+	}
+	offset := (int(t.Weekday()) + 6) % 7
+	return t.AddDate(0, 0, -offset).Format("2006-01-02")
+}
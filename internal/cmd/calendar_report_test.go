@@ -0,0 +1,325 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func reportTestEvent(id, start, end string, attendees ...*calendar.EventAttendee) *calendar.Event {
+	return &calendar.Event{
+		Id:        id,
+		Status:    "confirmed",
+		Start:     &calendar.EventDateTime{DateTime: start},
+		End:       &calendar.EventDateTime{DateTime: end},
+		Attendees: attendees,
+	}
+}
+
+func TestReportMeetingFromEvent(t *testing.T) {
+	internal := map[string]bool{"example.com": true}
+	self := &calendar.EventAttendee{Email: "me@example.com", Self: true, ResponseStatus: "accepted"}
+
+	oneOnOne := reportTestEvent("e1", "2025-01-06T10:00:00Z", "2025-01-06T10:30:00Z", self,
+		&calendar.EventAttendee{Email: "boss@example.com"})
+	m, ok := reportMeetingFromEvent("me@example.com", oneOnOne, internal)
+	if !ok || !m.oneOnOne || m.external || m.minutes != 30 {
+		t.Fatalf("unexpected 1:1 result: %#v ok=%v", m, ok)
+	}
+
+	external := reportTestEvent("e2", "2025-01-06T11:00:00Z", "2025-01-06T12:00:00Z", self,
+		&calendar.EventAttendee{Email: "a@example.com"},
+		&calendar.EventAttendee{Email: "client@other.org"},
+		&calendar.EventAttendee{Email: "room@resource.calendar.google.com", Resource: true})
+	m, ok = reportMeetingFromEvent("me@example.com", external, internal)
+	if !ok || m.oneOnOne || !m.external || len(m.attendees) != 2 {
+		t.Fatalf("unexpected group result: %#v ok=%v", m, ok)
+	}
+
+	declined := reportTestEvent("e3", "2025-01-06T13:00:00Z", "2025-01-06T14:00:00Z",
+		&calendar.EventAttendee{Email: "me@example.com", Self: true, ResponseStatus: "declined"},
+		&calendar.EventAttendee{Email: "a@example.com"})
+	if _, ok := reportMeetingFromEvent("me@example.com", declined, internal); ok {
+		t.Fatalf("declined event should be skipped")
+	}
+
+	focus := reportTestEvent("e4", "2025-01-06T15:00:00Z", "2025-01-06T16:00:00Z", self,
+		&calendar.EventAttendee{Email: "a@example.com"})
+	focus.EventType = eventTypeFocusTime
+	if _, ok := reportMeetingFromEvent("me@example.com", focus, internal); ok {
+		t.Fatalf("focus time should be skipped")
+	}
+
+	// A teammate's calendar: the caller (Self) is not invited, and acceptance
+	// comes from the teammate's own response.
+	teammate := reportTestEvent("e6", "2025-01-07T09:00:00Z", "2025-01-07T10:00:00Z",
+		&calendar.EventAttendee{Email: "Ana@example.com", ResponseStatus: "accepted"},
+		&calendar.EventAttendee{Email: "bo@example.com", ResponseStatus: "accepted"},
+		&calendar.EventAttendee{Email: "cy@example.com", ResponseStatus: "needsAction"})
+	m, ok = reportMeetingFromEvent("ana@example.com", teammate, internal)
+	if !ok || m.calendarID != "ana@example.com" || strings.Join(m.attendees, ",") != "bo@example.com,cy@example.com" {
+		t.Fatalf("unexpected teammate result: %#v ok=%v", m, ok)
+	}
+	if _, ok := reportMeetingFromEvent("cy@example.com", teammate, internal); ok {
+		t.Fatalf("meeting the reported calendar has not accepted should be skipped")
+	}
+	organized := reportTestEvent("e7", "2025-01-07T11:00:00Z", "2025-01-07T11:30:00Z",
+		&calendar.EventAttendee{Email: "me@example.com", Self: true, ResponseStatus: "declined"},
+		&calendar.EventAttendee{Email: "bo@example.com"})
+	organized.Organizer = &calendar.EventOrganizer{Email: "ana@example.com"}
+	m, ok = reportMeetingFromEvent("ana@example.com", organized, internal)
+	if !ok || m.oneOnOne || strings.Join(m.attendees, ",") != "me@example.com,bo@example.com" {
+		t.Fatalf("teammate-organized meeting should count the caller as another attendee: %#v ok=%v", m, ok)
+	}
+
+	// A group calendar is never an attendee address; the caller's own entry
+	// decides acceptance.
+	team := reportTestEvent("e8", "2025-01-07T13:00:00Z", "2025-01-07T13:30:00Z", self,
+		&calendar.EventAttendee{Email: "bo@example.com"})
+	m, ok = reportMeetingFromEvent("team@group.calendar.google.com", team, internal)
+	if !ok || !m.oneOnOne || strings.Join(m.attendees, ",") != "bo@example.com" {
+		t.Fatalf("group calendar should fall back to the Self attendee: %#v ok=%v", m, ok)
+	}
+
+	solo := reportTestEvent("e5", "2025-01-06T17:00:00Z", "2025-01-06T18:00:00Z")
+	solo.Organizer = &calendar.EventOrganizer{Email: "me@example.com", Self: true}
+	if _, ok := reportMeetingFromEvent("me@example.com", solo, internal); ok {
+		t.Fatalf("event without other attendees should be skipped")
+	}
+}
+
+func TestReportWeekStart(t *testing.T) {
+	sunday := time.Date(2025, 1, 12, 23, 0, 0, 0, time.UTC)
+	if got := reportWeekStart(sunday, time.UTC); got != "2025-01-06" {
+		t.Fatalf("unexpected week start: %s", got)
+	}
+	monday := time.Date(2025, 1, 13, 0, 30, 0, 0, time.UTC)
+	if got := reportWeekStart(monday, time.UTC); got != "2025-01-13" {
+		t.Fatalf("unexpected week start: %s", got)
+	}
+}
+
+func TestCalendarReportCmd_GroupByDomainJSON(t *testing.T) {
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+
+	svc := newCalendarChangesTestService(t, withPrimaryCalendar(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/users/me/calendarList") {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"items": []map[string]any{{"id": "me@example.com", "summary": "Me"}},
+			})
+			return
+		}
+		if !strings.Contains(r.URL.Path, "/calendars/me@example.com/events") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"items": []map[string]any{
+				{
+					"id": "a", "status": "confirmed",
+					"start": map[string]any{"dateTime": "2025-01-06T10:00:00Z"},
+					"end":   map[string]any{"dateTime": "2025-01-06T11:00:00Z"},
+					"attendees": []map[string]any{
+						{"email": "me@example.com", "self": true, "responseStatus": "accepted"},
+						{"email": "client@other.org"},
+					},
+				},
+				{
+					"id": "b", "status": "confirmed", "recurringEventId": "series",
+					"start": map[string]any{"dateTime": "2025-01-07T10:00:00Z"},
+					"end":   map[string]any{"dateTime": "2025-01-07T10:30:00Z"},
+					"attendees": []map[string]any{
+						{"email": "me@example.com", "self": true, "responseStatus": "accepted"},
+						{"email": "a@example.com"},
+						{"email": "b@example.com"},
+					},
+				},
+			},
+		})
+	})).ServeHTTP)
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{
+				"--json", "--account", "me@example.com",
+				"calendar", "report",
+				"--cal", "me@example.com",
+				"--from", "2025-01-06T00:00:00Z", "--to", "2025-01-13T00:00:00Z",
+				"--group-by", "domain",
+			}); err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+		})
+	})
+
+	var parsed struct {
+		Total  meetingStats   `json:"total"`
+		People []reportPerson `json:"people"`
+		Groups []reportBucket `json:"groups"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json parse: %v\nout=%q", err, out)
+	}
+	if parsed.Total.Meetings != 2 || parsed.Total.Minutes != 90 || parsed.Total.External != 1 || parsed.Total.OneOnOne != 1 {
+		t.Fatalf("unexpected totals: %#v", parsed.Total)
+	}
+	if len(parsed.People) != 1 || parsed.People[0].Calendar != "me@example.com" {
+		t.Fatalf("unexpected people: %#v", parsed.People)
+	}
+	if len(parsed.Groups) != 2 || parsed.Groups[0].Key != "other.org" || parsed.Groups[0].Minutes != 60 {
+		t.Fatalf("unexpected groups: %#v", parsed.Groups)
+	}
+}
+
+func TestCalendarReportCmd_TeammateCalendar(t *testing.T) {
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+
+	svc := newCalendarChangesTestService(t, withPrimaryCalendar(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/users/me/calendarList") {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"items": []map[string]any{{"id": "ana@example.com", "summary": "Ana"}},
+			})
+			return
+		}
+		if !strings.Contains(r.URL.Path, "/calendars/ana@example.com/events") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"items": []map[string]any{
+				{
+					// The caller is not invited at all.
+					"id": "a", "status": "confirmed",
+					"start": map[string]any{"dateTime": "2025-01-06T10:00:00Z"},
+					"end":   map[string]any{"dateTime": "2025-01-06T10:30:00Z"},
+					"attendees": []map[string]any{
+						{"email": "ana@example.com", "responseStatus": "accepted"},
+						{"email": "bo@example.com", "responseStatus": "accepted"},
+					},
+				},
+				{
+					// The caller declined, Ana accepted.
+					"id": "b", "status": "confirmed",
+					"start": map[string]any{"dateTime": "2025-01-07T10:00:00Z"},
+					"end":   map[string]any{"dateTime": "2025-01-07T11:00:00Z"},
+					"attendees": []map[string]any{
+						{"email": "me@example.com", "self": true, "responseStatus": "declined"},
+						{"email": "ana@example.com", "responseStatus": "accepted"},
+						{"email": "bo@example.com"},
+					},
+				},
+				{
+					// Ana declined, the caller accepted.
+					"id": "c", "status": "confirmed",
+					"start": map[string]any{"dateTime": "2025-01-08T10:00:00Z"},
+					"end":   map[string]any{"dateTime": "2025-01-08T11:00:00Z"},
+					"attendees": []map[string]any{
+						{"email": "me@example.com", "self": true, "responseStatus": "accepted"},
+						{"email": "ana@example.com", "responseStatus": "declined"},
+					},
+				},
+			},
+		})
+	})).ServeHTTP)
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{
+				"--json", "--account", "me@example.com",
+				"calendar", "report",
+				"--cal", "ana@example.com",
+				"--from", "2025-01-06T00:00:00Z", "--to", "2025-01-13T00:00:00Z",
+			}); err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+		})
+	})
+
+	var parsed struct {
+		Total  meetingStats   `json:"total"`
+		People []reportPerson `json:"people"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json parse: %v\nout=%q", err, out)
+	}
+	if parsed.Total.Meetings != 2 || parsed.Total.Minutes != 90 || parsed.Total.OneOnOne != 1 {
+		t.Fatalf("unexpected totals: %#v", parsed.Total)
+	}
+	if len(parsed.People) != 1 || parsed.People[0].Calendar != "ana@example.com" {
+		t.Fatalf("unexpected people: %#v", parsed.People)
+	}
+}
+
+func TestWriteReportCSV(t *testing.T) {
+	out := captureStdout(t, func() {
+		if err := writeReportCSV("week", []reportBucket{{Key: "2025-01-06", meetingStats: meetingStats{Meetings: 2, Minutes: 90, Hours: 1.5, OneOnOne: 1, Group: 1, Internal: 2}}}); err != nil {
+			t.Fatalf("writeReportCSV: %v", err)
+		}
+	})
+	want := "week,meetings,minutes,hours,one_on_one,group,external,internal\n2025-01-06,2,90,1.50,1,1,0,2\n"
+	if out != want {
+		t.Fatalf("unexpected csv:\n%s", out)
+	}
+}
+
+func TestCalendarReportCmd_WarnsWhenOwnerNotListed(t *testing.T) {
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+
+	svc := newCalendarChangesTestService(t, withPrimaryCalendar(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/users/me/calendarList") {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"items": []map[string]any{{"id": "imported@import.calendar.google.com", "summary": "Imported"}},
+			})
+			return
+		}
+		if !strings.Contains(r.URL.Path, "/calendars/imported@import.calendar.google.com/events") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"items": []map[string]any{{
+				"id": "a", "status": "confirmed",
+				"start": map[string]any{"dateTime": "2025-01-06T10:00:00Z"},
+				"end":   map[string]any{"dateTime": "2025-01-06T10:30:00Z"},
+				"attendees": []map[string]any{
+					{"email": "ana@example.com", "responseStatus": "accepted"},
+					{"email": "bo@example.com", "responseStatus": "accepted"},
+				},
+			}},
+		})
+	})).ServeHTTP)
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	var stderr string
+	_ = captureStdout(t, func() {
+		stderr = captureStderr(t, func() {
+			if err := Execute([]string{
+				"--json", "--account", "me@example.com",
+				"calendar", "report",
+				"--cal", "imported@import.calendar.google.com",
+				"--from", "2025-01-06T00:00:00Z", "--to", "2025-01-13T00:00:00Z",
+			}); err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+		})
+	})
+	if !strings.Contains(stderr, "imported@import.calendar.google.com: no event lists the calendar's owner") {
+		t.Fatalf("expected owner warning, got %q", stderr)
+	}
+}
@@ -0,0 +1,496 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/errfmt"
+	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+var newAdminResourcesService = googleapi.NewAdminDirectoryResources
+
+const (
+	adminMyCustomer             = "my_customer"
+	resourceCategoryRoom        = "CONFERENCE_ROOM"
+	calendarRoomAuto            = "auto"
+	freeBusyMaxItemsPerRequest  = 50
+	calendarResourcesPageSize   = 500
+	calendarResourcesSearchHint = "Tip: book a room with: gog calendar create primary --room <email> ... (or --room auto)"
+)
+
+var errNoFreeRoom = errors.New("no free room matches the requested filters")
+
+type CalendarResourcesCmd struct {
+	List     CalendarResourcesListCmd     `cmd:"" name:"list" aliases:"ls" help:"List rooms and other calendar resources"`
+	Search   CalendarResourcesSearchCmd   `cmd:"" name:"search" aliases:"find" help:"Search resources by name, email, building, or feature"`
+	FreeBusy CalendarResourcesFreeBusyCmd `cmd:"" name:"freebusy" aliases:"availability" help:"Show which rooms are free in a time range"`
+}
+
+// CalendarResourceFilterFlags narrows the resource directory client-side.
+type CalendarResourceFilterFlags struct {
+	Building string   `name:"building" help:"Only resources in this building ID"`
+	Capacity int64    `name:"capacity" help:"Minimum capacity"`
+	Feature  []string `name:"feature" help:"Required feature, e.g. 'Whiteboard' (can be repeated)"`
+	Category string   `name:"category" help:"Resource category: CONFERENCE_ROOM, OTHER, CATEGORY_UNKNOWN (default: any)"`
+}
+
+type calendarResourceFilter struct {
+	Building string
+	Capacity int64
+	Features []string
+	Category string
+}
+
+func (f CalendarResourceFilterFlags) filter() calendarResourceFilter {
+	return calendarResourceFilter{
+		Building: f.Building,
+		Capacity: f.Capacity,
+		Features: f.Feature,
+		Category: f.Category,
+	}
+}
+
+type calendarResource struct {
+	ID          string   `json:"id"`
+	Email       string   `json:"email"`
+	Name        string   `json:"name"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	BuildingID  string   `json:"buildingId,omitempty"`
+	Floor       string   `json:"floor,omitempty"`
+	Capacity    int64    `json:"capacity,omitempty"`
+	Features    []string `json:"features,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+func calendarResourceFromAdmin(r *admin.CalendarResource) calendarResource {
+	description := r.UserVisibleDescription
+	if description == "" {
+		description = r.ResourceDescription
+	}
+	return calendarResource{
+		ID:          r.ResourceId,
+		Email:       r.ResourceEmail,
+		Name:        r.ResourceName,
+		DisplayName: r.GeneratedResourceName,
+		Category:    r.ResourceCategory,
+		Type:        r.ResourceType,
+		BuildingID:  r.BuildingId,
+		Floor:       r.FloorName,
+		Capacity:    r.Capacity,
+		Features:    calendarResourceFeatures(r.FeatureInstances),
+		Description: description,
+	}
+}
+
+// calendarResourceFeatures decodes the loosely typed featureInstances field
+// ([{"feature":{"name":"..."}}]) into feature names.
+func calendarResourceFeatures(raw any) []string {
+	if raw == nil {
+		return nil
+	}
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+	var instances []struct {
+		Feature struct {
+			Name string `json:"name"`
+		} `json:"feature"`
+	}
+	if err := json.Unmarshal(b, &instances); err != nil {
+		return nil
+	}
+	out := make([]string, 0, len(instances))
+	for _, inst := range instances {
+		if name := strings.TrimSpace(inst.Feature.Name); name != "" {
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (f calendarResourceFilter) matches(r calendarResource) bool {
+	if b := strings.TrimSpace(f.Building); b != "" && !strings.EqualFold(b, r.BuildingID) {
+		return false
+	}
+	if f.Capacity > 0 && r.Capacity < f.Capacity {
+		return false
+	}
+	if c := strings.TrimSpace(f.Category); c != "" && !strings.EqualFold(c, r.Category) {
+		return false
+	}
+	for _, want := range f.Features {
+		want = strings.TrimSpace(want)
+		if want == "" {
+			continue
+		}
+		found := false
+		for _, have := range r.Features {
+			if strings.EqualFold(want, have) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesText reports whether any descriptive field contains the query (case-insensitive).
+func (r calendarResource) matchesText(query string) bool {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return true
+	}
+	fields := []string{r.Name, r.DisplayName, r.Email, r.Description, r.BuildingID, r.Floor, r.Type}
+	fields = append(fields, r.Features...)
+	for _, f := range fields {
+		if strings.Contains(strings.ToLower(f), query) {
+			return true
+		}
+	}
+	return false
+}
+
+func listCalendarResources(ctx context.Context, svc *admin.Service, account string) ([]calendarResource, error) {
+	fetch := func(pageToken string) ([]*admin.CalendarResource, string, error) {
+		call := svc.Resources.Calendars.List(adminMyCustomer).MaxResults(calendarResourcesPageSize).Context(ctx)
+		if strings.TrimSpace(pageToken) != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", wrapAdminResourcesError(err, account)
+		}
+		return resp.Items, resp.NextPageToken, nil
+	}
+	items, err := collectAllPages("", fetch)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]calendarResource, 0, len(items))
+	for _, item := range items {
+		if item == nil || strings.TrimSpace(item.ResourceEmail) == "" {
+			continue
+		}
+		out = append(out, calendarResourceFromAdmin(item))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].BuildingID != out[j].BuildingID {
+			return out[i].BuildingID < out[j].BuildingID
+		}
+		return strings.ToLower(out[i].Name) < strings.ToLower(out[j].Name)
+	})
+	return out, nil
+}
+
+func filterCalendarResources(resources []calendarResource, filter calendarResourceFilter, query string) []calendarResource {
+	out := make([]calendarResource, 0, len(resources))
+	for _, r := range resources {
+		if filter.matches(r) && r.matchesText(query) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func wrapAdminResourcesError(err error, account string) error {
+	errStr := err.Error()
+	if strings.Contains(errStr, "accessNotConfigured") ||
+		strings.Contains(errStr, "Admin SDK API has not been used") {
+		return errfmt.NewUserFacingError("Admin SDK API is not enabled; enable it at: https://console.developers.google.com/apis/api/admin.googleapis.com/overview", err)
+	}
+	if strings.Contains(errStr, "insufficientPermissions") ||
+		strings.Contains(errStr, "insufficient authentication scopes") {
+		return errfmt.NewUserFacingError("Insufficient permissions for calendar resources; re-authenticate with: gog auth add <account> --services resources", err)
+	}
+	if isConsumerAccount(account) {
+		return errfmt.NewUserFacingError("Calendar resources require a Google Workspace account; consumer accounts (gmail.com/googlemail.com) are not supported.", err)
+	}
+	return err
+}
+
+func loadCalendarResources(ctx context.Context, flags *RootFlags) (string, []calendarResource, error) {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return "", nil, err
+	}
+	svc, err := newAdminResourcesService(ctx, account)
+	if err != nil {
+		return "", nil, wrapAdminResourcesError(err, account)
+	}
+	resources, err := listCalendarResources(ctx, svc, account)
+	if err != nil {
+		return "", nil, err
+	}
+	return account, resources, nil
+}
+
+func writeCalendarResources(ctx context.Context, resources []calendarResource, failEmpty bool) error {
+	u := ui.FromContext(ctx)
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"resources": resources}); err != nil {
+			return err
+		}
+		if len(resources) == 0 {
+			return failEmptyExit(failEmpty)
+		}
+		return nil
+	}
+	if len(resources) == 0 {
+		u.Err().Println("No resources")
+		return failEmptyExit(failEmpty)
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "EMAIL\tNAME\tBUILDING\tFLOOR\tCAPACITY\tFEATURES")
+	for _, r := range resources {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.Email,
+			sanitizeTab(r.Name),
+			sanitizeTab(r.BuildingID),
+			sanitizeTab(r.Floor),
+			formatResourceCapacity(r.Capacity),
+			sanitizeTab(strings.Join(r.Features, ", ")),
+		)
+	}
+	return nil
+}
+
+func formatResourceCapacity(capacity int64) string {
+	if capacity <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d", capacity)
+}
+
+type CalendarResourcesListCmd struct {
+	CalendarResourceFilterFlags
+	FailEmpty bool `name:"fail-empty" aliases:"non-empty,require-results" help:"Exit with code 3 if no results"`
+}
+
+func (c *CalendarResourcesListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	_, resources, err := loadCalendarResources(ctx, flags)
+	if err != nil {
+		return err
+	}
+	return writeCalendarResources(ctx, filterCalendarResources(resources, c.filter(), ""), c.FailEmpty)
+}
+
+type CalendarResourcesSearchCmd struct {
+	Query []string `arg:"" name:"query" help:"Text to match against name, email, building, floor, or features"`
+	CalendarResourceFilterFlags
+	FailEmpty bool `name:"fail-empty" aliases:"non-empty,require-results" help:"Exit with code 3 if no results"`
+}
+
+func (c *CalendarResourcesSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
+	query := strings.TrimSpace(strings.Join(c.Query, " "))
+	if query == "" {
+		return usage("empty query")
+	}
+	_, resources, err := loadCalendarResources(ctx, flags)
+	if err != nil {
+		return err
+	}
+	matched := filterCalendarResources(resources, c.filter(), query)
+	if err := writeCalendarResources(ctx, matched, c.FailEmpty); err != nil {
+		return err
+	}
+	if len(matched) > 0 && !outfmt.IsJSON(ctx) {
+		ui.FromContext(ctx).Err().Println(calendarResourcesSearchHint)
+	}
+	return nil
+}
+
+type roomAvailability struct {
+	calendarResource
+	Free  bool                   `json:"free"`
+	Busy  []*calendar.TimePeriod `json:"busy,omitempty"`
+	Error string                 `json:"error,omitempty"`
+}
+
+// queryRoomAvailability runs free/busy for each room, batching to the API's
+// per-request calendar limit.
+func queryRoomAvailability(ctx context.Context, svc *calendar.Service, rooms []calendarResource, from, to string) ([]roomAvailability, error) {
+	out := make([]roomAvailability, 0, len(rooms))
+	for start := 0; start < len(rooms); start += freeBusyMaxItemsPerRequest {
+		end := start + freeBusyMaxItemsPerRequest
+		if end > len(rooms) {
+			end = len(rooms)
+		}
+		batch := rooms[start:end]
+		req := &calendar.FreeBusyRequest{
+			TimeMin: from,
+			TimeMax: to,
+			Items:   make([]*calendar.FreeBusyRequestItem, 0, len(batch)),
+		}
+		for _, r := range batch {
+			req.Items = append(req.Items, &calendar.FreeBusyRequestItem{Id: r.Email})
+		}
+		resp, err := svc.Freebusy.Query(req).Context(ctx).Do()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range batch {
+			avail := roomAvailability{calendarResource: r}
+			data, ok := resp.Calendars[r.Email]
+			switch {
+			case !ok:
+				avail.Error = "no free/busy data"
+			case len(data.Errors) > 0:
+				avail.Error = data.Errors[0].Reason
+			default:
+				avail.Busy = data.Busy
+				avail.Free = len(data.Busy) == 0
+			}
+			out = append(out, avail)
+		}
+	}
+	return out, nil
+}
+
+// roomsForBooking keeps conference rooms (unless another category was asked
+// for) and orders them smallest-first so auto-booking doesn't hog large rooms.
+func roomsForBooking(resources []calendarResource, filter calendarResourceFilter) []calendarResource {
+	if strings.TrimSpace(filter.Category) == "" {
+		filter.Category = resourceCategoryRoom
+	}
+	rooms := filterCalendarResources(resources, filter, "")
+	sort.SliceStable(rooms, func(i, j int) bool {
+		return rooms[i].Capacity < rooms[j].Capacity
+	})
+	return rooms
+}
+
+// pickAvailableRoom returns the smallest matching room that is free for the whole range.
+func pickAvailableRoom(ctx context.Context, svc *calendar.Service, resources []calendarResource, filter calendarResourceFilter, from, to string) (calendarResource, error) {
+	rooms := roomsForBooking(resources, filter)
+	if len(rooms) == 0 {
+		return calendarResource{}, errNoFreeRoom
+	}
+	avail, err := queryRoomAvailability(ctx, svc, rooms, from, to)
+	if err != nil {
+		return calendarResource{}, err
+	}
+	for _, a := range avail {
+		if a.Free {
+			return a.calendarResource, nil
+		}
+	}
+	return calendarResource{}, errNoFreeRoom
+}
+
+type CalendarResourcesFreeBusyCmd struct {
+	CalendarResourceFilterFlags
+	TimeRangeFlags
+	FreeOnly bool `name:"free-only" aliases:"available" help:"Only show rooms that are free for the whole range"`
+}
+
+func (c *CalendarResourcesFreeBusyCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, resources, err := loadCalendarResources(ctx, flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	tr, err := ResolveTimeRange(ctx, svc, c.TimeRangeFlags)
+	if err != nil {
+		return err
+	}
+	from, to := tr.FormatRFC3339()
+
+	rooms := roomsForBooking(resources, c.filter())
+	avail, err := queryRoomAvailability(ctx, svc, rooms, from, to)
+	if err != nil {
+		return err
+	}
+	if c.FreeOnly {
+		free := avail[:0]
+		for _, a := range avail {
+			if a.Free {
+				free = append(free, a)
+			}
+		}
+		avail = free
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"timeMin": from,
+			"timeMax": to,
+			"rooms":   avail,
+		})
+	}
+	if len(avail) == 0 {
+		u.Err().Println("No matching rooms")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "EMAIL\tNAME\tBUILDING\tCAPACITY\tSTATUS\tBUSY")
+	for _, a := range avail {
+		status := "busy"
+		if a.Free {
+			status = "free"
+		}
+		if a.Error != "" {
+			status = "error: " + a.Error
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			a.Email,
+			sanitizeTab(a.Name),
+			sanitizeTab(a.BuildingID),
+			formatResourceCapacity(a.Capacity),
+			status,
+			formatBusyPeriods(a.Busy, tr.Location),
+		)
+	}
+	return nil
+}
+
+func formatBusyPeriods(periods []*calendar.TimePeriod, loc *time.Location) string {
+	parts := make([]string, 0, len(periods))
+	for _, p := range periods {
+		if p == nil {
+			continue
+		}
+		start, errStart := time.Parse(time.RFC3339, p.Start)
+		end, errEnd := time.Parse(time.RFC3339, p.End)
+		if errStart != nil || errEnd != nil || loc == nil {
+			parts = append(parts, p.Start+"/"+p.End)
+			continue
+		}
+		start, end = start.In(loc), end.In(loc)
+		endLayout := "15:04"
+		if start.Format("2006-01-02") != end.Format("2006-01-02") {
+			endLayout = "2006-01-02 15:04"
+		}
+		parts = append(parts, start.Format("2006-01-02 15:04")+"-"+end.Format(endLayout))
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+// newResourceDirectoryStandIn serves a fixed resource directory in place of the Admin SDK.
+func newResourceDirectoryStandIn(t *testing.T) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/customer/my_customer/resources/calendars") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"items": []map[string]any{
+				{
+					"resourceId": "r1", "resourceName": "Atlas", "resourceEmail": "atlas@resource.calendar.google.com",
+					"resourceCategory": "CONFERENCE_ROOM", "buildingId": "HQ", "floorName": "2", "capacity": 12,
+					"featureInstances": []map[string]any{{"feature": map[string]any{"name": "Whiteboard"}}, {"feature": map[string]any{"name": "Display"}}},
+				},
+				{
+					"resourceId": "r2", "resourceName": "Nook", "resourceEmail": "nook@resource.calendar.google.com",
+					"resourceCategory": "CONFERENCE_ROOM", "buildingId": "HQ", "floorName": "1", "capacity": 6,
+				},
+				{
+					"resourceId": "r3", "resourceName": "Tiny", "resourceEmail": "tiny@resource.calendar.google.com",
+					"resourceCategory": "CONFERENCE_ROOM", "buildingId": "HQ", "capacity": 2,
+				},
+				{
+					"resourceId": "r4", "resourceName": "Projector", "resourceEmail": "projector@resource.calendar.google.com",
+					"resourceCategory": "OTHER", "buildingId": "HQ",
+				},
+				{
+					"resourceId": "r5", "resourceName": "Harbor", "resourceEmail": "harbor@resource.calendar.google.com",
+					"resourceCategory": "CONFERENCE_ROOM", "buildingId": "ANNEX", "capacity": 8,
+				},
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	orig := newAdminResourcesService
+	t.Cleanup(func() { newAdminResourcesService = orig })
+	newAdminResourcesService = func(ctx context.Context, _ string) (*admin.Service, error) {
+		return admin.NewService(ctx,
+			option.WithoutAuthentication(),
+			option.WithHTTPClient(srv.Client()),
+			option.WithEndpoint(srv.URL+"/"),
+		)
+	}
+}
+
+func TestCalendarResourceFeatures(t *testing.T) {
+	raw := []any{
+		map[string]any{"feature": map[string]any{"name": "Whiteboard"}},
+		map[string]any{"feature": map[string]any{"name": "Display"}},
+	}
+	got := calendarResourceFeatures(raw)
+	if strings.Join(got, ",") != "Display,Whiteboard" {
+		t.Fatalf("unexpected features: %v", got)
+	}
+	if calendarResourceFeatures(nil) != nil {
+		t.Fatalf("expected nil for missing features")
+	}
+}
+
+func TestCalendarResourceFilter(t *testing.T) {
+	room := calendarResource{Name: "Atlas", BuildingID: "HQ", Capacity: 12, Category: "CONFERENCE_ROOM", Features: []string{"Whiteboard"}}
+	if !(calendarResourceFilter{Building: "hq", Capacity: 6, Features: []string{"whiteboard"}}).matches(room) {
+		t.Fatalf("expected match")
+	}
+	if (calendarResourceFilter{Capacity: 20}).matches(room) {
+		t.Fatalf("expected capacity mismatch")
+	}
+	if (calendarResourceFilter{Features: []string{"Display"}}).matches(room) {
+		t.Fatalf("expected feature mismatch")
+	}
+	if !room.matchesText("atl") || room.matchesText("annex") {
+		t.Fatalf("unexpected text match result")
+	}
+}
+
+func TestCalendarResourcesListCmd_JSONFilters(t *testing.T) {
+	newResourceDirectoryStandIn(t)
+
+	out := captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{"--json", "--account", "a@example.com", "calendar", "resources", "list", "--building", "HQ", "--capacity", "6"}); err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+		})
+	})
+	var parsed struct {
+		Resources []calendarResource `json:"resources"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json parse: %v\nout=%q", err, out)
+	}
+	if len(parsed.Resources) != 2 || parsed.Resources[0].Name != "Atlas" || parsed.Resources[1].Name != "Nook" {
+		t.Fatalf("unexpected resources: %#v", parsed.Resources)
+	}
+	if strings.Join(parsed.Resources[0].Features, ",") != "Display,Whiteboard" {
+		t.Fatalf("unexpected features: %#v", parsed.Resources[0])
+	}
+}
+
+func TestCalendarCreate_RoomAutoBooksSmallestFreeRoom(t *testing.T) {
+	newResourceDirectoryStandIn(t)
+
+	var freeBusyItems []string
+	var inserted calendar.Event
+	svc := newCalendarChangesTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/freeBusy"):
+			var req calendar.FreeBusyRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			cals := map[string]any{}
+			for _, item := range req.Items {
+				freeBusyItems = append(freeBusyItems, item.Id)
+				busy := []map[string]any{}
+				if item.Id == "nook@resource.calendar.google.com" {
+					busy = append(busy, map[string]any{"start": "2025-02-03T10:00:00Z", "end": "2025-02-03T10:30:00Z"})
+				}
+				cals[item.Id] = map[string]any{"busy": busy}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"calendars": cals})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/calendars/primary/events"):
+			body, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(body, &inserted)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "new", "summary": inserted.Summary})
+		case strings.Contains(r.URL.Path, "/calendarList/primary"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "primary", "timeZone": "UTC"})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	_ = captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{
+				"--json", "--account", "a@example.com",
+				"calendar", "create", "primary",
+				"--summary", "Planning",
+				"--from", "2025-02-03T10:00:00Z", "--to", "2025-02-03T11:00:00Z",
+				"--attendees", "b@example.com",
+				"--room", "auto", "--capacity", "4", "--building", "HQ",
+			}); err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+		})
+	})
+
+	// Tiny is too small, Projector is not a room, Harbor is in another building.
+	if strings.Join(freeBusyItems, ",") != "nook@resource.calendar.google.com,atlas@resource.calendar.google.com" {
+		t.Fatalf("unexpected free/busy candidates: %v", freeBusyItems)
+	}
+	if len(inserted.Attendees) != 2 {
+		t.Fatalf("unexpected attendees: %#v", inserted.Attendees)
+	}
+	room := inserted.Attendees[1]
+	if room.Email != "atlas@resource.calendar.google.com" || !room.Resource {
+		t.Fatalf("expected Atlas booked as resource attendee, got %#v", room)
+	}
+}
+
+func TestCalendarCreate_RoomFiltersRequireAuto(t *testing.T) {
+	err := Execute([]string{
+		"--account", "a@example.com",
+		"calendar", "create", "primary",
+		"--summary", "Planning",
+		"--from", "2025-02-03T10:00:00Z", "--to", "2025-02-03T11:00:00Z",
+		"--capacity", "4",
+	})
+	if err == nil || !strings.Contains(err.Error(), "--room auto") {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
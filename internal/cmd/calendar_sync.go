@@ -0,0 +1,678 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	calendarSyncModeFull = "full"
+
+	// Private extended properties stamped on mirrored events so later runs can
+	// find (and never re-mirror) them.
+	calendarSyncPropSource        = "gogSyncSource"
+	calendarSyncPropSourceID      = "gogSyncSourceId"
+	calendarSyncPropSourceUpdated = "gogSyncSourceUpdated"
+
+	calendarSyncActionCreate    = "create"
+	calendarSyncActionUpdate    = "update"
+	calendarSyncActionDelete    = "delete"
+	calendarSyncActionUnchanged = "unchanged"
+	calendarSyncActionSkip      = "skip"
+)
+
+type CalendarSyncCmd struct {
+	Source      string `name:"source" required:"" help:"Source calendar as [account:]calendarId (e.g. me@gmail.com:primary)"`
+	Target      string `name:"target" required:"" help:"Target calendar as [account:]calendarId (e.g. work@company.com:Blocked)"`
+	Mode        string `name:"mode" help:"Copy mode: busy-only (anonymised) or full" enum:"busy-only,full" default:"busy-only"`
+	BusySummary string `name:"busy-summary" help:"Summary used for mirrored events in busy-only mode" default:"Busy"`
+	IncludeFree bool   `name:"include-free" help:"Also mirror events shown as free (transparent)"`
+	FullResync  bool   `name:"full-resync" aliases:"reset" help:"Ignore the stored sync token, re-sync everything and prune orphaned mirrors"`
+}
+
+type calendarSyncEndpoint struct {
+	Account    string `json:"account"`
+	CalendarID string `json:"calendarId"`
+}
+
+type calendarSyncState struct {
+	Source      calendarSyncEndpoint `json:"source"`
+	Target      calendarSyncEndpoint `json:"target"`
+	Mode        string               `json:"mode"`
+	SyncToken   string               `json:"syncToken,omitempty"`
+	UpdatedAtMs int64                `json:"updatedAtMs,omitempty"`
+}
+
+type calendarSyncResult struct {
+	Action   string `json:"action"`
+	SourceID string `json:"sourceId"`
+	TargetID string `json:"targetId,omitempty"`
+	Start    string `json:"start,omitempty"`
+	Summary  string `json:"summary,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+func (c *CalendarSyncCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+
+	srcAccount, srcCalendar, err := parseCalendarSyncEndpoint(c.Source)
+	if err != nil {
+		return usagef("--source: %v", err)
+	}
+	dstAccount, dstCalendar, err := parseCalendarSyncEndpoint(c.Target)
+	if err != nil {
+		return usagef("--target: %v", err)
+	}
+	busySummary := strings.TrimSpace(c.BusySummary)
+	if busySummary == "" {
+		busySummary = "Busy"
+	}
+
+	if dryRunErr := dryRunExit(ctx, flags, "calendar.sync", map[string]any{
+		"source":       c.Source,
+		"target":       c.Target,
+		"mode":         c.Mode,
+		"include_free": c.IncludeFree,
+		"full_resync":  c.FullResync,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	srcAccount, err = resolveCalendarSyncAccount(flags, srcAccount)
+	if err != nil {
+		return err
+	}
+	dstAccount, err = resolveCalendarSyncAccount(flags, dstAccount)
+	if err != nil {
+		return err
+	}
+
+	srcSvc, err := newCalendarService(ctx, srcAccount)
+	if err != nil {
+		return err
+	}
+	dstSvc := srcSvc
+	if !strings.EqualFold(srcAccount, dstAccount) {
+		dstSvc, err = newCalendarService(ctx, dstAccount)
+		if err != nil {
+			return err
+		}
+	}
+	srcCalendar, err = resolveCalendarID(ctx, srcSvc, srcCalendar)
+	if err != nil {
+		return err
+	}
+	dstCalendar, err = resolveCalendarID(ctx, dstSvc, dstCalendar)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(srcAccount, dstAccount) && srcCalendar == dstCalendar {
+		return usage("--source and --target must be different calendars")
+	}
+
+	source := calendarSyncEndpoint{Account: srcAccount, CalendarID: srcCalendar}
+	target := calendarSyncEndpoint{Account: dstAccount, CalendarID: dstCalendar}
+	store, err := loadCalendarSyncStore(source, target)
+	if err != nil {
+		return err
+	}
+	state := store.state
+	syncToken := state.SyncToken
+	if c.FullResync || state.Mode != c.Mode {
+		syncToken = ""
+	}
+
+	events, nextSyncToken, err := fetchCalendarSyncEvents(ctx, srcSvc, srcCalendar, syncToken)
+	if err != nil && syncToken != "" && isCalendarSyncTokenExpired(err) {
+		u.Err().Println("Sync token expired; running full sync")
+		syncToken = ""
+		events, nextSyncToken, err = fetchCalendarSyncEvents(ctx, srcSvc, srcCalendar, "")
+	}
+	if err != nil {
+		return err
+	}
+	fullSync := syncToken == ""
+
+	syncer := &calendarSyncer{
+		dst:         dstSvc,
+		dstCalendar: dstCalendar,
+		key:         calendarSyncKey(source),
+		mode:        c.Mode,
+		busySummary: busySummary,
+		includeFree: c.IncludeFree,
+	}
+	if fullSync {
+		if err := syncer.loadMirrorIndex(ctx); err != nil {
+			return err
+		}
+	}
+
+	results := syncer.apply(ctx, events)
+	if fullSync {
+		results = append(results, syncer.pruneOrphans(ctx)...)
+	}
+
+	failed := 0
+	counts := map[string]int{}
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+			continue
+		}
+		counts[r.Action]++
+	}
+
+	// Only advance the token when every change landed, so failures are retried.
+	if failed == 0 && nextSyncToken != "" {
+		state.Source = source
+		state.Target = target
+		state.Mode = c.Mode
+		state.SyncToken = nextSyncToken
+		state.UpdatedAtMs = time.Now().UnixMilli()
+		store.state = state
+		if err := store.Save(); err != nil {
+			return err
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"source":    source,
+			"target":    target,
+			"mode":      c.Mode,
+			"fullSync":  fullSync,
+			"results":   results,
+			"created":   counts[calendarSyncActionCreate],
+			"updated":   counts[calendarSyncActionUpdate],
+			"deleted":   counts[calendarSyncActionDelete],
+			"unchanged": counts[calendarSyncActionUnchanged],
+			"skipped":   counts[calendarSyncActionSkip],
+			"failed":    failed,
+		}); err != nil {
+			return err
+		}
+	} else {
+		printCalendarSyncResults(ctx, u, results)
+		u.Err().Printf("Synced %s -> %s: %d created, %d updated, %d deleted, %d unchanged, %d failed",
+			calendarSyncKey(source), calendarSyncKey(target),
+			counts[calendarSyncActionCreate], counts[calendarSyncActionUpdate], counts[calendarSyncActionDelete],
+			counts[calendarSyncActionUnchanged], failed)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d event(s) failed to sync; sync token not advanced", failed)
+	}
+	return nil
+}
+
+func printCalendarSyncResults(ctx context.Context, u *ui.UI, results []calendarSyncResult) {
+	changed := make([]calendarSyncResult, 0, len(results))
+	for _, r := range results {
+		if r.Action == calendarSyncActionUnchanged || r.Action == calendarSyncActionSkip {
+			continue
+		}
+		changed = append(changed, r)
+	}
+	if len(changed) == 0 {
+		u.Err().Println("No changes")
+		return
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ACTION\tSOURCE_ID\tTARGET_ID\tSTART\tSUMMARY\tERROR")
+	for _, r := range changed {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", r.Action, r.SourceID, r.TargetID, r.Start, r.Summary, r.Error)
+	}
+}
+
+// parseCalendarSyncEndpoint splits "[account:]calendarId". Calendar IDs never
+// contain ':', so the first colon separates an account (email or alias).
+func parseCalendarSyncEndpoint(raw string) (string, string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", "", errors.New("empty calendar")
+	}
+	account, calendarID, found := strings.Cut(raw, ":")
+	if !found {
+		return "", raw, nil
+	}
+	account = strings.TrimSpace(account)
+	calendarID = strings.TrimSpace(calendarID)
+	if calendarID == "" {
+		return "", "", fmt.Errorf("missing calendar in %q (expected account:calendarId)", raw)
+	}
+	return account, calendarID, nil
+}
+
+func resolveCalendarSyncAccount(flags *RootFlags, account string) (string, error) {
+	scoped := RootFlags{}
+	if flags != nil {
+		scoped = *flags
+	}
+	if strings.TrimSpace(account) != "" {
+		scoped.Account = account
+	}
+	return requireAccount(&scoped)
+}
+
+func calendarSyncKey(e calendarSyncEndpoint) string {
+	return strings.ToLower(strings.TrimSpace(e.Account)) + "/" + strings.TrimSpace(e.CalendarID)
+}
+
+type calendarSyncStore struct {
+	path  string
+	state calendarSyncState
+}
+
+func calendarSyncStatePath(source, target calendarSyncEndpoint) (string, error) {
+	dir, err := config.EnsureCalendarSyncDir()
+	if err != nil {
+		return "", err
+	}
+	name := sanitizeAccountForPath(source.Account+"_"+source.CalendarID) +
+		"--" + sanitizeAccountForPath(target.Account+"_"+target.CalendarID)
+	return filepath.Join(dir, name+".json"), nil
+}
+
+func loadCalendarSyncStore(source, target calendarSyncEndpoint) (*calendarSyncStore, error) {
+	path, err := calendarSyncStatePath(source, target)
+	if err != nil {
+		return nil, err
+	}
+	store := &calendarSyncStore{path: path}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return store, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &store.state); err != nil {
+		return nil, fmt.Errorf("read calendar sync state %s: %w", path, err)
+	}
+	return store, nil
+}
+
+func (s *calendarSyncStore) Save() error {
+	payload, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, append(payload, '\n'), 0o600)
+}
+
+// fetchCalendarSyncEvents lists every event (full sync) or only the changes
+// since syncToken (incremental sync), returning the token for the next run.
+func fetchCalendarSyncEvents(ctx context.Context, svc *calendar.Service, calendarID, syncToken string) ([]*calendar.Event, string, error) {
+	var (
+		events    []*calendar.Event
+		pageToken string
+	)
+	for {
+		call := svc.Events.List(calendarID).MaxResults(250).Context(ctx)
+		if syncToken != "" {
+			call = call.SyncToken(syncToken)
+		}
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		events = append(events, resp.Items...)
+		if resp.NextPageToken == "" {
+			return events, resp.NextSyncToken, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+func isCalendarSyncTokenExpired(err error) bool {
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) {
+		return gerr.Code == http.StatusGone
+	}
+	return false
+}
+
+type calendarSyncer struct {
+	dst         *calendar.Service
+	dstCalendar string
+	key         string
+	mode        string
+	busySummary string
+	includeFree bool
+
+	// index maps source event IDs to their mirrors; only populated on full sync.
+	index map[string]*calendar.Event
+	seen  map[string]bool
+}
+
+func (s *calendarSyncer) loadMirrorIndex(ctx context.Context) error {
+	s.index = map[string]*calendar.Event{}
+	s.seen = map[string]bool{}
+	pageToken := ""
+	for {
+		call := s.dst.Events.List(s.dstCalendar).
+			PrivateExtendedProperty(calendarSyncPropSource + "=" + s.key).
+			MaxResults(250).
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return err
+		}
+		for _, ev := range resp.Items {
+			// Modified instances inherit the master's properties; track masters only.
+			if ev == nil || ev.RecurringEventId != "" {
+				continue
+			}
+			if id := calendarSyncSourceID(ev); id != "" {
+				s.index[id] = ev
+			}
+		}
+		if resp.NextPageToken == "" {
+			return nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+func (s *calendarSyncer) findMirror(ctx context.Context, sourceID string) (*calendar.Event, error) {
+	if s.index != nil {
+		return s.index[sourceID], nil
+	}
+	resp, err := s.dst.Events.List(s.dstCalendar).
+		PrivateExtendedProperty(calendarSyncPropSource+"="+s.key, calendarSyncPropSourceID+"="+sourceID).
+		MaxResults(10).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+	for _, ev := range resp.Items {
+		if ev != nil && ev.RecurringEventId == "" {
+			return ev, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *calendarSyncer) apply(ctx context.Context, events []*calendar.Event) []calendarSyncResult {
+	// Masters first so modified instances can be matched against their mirror.
+	sorted := make([]*calendar.Event, 0, len(events))
+	for _, ev := range events {
+		if ev != nil {
+			sorted = append(sorted, ev)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].RecurringEventId == "" && sorted[j].RecurringEventId != ""
+	})
+
+	results := make([]calendarSyncResult, 0, len(sorted))
+	for _, ev := range sorted {
+		var r calendarSyncResult
+		if ev.RecurringEventId != "" {
+			r = s.applyInstance(ctx, ev)
+		} else {
+			r = s.applyEvent(ctx, ev)
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+func (s *calendarSyncer) applyEvent(ctx context.Context, ev *calendar.Event) calendarSyncResult {
+	r := calendarSyncResult{SourceID: ev.Id, Start: eventStart(ev), Summary: ev.Summary}
+	if s.seen != nil {
+		s.seen[ev.Id] = true
+	}
+
+	existing, err := s.findMirror(ctx, ev.Id)
+	if err != nil {
+		r.Action = calendarSyncActionUpdate
+		r.Error = err.Error()
+		return r
+	}
+
+	if reason := s.skipReason(ev); reason != "" {
+		if existing == nil {
+			r.Action = calendarSyncActionSkip
+			r.Reason = reason
+			return r
+		}
+		return s.deleteMirror(ctx, r, existing.Id, reason)
+	}
+
+	mirror := buildCalendarSyncMirror(ev, s.mode, s.busySummary, s.key)
+	r.Summary = mirror.Summary
+	if existing == nil {
+		created, err := s.dst.Events.Insert(s.dstCalendar, mirror).Context(ctx).Do()
+		r.Action = calendarSyncActionCreate
+		if err != nil {
+			r.Error = err.Error()
+			return r
+		}
+		r.TargetID = created.Id
+		return r
+	}
+
+	r.TargetID = existing.Id
+	if calendarSyncSourceUpdated(existing) == ev.Updated && ev.Updated != "" {
+		r.Action = calendarSyncActionUnchanged
+		return r
+	}
+	r.Action = calendarSyncActionUpdate
+	if _, err := s.dst.Events.Update(s.dstCalendar, existing.Id, mirror).Context(ctx).Do(); err != nil {
+		r.Error = err.Error()
+	}
+	return r
+}
+
+// applyInstance mirrors a modified or cancelled occurrence of a recurring
+// event onto the matching occurrence of the mirrored series.
+func (s *calendarSyncer) applyInstance(ctx context.Context, ev *calendar.Event) calendarSyncResult {
+	r := calendarSyncResult{SourceID: ev.Id, Start: eventStart(ev), Summary: ev.Summary}
+	if s.seen != nil {
+		s.seen[ev.Id] = true
+	}
+
+	master, err := s.findMirror(ctx, ev.RecurringEventId)
+	if err != nil {
+		r.Action = calendarSyncActionUpdate
+		r.Error = err.Error()
+		return r
+	}
+	if master == nil {
+		// Series not mirrored (e.g. skipped as free): the occurrence is mirrored
+		// on its own, and a cancellation removes that standalone mirror.
+		return s.applyEvent(ctx, ev)
+	}
+
+	instance, err := s.findMirrorInstance(ctx, master.Id, ev.OriginalStartTime)
+	if err != nil {
+		r.Action = calendarSyncActionUpdate
+		r.Error = err.Error()
+		return r
+	}
+	if instance == nil {
+		r.Action = calendarSyncActionSkip
+		r.Reason = "occurrence not found in mirrored series"
+		return r
+	}
+	r.TargetID = instance.Id
+
+	if ev.Status == eventStatusCancelled {
+		return s.deleteMirror(ctx, r, instance.Id, "")
+	}
+	if reason := s.skipReason(ev); reason != "" {
+		return s.deleteMirror(ctx, r, instance.Id, reason)
+	}
+
+	mirror := buildCalendarSyncMirror(ev, s.mode, s.busySummary, s.key)
+	mirror.Recurrence = nil
+	r.Summary = mirror.Summary
+	if calendarSyncSourceUpdated(instance) == ev.Updated && ev.Updated != "" {
+		r.Action = calendarSyncActionUnchanged
+		return r
+	}
+	r.Action = calendarSyncActionUpdate
+	if _, err := s.dst.Events.Patch(s.dstCalendar, instance.Id, mirror).Context(ctx).Do(); err != nil {
+		r.Error = err.Error()
+	}
+	return r
+}
+
+func (s *calendarSyncer) findMirrorInstance(ctx context.Context, masterID string, original *calendar.EventDateTime) (*calendar.Event, error) {
+	if original == nil {
+		return nil, nil
+	}
+	originalStart := original.DateTime
+	if originalStart == "" {
+		originalStart = original.Date
+	}
+	if originalStart == "" {
+		return nil, nil
+	}
+	resp, err := s.dst.Events.Instances(s.dstCalendar, masterID).
+		OriginalStart(originalStart).
+		MaxResults(1).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Items) == 0 {
+		return nil, nil
+	}
+	return resp.Items[0], nil
+}
+
+func (s *calendarSyncer) deleteMirror(ctx context.Context, r calendarSyncResult, targetID, reason string) calendarSyncResult {
+	r.Action = calendarSyncActionDelete
+	r.TargetID = targetID
+	r.Reason = reason
+	err := s.dst.Events.Delete(s.dstCalendar, targetID).Context(ctx).Do()
+	if err != nil && !isCalendarEventGoneError(err) {
+		r.Error = err.Error()
+	}
+	return r
+}
+
+// pruneOrphans deletes mirrors whose source event no longer exists. Only
+// meaningful after a full sync, when every live source event has been seen.
+func (s *calendarSyncer) pruneOrphans(ctx context.Context) []calendarSyncResult {
+	ids := make([]string, 0, len(s.index))
+	for id := range s.index {
+		if !s.seen[id] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	results := make([]calendarSyncResult, 0, len(ids))
+	for _, id := range ids {
+		mirror := s.index[id]
+		r := calendarSyncResult{SourceID: id, Start: eventStart(mirror), Summary: mirror.Summary}
+		results = append(results, s.deleteMirror(ctx, r, mirror.Id, "source event removed"))
+	}
+	return results
+}
+
+// skipReason reports why a live source event should not occupy the target
+// calendar (any existing mirror is removed).
+func (s *calendarSyncer) skipReason(ev *calendar.Event) string {
+	switch {
+	case ev.Status == eventStatusCancelled:
+		return "cancelled"
+	case calendarSyncSourceKey(ev) != "":
+		return "event is itself a mirror"
+	case ev.EventType == eventTypeWorkingLocation:
+		return "working location"
+	case ev.Transparency == transparencyTransparent && !s.includeFree:
+		return "shown as free"
+	}
+	for _, a := range ev.Attendees {
+		if a != nil && a.Self && a.ResponseStatus == "declined" {
+			return "declined"
+		}
+	}
+	return ""
+}
+
+func buildCalendarSyncMirror(src *calendar.Event, mode, busySummary, key string) *calendar.Event {
+	mirror := &calendar.Event{
+		Start:        src.Start,
+		End:          src.End,
+		Recurrence:   src.Recurrence,
+		Transparency: src.Transparency,
+		Reminders: &calendar.EventReminders{
+			UseDefault:      false,
+			ForceSendFields: []string{"UseDefault"},
+		},
+		ExtendedProperties: &calendar.EventExtendedProperties{
+			Private: map[string]string{
+				calendarSyncPropSource:        key,
+				calendarSyncPropSourceID:      src.Id,
+				calendarSyncPropSourceUpdated: src.Updated,
+			},
+		},
+	}
+	if mode == calendarSyncModeFull {
+		mirror.Summary = src.Summary
+		mirror.Description = src.Description
+		mirror.Location = src.Location
+		mirror.Visibility = src.Visibility
+		mirror.ColorId = src.ColorId
+		return mirror
+	}
+	mirror.Summary = busySummary
+	mirror.Visibility = "private"
+	return mirror
+}
+
+func calendarSyncPrivateProp(ev *calendar.Event, key string) string {
+	if ev == nil || ev.ExtendedProperties == nil {
+		return ""
+	}
+	return ev.ExtendedProperties.Private[key]
+}
+
+func calendarSyncSourceKey(ev *calendar.Event) string {
+	return calendarSyncPrivateProp(ev, calendarSyncPropSource)
+}
+
+func calendarSyncSourceID(ev *calendar.Event) string {
+	return calendarSyncPrivateProp(ev, calendarSyncPropSourceID)
+}
+
+func calendarSyncSourceUpdated(ev *calendar.Event) string {
+	return calendarSyncPrivateProp(ev, calendarSyncPropSourceUpdated)
+}
+
+func isCalendarEventGoneError(err error) bool {
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) {
+		return gerr.Code == http.StatusNotFound || gerr.Code == http.StatusGone
+	}
+	return false
+}
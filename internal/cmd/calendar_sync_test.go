@@ -0,0 +1,296 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+func TestParseCalendarSyncEndpoint(t *testing.T) {
+	tests := []struct {
+		in      string
+		account string
+		cal     string
+		wantErr bool
+	}{
+		{in: "primary", cal: "primary"},
+		{in: "me@gmail.com:primary", account: "me@gmail.com", cal: "primary"},
+		{in: "work:Blocked", account: "work", cal: "Blocked"},
+		{in: " a@b.com : c@group.calendar.google.com ", account: "a@b.com", cal: "c@group.calendar.google.com"},
+		{in: "a@b.com:", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		account, cal, err := parseCalendarSyncEndpoint(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("%q: err=%v wantErr=%v", tt.in, err, tt.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		if account != tt.account || cal != tt.cal {
+			t.Fatalf("%q: got (%q, %q), want (%q, %q)", tt.in, account, cal, tt.account, tt.cal)
+		}
+	}
+}
+
+func TestBuildCalendarSyncMirror(t *testing.T) {
+	src := &calendar.Event{
+		Id:          "e1",
+		Summary:     "Doctor",
+		Description: "private notes",
+		Location:    "Clinic",
+		Updated:     "2025-01-01T00:00:00Z",
+		Start:       &calendar.EventDateTime{DateTime: "2025-01-02T10:00:00Z"},
+		End:         &calendar.EventDateTime{DateTime: "2025-01-02T11:00:00Z"},
+		Recurrence:  []string{"RRULE:FREQ=WEEKLY"},
+	}
+
+	busy := buildCalendarSyncMirror(src, "busy-only", "Busy", "me@gmail.com/primary")
+	if busy.Summary != "Busy" || busy.Description != "" || busy.Location != "" || busy.Visibility != "private" {
+		t.Fatalf("busy-only mirror leaked details: %#v", busy)
+	}
+	if len(busy.Recurrence) != 1 {
+		t.Fatalf("expected recurrence to be copied: %#v", busy.Recurrence)
+	}
+	props := busy.ExtendedProperties.Private
+	if props[calendarSyncPropSource] != "me@gmail.com/primary" || props[calendarSyncPropSourceID] != "e1" || props[calendarSyncPropSourceUpdated] != src.Updated {
+		t.Fatalf("unexpected private props: %#v", props)
+	}
+
+	full := buildCalendarSyncMirror(src, calendarSyncModeFull, "Busy", "k")
+	if full.Summary != "Doctor" || full.Location != "Clinic" || full.Description != "private notes" {
+		t.Fatalf("full mirror missing details: %#v", full)
+	}
+}
+
+func TestCalendarSyncSkipReason(t *testing.T) {
+	s := &calendarSyncer{}
+	tests := []struct {
+		name string
+		ev   *calendar.Event
+		want string
+	}{
+		{name: "busy", ev: &calendar.Event{}, want: ""},
+		{name: "free", ev: &calendar.Event{Transparency: "transparent"}, want: "shown as free"},
+		{name: "declined", ev: &calendar.Event{Attendees: []*calendar.EventAttendee{{Self: true, ResponseStatus: "declined"}}}, want: "declined"},
+		{name: "working location", ev: &calendar.Event{EventType: eventTypeWorkingLocation}, want: "working location"},
+		{name: "mirror", ev: &calendar.Event{ExtendedProperties: &calendar.EventExtendedProperties{Private: map[string]string{calendarSyncPropSource: "x"}}}, want: "event is itself a mirror"},
+	}
+	for _, tt := range tests {
+		if got := s.skipReason(tt.ev); got != tt.want {
+			t.Fatalf("%s: got %q want %q", tt.name, got, tt.want)
+		}
+	}
+	s.includeFree = true
+	if got := s.skipReason(&calendar.Event{Transparency: "transparent"}); got != "" {
+		t.Fatalf("expected free event to be mirrored with --include-free, got %q", got)
+	}
+}
+
+func TestCalendarSyncCmd_CreatesThenDeletesMirror(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+
+	var (
+		mu      sync.Mutex
+		target  = map[string]map[string]any{}
+		nextID  = 0
+		deleted []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		path := r.URL.Path
+		switch {
+		case strings.Contains(path, "/calendars/src@example.com/events") && r.Method == http.MethodGet:
+			if r.URL.Query().Get("syncToken") == "tok1" {
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"items":         []map[string]any{{"id": "e1", "status": "cancelled"}},
+					"nextSyncToken": "tok2",
+				})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"items": []map[string]any{
+					{
+						"id":      "e1",
+						"status":  "confirmed",
+						"summary": "Dentist",
+						"updated": "2025-01-01T00:00:00Z",
+						"start":   map[string]any{"dateTime": "2025-01-02T10:00:00Z"},
+						"end":     map[string]any{"dateTime": "2025-01-02T11:00:00Z"},
+					},
+					{
+						"id":           "e2",
+						"status":       "confirmed",
+						"summary":      "Reminder",
+						"transparency": "transparent",
+						"start":        map[string]any{"dateTime": "2025-01-02T12:00:00Z"},
+						"end":          map[string]any{"dateTime": "2025-01-02T13:00:00Z"},
+					},
+				},
+				"nextSyncToken": "tok1",
+			})
+			return
+		case strings.Contains(path, "/calendars/dst@example.com/events") && r.Method == http.MethodGet:
+			items := []map[string]any{}
+			for _, ev := range target {
+				items = append(items, ev)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": items})
+			return
+		case strings.HasSuffix(path, "/calendars/dst@example.com/events") && r.Method == http.MethodPost:
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			nextID++
+			id := "m" + string(rune('0'+nextID))
+			body["id"] = id
+			target[id] = body
+			_ = json.NewEncoder(w).Encode(body)
+			return
+		case strings.Contains(path, "/calendars/dst@example.com/events/") && r.Method == http.MethodDelete:
+			id := path[strings.LastIndex(path, "/")+1:]
+			delete(target, id)
+			deleted = append(deleted, id)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	svc, err := calendar.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	run := func() map[string]any {
+		out := captureStdout(t, func() {
+			_ = captureStderr(t, func() {
+				if err := Execute([]string{
+					"--json",
+					"--account", "a@b.com",
+					"calendar", "sync",
+					"--source", "me@gmail.com:src@example.com",
+					"--target", "work@company.com:dst@example.com",
+				}); err != nil {
+					t.Fatalf("Execute: %v", err)
+				}
+			})
+		})
+		var parsed map[string]any
+		if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+			t.Fatalf("json parse: %v\nout=%q", err, out)
+		}
+		return parsed
+	}
+
+	first := run()
+	if first["fullSync"] != true || first["created"] != float64(1) || first["skipped"] != float64(1) {
+		t.Fatalf("unexpected first run: %#v", first)
+	}
+	mu.Lock()
+	if len(target) != 1 {
+		mu.Unlock()
+		t.Fatalf("expected 1 mirror, got %#v", target)
+	}
+	for _, ev := range target {
+		if ev["summary"] != "Busy" {
+			t.Fatalf("expected anonymised summary, got %#v", ev["summary"])
+		}
+	}
+	mu.Unlock()
+
+	path, err := calendarSyncStatePath(
+		calendarSyncEndpoint{Account: "me@gmail.com", CalendarID: "src@example.com"},
+		calendarSyncEndpoint{Account: "work@company.com", CalendarID: "dst@example.com"},
+	)
+	if err != nil {
+		t.Fatalf("state path: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), `"syncToken": "tok1"`) {
+		t.Fatalf("expected stored sync token, got %q (err=%v)", string(data), err)
+	}
+
+	second := run()
+	if second["fullSync"] != false || second["deleted"] != float64(1) {
+		t.Fatalf("unexpected second run: %#v", second)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(target) != 0 || len(deleted) != 1 {
+		t.Fatalf("expected mirror to be deleted, target=%#v deleted=%v", target, deleted)
+	}
+}
+
+func TestCalendarSyncer_CancelledInstanceDeletesStandaloneMirror(t *testing.T) {
+	var deleted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted = append(deleted, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	svc, err := calendar.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	s := &calendarSyncer{
+		dst:         svc,
+		dstCalendar: "dst@example.com",
+		key:         "k",
+		index:       map[string]*calendar.Event{"series_20250102": {Id: "m1"}},
+		seen:        map[string]bool{},
+	}
+
+	r := s.applyInstance(context.Background(), &calendar.Event{
+		Id:               "series_20250102",
+		RecurringEventId: "series",
+		Status:           eventStatusCancelled,
+	})
+	if r.Action != calendarSyncActionDelete || r.TargetID != "m1" || r.Error != "" {
+		t.Fatalf("expected standalone mirror to be deleted, got %#v", r)
+	}
+	if strings.Join(deleted, ",") != "m1" {
+		t.Fatalf("unexpected deletes: %v", deleted)
+	}
+
+	r = s.applyInstance(context.Background(), &calendar.Event{
+		Id:               "series_20250109",
+		RecurringEventId: "series",
+		Status:           eventStatusCancelled,
+	})
+	if r.Action != calendarSyncActionSkip || len(deleted) != 1 {
+		t.Fatalf("expected skip without a mirror, got %#v (deletes %v)", r, deleted)
+	}
+}
@@ -2,6 +2,8 @@ package cmd
 
 import "google.golang.org/api/calendar/v3"
 
+const eventStatusCancelled = "cancelled"
+
 func isAllDayEvent(e *calendar.Event) bool {
 	return e != nil && e.Start != nil && e.Start.Date != ""
 }
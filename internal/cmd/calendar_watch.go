@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type CalendarWatchCmd struct {
+	Serve  CalendarWatchServeCmd  `cmd:"" name:"serve" help:"Register an events.watch channel and handle push notifications"`
+	Status CalendarWatchStatusCmd `cmd:"" name:"status" aliases:"ls" help:"Show stored watch channels and sync tokens"`
+	Stop   CalendarWatchStopCmd   `cmd:"" name:"stop" aliases:"rm,delete" help:"Stop a watch channel"`
+}
+
+type CalendarWatchServeCmd struct {
+	CalendarID string `arg:"" name:"calendarId" optional:"" help:"Calendar ID or name (default: primary)"`
+	Bind       string `name:"bind" help:"Bind address" default:"127.0.0.1"`
+	Port       int    `name:"port" help:"Listen port" default:"8789"`
+	Path       string `name:"path" help:"Push handler path" default:"/calendar-push"`
+	Address    string `name:"address" help:"Public HTTPS URL Google should deliver notifications to (registers a channel; omit to reuse the stored one)"`
+	Token      string `name:"token" help:"Channel token expected in X-Goog-Channel-Token (default: random)"`
+	TTL        string `name:"ttl" help:"Requested channel lifetime (seconds or Go duration)"`
+	HookURL    string `name:"hook-url" help:"Webhook URL to forward changes to (default: print JSONL to stdout)"`
+	HookToken  string `name:"hook-token" help:"Webhook bearer token"`
+	SaveHook   bool   `name:"save-hook" help:"Persist hook settings to watch state"`
+}
+
+func (c *CalendarWatchServeCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	address, ttl, err := parseWatchServeFlags(c.Path, c.Port, c.Address, c.TTL, c.HookURL, c.HookToken)
+	if err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	calendarID := strings.TrimSpace(c.CalendarID)
+	if calendarID == "" {
+		calendarID = primaryCalendarID
+	}
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	calendarID, err = resolveCalendarID(ctx, svc, calendarID)
+	if err != nil {
+		return err
+	}
+
+	store, err := openCalendarWatchStore(account)
+	if err != nil {
+		return err
+	}
+
+	hook := resolveWatchHook(store.Get().Hook, c.HookURL, c.HookToken)
+	if c.SaveHook && hook != nil {
+		if err := store.Update(func(s *calendarWatchState) error {
+			s.Hook = hook
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	channel := store.CalendarState(calendarID).Channel
+	if address != "" && !watchChannelReusable(channel, address, time.Now()) {
+		channel, err = registerCalendarWatchChannel(ctx, svc, store, calendarID, address, c.Token, ttl)
+		if err != nil {
+			return err
+		}
+		u.Err().Printf("watch: registered channel %s (expires %s)", channel.ID, formatUnixMillis(channel.ExpirationMs))
+	}
+	if channel == nil {
+		return usage("no stored channel for this calendar; pass --address to register one")
+	}
+
+	// Establish a baseline so the first notification only reports new changes.
+	if store.CalendarState(calendarID).SyncToken == "" {
+		if _, err := collectCalendarChanges(ctx, svc, store, calendarID); err != nil {
+			return err
+		}
+	}
+
+	server := &calendarWatchServer{
+		watchPushServer: newWatchPushServer(ctx, c.Path, *channel, hook),
+		account:         account,
+		calendarID:      calendarID,
+		store:           store,
+		svc:             svc,
+	}
+	return serveWatch(ctx, c.Bind, c.Port, c.Path, server)
+}
+
+func registerCalendarWatchChannel(ctx context.Context, svc *calendar.Service, store *calendarWatchStore, calendarID, address, token string, ttl time.Duration) (*watchChannel, error) {
+	id, token, err := newWatchChannelIDs(token)
+	if err != nil {
+		return nil, err
+	}
+	req := &calendar.Channel{
+		Id:      id,
+		Type:    "web_hook",
+		Address: address,
+		Token:   token,
+	}
+	if ttl > 0 {
+		req.Params = map[string]string{"ttl": strconv.FormatInt(int64(ttl/time.Second), 10)}
+	}
+	resp, err := svc.Events.Watch(calendarID, req).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	previous := store.CalendarState(calendarID).Channel
+	channel := &watchChannel{
+		ID:           resp.Id,
+		ResourceID:   resp.ResourceId,
+		Address:      address,
+		Token:        token,
+		ExpirationMs: resp.Expiration,
+	}
+	if err := store.Update(func(s *calendarWatchState) error {
+		s.calendar(calendarID).Channel = channel
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	// Best-effort: the old channel would otherwise keep firing until it expires.
+	if previous != nil && previous.ID != "" && previous.ID != channel.ID {
+		_ = stopCalendarWatchChannel(ctx, svc, previous)
+	}
+	return channel, nil
+}
+
+func stopCalendarWatchChannel(ctx context.Context, svc *calendar.Service, ch *watchChannel) error {
+	return svc.Channels.Stop(&calendar.Channel{Id: ch.ID, ResourceId: ch.ResourceID}).Context(ctx).Do()
+}
+
+type calendarHookPayload struct {
+	Account    string           `json:"account"`
+	CalendarID string           `json:"calendarId"`
+	FullSync   bool             `json:"fullSync,omitempty"`
+	Changes    []calendarChange `json:"changes"`
+}
+
+type calendarWatchServer struct {
+	watchPushServer
+	account    string
+	calendarID string
+	store      *calendarWatchStore
+	svc        *calendar.Service
+}
+
+func (s *calendarWatchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.accept(w, r, "exists", "not_exists") {
+		return
+	}
+
+	s.mu.Lock()
+	result, err := collectCalendarChanges(r.Context(), s.svc, s.store, s.calendarID)
+	s.mu.Unlock()
+	if err != nil {
+		s.warnf("watch: fetch changes failed: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	payload := &calendarHookPayload{
+		Account:    s.account,
+		CalendarID: s.calendarID,
+		FullSync:   result.FullSync,
+		Changes:    result.Changes,
+	}
+	deliverWatchChanges(r.Context(), &s.watchPushServer, w, s.store, payload, result.Changes)
+}
+
+type CalendarWatchStatusCmd struct{}
+
+func (c *CalendarWatchStatusCmd) Run(ctx context.Context, flags *RootFlags) error {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	store, err := openCalendarWatchStore(account)
+	if err != nil {
+		return err
+	}
+	state := store.Get()
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"watch": state})
+	}
+
+	u := ui.FromContext(ctx)
+	if len(state.Calendars) == 0 {
+		u.Err().Println("No calendar watch state")
+		return nil
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "CALENDAR\tSYNC_TOKEN\tCHANNEL\tEXPIRES\tUPDATED")
+	ids := make([]string, 0, len(state.Calendars))
+	for id := range state.Calendars {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		cal := state.Calendars[id]
+		hasToken := "no"
+		if cal.SyncToken != "" {
+			hasToken = "yes"
+		}
+		channelID, expires := "", ""
+		if cal.Channel != nil {
+			channelID = cal.Channel.ID
+			expires = formatUnixMillis(cal.Channel.ExpirationMs)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", id, hasToken, channelID, expires, formatUnixMillis(cal.UpdatedAtMs))
+	}
+	return nil
+}
+
+type CalendarWatchStopCmd struct {
+	CalendarID string `arg:"" name:"calendarId" optional:"" help:"Calendar ID or name (default: primary)"`
+}
+
+func (c *CalendarWatchStopCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	calendarID := strings.TrimSpace(c.CalendarID)
+	if calendarID == "" {
+		calendarID = primaryCalendarID
+	}
+
+	if confirmErr := confirmDestructive(ctx, flags, "stop calendar watch channel for "+calendarID); confirmErr != nil {
+		return confirmErr
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	calendarID, err = resolveCalendarID(ctx, svc, calendarID)
+	if err != nil {
+		return err
+	}
+	store, err := openCalendarWatchStore(account)
+	if err != nil {
+		return err
+	}
+	channel := store.CalendarState(calendarID).Channel
+	if channel == nil {
+		return usagef("no stored channel for calendar %s", calendarID)
+	}
+	if err := stopCalendarWatchChannel(ctx, svc, channel); err != nil && !isNotFoundAPIError(err) {
+		return err
+	}
+	if err := store.Update(func(s *calendarWatchState) error {
+		s.calendar(calendarID).Channel = nil
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"stopped": true, "channelId": channel.ID})
+	}
+	u.Out().Printf("stopped\ttrue")
+	u.Out().Printf("channel_id\t%s", channel.ID)
+	return nil
+}
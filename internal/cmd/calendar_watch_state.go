@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/steipete/gogcli/internal/config"
+)
+
+type calendarWatchCalendarState struct {
+	SyncToken   string        `json:"syncToken,omitempty"`
+	UpdatedAtMs int64         `json:"updatedAtMs,omitempty"`
+	Channel     *watchChannel `json:"channel,omitempty"`
+}
+
+// calendarWatchState is persisted per account and keyed by calendar ID, so
+// `calendar changes` and `calendar watch serve` share sync tokens.
+type calendarWatchState struct {
+	Account   string                                 `json:"account"`
+	Calendars map[string]*calendarWatchCalendarState `json:"calendars,omitempty"`
+	Hook      *watchHook                             `json:"hook,omitempty"`
+	watchDelivery
+}
+
+func (s *calendarWatchState) calendar(calendarID string) *calendarWatchCalendarState {
+	if s.Calendars == nil {
+		s.Calendars = map[string]*calendarWatchCalendarState{}
+	}
+	cal := s.Calendars[calendarID]
+	if cal == nil {
+		cal = &calendarWatchCalendarState{}
+		s.Calendars[calendarID] = cal
+	}
+	return cal
+}
+
+type calendarWatchStore struct {
+	*watchStore[calendarWatchState]
+}
+
+func calendarWatchStatePath(account string) (string, error) {
+	dir, err := config.EnsureCalendarWatchDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, sanitizeAccountForPath(account)+".json"), nil
+}
+
+// openCalendarWatchStore loads stored state for account, starting empty when
+// nothing has been recorded yet.
+func openCalendarWatchStore(account string) (*calendarWatchStore, error) {
+	path, err := calendarWatchStatePath(account)
+	if err != nil {
+		return nil, err
+	}
+	store, err := openWatchStore(path, calendarWatchState{Account: account})
+	if err != nil {
+		return nil, err
+	}
+	return &calendarWatchStore{store}, nil
+}
+
+// CalendarState returns a copy of the stored state for calendarID.
+func (s *calendarWatchStore) CalendarState(calendarID string) calendarWatchCalendarState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cal := s.state.Calendars[calendarID]; cal != nil {
+		return *cal
+	}
+	return calendarWatchCalendarState{}
+}
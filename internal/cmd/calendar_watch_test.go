@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCalendarWatchServer_ForwardsChanges(t *testing.T) {
+	svc := newCalendarChangesTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("syncToken") != "t1" {
+			t.Errorf("expected stored sync token, got %q", r.URL.RawQuery)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"items": []map[string]any{
+				{"id": "e1", "status": "confirmed", "summary": "Planning", "created": "2025-01-01T00:00:00Z", "updated": "2025-01-03T00:00:00Z"},
+			},
+			"nextSyncToken": "t2",
+		})
+	})
+
+	var hookBody []byte
+	var hookAuth string
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hookAuth = r.Header.Get("Authorization")
+		hookBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer hook.Close()
+
+	store, err := openCalendarWatchStore("watch-user@example.com")
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	if err := store.Update(func(s *calendarWatchState) error {
+		s.calendar("primary").SyncToken = "t1"
+		return nil
+	}); err != nil {
+		t.Fatalf("seed store: %v", err)
+	}
+
+	var out bytes.Buffer
+	server := &calendarWatchServer{
+		watchPushServer: watchPushServer{
+			path:       "/calendar-push",
+			channel:    watchChannel{ID: "chan-1", Token: "secret"},
+			hook:       &watchHook{URL: hook.URL, Token: "hook-token"},
+			hookClient: hook.Client(),
+			out:        json.NewEncoder(&out),
+			logf:       func(string, ...any) {},
+			warnf:      func(string, ...any) {},
+		},
+		account:    "watch-user@example.com",
+		calendarID: "primary",
+		store:      store,
+		svc:        svc,
+	}
+
+	notify := func(channelID, token, state string) int {
+		req := httptest.NewRequest(http.MethodPost, "/calendar-push", nil)
+		req.Header.Set("X-Goog-Channel-ID", channelID)
+		req.Header.Set("X-Goog-Channel-Token", token)
+		req.Header.Set("X-Goog-Resource-State", state)
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := notify("chan-1", "wrong", "exists"); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for bad token, got %d", code)
+	}
+	if code := notify("other", "secret", "exists"); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for unknown channel, got %d", code)
+	}
+	if code := notify("chan-1", "secret", "sync"); code != http.StatusOK {
+		t.Fatalf("expected 200 for sync handshake, got %d", code)
+	}
+	if hookBody != nil {
+		t.Fatalf("sync handshake should not call hook")
+	}
+
+	if code := notify("chan-1", "secret", "exists"); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if hookAuth != "Bearer hook-token" {
+		t.Fatalf("unexpected hook auth: %q", hookAuth)
+	}
+	var payload calendarHookPayload
+	if err := json.Unmarshal(hookBody, &payload); err != nil {
+		t.Fatalf("hook payload: %v (%s)", err, hookBody)
+	}
+	if payload.CalendarID != "primary" || len(payload.Changes) != 1 || payload.Changes[0].Type != calendarChangeUpdated {
+		t.Fatalf("unexpected hook payload: %#v", payload)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("expected no stdout output when hook configured, got %q", out.String())
+	}
+
+	state := store.Get()
+	if state.Calendars["primary"].SyncToken != "t2" {
+		t.Fatalf("expected sync token to advance, got %#v", state.Calendars["primary"])
+	}
+	if state.LastDeliveryStatus != "ok" {
+		t.Fatalf("expected delivery status ok, got %q", state.LastDeliveryStatus)
+	}
+}
+
+func TestCalendarWatchServer_RejectsWrongMethodAndPath(t *testing.T) {
+	server := &calendarWatchServer{watchPushServer: watchPushServer{path: "/calendar-push", channel: watchChannel{ID: "c"}}}
+
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/calendar-push", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/elsewhere", strings.NewReader("")))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
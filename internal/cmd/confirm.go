@@ -13,6 +13,10 @@ import (
 	"github.com/steipete/gogcli/internal/input"
 )
 
+// confirmPrompt reads the answer to a confirmation prompt; the drive shell
+// swaps it for its own line reader while it runs.
+var confirmPrompt = input.PromptLine
+
 func confirmDestructive(ctx context.Context, flags *RootFlags, action string) error {
 	if err := dryRunExit(ctx, flags, action, nil); err != nil {
 		return err
@@ -27,7 +31,7 @@ func confirmDestructive(ctx context.Context, flags *RootFlags, action string) er
 	}
 
 	prompt := fmt.Sprintf("Proceed to %s? [y/N]: ", action)
-	line, readErr := input.PromptLine(ctx, prompt)
+	line, readErr := confirmPrompt(ctx, prompt)
 	if readErr != nil && !errors.Is(readErr, os.ErrClosed) {
 		if errors.Is(readErr, io.EOF) {
 			return &ExitError{Code: 1, Err: errors.New("cancelled")}
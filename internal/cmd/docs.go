@@ -22,26 +22,38 @@ import (
 var newDocsService = googleapi.NewDocs
 
 type DocsCmd struct {
-	Export      DocsExportCmd      `cmd:"" name:"export" aliases:"download,dl" help:"Export a Google Doc (pdf|docx|txt)"`
+	Export      DocsExportCmd      `cmd:"" name:"export" aliases:"download,dl" help:"Export a Google Doc (pdf|docx|txt|md)"`
 	Info        DocsInfoCmd        `cmd:"" name:"info" aliases:"get,show" help:"Get Google Doc metadata"`
 	Create      DocsCreateCmd      `cmd:"" name:"create" aliases:"add,new" help:"Create a Google Doc"`
 	Copy        DocsCopyCmd        `cmd:"" name:"copy" aliases:"cp,duplicate" help:"Copy a Google Doc"`
-	Cat         DocsCatCmd         `cmd:"" name:"cat" aliases:"text,read" help:"Print a Google Doc as plain text"`
+	Cat         DocsCatCmd         `cmd:"" name:"cat" aliases:"text,read" help:"Print a Google Doc as plain text or Markdown"`
 	Comments    DocsCommentsCmd    `cmd:"" name:"comments" help:"Manage comments on a Google Doc"`
+	Suggestions DocsSuggestionsCmd `cmd:"" name:"suggestions" help:"Review suggestions (tracked changes) in a Google Doc"`
 	ListTabs    DocsListTabsCmd    `cmd:"" name:"list-tabs" help:"List all tabs in a Google Doc"`
 	Write       DocsWriteCmd       `cmd:"" name:"write" help:"Write content to a Google Doc"`
 	Insert      DocsInsertCmd      `cmd:"" name:"insert" help:"Insert text at a specific position"`
 	Delete      DocsDeleteCmd      `cmd:"" name:"delete" help:"Delete text range from document"`
+	Break       DocsBreakCmd       `cmd:"" name:"break" help:"Insert a page or section break"`
+	Header      DocsHeaderCmd      `cmd:"" name:"header" help:"Set or remove the page header"`
+	Footer      DocsFooterCmd      `cmd:"" name:"footer" help:"Set or remove the page footer"`
+	PageSetup   DocsPageSetupCmd   `cmd:"" name:"page-setup" help:"Set page size, orientation, and margins"`
+	Ranges      DocsRangesCmd      `cmd:"" name:"ranges" aliases:"named-ranges" help:"Manage named ranges (update regions by name)"`
 	FindReplace DocsFindReplaceCmd `cmd:"" name:"find-replace" help:"Find and replace text in document"`
 	Update      DocsUpdateCmd      `cmd:"" name:"update" help:"Update content in a Google Doc"`
+	Sync        DocsSyncCmd        `cmd:"" name:"sync" help:"Patch a Google Doc to match a Markdown file (only changed paragraphs)"`
+	Render      DocsRenderCmd      `cmd:"" name:"render" help:"Generate Docs from a template by filling {{placeholders}} from JSON or CSV"`
 }
 type DocsExportCmd struct {
-	DocID  string         `arg:"" name:"docId" help:"Doc ID"`
-	Output OutputPathFlag `embed:""`
-	Format string         `name:"format" help:"Export format: pdf|docx|txt" default:"pdf"`
+	DocID    string         `arg:"" name:"docId" help:"Doc ID"`
+	Output   OutputPathFlag `embed:""`
+	Format   string         `name:"format" help:"Export format: pdf|docx|txt|md" default:"pdf"`
+	ImageDir string         `name:"image-dir" help:"With --format md: download inline images into this directory (default: link to short-lived image URLs)"`
 }
 
 func (c *DocsExportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	if isDocsMarkdownFormat(c.Format) {
+		return c.runMarkdown(ctx, flags)
+	}
 	return exportViaDrive(ctx, flags, exportViaDriveOptions{
 		ArgName:       "docId",
 		ExpectedMime:  "application/vnd.google-apps.document",
@@ -271,6 +283,8 @@ type DocsCatCmd struct {
 	MaxBytes int64  `name:"max-bytes" help:"Max bytes to read (0 = unlimited)" default:"2000000"`
 	Tab      string `name:"tab" help:"Tab title or ID to read (omit for default behavior)"`
 	AllTabs  bool   `name:"all-tabs" help:"Show all tabs with headers"`
+	Format   string `name:"format" help:"Output format: text|markdown" default:"text" enum:"text,markdown,md"`
+	ImageDir string `name:"image-dir" help:"With --format markdown: download inline images into this directory"`
 }
 
 func (c *DocsCatCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -291,7 +305,7 @@ func (c *DocsCatCmd) Run(ctx context.Context, flags *RootFlags) error {
 
 	// Use tabs API when --tab or --all-tabs is specified.
 	if c.Tab != "" || c.AllTabs {
-		return c.runWithTabs(ctx, svc, id)
+		return c.runWithTabs(ctx, svc, account, id)
 	}
 
 	// Default: original behavior (no tabs API).
@@ -309,6 +323,12 @@ func (c *DocsCatCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 
 	text := docsPlainText(doc, c.MaxBytes)
+	if c.markdown() {
+		text, err = c.renderMarkdown(ctx, account, docsMarkdownSourceFromDoc(doc))
+		if err != nil {
+			return err
+		}
+	}
 
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"text": text})
@@ -460,19 +480,8 @@ func (c *DocsUpdateCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return fmt.Errorf("update document: %w", err)
 	}
 
-	if len(tables) > 0 {
-		tableInserter := NewTableInserter(svc, id)
-		tableOffset := int64(0)
-		for _, table := range tables {
-			tableIndex := table.StartIndex + tableOffset
-			tableEnd, err := tableInserter.InsertNativeTable(ctx, tableIndex, table.Cells)
-			if err != nil {
-				return fmt.Errorf("insert native table: %w", err)
-			}
-			if tableEnd > tableIndex {
-				tableOffset += (tableEnd - tableIndex) - 1
-			}
-		}
+	if err = insertDocsTables(ctx, svc, id, tables); err != nil {
+		return err
 	}
 
 	if outfmt.IsJSON(ctx) {
@@ -491,7 +500,7 @@ func (c *DocsUpdateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
-func (c *DocsCatCmd) runWithTabs(ctx context.Context, svc *docs.Service, id string) error {
+func (c *DocsCatCmd) runWithTabs(ctx context.Context, svc *docs.Service, account, id string) error {
 	doc, err := svc.Documents.Get(id).
 		IncludeTabsContent(true).
 		Context(ctx).
@@ -513,7 +522,10 @@ func (c *DocsCatCmd) runWithTabs(ctx context.Context, svc *docs.Service, id stri
 		if tab == nil {
 			return fmt.Errorf("tab not found: %s", c.Tab)
 		}
-		text := tabPlainText(tab, c.MaxBytes)
+		text, err := c.tabText(ctx, account, tab)
+		if err != nil {
+			return err
+		}
 		if outfmt.IsJSON(ctx) {
 			return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
 				"tab": tabJSON(tab, text),
@@ -527,7 +539,10 @@ func (c *DocsCatCmd) runWithTabs(ctx context.Context, svc *docs.Service, id stri
 	if outfmt.IsJSON(ctx) {
 		var out []map[string]any
 		for _, tab := range tabs {
-			text := tabPlainText(tab, c.MaxBytes)
+			text, err := c.tabText(ctx, account, tab)
+			if err != nil {
+				return err
+			}
 			out = append(out, tabJSON(tab, text))
 		}
 		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"tabs": out})
@@ -540,10 +555,17 @@ func (c *DocsCatCmd) runWithTabs(ctx context.Context, svc *docs.Service, id stri
 				return err
 			}
 		}
-		if _, err := fmt.Fprintf(os.Stdout, "=== Tab: %s ===\n", title); err != nil {
+		header := fmt.Sprintf("=== Tab: %s ===\n", title)
+		if c.markdown() {
+			header = docsMarkdownTabMarker(tab)
+		}
+		if _, err := io.WriteString(os.Stdout, header); err != nil {
+			return err
+		}
+		text, err := c.tabText(ctx, account, tab)
+		if err != nil {
 			return err
 		}
-		text := tabPlainText(tab, c.MaxBytes)
 		if _, err := io.WriteString(os.Stdout, text); err != nil {
 			return err
 		}
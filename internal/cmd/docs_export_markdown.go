@@ -0,0 +1,701 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// docsMarkdownSource is the content of a document or of one tab; tabs carry
+// their own lists, footnotes, and inline objects.
+type docsMarkdownSource struct {
+	Body          *docs.Body
+	Lists         map[string]docs.List
+	Footnotes     map[string]docs.Footnote
+	InlineObjects map[string]docs.InlineObject
+}
+
+type docsMarkdownOptions struct {
+	// ImagePaths maps inline object IDs to local image paths used in place of
+	// the (short-lived) contentUri.
+	ImagePaths map[string]string
+}
+
+var (
+	docsMarkdownMonoFonts = map[string]bool{
+		"courier new": true, "consolas": true, "roboto mono": true, "source code pro": true,
+		"inconsolata": true, "ubuntu mono": true, "fira code": true, "courier": true,
+	}
+	docsMarkdownBlockStart = regexp.MustCompile(`^(#{1,6}\s|[-+*]\s|>|\x60{3}|~{3})`)
+	docsMarkdownOrdered    = regexp.MustCompile(`^\d+[.)]\s`)
+	docsMarkdownUnsafeName = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+	docsMarkdownEscaper    = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `_`, `\_`, "`", "\\`", `[`, `\[`, `]`, `\]`, `<`, `\<`)
+)
+
+func docsMarkdownSourceFromDoc(doc *docs.Document) docsMarkdownSource {
+	if doc == nil {
+		return docsMarkdownSource{}
+	}
+	return docsMarkdownSource{Body: doc.Body, Lists: doc.Lists, Footnotes: doc.Footnotes, InlineObjects: doc.InlineObjects}
+}
+
+func docsMarkdownSourceFromTab(tab *docs.Tab) docsMarkdownSource {
+	if tab == nil || tab.DocumentTab == nil {
+		return docsMarkdownSource{}
+	}
+	dt := tab.DocumentTab
+	return docsMarkdownSource{Body: dt.Body, Lists: dt.Lists, Footnotes: dt.Footnotes, InlineObjects: dt.InlineObjects}
+}
+
+// docsMarkdown renders document content as GitHub-flavoured Markdown: headings,
+// nested lists, bold/italic/strikethrough/code spans, links, tables, code
+// blocks (runs of monospace paragraphs), images, and footnotes.
+func docsMarkdown(src docsMarkdownSource, opts docsMarkdownOptions) string {
+	if src.Body == nil {
+		return ""
+	}
+	w := &docsMarkdownWriter{src: src, opts: opts, counters: map[string][]int{}, footnoteNum: map[string]int{}}
+	w.writeContent(src.Body.Content)
+	w.flushCode()
+
+	out := strings.Join(w.blocks, "\n\n")
+	if len(w.footnoteOrder) > 0 {
+		notes := make([]string, 0, len(w.footnoteOrder))
+		for i, id := range w.footnoteOrder {
+			notes = append(notes, fmt.Sprintf("[^%d]: %s", i+1, w.footnoteText(id)))
+		}
+		out += "\n\n" + strings.Join(notes, "\n")
+	}
+	if out == "" {
+		return ""
+	}
+	return out + "\n"
+}
+
+type docsMarkdownWriter struct {
+	src  docsMarkdownSource
+	opts docsMarkdownOptions
+
+	blocks []string
+	// list state: the previous block was a list item, per-list counters by level,
+	// and marker widths used to indent nested items.
+	inList   bool
+	counters map[string][]int
+	widths   []int
+	code     []string
+
+	footnoteNum   map[string]int
+	footnoteOrder []string
+}
+
+func (w *docsMarkdownWriter) add(block string, listItem bool) {
+	if listItem && w.inList && len(w.blocks) > 0 {
+		w.blocks[len(w.blocks)-1] += "\n" + block
+	} else {
+		w.blocks = append(w.blocks, block)
+	}
+	w.inList = listItem
+}
+
+func (w *docsMarkdownWriter) flushCode() {
+	if len(w.code) == 0 {
+		return
+	}
+	body := strings.Join(w.code, "\n")
+	fence := "```"
+	for strings.Contains(body, fence) {
+		fence += "`"
+	}
+	w.add(fence+"\n"+body+"\n"+fence, false)
+	w.code = nil
+}
+
+func (w *docsMarkdownWriter) writeContent(content []*docs.StructuralElement) {
+	for _, el := range content {
+		if el == nil {
+			continue
+		}
+		switch {
+		case el.Paragraph != nil:
+			w.writeParagraph(el.Paragraph)
+		case el.Table != nil:
+			w.flushCode()
+			w.add(w.table(el.Table), false)
+		}
+	}
+}
+
+func (w *docsMarkdownWriter) writeParagraph(p *docs.Paragraph) {
+	style := ""
+	if p.ParagraphStyle != nil {
+		style = p.ParagraphStyle.NamedStyleType
+	}
+	if p.Bullet == nil && docsMarkdownHeadingLevel(style) == 0 && docsParagraphIsMonospace(p) {
+		w.code = append(w.code, strings.TrimRight(docsParagraphRawText(p), "\n"))
+		return
+	}
+	w.flushCode()
+
+	for _, pe := range p.Elements {
+		if pe != nil && pe.HorizontalRule != nil {
+			w.add("---", false)
+		}
+	}
+	text := strings.TrimSpace(w.inline(p.Elements, false))
+	if text == "" {
+		if p.Bullet == nil {
+			w.inList = false
+		}
+		return
+	}
+
+	if level := docsMarkdownHeadingLevel(style); level > 0 {
+		w.add(strings.Repeat("#", level)+" "+text, false)
+		return
+	}
+	if p.Bullet != nil {
+		w.add(w.listItem(p.Bullet, text), true)
+		return
+	}
+	switch {
+	case docsMarkdownOrdered.MatchString(text):
+		// A backslash before a digit stays literal; escape the delimiter.
+		i := strings.IndexAny(text, ".)")
+		text = text[:i] + `\` + text[i:]
+	case docsMarkdownBlockStart.MatchString(text):
+		text = `\` + text
+	}
+	w.add(text, false)
+}
+
+func (w *docsMarkdownWriter) listItem(b *docs.Bullet, text string) string {
+	level := int(b.NestingLevel)
+	ordered := docsListOrdered(w.src.Lists, b.ListId, level)
+
+	counts := w.counters[b.ListId]
+	for len(counts) <= level {
+		counts = append(counts, 0)
+	}
+	counts[level]++
+	for i := level + 1; i < len(counts); i++ {
+		counts[i] = 0
+	}
+	w.counters[b.ListId] = counts
+
+	marker := "-"
+	if ordered {
+		marker = strconv.Itoa(counts[level]) + "."
+	}
+	for len(w.widths) <= level {
+		w.widths = append(w.widths, 2)
+	}
+	w.widths[level] = len(marker) + 1
+	indent := 0
+	for i := 0; i < level; i++ {
+		indent += w.widths[i]
+	}
+	text = strings.ReplaceAll(text, "\n", "\n"+strings.Repeat(" ", indent+len(marker)+1))
+	return strings.Repeat(" ", indent) + marker + " " + text
+}
+
+// docsListOrdered reports whether the given nesting level of a list uses
+// numbers or letters rather than bullet glyphs.
+func docsListOrdered(lists map[string]docs.List, listID string, level int) bool {
+	list, ok := lists[listID]
+	if !ok || list.ListProperties == nil || level >= len(list.ListProperties.NestingLevels) {
+		return false
+	}
+	nl := list.ListProperties.NestingLevels[level]
+	if nl == nil {
+		return false
+	}
+	switch nl.GlyphType {
+	case "DECIMAL", "ZERO_DECIMAL", "UPPER_ALPHA", "ALPHA", "UPPER_ROMAN", "ROMAN":
+		return true
+	}
+	return false
+}
+
+// inline renders paragraph elements. Adjacent runs with the same Markdown
+// style are merged so style-only differences (color, size) do not split spans.
+func (w *docsMarkdownWriter) inline(elements []*docs.ParagraphElement, inTable bool) string {
+	type span struct {
+		key  string
+		text string
+		st   *docs.TextStyle
+	}
+	var spans []span
+	var sb strings.Builder
+	flush := func() {
+		for _, s := range spans {
+			sb.WriteString(docsMarkdownStyled(s.text, s.st, inTable))
+		}
+		spans = nil
+	}
+	for _, pe := range elements {
+		if pe == nil {
+			continue
+		}
+		switch {
+		case pe.TextRun != nil:
+			st := pe.TextRun.TextStyle
+			key := docsMarkdownStyleKey(st)
+			if n := len(spans); n > 0 && spans[n-1].key == key {
+				spans[n-1].text += pe.TextRun.Content
+			} else {
+				spans = append(spans, span{key: key, text: pe.TextRun.Content, st: st})
+			}
+		case pe.InlineObjectElement != nil:
+			flush()
+			sb.WriteString(w.image(pe.InlineObjectElement.InlineObjectId))
+		case pe.FootnoteReference != nil:
+			flush()
+			sb.WriteString(w.footnoteRef(pe.FootnoteReference.FootnoteId))
+		case pe.RichLink != nil && pe.RichLink.RichLinkProperties != nil:
+			flush()
+			props := pe.RichLink.RichLinkProperties
+			sb.WriteString("[" + docsMarkdownEscaper.Replace(orEmpty(props.Title, props.Uri)) + "](" + props.Uri + ")")
+		case pe.Person != nil && pe.Person.PersonProperties != nil:
+			flush()
+			sb.WriteString(docsMarkdownEscaper.Replace(orEmpty(pe.Person.PersonProperties.Email, pe.Person.PersonProperties.Name)))
+		}
+	}
+	flush()
+	return sb.String()
+}
+
+func docsMarkdownStyleKey(st *docs.TextStyle) string {
+	if st == nil {
+		return ""
+	}
+	link := ""
+	if st.Link != nil {
+		link = st.Link.Url
+	}
+	return fmt.Sprintf("%t|%t|%t|%t|%s", st.Bold, st.Italic, st.Strikethrough, docsTextIsMonospace(st), link)
+}
+
+// docsMarkdownStyled wraps text in Markdown markers, keeping surrounding
+// whitespace and the paragraph newline outside of them.
+func docsMarkdownStyled(text string, st *docs.TextStyle, inTable bool) string {
+	text = strings.TrimSuffix(text, "\n")
+	breakWith := "\\\n"
+	if inTable {
+		breakWith = "<br>"
+	}
+	core := strings.TrimSpace(text)
+	if core == "" {
+		return strings.ReplaceAll(text, "\v", breakWith)
+	}
+	lead := text[:strings.Index(text, core)]
+	trail := text[len(lead)+len(core):]
+
+	if st != nil && docsTextIsMonospace(st) {
+		fence := "`"
+		for strings.Contains(core, fence) {
+			fence += "`"
+		}
+		pad := ""
+		if strings.HasPrefix(core, "`") || strings.HasSuffix(core, "`") {
+			pad = " "
+		}
+		core = fence + pad + strings.ReplaceAll(core, "\v", " ") + pad + fence
+	} else {
+		core = strings.ReplaceAll(docsMarkdownEscaper.Replace(core), "\v", breakWith)
+		if inTable {
+			core = strings.ReplaceAll(core, "|", `\|`)
+		}
+		if st != nil {
+			switch {
+			case st.Bold && st.Italic:
+				core = "***" + core + "***"
+			case st.Bold:
+				core = "**" + core + "**"
+			case st.Italic:
+				core = "*" + core + "*"
+			}
+			if st.Strikethrough {
+				core = "~~" + core + "~~"
+			}
+		}
+	}
+	if st != nil && st.Link != nil && st.Link.Url != "" {
+		core = "[" + core + "](" + docsMarkdownURL(st.Link.Url) + ")"
+	}
+	return lead + core + trail
+}
+
+func docsMarkdownURL(u string) string {
+	if strings.ContainsAny(u, " ()") {
+		return "<" + u + ">"
+	}
+	return u
+}
+
+func (w *docsMarkdownWriter) table(t *docs.Table) string {
+	var rows [][]string
+	cols := int(t.Columns)
+	for _, row := range t.TableRows {
+		if row == nil {
+			continue
+		}
+		cells := make([]string, 0, len(row.TableCells))
+		for _, cell := range row.TableCells {
+			cells = append(cells, w.tableCell(cell))
+		}
+		if len(cells) > cols {
+			cols = len(cells)
+		}
+		rows = append(rows, cells)
+	}
+	if len(rows) == 0 || cols == 0 {
+		return ""
+	}
+	line := func(cells []string) string {
+		for len(cells) < cols {
+			cells = append(cells, "")
+		}
+		return "| " + strings.Join(cells, " | ") + " |"
+	}
+	sep := make([]string, cols)
+	for i := range sep {
+		sep[i] = "---"
+	}
+	out := []string{line(rows[0]), line(sep)}
+	for _, r := range rows[1:] {
+		out = append(out, line(r))
+	}
+	return strings.Join(out, "\n")
+}
+
+func (w *docsMarkdownWriter) tableCell(cell *docs.TableCell) string {
+	if cell == nil {
+		return ""
+	}
+	var parts []string
+	for _, el := range cell.Content {
+		if el == nil || el.Paragraph == nil {
+			continue
+		}
+		if text := strings.TrimSpace(w.inline(el.Paragraph.Elements, true)); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, "<br>")
+}
+
+func (w *docsMarkdownWriter) image(objectID string) string {
+	obj, ok := w.src.InlineObjects[objectID]
+	if !ok || obj.InlineObjectProperties == nil || obj.InlineObjectProperties.EmbeddedObject == nil {
+		return ""
+	}
+	eo := obj.InlineObjectProperties.EmbeddedObject
+	alt := strings.TrimSpace(orEmpty(eo.Title, eo.Description))
+	target := w.opts.ImagePaths[objectID]
+	if target == "" && eo.ImageProperties != nil {
+		target = eo.ImageProperties.ContentUri
+	}
+	if target == "" {
+		return ""
+	}
+	return "![" + docsMarkdownEscaper.Replace(alt) + "](" + docsMarkdownURL(filepath.ToSlash(target)) + ")"
+}
+
+func (w *docsMarkdownWriter) footnoteRef(id string) string {
+	n, ok := w.footnoteNum[id]
+	if !ok {
+		w.footnoteOrder = append(w.footnoteOrder, id)
+		n = len(w.footnoteOrder)
+		w.footnoteNum[id] = n
+	}
+	return fmt.Sprintf("[^%d]", n)
+}
+
+func (w *docsMarkdownWriter) footnoteText(id string) string {
+	fn, ok := w.src.Footnotes[id]
+	if !ok {
+		return ""
+	}
+	var parts []string
+	for _, el := range fn.Content {
+		if el == nil || el.Paragraph == nil {
+			continue
+		}
+		if text := strings.TrimSpace(w.inline(el.Paragraph.Elements, false)); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func docsMarkdownHeadingLevel(namedStyle string) int {
+	switch namedStyle {
+	case "TITLE", "HEADING_1":
+		return 1
+	case "SUBTITLE", "HEADING_2":
+		return 2
+	case "HEADING_3":
+		return 3
+	case "HEADING_4":
+		return 4
+	case "HEADING_5":
+		return 5
+	case "HEADING_6":
+		return 6
+	}
+	return 0
+}
+
+func docsTextIsMonospace(st *docs.TextStyle) bool {
+	return st != nil && st.WeightedFontFamily != nil && docsMarkdownMonoFonts[strings.ToLower(st.WeightedFontFamily.FontFamily)]
+}
+
+// docsParagraphIsMonospace reports whether every non-blank run of p is set in a
+// monospace font (how code blocks are written by the Markdown importer).
+func docsParagraphIsMonospace(p *docs.Paragraph) bool {
+	seen := false
+	for _, pe := range p.Elements {
+		if pe == nil {
+			continue
+		}
+		if pe.TextRun == nil {
+			return false
+		}
+		if strings.TrimSpace(pe.TextRun.Content) == "" {
+			continue
+		}
+		if !docsTextIsMonospace(pe.TextRun.TextStyle) {
+			return false
+		}
+		seen = true
+	}
+	return seen
+}
+
+// downloadDocsImages saves the inline images of src into dir and returns the
+// paths to reference from Markdown (relative to linkBase when set).
+func downloadDocsImages(ctx context.Context, client *http.Client, src docsMarkdownSource, dir, linkBase string) (map[string]string, error) {
+	ids := make([]string, 0, len(src.InlineObjects))
+	for id := range src.InlineObjects {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	paths := map[string]string{}
+	if len(ids) == 0 {
+		return paths, nil
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		obj := src.InlineObjects[id]
+		if obj.InlineObjectProperties == nil || obj.InlineObjectProperties.EmbeddedObject == nil ||
+			obj.InlineObjectProperties.EmbeddedObject.ImageProperties == nil {
+			continue
+		}
+		uri := obj.InlineObjectProperties.EmbeddedObject.ImageProperties.ContentUri
+		if uri == "" {
+			continue
+		}
+		name, err := downloadDocsImage(ctx, client, uri, dir, id)
+		if err != nil {
+			return nil, fmt.Errorf("download image %s: %w", id, err)
+		}
+		local := filepath.Join(dir, name)
+		if linkBase != "" {
+			if rel, relErr := filepath.Rel(linkBase, local); relErr == nil {
+				local = rel
+			}
+		}
+		paths[id] = local
+	}
+	return paths, nil
+}
+
+func downloadDocsImage(ctx context.Context, client *http.Client, uri, dir, id string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	ext := extPNG
+	if ct, _, parseErr := mime.ParseMediaType(resp.Header.Get("Content-Type")); parseErr == nil {
+		switch ct {
+		case "image/jpeg":
+			ext = ".jpg"
+		case "image/gif":
+			ext = ".gif"
+		case "image/webp":
+			ext = ".webp"
+		case "image/svg+xml":
+			ext = ".svg"
+		}
+	}
+	name := docsMarkdownUnsafeName.ReplaceAllString(id, "_") + ext
+	f, err := os.Create(filepath.Join(dir, name)) //nolint:gosec // user-provided directory
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+	return name, f.Close()
+}
+
+func isDocsMarkdownFormat(format string) bool {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "md", "markdown":
+		return true
+	}
+	return false
+}
+
+// docsMarkdownTabMarker separates tabs in Markdown output; an HTML comment keeps
+// the tab boundary machine-readable without rendering.
+func docsMarkdownTabMarker(tab *docs.Tab) string {
+	id := ""
+	if tab != nil && tab.TabProperties != nil {
+		id = tab.TabProperties.TabId
+	}
+	return fmt.Sprintf("<!-- tab: %s (%s) -->\n\n", tabTitle(tab), id)
+}
+
+func (c *DocsCatCmd) markdown() bool {
+	return isDocsMarkdownFormat(c.Format)
+}
+
+func (c *DocsCatCmd) tabText(ctx context.Context, account string, tab *docs.Tab) (string, error) {
+	if !c.markdown() {
+		return tabPlainText(tab, c.MaxBytes), nil
+	}
+	return c.renderMarkdown(ctx, account, docsMarkdownSourceFromTab(tab))
+}
+
+func (c *DocsCatCmd) renderMarkdown(ctx context.Context, account string, src docsMarkdownSource) (string, error) {
+	opts := docsMarkdownOptions{}
+	if dir := strings.TrimSpace(c.ImageDir); dir != "" {
+		paths, err := docsMarkdownImagePaths(ctx, account, src, dir, "")
+		if err != nil {
+			return "", err
+		}
+		opts.ImagePaths = paths
+	}
+	text := docsMarkdown(src, opts)
+	if c.MaxBytes > 0 && int64(len(text)) > c.MaxBytes {
+		text = text[:c.MaxBytes]
+	}
+	return text, nil
+}
+
+func docsMarkdownImagePaths(ctx context.Context, account string, src docsMarkdownSource, dir, linkBase string) (map[string]string, error) {
+	dir, err := config.ExpandPath(dir)
+	if err != nil {
+		return nil, err
+	}
+	client, err := newDriveHTTPClient(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	return downloadDocsImages(ctx, client, src, dir, linkBase)
+}
+
+// runMarkdown renders the document (all tabs, with tab markers when there is
+// more than one) and writes it as a .md file.
+func (c *DocsExportCmd) runMarkdown(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	id := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	if id == "" {
+		return usage("empty docId")
+	}
+	if err := dryRunExit(ctx, flags, "docs.export", map[string]any{
+		"id":        id,
+		"out":       strings.TrimSpace(c.Output.Path),
+		"format":    "md",
+		"image_dir": strings.TrimSpace(c.ImageDir),
+	}); err != nil {
+		return err
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newDocsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	doc, err := svc.Documents.Get(id).IncludeTabsContent(true).Context(ctx).Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return fmt.Errorf("doc not found or not a Google Doc (id=%s)", id)
+		}
+		return err
+	}
+
+	destPath, err := resolveDriveDownloadDestPath(&drive.File{Id: id, Name: orEmpty(doc.Title, "document") + ".md"}, c.Output.Path)
+	if err != nil {
+		return err
+	}
+
+	tabs := flattenTabs(doc.Tabs)
+	sources := []docsMarkdownSource{docsMarkdownSourceFromDoc(doc)}
+	if len(tabs) > 0 {
+		sources = sources[:0]
+		for _, tab := range tabs {
+			sources = append(sources, docsMarkdownSourceFromTab(tab))
+		}
+	}
+
+	var sb strings.Builder
+	for i, src := range sources {
+		opts := docsMarkdownOptions{}
+		if dir := strings.TrimSpace(c.ImageDir); dir != "" {
+			paths, imgErr := docsMarkdownImagePaths(ctx, account, src, dir, filepath.Dir(destPath))
+			if imgErr != nil {
+				return imgErr
+			}
+			opts.ImagePaths = paths
+		}
+		if len(tabs) > 1 {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(docsMarkdownTabMarker(tabs[i]))
+		}
+		sb.WriteString(docsMarkdown(src, opts))
+	}
+
+	if err := os.WriteFile(destPath, []byte(sb.String()), 0o600); err != nil {
+		return err
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"path": destPath, "size": sb.Len()})
+	}
+	u.Out().Printf("path\t%s", destPath)
+	u.Out().Printf("size\t%s", formatDriveSize(int64(sb.Len())))
+	return nil
+}
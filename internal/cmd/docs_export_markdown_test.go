@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+func mdRun(text string, st *docs.TextStyle) *docs.ParagraphElement {
+	return &docs.ParagraphElement{TextRun: &docs.TextRun{Content: text, TextStyle: st}}
+}
+
+func mdPara(style string, bullet *docs.Bullet, els ...*docs.ParagraphElement) *docs.StructuralElement {
+	return &docs.StructuralElement{Paragraph: &docs.Paragraph{
+		Elements:       els,
+		Bullet:         bullet,
+		ParagraphStyle: &docs.ParagraphStyle{NamedStyleType: style},
+	}}
+}
+
+func TestDocsMarkdown_Structure(t *testing.T) {
+	mono := &docs.TextStyle{WeightedFontFamily: &docs.WeightedFontFamily{FontFamily: "Courier New"}}
+	cell := func(text string) *docs.TableCell {
+		return &docs.TableCell{Content: []*docs.StructuralElement{mdPara("NORMAL_TEXT", nil, mdRun(text+"\n", nil))}}
+	}
+	src := docsMarkdownSource{
+		Body: &docs.Body{Content: []*docs.StructuralElement{
+			mdPara("TITLE", nil, mdRun("Plan\n", nil)),
+			mdPara("NORMAL_TEXT", nil,
+				mdRun("Ship ", nil),
+				mdRun("fast ", &docs.TextStyle{Bold: true}),
+				mdRun("and ", nil),
+				mdRun("safe", &docs.TextStyle{Italic: true, ForegroundColor: &docs.OptionalColor{}}),
+				mdRun(" with ", nil),
+				mdRun("gog", mono),
+				mdRun(", see ", nil),
+				mdRun("docs", &docs.TextStyle{Link: &docs.Link{Url: "https://example.com/docs"}}),
+				&docs.ParagraphElement{FootnoteReference: &docs.FootnoteReference{FootnoteId: "fn1"}},
+				mdRun(" *literally*\n", nil),
+			),
+			mdPara("HEADING_2", nil, mdRun("Steps\n", nil)),
+			mdPara("NORMAL_TEXT", &docs.Bullet{ListId: "num"}, mdRun("First\n", nil)),
+			mdPara("NORMAL_TEXT", &docs.Bullet{ListId: "bul", NestingLevel: 1}, mdRun("detail\n", nil)),
+			mdPara("NORMAL_TEXT", &docs.Bullet{ListId: "num"}, mdRun("Second\n", nil)),
+			mdPara("NORMAL_TEXT", nil, mdRun("go build ./...\n", mono)),
+			mdPara("NORMAL_TEXT", nil, mdRun("go test ./...\n", mono)),
+			{Table: &docs.Table{Columns: 2, TableRows: []*docs.TableRow{
+				{TableCells: []*docs.TableCell{cell("Name"), cell("Value")}},
+				{TableCells: []*docs.TableCell{cell("a|b"), cell("1")}},
+			}}},
+			mdPara("NORMAL_TEXT", nil, &docs.ParagraphElement{InlineObjectElement: &docs.InlineObjectElement{InlineObjectId: "img1"}}, mdRun("\n", nil)),
+			mdPara("NORMAL_TEXT", nil, mdRun("# not a heading\n", nil)),
+		}},
+		Lists: map[string]docs.List{
+			"num": {ListProperties: &docs.ListProperties{NestingLevels: []*docs.NestingLevel{{GlyphType: "DECIMAL"}}}},
+			"bul": {ListProperties: &docs.ListProperties{NestingLevels: []*docs.NestingLevel{{GlyphSymbol: "●"}, {GlyphSymbol: "○"}}}},
+		},
+		Footnotes: map[string]docs.Footnote{
+			"fn1": {Content: []*docs.StructuralElement{mdPara("NORMAL_TEXT", nil, mdRun(" Internal wiki.\n", nil))}},
+		},
+		InlineObjects: map[string]docs.InlineObject{
+			"img1": {InlineObjectProperties: &docs.InlineObjectProperties{EmbeddedObject: &docs.EmbeddedObject{
+				Title:           "Diagram",
+				ImageProperties: &docs.ImageProperties{ContentUri: "https://lh3.example.com/img1"},
+			}}},
+		},
+	}
+
+	got := docsMarkdown(src, docsMarkdownOptions{})
+	want := strings.Join([]string{
+		"# Plan",
+		"",
+		"Ship **fast** and *safe* with `gog`, see [docs](https://example.com/docs)[^1] \\*literally\\*",
+		"",
+		"## Steps",
+		"",
+		"1. First",
+		"   - detail",
+		"2. Second",
+		"",
+		"```",
+		"go build ./...",
+		"go test ./...",
+		"```",
+		"",
+		"| Name | Value |",
+		"| --- | --- |",
+		"| a\\|b | 1 |",
+		"",
+		"![Diagram](https://lh3.example.com/img1)",
+		"",
+		"\\# not a heading",
+		"",
+		"[^1]: Internal wiki.",
+		"",
+	}, "\n")
+	if got != want {
+		t.Fatalf("unexpected markdown:\n%s\n--- want ---\n%s", got, want)
+	}
+
+	local := docsMarkdown(src, docsMarkdownOptions{ImagePaths: map[string]string{"img1": "images/img1.png"}})
+	if !strings.Contains(local, "![Diagram](images/img1.png)") {
+		t.Fatalf("expected local image path, got:\n%s", local)
+	}
+}
+
+func TestDocsMarkdown_BlockLookalikesRoundTrip(t *testing.T) {
+	lines := []string{
+		"2026. A year in review",
+		"2026) Budget",
+		"# not a heading",
+		"- not a bullet",
+		"> not a quote",
+	}
+	content := make([]*docs.StructuralElement, 0, len(lines))
+	for _, line := range lines {
+		content = append(content, mdPara("NORMAL_TEXT", nil, mdRun(line+"\n", nil)))
+	}
+	got := docsMarkdown(docsMarkdownSource{Body: &docs.Body{Content: content}}, docsMarkdownOptions{})
+	if !strings.Contains(got, "2026\\. A year in review") || !strings.Contains(got, "2026\\) Budget") {
+		t.Fatalf("expected escaped delimiters, got:\n%s", got)
+	}
+
+	elements := ParseMarkdown(got)
+	if len(elements) != len(lines) {
+		t.Fatalf("expected %d paragraphs, got %d:\n%s", len(lines), len(elements), got)
+	}
+	for i, el := range elements {
+		if el.Type != MDParagraph {
+			t.Fatalf("line %d parsed as type %d, want paragraph", i, el.Type)
+		}
+		if _, text := el.inline(); text != lines[i] {
+			t.Fatalf("line %d round-tripped as %q, want %q", i, text, lines[i])
+		}
+	}
+}
+
+func TestDocsExport_MarkdownWithTabs(t *testing.T) {
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	docSvc, cleanup := newTabsTestServer(t)
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docSvc, nil }
+
+	flags := &RootFlags{Account: "a@b.com"}
+	u, _ := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	ctx := ui.WithUI(context.Background(), u)
+
+	dest := filepath.Join(t.TempDir(), "doc.md")
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DocsExportCmd{}, []string{"doc1", "--format", "md", "--out", dest}, ctx, flags); err != nil {
+			t.Fatalf("export: %v", err)
+		}
+	})
+	b, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	got := string(b)
+	for _, want := range []string{"<!-- tab: Overview (t.0) -->", "<!-- tab: Sub-Detail (t.child1) -->\n\nchild text\n"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("missing %q in:\n%s", want, got)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"google.golang.org/api/docs/v1"
@@ -19,252 +20,336 @@ type TableData struct {
 // MarkdownToDocsRequests converts parsed markdown elements to Google Docs batch
 // update requests. baseIndex is the insertion location in the document.
 // Returns: requests, plainText, tableData (for native table insertion)
+//
+// List items are written with one leading tab per nesting level, which
+// createParagraphBullets turns into the nesting level and removes. Bullet
+// requests therefore come last, in reverse document order, and table indices
+// already account for the removed tabs.
 func MarkdownToDocsRequests(elements []MarkdownElement, baseIndex int64) ([]*docs.Request, string, []TableData) {
-	var requests []*docs.Request
-	var plainText strings.Builder
-	var tables []TableData
-	charOffset := baseIndex
+	f := &docsMarkdownFormatter{offset: baseIndex}
 
 	if debugMarkdown {
 		fmt.Printf("[DEBUG] Starting MarkdownToDocsRequests with %d elements\n", len(elements))
 	}
 
-	for _, el := range elements {
-		startOffset := charOffset
+	for i, el := range elements {
+		f.element(i, el)
+	}
+	f.listBullets()
 
-		switch el.Type {
-		case MDHeading1, MDHeading2, MDHeading3, MDHeading4, MDHeading5, MDHeading6:
-			// Parse inline formatting for heading content
-			styles, strippedContent := ParseInlineFormatting(el.Content)
+	if debugMarkdown {
+		fmt.Printf("\n[FINAL] plainText length: %d\n", f.text.Len())
+		fmt.Printf("[FINAL] Final charOffset: %d\n", f.offset)
+		fmt.Printf("[FINAL] Total requests: %d\n", len(f.requests)+len(f.bullets))
+		fmt.Printf("[FINAL] Total tables: %d\n", len(f.tables))
+		fmt.Printf("\n[FINAL] plainText content:\n%s\n[END]\n", f.text.String())
+	}
 
-			if debugMarkdown {
-				fmt.Printf("[DEBUG] Heading: content=%q stripped=%q styles=%d\n", el.Content, strippedContent, len(styles))
-			}
+	return append(f.requests, f.bullets...), f.text.String(), f.tables
+}
 
-			if debugMarkdown {
-				fmt.Printf("[HEADING] Content: %q\n", el.Content)
-				fmt.Printf("  Stripped: %q (len=%d)\n", strippedContent, len(strippedContent))
-				fmt.Printf("  Styles: %v\n", styles)
-			}
+type docsMarkdownFormatter struct {
+	requests []*docs.Request
+	bullets  []*docs.Request
+	text     strings.Builder
+	tables   []TableData
+	offset   int64
+	tabs     int64 // leading tabs written so far (removed with the bullets)
+	items    []docsListParagraph
+}
 
-			// Add stripped heading text with newline
-			plainText.WriteString(strippedContent)
-			plainText.WriteString("\n")
-			charOffset += utf16Len(strippedContent + "\n")
+// docsListParagraph is a list item paragraph waiting for its bullets.
+type docsListParagraph struct {
+	start  int64
+	end    int64
+	kind   string
+	level  int
+	listID int
+	index  int // element index, to detect interruptions
+}
 
-			// Apply heading style
-			headingStyle := getHeadingStyle(el.Type)
-			requests = append(requests, &docs.Request{
-				UpdateParagraphStyle: &docs.UpdateParagraphStyleRequest{
-					Range: &docs.Range{
-						StartIndex: startOffset,
-						EndIndex:   charOffset,
-					},
-					ParagraphStyle: &docs.ParagraphStyle{
-						NamedStyleType: headingStyle,
-					},
-					Fields: "namedStyleType",
-				},
-			})
-
-			// Apply inline text styles
-			for _, style := range styles {
-				textStyleReq := buildTextStyleRequest(style, startOffset)
-				if textStyleReq != nil {
-					if debugMarkdown {
-						fmt.Printf("  Style request: [%d, %d]\n",
-							textStyleReq.UpdateTextStyle.Range.StartIndex,
-							textStyleReq.UpdateTextStyle.Range.EndIndex)
-					}
-					requests = append(requests, textStyleReq)
-				}
+func (f *docsMarkdownFormatter) write(s string) {
+	f.text.WriteString(s)
+	f.offset += utf16Len(s)
+}
+
+func (f *docsMarkdownFormatter) inlineStyles(styles []TextStyle, base int64) {
+	for _, style := range styles {
+		if req := buildTextStyleRequest(style, base); req != nil {
+			if debugMarkdown {
+				fmt.Printf("  Style request: [%d, %d]\n", req.UpdateTextStyle.Range.StartIndex, req.UpdateTextStyle.Range.EndIndex)
 			}
+			f.requests = append(f.requests, req)
+		}
+	}
+}
 
-		case MDCodeBlock:
-			// Add code block text (no inline formatting in code blocks)
-			codeContent := el.Content + "\n"
-			plainText.WriteString(codeContent)
-			charOffset += utf16Len(codeContent)
+func (f *docsMarkdownFormatter) element(i int, el MarkdownElement) {
+	startOffset := f.offset
 
-			// Apply monospace font to entire code block
-			requests = append(requests, &docs.Request{
+	if debugMarkdown {
+		fmt.Printf("[ELEMENT] type=%d level=%d quote=%d content=%q at %d\n", el.Type, el.Level, el.Quote, el.Content, startOffset)
+	}
+
+	switch el.Type {
+	case MDHeading1, MDHeading2, MDHeading3, MDHeading4, MDHeading5, MDHeading6:
+		styles, text := el.inline()
+		f.write(text + "\n")
+		f.requests = append(f.requests, &docs.Request{
+			UpdateParagraphStyle: &docs.UpdateParagraphStyleRequest{
+				Range: &docs.Range{
+					StartIndex: startOffset,
+					EndIndex:   f.offset,
+				},
+				ParagraphStyle: &docs.ParagraphStyle{
+					NamedStyleType: getHeadingStyle(el.Type),
+				},
+				Fields: "namedStyleType",
+			},
+		})
+		f.inlineStyles(styles, startOffset)
+		f.indent(startOffset, el)
+
+	case MDCodeBlock:
+		// Code blocks keep their text verbatim: monospace on shaded paragraphs.
+		f.write(el.Content + "\n")
+		f.requests = append(f.requests,
+			&docs.Request{
 				UpdateTextStyle: &docs.UpdateTextStyleRequest{
 					Range: &docs.Range{
 						StartIndex: startOffset,
-						EndIndex:   charOffset,
+						EndIndex:   f.offset,
 					},
 					TextStyle: &docs.TextStyle{
 						WeightedFontFamily: &docs.WeightedFontFamily{
 							FontFamily: "Courier New",
 							Weight:     400,
 						},
-						BackgroundColor: &docs.OptionalColor{
-							Color: &docs.Color{
-								RgbColor: &docs.RgbColor{
-									Red:   0.95,
-									Green: 0.95,
-									Blue:  0.95,
-								},
-							},
-						},
 					},
-					Fields: "weightedFontFamily,backgroundColor",
+					Fields: "weightedFontFamily",
 				},
-			})
-
-		case MDBlockquote:
-			// Parse inline formatting for blockquote content
-			styles, strippedContent := ParseInlineFormatting(el.Content)
-
-			if debugMarkdown {
-				fmt.Printf("[BLOCKQUOTE] Content: %q -> stripped=%q\n", el.Content, strippedContent)
-			}
-
-			// Add stripped blockquote text
-			plainText.WriteString(strippedContent)
-			plainText.WriteString("\n")
-			charOffset += utf16Len(strippedContent + "\n")
-
-			// Apply blockquote style (indent)
-			requests = append(requests, &docs.Request{
+			},
+			&docs.Request{
 				UpdateParagraphStyle: &docs.UpdateParagraphStyleRequest{
 					Range: &docs.Range{
 						StartIndex: startOffset,
-						EndIndex:   charOffset,
+						EndIndex:   f.offset,
 					},
 					ParagraphStyle: &docs.ParagraphStyle{
-						IndentStart: &docs.Dimension{
-							Magnitude: 36,
-							Unit:      "PT",
+						Shading: &docs.Shading{
+							BackgroundColor: &docs.OptionalColor{
+								Color: &docs.Color{
+									RgbColor: &docs.RgbColor{
+										Red:   0.95,
+										Green: 0.95,
+										Blue:  0.95,
+									},
+								},
+							},
 						},
 					},
-					Fields: "indentStart",
+					Fields: "shading",
 				},
-			})
-
-			// Apply inline text styles
-			for _, style := range styles {
-				textStyleReq := buildTextStyleRequest(style, startOffset)
-				if textStyleReq != nil {
-					if debugMarkdown {
-						fmt.Printf("  Style request: [%d, %d] (base=%d, style=[%d,%d])\n",
-							textStyleReq.UpdateTextStyle.Range.StartIndex,
-							textStyleReq.UpdateTextStyle.Range.EndIndex,
-							startOffset, style.Start, style.End)
-					}
-					requests = append(requests, textStyleReq)
-				}
-			}
-
-		case MDListItem, MDNumberedList:
-			// Parse inline formatting for list item content
-			styles, strippedContent := ParseInlineFormatting(el.Content)
-
-			if debugMarkdown {
-				fmt.Printf("[LIST] Content: %q -> stripped=%q styles=%d\n", el.Content, strippedContent, len(styles))
-			}
-
-			// Add list item with prefix
-			prefix := "• "
-			if el.Type == MDNumberedList {
-				prefix = "1. "
-			}
-			prefixLen := utf16Len(prefix)
-			plainText.WriteString(prefix)
-			plainText.WriteString(strippedContent)
-			plainText.WriteString("\n")
-			charOffset += prefixLen + utf16Len(strippedContent+"\n")
-
-			// Apply inline text styles (offset by prefix length)
-			for _, style := range styles {
-				textStyleReq := buildTextStyleRequest(style, startOffset+prefixLen)
-				if textStyleReq != nil {
-					requests = append(requests, textStyleReq)
-				}
-			}
-
-		case MDHorizontalRule:
-			// Add horizontal rule as a separator line using ASCII dashes
-			separator := strings.Repeat("-", 40)
-			plainText.WriteString(separator)
-			plainText.WriteString("\n")
-			charOffset += utf16Len(separator + "\n")
-
-		case MDParagraph:
-			// Parse inline formatting for paragraph content
-			styles, strippedContent := ParseInlineFormatting(el.Content)
-
-			if debugMarkdown {
-				fmt.Printf("[PARAGRAPH] Content: %q\n", el.Content)
-				fmt.Printf("  Stripped: %q (len=%d)\n", strippedContent, len(strippedContent))
-				fmt.Printf("  Styles: %v\n", styles)
-				fmt.Printf("  startOffset: %d, len+1: %d\n", startOffset, len(strippedContent)+1)
-			}
+			},
+		)
+		f.indent(startOffset, el)
+
+	case MDParagraph, MDBlockquote:
+		styles, text := el.inline()
+		f.write(text + "\n")
+		f.inlineStyles(styles, startOffset)
+		f.indent(startOffset, el)
+
+	case MDListItem, MDNumberedList, MDTaskList:
+		styles, text := el.inline()
+		prefix := strings.Repeat("\t", el.Level)
+		prefixLen := utf16Len(prefix)
+		f.write(prefix + text + "\n")
+		f.inlineStyles(styles, startOffset+prefixLen)
+		if el.Checked && text != "" {
+			// Docs cannot tick checkboxes through the API; show done items
+			// struck through the way Docs renders checked ones.
+			f.inlineStyles([]TextStyle{{Strikethrough: true, Start: 0, End: utf16Len(text)}}, startOffset+prefixLen)
+		}
+		f.tabs += prefixLen
+		f.items = append(f.items, docsListParagraph{
+			start:  startOffset,
+			end:    f.offset,
+			kind:   docsListKind(el.Type),
+			level:  el.Level,
+			listID: el.ListID,
+			index:  i,
+		})
+
+	case MDHorizontalRule:
+		// Add horizontal rule as a separator line using ASCII dashes
+		f.write(strings.Repeat("-", 40) + "\n")
+
+	case MDEmptyLine:
+		f.write("\n")
+
+	case MDTable:
+		// Handle markdown table - save for native insertion
+		if len(el.TableCells) == 0 || len(el.TableCells[0]) == 0 {
+			return
+		}
+		f.tables = append(f.tables, TableData{
+			StartIndex: f.offset - f.tabs,
+			Cells:      el.TableCells,
+		})
+		// Add a placeholder newline (table will be inserted here)
+		f.write("\n")
+
+	case MDFootnote:
+		styles, text := el.inline()
+		number := strconv.Itoa(el.Level)
+		f.write(number + " " + text + "\n")
+		f.inlineStyles([]TextStyle{{Superscript: true, Start: 0, End: utf16Len(number)}}, startOffset)
+		f.inlineStyles(styles, startOffset+utf16Len(number+" "))
+	}
+}
 
-			// Add stripped paragraph text
-			plainText.WriteString(strippedContent)
-			plainText.WriteString("\n")
-			charOffset += utf16Len(strippedContent + "\n")
+// indent shifts paragraphs inside block quotes and list items.
+func (f *docsMarkdownFormatter) indent(start int64, el MarkdownElement) {
+	depth := el.Quote + el.Indent
+	if depth == 0 {
+		return
+	}
+	size := &docs.Dimension{Magnitude: float64(36 * depth), Unit: "PT"}
+	f.requests = append(f.requests, &docs.Request{
+		UpdateParagraphStyle: &docs.UpdateParagraphStyleRequest{
+			Range: &docs.Range{
+				StartIndex: start,
+				EndIndex:   f.offset,
+			},
+			ParagraphStyle: &docs.ParagraphStyle{
+				IndentStart:     size,
+				IndentFirstLine: size,
+			},
+			Fields: "indentStart,indentFirstLine",
+		},
+	})
+}
 
-			if debugMarkdown {
-				fmt.Printf("  charOffset after: %d, plainText.Len: %d\n", charOffset, plainText.Len())
+// listBullets creates the bullets for each list, one preset per run of
+// items of the same kind. Lists are bulleted from the end of the document
+// back so the leading tabs each request removes do not shift later ranges.
+func (f *docsMarkdownFormatter) listBullets() {
+	var groups [][]*docs.Request
+	for i := 0; i < len(f.items); {
+		j := i + 1
+		for j < len(f.items) && f.items[j].listID == f.items[i].listID && f.items[j].index == f.items[j-1].index+1 {
+			j++
+		}
+		group := f.items[i:j]
+		top := group[0]
+		for _, item := range group {
+			if item.level < top.level {
+				top = item
 			}
-
-			// Apply inline text styles
-			for _, style := range styles {
-				textStyleReq := buildTextStyleRequest(style, startOffset)
-				if textStyleReq != nil {
-					if debugMarkdown {
-						fmt.Printf("  Style request: [%d, %d]\n",
-							textStyleReq.UpdateTextStyle.Range.StartIndex,
-							textStyleReq.UpdateTextStyle.Range.EndIndex)
-					}
-					requests = append(requests, textStyleReq)
-				}
+		}
+		if top.kind == "number" {
+			groups = append(groups, docsNumberedListBullets(group))
+		} else {
+			runs := docsListRuns(group)
+			reqs := make([]*docs.Request, 0, len(runs))
+			for k := len(runs) - 1; k >= 0; k-- {
+				run := runs[k]
+				reqs = append(reqs, docsBulletsRequest(run[0].start, run[len(run)-1].end, run[0].kind))
 			}
+			groups = append(groups, reqs)
+		}
+		i = j
+	}
 
-		case MDEmptyLine:
-			// Add empty line
-			plainText.WriteString("\n")
-			charOffset += utf16Len("\n")
-
-		case MDTable:
-			// Handle markdown table - save for native insertion
-			if len(el.TableCells) == 0 {
-				continue
-			}
+	for k := len(groups) - 1; k >= 0; k-- {
+		f.bullets = append(f.bullets, groups[k]...)
+	}
+}
 
-			rows := len(el.TableCells)
-			cols := len(el.TableCells[0])
-			if rows == 0 || cols == 0 {
-				continue
-			}
+// docsNumberedListBullets numbers the whole list in one preset so numbering
+// continues across nested items, then moves each nested run of another kind
+// into its own list: its leading tabs (removed by the first request) are put
+// back so the run keeps its nesting level under the new preset.
+func docsNumberedListBullets(group []docsListParagraph) []*docs.Request {
+	reqs := []*docs.Request{docsBulletsRequest(group[0].start, group[len(group)-1].end, "number")}
+
+	// removed[k] is the number of tabs the numbered bullets strip before item k.
+	removed := make([]int64, len(group))
+	var tabs int64
+	for k, item := range group {
+		removed[k] = tabs
+		tabs += int64(item.level)
+	}
 
-			if debugMarkdown {
-				fmt.Printf("[TABLE] %d rows x %d cols at offset %d - saving for native insertion\n", rows, cols, charOffset)
+	runs := docsListRuns(group)
+	first := len(group)
+	for k := len(runs) - 1; k >= 0; k-- {
+		run := runs[k]
+		first -= len(run)
+		if run[0].kind == "number" {
+			continue
+		}
+		for n := len(run) - 1; n >= 0; n-- {
+			if level := run[n].level; level > 0 {
+				reqs = append(reqs, &docs.Request{
+					InsertText: &docs.InsertTextRequest{
+						Location: &docs.Location{Index: run[n].start - removed[first+n]},
+						Text:     strings.Repeat("\t", level),
+					},
+				})
 			}
+		}
+		shift := removed[first]
+		reqs = append(reqs, docsBulletsRequest(run[0].start-shift, run[len(run)-1].end-shift, run[0].kind))
+	}
+	return reqs
+}
 
-			// Save table data for native insertion
-			tables = append(tables, TableData{
-				StartIndex: charOffset,
-				Cells:      el.TableCells,
-			})
-
-			// Add a placeholder newline (table will be inserted here)
-			plainText.WriteString("\n")
-			charOffset += utf16Len("\n")
+// docsListRuns splits a list into runs of consecutive items of one kind.
+func docsListRuns(group []docsListParagraph) [][]docsListParagraph {
+	var runs [][]docsListParagraph
+	for a := 0; a < len(group); {
+		b := a + 1
+		for b < len(group) && group[b].kind == group[a].kind {
+			b++
 		}
+		runs = append(runs, group[a:b])
+		a = b
+	}
+	return runs
+}
+
+func docsBulletsRequest(start, end int64, kind string) *docs.Request {
+	return &docs.Request{
+		CreateParagraphBullets: &docs.CreateParagraphBulletsRequest{
+			Range:        &docs.Range{StartIndex: start, EndIndex: end},
+			BulletPreset: docsBulletPreset(kind),
+		},
 	}
+}
 
-	if debugMarkdown {
-		fmt.Printf("\n[FINAL] plainText length: %d\n", plainText.Len())
-		fmt.Printf("[FINAL] Final charOffset: %d\n", charOffset)
-		fmt.Printf("[FINAL] Total requests: %d\n", len(requests))
-		fmt.Printf("[FINAL] Total tables: %d\n", len(tables))
-		fmt.Printf("\n[FINAL] plainText content:\n%s\n[END]\n", plainText.String())
+func docsListKind(t MarkdownElementType) string {
+	switch t {
+	case MDNumberedList:
+		return "number"
+	case MDTaskList:
+		return "task"
+	default:
+		return "bullet"
 	}
+}
 
-	return requests, plainText.String(), tables
+// docsBulletPreset maps a list kind to its Docs bullet preset.
+func docsBulletPreset(kind string) string {
+	switch kind {
+	case "number":
+		return "NUMBERED_DECIMAL_ALPHA_ROMAN"
+	case "task":
+		return "BULLET_CHECKBOX"
+	default:
+		return "BULLET_DISC_CIRCLE_SQUARE"
+	}
 }
 
 // buildTextStyleRequest creates a text style update request from a TextStyle
@@ -285,6 +370,14 @@ func buildTextStyleRequest(style TextStyle, baseOffset int64) *docs.Request {
 		textStyle.Italic = true
 		fields = append(fields, "italic")
 	}
+	if style.Strikethrough {
+		textStyle.Strikethrough = true
+		fields = append(fields, "strikethrough")
+	}
+	if style.Superscript {
+		textStyle.BaselineOffset = "SUPERSCRIPT"
+		fields = append(fields, "baselineOffset")
+	}
 	if style.Code {
 		textStyle.WeightedFontFamily = &docs.WeightedFontFamily{
 			FontFamily: "Courier New",
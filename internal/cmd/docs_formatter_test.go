@@ -1,6 +1,10 @@
 package cmd
 
-import "testing"
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
 
 func TestMarkdownToDocsRequests_BaseIndex(t *testing.T) {
 	elements := []MarkdownElement{{Type: MDParagraph, Content: "**bold**"}}
@@ -39,3 +43,105 @@ func TestMarkdownToDocsRequests_TableStartIndexUsesBase(t *testing.T) {
 		t.Fatalf("unexpected table start index: %d", tables[0].StartIndex)
 	}
 }
+
+func TestMarkdownToDocsRequests_ListsCodeAndQuotes(t *testing.T) {
+	md := "1. first\n   - child\n2. second\n\n- [ ] todo\n- [x] done\n\n> quote\n\n```\ncode\n```\n\n| **h** |\n|---|\n| v |\n"
+	requests, text, tables := MarkdownToDocsRequests(ParseMarkdown(md), 1)
+
+	if text != "first\n\tchild\nsecond\ntodo\ndone\nquote\ncode\n\n" {
+		t.Fatalf("unexpected text: %q", text)
+	}
+	// The table follows the leading tab of "child", which the bullets remove.
+	if len(tables) != 1 || tables[0].StartIndex != 41 {
+		t.Fatalf("unexpected tables: %+v", tables)
+	}
+
+	var presets []string
+	var bulletRanges [][2]int64
+	var retabs []int64
+	var strike, shading, indent, mono bool
+	for _, req := range requests {
+		switch {
+		case req.CreateParagraphBullets != nil:
+			presets = append(presets, req.CreateParagraphBullets.BulletPreset)
+			rng := req.CreateParagraphBullets.Range
+			bulletRanges = append(bulletRanges, [2]int64{rng.StartIndex, rng.EndIndex})
+		case len(presets) > 0 && req.InsertText != nil && req.InsertText.Text == "\t":
+			retabs = append(retabs, req.InsertText.Location.Index)
+		case len(presets) > 0:
+			t.Fatalf("bullet requests must come last: %#v", req)
+		case req.UpdateTextStyle != nil:
+			strike = strike || req.UpdateTextStyle.TextStyle.Strikethrough
+			mono = mono || req.UpdateTextStyle.TextStyle.WeightedFontFamily != nil
+		case req.UpdateParagraphStyle != nil:
+			shading = shading || req.UpdateParagraphStyle.ParagraphStyle.Shading != nil
+			indent = indent || req.UpdateParagraphStyle.ParagraphStyle.IndentStart != nil
+		}
+	}
+
+	// The numbered list is bulleted as a whole so "second" continues its
+	// numbering; the nested child then gets its tab back and its own bullets.
+	wantPresets := []string{"BULLET_CHECKBOX", "NUMBERED_DECIMAL_ALPHA_ROMAN", "BULLET_DISC_CIRCLE_SQUARE"}
+	if strings.Join(presets, ",") != strings.Join(wantPresets, ",") {
+		t.Fatalf("unexpected presets: %v", presets)
+	}
+	if bulletRanges[0] != [2]int64{21, 31} || bulletRanges[1] != [2]int64{1, 21} || bulletRanges[2] != [2]int64{7, 14} {
+		t.Fatalf("unexpected bullet ranges: %v", bulletRanges)
+	}
+	if len(retabs) != 1 || retabs[0] != 7 {
+		t.Fatalf("unexpected re-inserted tabs: %v", retabs)
+	}
+	if !strike || !shading || !indent || !mono {
+		t.Fatalf("missing styles: strike=%t shading=%t indent=%t mono=%t", strike, shading, indent, mono)
+	}
+}
+
+func TestMarkdownToDocsRequests_MixedNestedListBullets(t *testing.T) {
+	md := "1. one\n   - sub a\n   - sub b\n2. two\n   1. deep\n3. three\n"
+	requests, text, _ := MarkdownToDocsRequests(ParseMarkdown(md), 1)
+	if text != "one\n\tsub a\n\tsub b\ntwo\n\tdeep\nthree\n" {
+		t.Fatalf("unexpected text: %q", text)
+	}
+
+	// Replay the bullet phase the way Docs applies it: each bullets request
+	// records its paragraphs with their nesting (leading tabs), then removes
+	// those tabs.
+	doc := " " + text
+	var got []string
+	for _, req := range requests {
+		switch {
+		case req.InsertText != nil:
+			at := req.InsertText.Location.Index
+			doc = doc[:at] + req.InsertText.Text + doc[at:]
+		case req.CreateParagraphBullets != nil:
+			rng := req.CreateParagraphBullets.Range
+			lines := strings.SplitAfter(doc[rng.StartIndex:rng.EndIndex], "\n")
+			var stripped strings.Builder
+			for _, line := range lines {
+				if line == "" {
+					continue
+				}
+				body := strings.TrimLeft(line, "\t")
+				got = append(got, fmt.Sprintf("%s/%d/%s", req.CreateParagraphBullets.BulletPreset, len(line)-len(body), strings.TrimSuffix(body, "\n")))
+				stripped.WriteString(body)
+			}
+			doc = doc[:rng.StartIndex] + stripped.String() + doc[rng.EndIndex:]
+		}
+	}
+	if doc != " one\nsub a\nsub b\ntwo\ndeep\nthree\n" {
+		t.Fatalf("unexpected text after bullets: %q", doc)
+	}
+	want := []string{
+		"NUMBERED_DECIMAL_ALPHA_ROMAN/0/one",
+		"NUMBERED_DECIMAL_ALPHA_ROMAN/1/sub a",
+		"NUMBERED_DECIMAL_ALPHA_ROMAN/1/sub b",
+		"NUMBERED_DECIMAL_ALPHA_ROMAN/0/two",
+		"NUMBERED_DECIMAL_ALPHA_ROMAN/1/deep",
+		"NUMBERED_DECIMAL_ALPHA_ROMAN/0/three",
+		"BULLET_DISC_CIRCLE_SQUARE/1/sub a",
+		"BULLET_DISC_CIRCLE_SQUARE/1/sub b",
+	}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("unexpected bullets:\n got %v\nwant %v", got, want)
+	}
+}
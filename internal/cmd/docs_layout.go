@@ -0,0 +1,466 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type DocsHeaderCmd struct {
+	Set    DocsHeaderSetCmd    `cmd:"" name:"set" aliases:"update" help:"Create or replace the page header text"`
+	Delete DocsHeaderDeleteCmd `cmd:"" name:"delete" aliases:"rm,remove" help:"Remove the page header"`
+}
+
+type DocsHeaderSetCmd struct {
+	DocID   string `arg:"" name:"docId" help:"Doc ID"`
+	Content string `arg:"" optional:"" name:"content" help:"Header text (or use --file / stdin)"`
+	File    string `name:"file" short:"f" help:"Read content from file (use - for stdin)"`
+}
+
+func (c *DocsHeaderSetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return setDocsHeaderFooter(ctx, flags, "header", c.DocID, c.Content, c.File)
+}
+
+type DocsHeaderDeleteCmd struct {
+	DocID string `arg:"" name:"docId" help:"Doc ID"`
+}
+
+func (c *DocsHeaderDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return deleteDocsHeaderFooter(ctx, flags, "header", c.DocID)
+}
+
+type DocsFooterCmd struct {
+	Set    DocsFooterSetCmd    `cmd:"" name:"set" aliases:"update" help:"Create or replace the page footer text"`
+	Delete DocsFooterDeleteCmd `cmd:"" name:"delete" aliases:"rm,remove" help:"Remove the page footer"`
+}
+
+type DocsFooterSetCmd struct {
+	DocID   string `arg:"" name:"docId" help:"Doc ID"`
+	Content string `arg:"" optional:"" name:"content" help:"Footer text (or use --file / stdin)"`
+	File    string `name:"file" short:"f" help:"Read content from file (use - for stdin)"`
+}
+
+func (c *DocsFooterSetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return setDocsHeaderFooter(ctx, flags, "footer", c.DocID, c.Content, c.File)
+}
+
+type DocsFooterDeleteCmd struct {
+	DocID string `arg:"" name:"docId" help:"Doc ID"`
+}
+
+func (c *DocsFooterDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return deleteDocsHeaderFooter(ctx, flags, "footer", c.DocID)
+}
+
+// docsHeaderFooter returns the default header or footer ID and its content.
+func docsHeaderFooter(doc *docs.Document, kind string) (string, []*docs.StructuralElement) {
+	if doc.DocumentStyle == nil {
+		return "", nil
+	}
+	if kind == "header" {
+		id := doc.DocumentStyle.DefaultHeaderId
+		return id, doc.Headers[id].Content
+	}
+	id := doc.DocumentStyle.DefaultFooterId
+	return id, doc.Footers[id].Content
+}
+
+// setDocsHeaderFooter creates the default header/footer when missing and
+// replaces its text. The API only creates DEFAULT headers and footers, which
+// apply to every page unless first-page/even-page variants were set up in Docs.
+func setDocsHeaderFooter(ctx context.Context, flags *RootFlags, kind, rawDocID, content, file string) error {
+	u := ui.FromContext(ctx)
+	docID := normalizeGoogleID(strings.TrimSpace(rawDocID))
+	if docID == "" {
+		return usage("empty docId")
+	}
+	content, err := resolveContentInput(content, file)
+	if err != nil {
+		return err
+	}
+	content = strings.TrimRight(content, "\n")
+	if content == "" {
+		return usage("no content provided (use argument, --file, or stdin)")
+	}
+
+	if err = dryRunExit(ctx, flags, "docs."+kind+".set", map[string]any{
+		"documentId": docID,
+		"content":    content,
+	}); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newDocsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	doc, err := svc.Documents.Get(docID).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	id, existing := docsHeaderFooter(doc, kind)
+	created := false
+	if id == "" {
+		req := &docs.Request{CreateHeader: &docs.CreateHeaderRequest{Type: "DEFAULT"}}
+		if kind == "footer" {
+			req = &docs.Request{CreateFooter: &docs.CreateFooterRequest{Type: "DEFAULT"}}
+		}
+		resp, err := svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{
+			Requests: []*docs.Request{req},
+		}).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("creating %s: %w", kind, err)
+		}
+		if len(resp.Replies) > 0 && resp.Replies[0].CreateHeader != nil {
+			id = resp.Replies[0].CreateHeader.HeaderId
+		}
+		if len(resp.Replies) > 0 && resp.Replies[0].CreateFooter != nil {
+			id = resp.Replies[0].CreateFooter.FooterId
+		}
+		if id == "" {
+			return fmt.Errorf("creating %s: no %s ID in response", kind, kind)
+		}
+		created = true
+	}
+
+	var reqs []*docs.Request
+	if n := len(existing); n > 0 {
+		// Keep the final newline; a segment cannot be emptied completely.
+		start, end := existing[0].StartIndex, existing[n-1].EndIndex-1
+		if end > start {
+			reqs = append(reqs, &docs.Request{DeleteContentRange: &docs.DeleteContentRangeRequest{
+				Range: &docs.Range{SegmentId: id, StartIndex: start, EndIndex: end},
+			}})
+		}
+	}
+	reqs = append(reqs, &docs.Request{InsertText: &docs.InsertTextRequest{
+		Location: &docs.Location{SegmentId: id, Index: 0},
+		Text:     content,
+	}})
+	if _, err = svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{Requests: reqs}).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("writing %s: %w", kind, err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"documentId": docID,
+			kind + "Id":  id,
+			"created":    created,
+		})
+	}
+	u.Out().Printf("documentId\t%s", docID)
+	u.Out().Printf("%sId\t%s", kind, id)
+	u.Out().Printf("created\t%t", created)
+	return nil
+}
+
+func deleteDocsHeaderFooter(ctx context.Context, flags *RootFlags, kind, rawDocID string) error {
+	u := ui.FromContext(ctx)
+	docID := normalizeGoogleID(strings.TrimSpace(rawDocID))
+	if docID == "" {
+		return usage("empty docId")
+	}
+	if err := dryRunExit(ctx, flags, "docs."+kind+".delete", map[string]any{"documentId": docID}); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newDocsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	doc, err := svc.Documents.Get(docID).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	id, _ := docsHeaderFooter(doc, kind)
+	if id == "" {
+		return usagef("document has no %s", kind)
+	}
+
+	req := &docs.Request{DeleteHeader: &docs.DeleteHeaderRequest{HeaderId: id}}
+	if kind == "footer" {
+		req = &docs.Request{DeleteFooter: &docs.DeleteFooterRequest{FooterId: id}}
+	}
+	if _, err = svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{
+		Requests: []*docs.Request{req},
+	}).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("deleting %s: %w", kind, err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"documentId": docID, "deleted": id})
+	}
+	u.Out().Printf("documentId\t%s", docID)
+	u.Out().Printf("deleted\t%s", id)
+	return nil
+}
+
+type DocsPageSetupCmd struct {
+	DocID        string `arg:"" name:"docId" help:"Doc ID"`
+	Size         string `name:"size" help:"Page size: letter|legal|tabloid|a3|a4|a5 or WIDTHxHEIGHT (e.g. 8.5inx11in, 210mmx297mm)"`
+	Orientation  string `name:"orientation" help:"Page orientation: portrait|landscape"`
+	Margin       string `name:"margin" help:"All four margins (e.g. 1in, 2.5cm, 72pt)"`
+	MarginTop    string `name:"margin-top" help:"Top margin"`
+	MarginBottom string `name:"margin-bottom" help:"Bottom margin"`
+	MarginLeft   string `name:"margin-left" help:"Left margin"`
+	MarginRight  string `name:"margin-right" help:"Right margin"`
+}
+
+// docsPageSizes holds portrait page sizes in points.
+var docsPageSizes = map[string][2]float64{
+	"letter":  {612, 792},
+	"legal":   {612, 1008},
+	"tabloid": {792, 1224},
+	"a3":      {841.89, 1190.55},
+	"a4":      {595.28, 841.89},
+	"a5":      {419.53, 595.28},
+}
+
+var docsDimensionRe = regexp.MustCompile(`^(\d*\.?\d+)\s*(pt|in|cm|mm)?$`)
+
+// parseDocsDimension converts "1in", "2.5cm", "20mm", or "72pt" (the default
+// unit) to points.
+func parseDocsDimension(s string) (float64, error) {
+	m := docsDimensionRe.FindStringSubmatch(strings.ToLower(strings.TrimSpace(s)))
+	if m == nil {
+		return 0, fmt.Errorf("invalid length %q (use e.g. 1in, 2.5cm, 20mm, 72pt)", s)
+	}
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid length %q: %w", s, err)
+	}
+	switch m[2] {
+	case "in":
+		v *= 72
+	case "cm":
+		v *= 72 / 2.54
+	case "mm":
+		v *= 72 / 25.4
+	}
+	return v, nil
+}
+
+func parseDocsPageSize(s string) (float64, float64, error) {
+	key := strings.ToLower(strings.TrimSpace(s))
+	if size, ok := docsPageSizes[key]; ok {
+		return size[0], size[1], nil
+	}
+	w, h, ok := strings.Cut(key, "x")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid page size %q (use letter, a4, ... or WIDTHxHEIGHT)", s)
+	}
+	width, err := parseDocsDimension(w)
+	if err != nil {
+		return 0, 0, err
+	}
+	height, err := parseDocsDimension(h)
+	if err != nil {
+		return 0, 0, err
+	}
+	return width, height, nil
+}
+
+func (c *DocsPageSetupCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	docID := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	if docID == "" {
+		return usage("empty docId")
+	}
+
+	style := &docs.DocumentStyle{}
+	var fields []string
+	if c.Size != "" {
+		width, height, err := parseDocsPageSize(c.Size)
+		if err != nil {
+			return usage(err.Error())
+		}
+		// Sizes are stored portrait; --orientation flips them.
+		if width > height {
+			width, height = height, width
+		}
+		style.PageSize = &docs.Size{
+			Width:  &docs.Dimension{Magnitude: width, Unit: "PT"},
+			Height: &docs.Dimension{Magnitude: height, Unit: "PT"},
+		}
+		fields = append(fields, "pageSize")
+	}
+	switch strings.ToLower(strings.TrimSpace(c.Orientation)) {
+	case "":
+	case "portrait":
+		fields = append(fields, "flipPageOrientation")
+	case "landscape":
+		style.FlipPageOrientation = true
+		fields = append(fields, "flipPageOrientation")
+	default:
+		return usagef("invalid --orientation %q (use portrait or landscape)", c.Orientation)
+	}
+
+	margins := []struct {
+		value string
+		field string
+		set   func(*docs.Dimension)
+	}{
+		{c.MarginTop, "marginTop", func(d *docs.Dimension) { style.MarginTop = d }},
+		{c.MarginBottom, "marginBottom", func(d *docs.Dimension) { style.MarginBottom = d }},
+		{c.MarginLeft, "marginLeft", func(d *docs.Dimension) { style.MarginLeft = d }},
+		{c.MarginRight, "marginRight", func(d *docs.Dimension) { style.MarginRight = d }},
+	}
+	for _, m := range margins {
+		value := m.value
+		if value == "" {
+			value = c.Margin
+		}
+		if value == "" {
+			continue
+		}
+		pt, err := parseDocsDimension(value)
+		if err != nil {
+			return usage(err.Error())
+		}
+		m.set(&docs.Dimension{Magnitude: pt, Unit: "PT"})
+		fields = append(fields, m.field)
+	}
+	if len(fields) == 0 {
+		return usage("nothing to change (use --size, --orientation, or --margin*)")
+	}
+
+	if err := dryRunExit(ctx, flags, "docs.page_setup", map[string]any{
+		"documentId":    docID,
+		"documentStyle": style,
+		"fields":        strings.Join(fields, ","),
+	}); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newDocsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	if _, err = svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{
+		Requests: []*docs.Request{{UpdateDocumentStyle: &docs.UpdateDocumentStyleRequest{
+			DocumentStyle: style,
+			Fields:        strings.Join(fields, ","),
+		}}},
+	}).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("updating page setup: %w", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"documentId": docID,
+			"updated":    fields,
+		})
+	}
+	u.Out().Printf("documentId\t%s", docID)
+	u.Out().Printf("updated\t%s", strings.Join(fields, ","))
+	return nil
+}
+
+type DocsBreakCmd struct {
+	DocID  string `arg:"" name:"docId" help:"Doc ID"`
+	Type   string `name:"type" help:"Break type: page|section|section-continuous" default:"page" enum:"page,section,section-continuous"`
+	Index  int64  `name:"index" help:"Character index to insert at (default: end of document)"`
+	Before string `name:"before" help:"Insert before the first occurrence of this text instead of --index"`
+}
+
+func (c *DocsBreakCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	docID := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	if docID == "" {
+		return usage("empty docId")
+	}
+	if c.Index < 0 {
+		return usage("--index must be >= 1")
+	}
+	if c.Index > 0 && c.Before != "" {
+		return usage("use either --index or --before")
+	}
+
+	if err := dryRunExit(ctx, flags, "docs.break", map[string]any{
+		"documentId": docID,
+		"type":       c.Type,
+		"index":      c.Index,
+		"before":     c.Before,
+	}); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newDocsService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	index := c.Index
+	if c.Before != "" {
+		doc, err := svc.Documents.Get(docID).Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+		start, _, ok := docsFindText(doc, c.Before, 1)
+		if !ok {
+			return usagef("text %q not found", c.Before)
+		}
+		index = start
+	}
+
+	var loc *docs.Location
+	var end *docs.EndOfSegmentLocation
+	if index > 0 {
+		loc = &docs.Location{Index: index}
+	} else {
+		end = &docs.EndOfSegmentLocation{}
+	}
+	req := &docs.Request{InsertPageBreak: &docs.InsertPageBreakRequest{Location: loc, EndOfSegmentLocation: end}}
+	switch c.Type {
+	case "section":
+		req = &docs.Request{InsertSectionBreak: &docs.InsertSectionBreakRequest{SectionType: "NEXT_PAGE", Location: loc, EndOfSegmentLocation: end}}
+	case "section-continuous":
+		req = &docs.Request{InsertSectionBreak: &docs.InsertSectionBreakRequest{SectionType: "CONTINUOUS", Location: loc, EndOfSegmentLocation: end}}
+	}
+	if _, err = svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{
+		Requests: []*docs.Request{req},
+	}).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("inserting %s break: %w", c.Type, err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"documentId": docID,
+			"type":       c.Type,
+			"atIndex":    index,
+		})
+	}
+	u.Out().Printf("documentId\t%s", docID)
+	u.Out().Printf("type\t%s", c.Type)
+	if index > 0 {
+		u.Out().Printf("atIndex\t%d", index)
+	} else {
+		u.Out().Printf("atIndex\tend")
+	}
+	return nil
+}
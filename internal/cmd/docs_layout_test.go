@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func TestParseDocsDimension(t *testing.T) {
+	tests := map[string]float64{"72": 72, "1in": 72, "2.54cm": 72, "25.4 mm": 72, "10pt": 10}
+	for in, want := range tests {
+		got, err := parseDocsDimension(in)
+		if err != nil || math.Abs(got-want) > 0.001 {
+			t.Errorf("parseDocsDimension(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := parseDocsDimension("1ft"); err == nil {
+		t.Error("expected error for unknown unit")
+	}
+	if w, h, err := parseDocsPageSize("297mmx210mm"); err != nil || math.Abs(w-841.89) > 0.01 || math.Abs(h-595.28) > 0.01 {
+		t.Errorf("parseDocsPageSize = %v, %v, %v", w, h, err)
+	}
+}
+
+func TestDocsHeaderSetAndPageSetup(t *testing.T) {
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	doc := &docs.Document{
+		DocumentId:    "doc1",
+		DocumentStyle: &docs.DocumentStyle{DefaultFooterId: "kix.f1"},
+		Footers: map[string]docs.Footer{"kix.f1": {FooterId: "kix.f1", Content: []*docs.StructuralElement{
+			{StartIndex: 0, EndIndex: 9, Paragraph: &docs.Paragraph{}},
+		}}},
+	}
+	var batches [][]*docs.Request
+	docSvc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/documents/"):
+			_ = json.NewEncoder(w).Encode(doc)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":batchUpdate"):
+			var req docs.BatchUpdateDocumentRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode batchUpdate: %v", err)
+			}
+			batches = append(batches, req.Requests)
+			resp := map[string]any{"documentId": "doc1"}
+			if req.Requests[0].CreateHeader != nil {
+				resp["replies"] = []any{map[string]any{"createHeader": map[string]any{"headerId": "kix.h1"}}}
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docSvc, nil }
+	flags := &RootFlags{Account: "a@b.com"}
+
+	// No header yet: create it, then write into the new segment.
+	if err := runKong(t, &DocsHeaderCmd{}, []string{"set", "doc1", "ACME Confidential"}, newDocsCmdContext(t), flags); err != nil {
+		t.Fatalf("header set: %v", err)
+	}
+	if len(batches) != 2 || batches[0][0].CreateHeader == nil || batches[0][0].CreateHeader.Type != "DEFAULT" {
+		t.Fatalf("expected header creation, got %#v", batches)
+	}
+	if ins := batches[1][0].InsertText; ins == nil || ins.Location.SegmentId != "kix.h1" || ins.Location.Index != 0 || ins.Text != "ACME Confidential" {
+		t.Fatalf("unexpected header insert: %#v", batches[1])
+	}
+
+	// Existing footer: replace its text, keeping the final newline.
+	batches = nil
+	if err := runKong(t, &DocsFooterCmd{}, []string{"set", "doc1", "Page footer\n"}, newDocsCmdContext(t), flags); err != nil {
+		t.Fatalf("footer set: %v", err)
+	}
+	if len(batches) != 1 || len(batches[0]) != 2 {
+		t.Fatalf("expected delete+insert, got %#v", batches)
+	}
+	if del := batches[0][0].DeleteContentRange; del == nil || del.Range.SegmentId != "kix.f1" || del.Range.StartIndex != 0 || del.Range.EndIndex != 8 {
+		t.Fatalf("unexpected footer delete: %#v", batches[0][0])
+	}
+	if ins := batches[0][1].InsertText; ins == nil || ins.Text != "Page footer" {
+		t.Fatalf("unexpected footer insert: %#v", batches[0][1])
+	}
+
+	batches = nil
+	if err := runKong(t, &DocsPageSetupCmd{}, []string{"doc1", "--size", "a4", "--orientation", "landscape", "--margin", "2cm", "--margin-top", "1in"}, newDocsCmdContext(t), flags); err != nil {
+		t.Fatalf("page-setup: %v", err)
+	}
+	upd := batches[0][0].UpdateDocumentStyle
+	if upd == nil || upd.Fields != "pageSize,flipPageOrientation,marginTop,marginBottom,marginLeft,marginRight" {
+		t.Fatalf("unexpected page setup: %#v", batches[0][0])
+	}
+	st := upd.DocumentStyle
+	if !st.FlipPageOrientation || st.PageSize.Width.Magnitude != 595.28 || st.MarginTop.Magnitude != 72 || math.Abs(st.MarginLeft.Magnitude-56.69) > 0.01 {
+		t.Fatalf("unexpected document style: %#v", st)
+	}
+
+	err := runKong(t, &DocsHeaderCmd{}, []string{"delete", "doc1"}, newDocsCmdContext(t), flags)
+	if err == nil || ExitCode(err) != 2 {
+		t.Fatalf("expected usage error deleting a missing header, got %v", err)
+	}
+}
@@ -3,6 +3,8 @@ package cmd
 import (
 	"fmt"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"unicode/utf16"
 )
@@ -32,33 +34,47 @@ const (
 	MDParagraph
 	MDEmptyLine
 	MDTable
+	MDTaskList
+	MDFootnote
 )
 
-// MarkdownElement represents a parsed markdown element
+// MarkdownElement represents a parsed markdown element. Block structure
+// (nesting in lists and block quotes) is flattened: each element carries the
+// depth it was found at.
 type MarkdownElement struct {
 	Type       MarkdownElementType
 	Content    string
 	Children   []MarkdownElement
 	URL        string     // for links
-	Level      int        // for headings and lists
+	Level      int        // headings: 1-6; list items: nesting depth (0 = top level); footnotes: number
 	TableCells [][]string // for tables: rows of cells
+	Language   string     // for fenced code blocks: the info string's first word
+	Checked    bool       // for task list items
+	ListID     int        // list items: identifies the top-level list the item belongs to
+	Quote      int        // block quote depth
+	Indent     int        // continuation blocks inside list items: indentation level
+
+	refs *markdownRefs
 }
 
 // TextStyle represents text formatting
 type TextStyle struct {
-	Bold   bool
-	Italic bool
-	Code   bool
-	Link   string
-	Start  int64
-	End    int64
+	Bold          bool
+	Italic        bool
+	Code          bool
+	Strikethrough bool
+	Superscript   bool
+	Link          string
+	Start         int64
+	End           int64
 }
 
-// ParagraphStyle represents paragraph-level formatting
-type ParagraphStyle struct {
-	Type  MarkdownElementType
-	Start int64
-	End   int64
+// markdownRefs holds the link reference and footnote definitions of a
+// document, shared by all of its elements for inline parsing.
+type markdownRefs struct {
+	links     map[string]string
+	notes     map[string]string // footnote label -> definition
+	footnotes map[string]int    // footnote label -> number, by first reference
 }
 
 // utf16Len returns the number of UTF-16 code units in a string
@@ -66,411 +82,585 @@ func utf16Len(s string) int64 {
 	return int64(len(utf16.Encode([]rune(s))))
 }
 
-// ParseMarkdown parses markdown text into structured elements
+var (
+	atxHeadingRe      = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*))?$`)
+	setextUnderlineRe = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	fenceOpenRe       = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})(.*)$")
+	linkRefDefRe      = regexp.MustCompile(`^ {0,3}\[([^\]^][^\]]*)\]:[ \t]*(<[^>]*>|\S+)(?:[ \t]+("[^"]*"|'[^']*'|\([^)]*\)))?[ \t]*$`)
+	footnoteDefRe     = regexp.MustCompile(`^ {0,3}\[\^([^\]\s]+)\]:[ \t]?(.*)$`)
+	footnoteRefRe     = regexp.MustCompile(`\[\^([^\]\s]+)\]`)
+	taskMarkerRe      = regexp.MustCompile(`^\[([ xX])\](?:[ \t]+|$)`)
+	tableDelimCellRe  = regexp.MustCompile(`^:?-+:?$`)
+)
+
+// ParseMarkdown parses CommonMark with the GitHub extensions (tables, task
+// lists, strikethrough, footnotes, autolinks) into a flat list of elements.
+// Inline markup stays in Content and is parsed by ParseInlineFormatting.
 func ParseMarkdown(text string) []MarkdownElement {
-	var elements []MarkdownElement
+	text = strings.ReplaceAll(text, "\r\n", "\n")
 	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = expandLeadingTabs(line)
+	}
 
-	inCodeBlock := false
-	var codeBlockContent strings.Builder
+	p := &markdownParser{refs: &markdownRefs{
+		links:     map[string]string{},
+		notes:     map[string]string{},
+		footnotes: map[string]int{},
+	}}
+	p.parseBlocks(lines, markdownBlockContext{})
+	p.addFootnotes()
+	for i := range p.out {
+		p.out[i].refs = p.refs
+	}
 
-	for i := 0; i < len(lines); i++ {
-		line := lines[i]
+	if debugMarkdown {
+		for _, el := range p.out {
+			fmt.Printf("[PARSE] type=%d level=%d quote=%d content=%q\n", el.Type, el.Level, el.Quote, el.Content)
+		}
+	}
+	return p.out
+}
 
-		// Handle code blocks
-		if strings.HasPrefix(line, "```") {
-			if inCodeBlock {
-				// End code block
-				elements = append(elements, MarkdownElement{
-					Type:    MDCodeBlock,
-					Content: codeBlockContent.String(),
-				})
-				codeBlockContent.Reset()
-				inCodeBlock = false
-			} else {
-				// Start code block
-				inCodeBlock = true
-			}
+type markdownParser struct {
+	refs      *markdownRefs
+	out       []MarkdownElement
+	nextList  int
+	footOrder []string
+}
+
+type markdownBlockContext struct {
+	quote     int
+	listLevel int
+	indent    int
+	rootList  int
+}
+
+func (p *markdownParser) emit(el MarkdownElement, ctx markdownBlockContext) {
+	el.Quote = ctx.quote
+	if el.Type != MDListItem && el.Type != MDNumberedList && el.Type != MDTaskList {
+		el.Indent = ctx.indent
+	}
+	p.out = append(p.out, el)
+}
+
+func (p *markdownParser) parseBlocks(lines []string, ctx markdownBlockContext) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		if isBlankLine(line) {
+			i++
 			continue
 		}
+		indent := indentWidth(line)
+		rest := line[indent:]
 
-		if inCodeBlock {
-			if codeBlockContent.Len() > 0 {
-				codeBlockContent.WriteString("\n")
-			}
-			codeBlockContent.WriteString(line)
+		if indent >= 4 {
+			i = p.parseIndentedCode(lines, i, ctx)
 			continue
 		}
-
-		// Empty line
-		if strings.TrimSpace(line) == "" {
+		if m := fenceOpenRe.FindStringSubmatch(line); m != nil && !(m[2][0] == '`' && strings.Contains(m[3], "`")) {
+			i = p.parseFencedCode(lines, i, m, ctx)
+			continue
+		}
+		if strings.HasPrefix(rest, "<!--") {
+			i = skipHTMLComment(lines, i)
+			continue
+		}
+		if level, content := parseHeading(line); level > 0 {
+			p.emit(MarkdownElement{Type: headingType(level), Content: content, Level: level}, ctx)
+			i++
 			continue
 		}
-
-		// Horizontal rule
 		if isHorizontalRule(line) {
-			elements = append(elements, MarkdownElement{
-				Type: MDHorizontalRule,
-			})
+			p.emit(MarkdownElement{Type: MDHorizontalRule}, ctx)
+			i++
 			continue
 		}
-
-		// Headings
-		if headingLevel, content := parseHeading(line); headingLevel > 0 {
-			headingType := MDHeading1
-			switch headingLevel {
-			case 1:
-				headingType = MDHeading1
-			case 2:
-				headingType = MDHeading2
-			case 3:
-				headingType = MDHeading3
-			case 4:
-				headingType = MDHeading4
-			case 5:
-				headingType = MDHeading5
-			case 6:
-				headingType = MDHeading6
-			}
-			elements = append(elements, MarkdownElement{
-				Type:    headingType,
-				Content: content,
-			})
+		if strings.HasPrefix(rest, ">") {
+			i = p.parseBlockquote(lines, i, ctx)
 			continue
 		}
-
-		// Blockquote
-		if strings.HasPrefix(line, "> ") {
-			content := strings.TrimPrefix(line, "> ")
-			if debugMarkdown {
-				fmt.Printf("[PARSE] Blockquote detected: %q -> %q\n", line, content)
-			}
-			elements = append(elements, MarkdownElement{
-				Type:    MDBlockquote,
-				Content: content,
-			})
+		if _, ok := parseListMarker(line); ok {
+			i = p.parseList(lines, i, ctx)
 			continue
 		}
-
-		// Numbered list
-		if match := regexp.MustCompile(`^(\d+)\.\s+(.+)`).FindStringSubmatch(line); match != nil {
-			elements = append(elements, MarkdownElement{
-				Type:    MDNumberedList,
-				Content: match[2],
-			})
+		if m := footnoteDefRe.FindStringSubmatch(line); m != nil {
+			i = p.parseFootnoteDef(lines, i, m)
 			continue
 		}
-
-		// Bullet list
-		if strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") {
-			content := strings.TrimPrefix(strings.TrimPrefix(line, "- "), "* ")
-			elements = append(elements, MarkdownElement{
-				Type:    MDListItem,
-				Content: content,
-			})
+		if m := linkRefDefRe.FindStringSubmatch(line); m != nil {
+			label := normalizeMarkdownLabel(m[1])
+			if _, exists := p.refs.links[label]; !exists {
+				p.refs.links[label] = strings.TrimSuffix(strings.TrimPrefix(m[2], "<"), ">")
+			}
+			i++
 			continue
 		}
+		if isTableStart(lines, i) {
+			i = p.parseTable(lines, i, ctx)
+			continue
+		}
+		i = p.parseParagraph(lines, i, ctx)
+	}
+}
 
-		// Table detection - line starts with | and has multiple |
-		if strings.HasPrefix(line, "|") && strings.Count(line, "|") >= 2 {
-			if debugMarkdown {
-				fmt.Printf("[TABLE DEBUG] Found potential table row: %q\n", line)
-				if i+1 < len(lines) {
-					fmt.Printf("[TABLE DEBUG] Next line: %q, isSep: %v\n", lines[i+1], isTableSeparator(lines[i+1]))
-				}
-			}
-			// Check if next line is separator (|---|---| pattern)
-			if i+1 < len(lines) && isTableSeparator(lines[i+1]) {
-				if debugMarkdown {
-					fmt.Printf("[TABLE DEBUG] Parsing table starting at line %d\n", i)
-				}
-				// Parse table
-				tableCells := parseMarkdownTable(lines[i:])
-				elements = append(elements, MarkdownElement{
-					Type:       MDTable,
-					TableCells: tableCells,
-				})
-				// Skip all table lines
-				i += len(tableCells) // loop increment handles separator line offset
-				continue
+func (p *markdownParser) parseParagraph(lines []string, i int, ctx markdownBlockContext) int {
+	parts := []string{strings.TrimLeft(lines[i], " ")}
+	j := i + 1
+	for ; j < len(lines); j++ {
+		line := lines[j]
+		if isBlankLine(line) {
+			break
+		}
+		if m := setextUnderlineRe.FindStringSubmatch(line); m != nil {
+			level := 1
+			if m[1][0] == '-' {
+				level = 2
 			}
+			content := strings.TrimSpace(strings.Join(parts, "\n"))
+			p.emit(MarkdownElement{Type: headingType(level), Content: content, Level: level}, ctx)
+			return j + 1
 		}
-
-		// Regular paragraph
-		elements = append(elements, MarkdownElement{
-			Type:    MDParagraph,
-			Content: line,
-		})
+		if markdownInterrupts(lines, j, true) {
+			break
+		}
+		parts = append(parts, strings.TrimLeft(line, " "))
 	}
 
-	return elements
+	typ := MDParagraph
+	if ctx.quote > 0 {
+		typ = MDBlockquote
+	}
+	p.emit(MarkdownElement{Type: typ, Content: strings.TrimRight(strings.Join(parts, "\n"), " \t")}, ctx)
+	return j
 }
 
-// isTableSeparator checks if a line is a markdown table separator (|---|---|)
-func isTableSeparator(line string) bool {
-	trimmed := strings.TrimSpace(line)
-	if !strings.HasPrefix(trimmed, "|") || !strings.HasSuffix(trimmed, "|") {
-		return false
-	}
-	// Remove outer pipes
-	inner := strings.Trim(trimmed, "|")
-	// Split by | and check each segment
-	segments := strings.Split(inner, "|")
-	for _, seg := range segments {
-		seg = strings.TrimSpace(seg)
-		if seg == "" {
+func (p *markdownParser) parseIndentedCode(lines []string, i int, ctx markdownBlockContext) int {
+	var code []string
+	j := i
+	for ; j < len(lines); j++ {
+		line := lines[j]
+		if isBlankLine(line) {
+			code = append(code, "")
 			continue
 		}
-		// Each segment should be only dashes (with optional leading/trailing colon for alignment)
-		for i, c := range seg {
-			if c != '-' && c != ' ' && c != ':' {
-				return false
-			}
-			// Colon only allowed at start or end for alignment
-			if c == ':' && i != 0 && i != len(seg)-1 {
-				return false
-			}
-		}
-		// Must have at least one dash
-		if strings.Count(seg, "-") == 0 {
-			return false
+		if indentWidth(line) < 4 {
+			break
 		}
+		code = append(code, line[4:])
 	}
-	return len(segments) > 1
+	for len(code) > 0 && code[len(code)-1] == "" {
+		code = code[:len(code)-1]
+	}
+	p.emit(MarkdownElement{Type: MDCodeBlock, Content: strings.Join(code, "\n")}, ctx)
+	return j
 }
 
-// parseMarkdownTable parses a markdown table into rows of cells
-func parseMarkdownTable(lines []string) [][]string {
-	var rows [][]string
+func (p *markdownParser) parseFencedCode(lines []string, i int, m []string, ctx markdownBlockContext) int {
+	openIndent, fence := len(m[1]), m[2]
+	lang := ""
+	if fields := strings.Fields(m[3]); len(fields) > 0 {
+		lang = fields[0]
+	}
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
+	var code []string
+	j := i + 1
+	for ; j < len(lines); j++ {
+		line := lines[j]
+		trimmed := strings.TrimSpace(line)
+		if indentWidth(line) < 4 && len(trimmed) >= len(fence) &&
+			strings.Trim(trimmed, fence[:1]) == "" {
+			j++
 			break
 		}
-		if !strings.HasPrefix(line, "|") {
-			break
-		}
-		// Skip separator line
-		if isTableSeparator(line) {
-			continue
+		strip := indentWidth(line)
+		if strip > openIndent {
+			strip = openIndent
 		}
+		code = append(code, line[strip:])
+	}
+	p.emit(MarkdownElement{Type: MDCodeBlock, Content: strings.Join(code, "\n"), Language: lang}, ctx)
+	return j
+}
 
-		// Parse row: | cell1 | cell2 | cell3 |
-		cells := parseTableRow(line)
-		if len(cells) > 0 {
-			rows = append(rows, cells)
+func skipHTMLComment(lines []string, i int) int {
+	for j := i; j < len(lines); j++ {
+		line := lines[j]
+		if j == i {
+			line = line[strings.Index(line, "<!--")+4:]
+		}
+		if strings.Contains(line, "-->") {
+			return j + 1
 		}
 	}
-
-	return rows
+	return len(lines)
 }
 
-// parseTableRow parses a single table row into cells
-func parseTableRow(line string) []string {
-	// Remove outer pipes
-	trimmed := strings.Trim(line, "|")
-
-	// Split by |
-	parts := strings.Split(trimmed, "|")
-
-	cells := make([]string, 0, len(parts))
-	for _, part := range parts {
-		cell := strings.TrimSpace(part)
-		cells = append(cells, cell)
+func (p *markdownParser) parseBlockquote(lines []string, i int, ctx markdownBlockContext) int {
+	var inner []string
+	j := i
+	for ; j < len(lines); j++ {
+		line := lines[j]
+		indent := indentWidth(line)
+		if indent < 4 && strings.HasPrefix(line[indent:], ">") {
+			s := line[indent+1:]
+			s = strings.TrimPrefix(s, " ")
+			inner = append(inner, s)
+			continue
+		}
+		// Lazy continuation of a paragraph inside the quote.
+		if !isBlankLine(line) && len(inner) > 0 && !isBlankLine(inner[len(inner)-1]) && !markdownInterrupts(lines, j, false) {
+			inner = append(inner, line)
+			continue
+		}
+		break
 	}
-
-	return cells
+	quoted := ctx
+	quoted.quote++
+	p.parseBlocks(inner, quoted)
+	return j
 }
 
-// InlineMatch represents a matched inline pattern
-const inlineTypeCode = "code"
-
-type InlineMatch struct {
-	Start   int
-	End     int
-	Content string
-	Type    string
-	URL     string
+// markdownListMarker describes the start of a list item.
+type markdownListMarker struct {
+	ordered bool
+	delim   byte // bullet character, or '.'/')' after the number
+	start   int
+	width   int // column where the item content starts
+	content string
 }
 
-// ParseInlineFormatting parses inline markdown formatting within text
-// Returns styles with indices relative to the stripped plain text (UTF-16 code units)
-func ParseInlineFormatting(text string) ([]TextStyle, string) {
-	var matches []InlineMatch
-
-	// Find all links [text](url)
-	linkRegex := regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
-	for _, idx := range linkRegex.FindAllStringSubmatchIndex(text, -1) {
-		matches = append(matches, InlineMatch{
-			Start:   idx[0],
-			End:     idx[1],
-			Content: text[idx[2]:idx[3]],
-			Type:    "link",
-			URL:     text[idx[4]:idx[5]],
-		})
+func parseListMarker(line string) (markdownListMarker, bool) {
+	indent := indentWidth(line)
+	if indent >= 4 || isHorizontalRule(line) {
+		return markdownListMarker{}, false
 	}
-
-	// Find all inline code `code`
-	codeRegex := regexp.MustCompile("`([^`]+)`")
-	for _, idx := range codeRegex.FindAllStringSubmatchIndex(text, -1) {
-		matches = append(matches, InlineMatch{
-			Start:   idx[0],
-			End:     idx[1],
-			Content: text[idx[2]:idx[3]],
-			Type:    inlineTypeCode,
-		})
+	rest := line[indent:]
+	m := markdownListMarker{}
+	end := 0
+	switch {
+	case rest != "" && (rest[0] == '-' || rest[0] == '*' || rest[0] == '+'):
+		m.delim, end = rest[0], 1
+	default:
+		for end < len(rest) && end < 9 && rest[end] >= '0' && rest[end] <= '9' {
+			end++
+		}
+		if end == 0 || end >= len(rest) || (rest[end] != '.' && rest[end] != ')') {
+			return markdownListMarker{}, false
+		}
+		m.ordered, m.delim = true, rest[end]
+		m.start, _ = strconv.Atoi(rest[:end])
+		end++
+	}
+	after := rest[end:]
+	if after != "" && after[0] != ' ' {
+		return markdownListMarker{}, false
 	}
+	spaces := len(after) - len(strings.TrimLeft(after, " "))
+	switch {
+	case strings.TrimSpace(after) == "":
+		m.width = indent + end + 1
+	case spaces > 4:
+		m.width = indent + end + 1
+	default:
+		m.width = indent + end + spaces
+	}
+	if m.width-indent-end <= len(after) {
+		m.content = after[m.width-indent-end:]
+	}
+	return m, true
+}
 
-	// Find bold-italic ***text***
-	biRegex := regexp.MustCompile(`\*\*\*([^*]+)\*\*\*`)
-	for _, idx := range biRegex.FindAllStringSubmatchIndex(text, -1) {
-		matches = append(matches, InlineMatch{
-			Start:   idx[0],
-			End:     idx[1],
-			Content: text[idx[2]:idx[3]],
-			Type:    "bolditalic",
-		})
+func (p *markdownParser) parseList(lines []string, i int, ctx markdownBlockContext) int {
+	first, _ := parseListMarker(lines[i])
+	listCtx := ctx
+	if ctx.rootList == 0 {
+		p.nextList++
+		listCtx.rootList = p.nextList
 	}
 
-	// Find bold **text** (not overlapping with other patterns)
-	boldRegex := regexp.MustCompile(`\*\*([^*]+)\*\*`)
-	for _, idx := range boldRegex.FindAllStringSubmatchIndex(text, -1) {
-		overlaps := false
-		for _, m := range matches {
-			if idx[0] < m.End && idx[1] > m.Start {
-				overlaps = true
-				break
+	j := i
+	for j < len(lines) {
+		m, ok := parseListMarker(lines[j])
+		if !ok || m.ordered != first.ordered || m.delim != first.delim {
+			break
+		}
+		item := []string{m.content}
+		k := j + 1
+		for ; k < len(lines); k++ {
+			line := lines[k]
+			if isBlankLine(line) {
+				item = append(item, "")
+				continue
+			}
+			if indentWidth(line) >= m.width {
+				item = append(item, line[m.width:])
+				continue
+			}
+			// Lazy continuation of the item's paragraph.
+			if !isBlankLine(item[len(item)-1]) && !markdownInterrupts(lines, k, false) {
+				item = append(item, strings.TrimLeft(line, " "))
+				continue
 			}
+			break
 		}
-		if !overlaps {
-			matches = append(matches, InlineMatch{
-				Start:   idx[0],
-				End:     idx[1],
-				Content: text[idx[2]:idx[3]],
-				Type:    "bold",
-			})
+		for len(item) > 1 && isBlankLine(item[len(item)-1]) {
+			item = item[:len(item)-1]
 		}
+		p.parseListItem(item, m, listCtx)
+		j = k
 	}
+	return j
+}
 
-	// For italic, we need to be careful not to match asterisks that are part of bold
-	boldPositions := make(map[int]bool)
-	for _, m := range matches {
-		if m.Type == "bold" || m.Type == "bolditalic" {
-			for i := m.Start; i <= m.End; i++ {
-				boldPositions[i] = true
-			}
+// parseListItem emits the item's first paragraph as the list element and its
+// remaining blocks nested one level deeper. Later paragraphs are joined into
+// the item with line breaks so numbering is not interrupted.
+func (p *markdownParser) parseListItem(item []string, m markdownListMarker, ctx markdownBlockContext) {
+	typ := MDListItem
+	if m.ordered {
+		typ = MDNumberedList
+	}
+	checked := false
+	if !m.ordered {
+		if tm := taskMarkerRe.FindStringSubmatch(item[0]); tm != nil {
+			typ = MDTaskList
+			checked = tm[1] != " "
+			item[0] = item[0][len(tm[0]):]
 		}
 	}
 
-	// Find italic *text* but skip positions that are part of bold markers
-	italicRegex := regexp.MustCompile(`\*([^*]+)\*`)
-	for _, idx := range italicRegex.FindAllStringSubmatchIndex(text, -1) {
-		touchesBold := false
-		for i := idx[0]; i <= idx[1]; i++ {
-			if boldPositions[i] {
-				touchesBold = true
-				break
-			}
+	inner := ctx
+	inner.listLevel = ctx.listLevel + 1
+	inner.indent = ctx.listLevel + 1
+
+	start := len(p.out)
+	p.parseBlocks(item, inner)
+
+	el := MarkdownElement{Type: typ, Level: ctx.listLevel, ListID: ctx.rootList, Checked: checked, Quote: ctx.quote}
+	children := p.out[start:]
+	merged := 0
+	for merged < len(children) {
+		c := children[merged]
+		if (c.Type != MDParagraph && c.Type != MDBlockquote) || c.Indent != inner.indent || c.Quote != ctx.quote {
+			break
 		}
-		if !touchesBold {
-			overlaps := false
-			for _, m := range matches {
-				if idx[0] < m.End && idx[1] > m.Start {
-					overlaps = true
-					break
-				}
-			}
-			if !overlaps {
-				matches = append(matches, InlineMatch{
-					Start:   idx[0],
-					End:     idx[1],
-					Content: text[idx[2]:idx[3]],
-					Type:    "italic",
-				})
-			}
+		if merged > 0 {
+			el.Content += "\\\n"
 		}
+		el.Content += c.Content
+		merged++
 	}
+	rest := append([]MarkdownElement(nil), children[merged:]...)
+	p.out = append(append(p.out[:start], el), rest...)
+}
 
-	// Sort matches by start position
-	for i := 0; i < len(matches)-1; i++ {
-		for j := i + 1; j < len(matches); j++ {
-			if matches[i].Start > matches[j].Start {
-				matches[i], matches[j] = matches[j], matches[i]
+func (p *markdownParser) parseFootnoteDef(lines []string, i int, m []string) int {
+	parts := []string{strings.TrimSpace(m[2])}
+	j := i + 1
+	for ; j < len(lines); j++ {
+		line := lines[j]
+		if isBlankLine(line) {
+			if j+1 < len(lines) && indentWidth(lines[j+1]) >= 4 && !isBlankLine(lines[j+1]) {
+				parts = append(parts, "\\")
+				continue
 			}
+			break
+		}
+		if indentWidth(line) >= 4 || !markdownInterrupts(lines, j, false) {
+			parts = append(parts, strings.TrimSpace(line))
+			continue
 		}
+		break
 	}
+	if _, exists := p.refs.notes[m[1]]; !exists {
+		p.refs.notes[m[1]] = strings.Join(parts, "\n")
+	}
+	return j
+}
 
-	// Build stripped text and position map simultaneously
-	var stripped strings.Builder
-	// positionMap stores original byte offset -> stripped UTF-16 offset
-	positionMap := make(map[int]int64)
-
-	currentByte := 0
-	var strippedUTF16Len int64 = 0
+func (p *markdownParser) parseTable(lines []string, i int, ctx markdownBlockContext) int {
+	header := splitTableRow(lines[i])
+	rows := [][]string{header}
+	j := i + 2
+	for ; j < len(lines); j++ {
+		line := lines[j]
+		if isBlankLine(line) || !strings.Contains(line, "|") || markdownInterrupts(lines, j, false) {
+			break
+		}
+		cells := splitTableRow(line)
+		row := make([]string, len(header))
+		copy(row, cells)
+		rows = append(rows, row)
+	}
+	p.emit(MarkdownElement{Type: MDTable, TableCells: rows}, ctx)
+	return j
+}
 
-	for currentByte < len(text) {
-		matchFound := false
-		for _, m := range matches {
-			if m.Start == currentByte {
-				positionMap[currentByte] = strippedUTF16Len
-				stripped.WriteString(m.Content)
-				strippedUTF16Len += utf16Len(m.Content)
-				currentByte = m.End
-				matchFound = true
-				break
+// addFootnotes numbers footnotes in order of first reference and appends
+// their definitions; unreferenced definitions are dropped.
+func (p *markdownParser) addFootnotes() {
+	if len(p.refs.notes) == 0 {
+		return
+	}
+	scan := func(s string) {
+		for _, m := range footnoteRefRe.FindAllStringSubmatch(s, -1) {
+			label := m[1]
+			if _, defined := p.refs.notes[label]; !defined {
+				continue
+			}
+			if _, seen := p.refs.footnotes[label]; !seen {
+				p.refs.footnotes[label] = len(p.refs.footnotes) + 1
+				p.footOrder = append(p.footOrder, label)
 			}
 		}
-
-		if !matchFound {
-			positionMap[currentByte] = strippedUTF16Len
-			char, size := nextRune(text[currentByte:])
-			stripped.WriteString(char)
-			strippedUTF16Len += utf16Len(char)
-			currentByte += size
+	}
+	for _, el := range p.out {
+		if el.Type == MDCodeBlock {
+			continue
+		}
+		scan(el.Content)
+		for _, row := range el.TableCells {
+			for _, cell := range row {
+				scan(cell)
+			}
 		}
 	}
+	// Footnotes may reference other footnotes.
+	for i := 0; i < len(p.footOrder); i++ {
+		scan(p.refs.notes[p.footOrder[i]])
+	}
+	labels := append([]string(nil), p.footOrder...)
+	sort.SliceStable(labels, func(a, b int) bool { return p.refs.footnotes[labels[a]] < p.refs.footnotes[labels[b]] })
+	for _, label := range labels {
+		p.out = append(p.out, MarkdownElement{Type: MDFootnote, Content: p.refs.notes[label], Level: p.refs.footnotes[label]})
+	}
+}
 
-	positionMap[len(text)] = strippedUTF16Len
-	strippedText := stripped.String()
-
-	// Convert matches to styles with stripped UTF-16 positions
-	styles := make([]TextStyle, 0, len(matches))
-	for _, m := range matches {
-		styles = append(styles, TextStyle{
-			Start:  positionMap[m.Start],
-			End:    positionMap[m.End],
-			Bold:   m.Type == "bold" || m.Type == "bolditalic",
-			Italic: m.Type == "italic" || m.Type == "bolditalic",
-			Code:   m.Type == inlineTypeCode,
-			Link:   m.URL,
-		})
+// markdownInterrupts reports whether lines[j] starts a new block. Inside a
+// paragraph only bullet items and ordered items starting at 1 may interrupt.
+func markdownInterrupts(lines []string, j int, paragraph bool) bool {
+	line := lines[j]
+	indent := indentWidth(line)
+	if indent >= 4 {
+		return false
 	}
+	rest := line[indent:]
+	switch {
+	case fenceOpenRe.MatchString(line),
+		strings.HasPrefix(rest, "<!--"),
+		strings.HasPrefix(rest, ">"),
+		isHorizontalRule(line),
+		footnoteDefRe.MatchString(line),
+		isTableStart(lines, j):
+		return true
+	}
+	if level, _ := parseHeading(line); level > 0 {
+		return true
+	}
+	if m, ok := parseListMarker(line); ok {
+		if !paragraph {
+			return true
+		}
+		return strings.TrimSpace(m.content) != "" && (!m.ordered || m.start == 1)
+	}
+	return false
+}
 
-	return styles, strippedText
+func isTableStart(lines []string, i int) bool {
+	if i+1 >= len(lines) || !strings.Contains(lines[i], "|") || indentWidth(lines[i]) >= 4 {
+		return false
+	}
+	return isTableSeparator(lines[i+1]) && len(splitTableRow(lines[i])) == len(splitTableRow(lines[i+1]))
 }
 
-// nextRune returns the first rune and its byte size from a string
-func nextRune(s string) (string, int) {
-	for i, r := range s {
-		if i > 0 {
-			return s[:i], i
+// isTableSeparator checks if a line is a GFM table delimiter row (| --- | :-: |)
+func isTableSeparator(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if !strings.Contains(trimmed, "|") {
+		return false
+	}
+	cells := splitTableRow(trimmed)
+	if len(cells) == 0 {
+		return false
+	}
+	for _, cell := range cells {
+		if !tableDelimCellRe.MatchString(cell) {
+			return false
 		}
-		if len(s) == 1 {
-			return s, 1
+	}
+	return true
+}
+
+// splitTableRow splits a table row on unescaped pipes outside code spans.
+// Leading and trailing pipes are optional; `\|` becomes a literal pipe.
+func splitTableRow(line string) []string {
+	s := strings.TrimSpace(line)
+	s = strings.TrimPrefix(s, "|")
+	if strings.HasSuffix(s, "|") && !strings.HasSuffix(s, `\|`) {
+		s = s[:len(s)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	inCode := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && s[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case c == '`':
+			n := 1
+			for i+n < len(s) && s[i+n] == '`' {
+				n++
+			}
+			switch {
+			case inCode == 0:
+				inCode = n
+			case inCode == n:
+				inCode = 0
+			}
+			cell.WriteString(s[i : i+n])
+			i += n - 1
+		case c == '|' && inCode == 0:
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(c)
 		}
-		_ = r
 	}
-	return "", 0
+	return append(cells, strings.TrimSpace(cell.String()))
 }
 
 func parseHeading(line string) (int, string) {
-	headingRegex := regexp.MustCompile(`^(#{1,6})\s+(.+)$`)
-	match := headingRegex.FindStringSubmatch(line)
+	match := atxHeadingRe.FindStringSubmatch(line)
 	if match == nil {
 		return 0, ""
 	}
-	return len(match[1]), match[2]
+	content := strings.TrimSpace(match[2])
+	// Drop an optional closing sequence of #s.
+	if stripped := strings.TrimRight(content, "#"); stripped == "" || strings.HasSuffix(stripped, " ") {
+		content = strings.TrimSpace(stripped)
+	}
+	return len(match[1]), content
+}
+
+func headingType(level int) MarkdownElementType {
+	switch level {
+	case 2:
+		return MDHeading2
+	case 3:
+		return MDHeading3
+	case 4:
+		return MDHeading4
+	case 5:
+		return MDHeading5
+	case 6:
+		return MDHeading6
+	default:
+		return MDHeading1
+	}
 }
 
 func isHorizontalRule(line string) bool {
@@ -482,10 +672,48 @@ func isHorizontalRule(line string) bool {
 	if char != '-' && char != '*' && char != '_' {
 		return false
 	}
+	count := 0
 	for _, c := range trimmed {
-		if c != rune(char) && c != ' ' {
+		if c == rune(char) {
+			count++
+		} else if c != ' ' && c != '\t' {
 			return false
 		}
 	}
-	return true
+	return count >= 3
+}
+
+func isBlankLine(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func indentWidth(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// expandLeadingTabs replaces tabs in a line's indentation with spaces up to
+// the next multiple of four, so nesting can be measured in columns.
+func expandLeadingTabs(line string) string {
+	if !strings.HasPrefix(line, "\t") && !strings.HasPrefix(strings.TrimLeft(line, " "), "\t") {
+		return line
+	}
+	var sb strings.Builder
+	col := 0
+	i := 0
+	for ; i < len(line) && (line[i] == ' ' || line[i] == '\t'); i++ {
+		if line[i] == '\t' {
+			n := 4 - col%4
+			sb.WriteString(strings.Repeat(" ", n))
+			col += n
+			continue
+		}
+		sb.WriteByte(' ')
+		col++
+	}
+	sb.WriteString(line[i:])
+	return sb.String()
+}
+
+func normalizeMarkdownLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
 }
@@ -0,0 +1,557 @@
+package cmd
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	inlineAutolinkRe = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^<>\s]*)>`)
+	inlineEmailRe    = regexp.MustCompile(`^<([a-zA-Z0-9.!#$%&'*+/=?^_` + "`" + `{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*)>`)
+	inlineHTMLTagRe  = regexp.MustCompile(`^(?:<!--[\s\S]*?-->|</?([a-zA-Z][a-zA-Z0-9-]*)(?:\s[^<>]*)?/?>)`)
+	inlineEntityRe   = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[a-zA-Z][a-zA-Z0-9]{1,31});`)
+	inlineFootRefRe  = regexp.MustCompile(`^\[\^([^\]\s]+)\]`)
+	bareURLRe        = regexp.MustCompile(`^(?:https?://|www\.)[^\s<]*[^\s<?!.,:*_~'"\]]`)
+)
+
+// markdownInline is a run of inline text with the styles applied to it.
+type markdownInline struct {
+	text  string
+	style TextStyle
+	plain bool // literal text that later text may be merged into
+}
+
+type markdownDelimiter struct {
+	node     int
+	char     byte
+	count    int
+	orig     int
+	canOpen  bool
+	canClose bool
+}
+
+type markdownBracket struct {
+	node   int
+	image  bool
+	active bool
+	bottom int // delimiter stack height when the bracket was opened
+	pos    int // source offset just after the bracket
+}
+
+type markdownInlineParser struct {
+	src      string
+	pos      int
+	refs     *markdownRefs
+	nodes    []markdownInline
+	delims   []markdownDelimiter
+	brackets []markdownBracket
+}
+
+// ParseInlineFormatting parses inline markdown formatting within text
+// Returns styles with indices relative to the stripped plain text (UTF-16 code units)
+func ParseInlineFormatting(text string) ([]TextStyle, string) {
+	return parseInlineMarkdown(text, nil)
+}
+
+// inline parses the element's Content with the document's link references
+// and footnote numbers.
+func (el MarkdownElement) inline() ([]TextStyle, string) {
+	return parseInlineMarkdown(el.Content, el.refs)
+}
+
+// parseInlineMarkdown implements CommonMark inlines (code spans, emphasis,
+// links, images, autolinks, entities, escapes, line breaks) plus GFM
+// strikethrough, bare URL autolinks, and footnote references. Images become
+// links to their source; raw HTML is dropped except for <br>.
+func parseInlineMarkdown(text string, refs *markdownRefs) ([]TextStyle, string) {
+	p := &markdownInlineParser{src: text, refs: refs}
+	p.parse()
+	p.processEmphasis(0)
+	return p.flatten()
+}
+
+func (p *markdownInlineParser) parse() {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch c {
+		case '\\':
+			p.escape()
+		case '`':
+			p.codeSpan()
+		case '*', '_', '~':
+			p.delimiterRun(c)
+		case '[':
+			if m := inlineFootRefRe.FindStringSubmatch(p.src[p.pos:]); m != nil && p.footnote(m[1]) > 0 {
+				p.push(markdownInline{text: strconv.Itoa(p.footnote(m[1])), style: TextStyle{Superscript: true}})
+				p.pos += len(m[0])
+				continue
+			}
+			p.brackets = append(p.brackets, markdownBracket{node: len(p.nodes), active: true, bottom: len(p.delims), pos: p.pos + 1})
+			p.push(markdownInline{text: "["})
+			p.pos++
+		case '!':
+			if p.pos+1 < len(p.src) && p.src[p.pos+1] == '[' {
+				p.brackets = append(p.brackets, markdownBracket{node: len(p.nodes), image: true, active: true, bottom: len(p.delims), pos: p.pos + 2})
+				p.push(markdownInline{text: "!["})
+				p.pos += 2
+				continue
+			}
+			p.text("!")
+			p.pos++
+		case ']':
+			p.closeBracket()
+		case '<':
+			p.angle()
+		case '&':
+			if m := inlineEntityRe.FindString(p.src[p.pos:]); m != "" {
+				p.text(html.UnescapeString(m))
+				p.pos += len(m)
+				continue
+			}
+			p.text("&")
+			p.pos++
+		case '\n':
+			p.lineBreak()
+		default:
+			if p.bareURL() {
+				continue
+			}
+			end := p.pos + 1
+			for end < len(p.src) && !strings.ContainsRune("\\`*_~[!]<&\n", rune(p.src[end])) && !p.urlStartsAt(end) {
+				end++
+			}
+			p.text(p.src[p.pos:end])
+			p.pos = end
+		}
+	}
+}
+
+func (p *markdownInlineParser) push(n markdownInline) {
+	p.nodes = append(p.nodes, n)
+}
+
+// text appends literal text, merging into the previous literal node.
+func (p *markdownInlineParser) text(s string) {
+	if n := len(p.nodes); n > 0 && p.nodes[n-1].plain {
+		p.nodes[n-1].text += s
+		return
+	}
+	p.push(markdownInline{text: s, plain: true})
+}
+
+// urlStartsAt reports whether a GFM bare URL (https://..., www....) may
+// start at offset i: at the start of text, after whitespace, or after one of
+// "(*_~".
+func (p *markdownInlineParser) urlStartsAt(i int) bool {
+	rest := p.src[i:]
+	if !strings.HasPrefix(rest, "http://") && !strings.HasPrefix(rest, "https://") && !strings.HasPrefix(rest, "www.") {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	prev, _ := utf8.DecodeLastRuneInString(p.src[:i])
+	return unicode.IsSpace(prev) || strings.ContainsRune("(*_~", prev)
+}
+
+func (p *markdownInlineParser) bareURL() bool {
+	if !p.urlStartsAt(p.pos) {
+		return false
+	}
+	url := bareURLRe.FindString(p.src[p.pos:])
+	// Keep a trailing ")" only when parentheses are balanced.
+	for strings.HasSuffix(url, ")") && strings.Count(url, ")") > strings.Count(url, "(") {
+		url = url[:len(url)-1]
+	}
+	if url == "" {
+		return false
+	}
+	href := url
+	if strings.HasPrefix(href, "www.") {
+		href = "http://" + href
+	}
+	p.push(markdownInline{text: url, style: TextStyle{Link: href}})
+	p.pos += len(url)
+	return true
+}
+
+func (p *markdownInlineParser) escape() {
+	if p.pos+1 < len(p.src) {
+		next := p.src[p.pos+1]
+		if next == '\n' {
+			p.trimTrailingSpaces()
+			p.push(markdownInline{text: "\v"})
+			p.pos += 2
+			p.skipLeadingSpaces()
+			return
+		}
+		if (next < utf8.RuneSelf && unicode.IsPunct(rune(next))) || strings.ContainsRune("$+<=>^`|~", rune(next)) {
+			p.text(string(next))
+			p.pos += 2
+			return
+		}
+	}
+	p.text("\\")
+	p.pos++
+}
+
+func (p *markdownInlineParser) codeSpan() {
+	n := runLength(p.src, p.pos, '`')
+	for i := p.pos + n; i < len(p.src); {
+		j := strings.IndexByte(p.src[i:], '`')
+		if j < 0 {
+			break
+		}
+		start := i + j
+		m := runLength(p.src, start, '`')
+		if m == n {
+			code := strings.ReplaceAll(p.src[p.pos+n:start], "\n", " ")
+			if len(code) >= 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+				code = code[1 : len(code)-1]
+			}
+			p.push(markdownInline{text: code, style: TextStyle{Code: true}})
+			p.pos = start + m
+			return
+		}
+		i = start + m
+	}
+	p.text(p.src[p.pos : p.pos+n])
+	p.pos += n
+}
+
+func (p *markdownInlineParser) delimiterRun(c byte) {
+	n := runLength(p.src, p.pos, c)
+	if c == '~' && n > 2 {
+		p.text(p.src[p.pos : p.pos+n])
+		p.pos += n
+		return
+	}
+
+	before, after := ' ', ' '
+	if p.pos > 0 {
+		before, _ = utf8.DecodeLastRuneInString(p.src[:p.pos])
+	}
+	if p.pos+n < len(p.src) {
+		after, _ = utf8.DecodeRuneInString(p.src[p.pos+n:])
+	}
+	left := !unicode.IsSpace(after) && (!isMarkdownPunct(after) || unicode.IsSpace(before) || isMarkdownPunct(before))
+	right := !unicode.IsSpace(before) && (!isMarkdownPunct(before) || unicode.IsSpace(after) || isMarkdownPunct(after))
+	canOpen, canClose := left, right
+	if c == '_' {
+		canOpen = left && (!right || isMarkdownPunct(before))
+		canClose = right && (!left || isMarkdownPunct(after))
+	}
+
+	p.delims = append(p.delims, markdownDelimiter{node: len(p.nodes), char: c, count: n, orig: n, canOpen: canOpen, canClose: canClose})
+	p.push(markdownInline{text: p.src[p.pos : p.pos+n]})
+	p.pos += n
+}
+
+// processEmphasis pairs delimiter runs above bottom (CommonMark's "process
+// emphasis" procedure) and styles the nodes between each pair.
+func (p *markdownInlineParser) processEmphasis(bottom int) {
+	for ci := bottom; ci < len(p.delims); {
+		closer := p.delims[ci]
+		if !closer.canClose || closer.count == 0 {
+			ci++
+			continue
+		}
+		oi := -1
+		for o := ci - 1; o >= bottom; o-- {
+			opener := p.delims[o]
+			if opener.char != closer.char || !opener.canOpen || opener.count == 0 {
+				continue
+			}
+			if closer.char == '~' {
+				if opener.count != closer.count {
+					continue
+				}
+			} else if (opener.canClose || closer.canOpen) && (opener.orig+closer.orig)%3 == 0 &&
+				(opener.orig%3 != 0 || closer.orig%3 != 0) {
+				continue
+			}
+			oi = o
+			break
+		}
+		if oi < 0 {
+			ci++
+			continue
+		}
+
+		opener := &p.delims[oi]
+		use := 1
+		switch {
+		case closer.char == '~':
+			use = closer.count
+		case opener.count >= 2 && closer.count >= 2:
+			use = 2
+		}
+		for n := opener.node + 1; n < closer.node; n++ {
+			st := &p.nodes[n].style
+			switch {
+			case closer.char == '~':
+				st.Strikethrough = true
+			case use == 2:
+				st.Bold = true
+			default:
+				st.Italic = true
+			}
+			p.nodes[n].plain = false
+		}
+		opener.count -= use
+		p.nodes[opener.node].text = p.nodes[opener.node].text[:opener.count]
+		closer.count -= use
+		p.nodes[closer.node].text = p.nodes[closer.node].text[:closer.count]
+
+		p.delims = append(p.delims[:oi+1], p.delims[ci:]...)
+		ci = oi + 1
+		p.delims[ci] = closer
+	}
+	p.delims = p.delims[:bottom]
+}
+
+func (p *markdownInlineParser) closeBracket() {
+	if len(p.brackets) == 0 {
+		p.text("]")
+		p.pos++
+		return
+	}
+	b := p.brackets[len(p.brackets)-1]
+	p.brackets = p.brackets[:len(p.brackets)-1]
+	if !b.active {
+		p.text("]")
+		p.pos++
+		return
+	}
+
+	url, end, ok := p.linkTarget(b)
+	if !ok {
+		p.text("]")
+		p.pos++
+		return
+	}
+
+	p.processEmphasis(b.bottom)
+	p.nodes[b.node].text = ""
+	p.nodes[b.node].plain = false
+	if b.image && b.node == len(p.nodes)-1 {
+		p.push(markdownInline{text: url})
+	}
+	for n := b.node + 1; n < len(p.nodes); n++ {
+		p.nodes[n].style.Link = url
+		p.nodes[n].plain = false
+	}
+	if !b.image {
+		// Links may not contain other links.
+		for i := range p.brackets {
+			if !p.brackets[i].image {
+				p.brackets[i].active = false
+			}
+		}
+	}
+	p.pos = end
+}
+
+// linkTarget parses what follows "]": an inline destination, a full or
+// collapsed reference, or a shortcut reference.
+func (p *markdownInlineParser) linkTarget(b markdownBracket) (string, int, bool) {
+	after := p.pos + 1
+	if after < len(p.src) && p.src[after] == '(' {
+		if url, end, ok := parseInlineDestination(p.src, after+1); ok {
+			return url, end, true
+		}
+	}
+	if p.refs == nil {
+		return "", 0, false
+	}
+	label := p.src[b.pos:p.pos]
+	end := after
+	if after < len(p.src) && p.src[after] == '[' {
+		if close := strings.IndexByte(p.src[after+1:], ']'); close >= 0 {
+			if ref := p.src[after+1 : after+1+close]; strings.TrimSpace(ref) != "" {
+				label = ref
+			}
+			end = after + close + 2
+		}
+	}
+	url, ok := p.refs.links[normalizeMarkdownLabel(label)]
+	return url, end, ok
+}
+
+// parseInlineDestination parses `dest "title")` starting after "(" and
+// returns the destination and the offset after ")".
+func parseInlineDestination(s string, i int) (string, int, bool) {
+	skip := func() {
+		for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n') {
+			i++
+		}
+	}
+	skip()
+	var dest string
+	if i < len(s) && s[i] == '<' {
+		end := strings.IndexAny(s[i+1:], ">\n")
+		if end < 0 || s[i+1+end] != '>' {
+			return "", 0, false
+		}
+		dest = s[i+1 : i+1+end]
+		i += end + 2
+	} else {
+		start, depth := i, 0
+		for i < len(s) {
+			c := s[i]
+			if c == '\\' && i+1 < len(s) {
+				i += 2
+				continue
+			}
+			if c == '(' {
+				depth++
+			} else if c == ')' {
+				if depth == 0 {
+					break
+				}
+				depth--
+			} else if c == ' ' || c == '\t' || c == '\n' || c < 0x20 {
+				break
+			}
+			i++
+		}
+		dest = s[start:i]
+	}
+	skip()
+	if i < len(s) && (s[i] == '"' || s[i] == '\'' || s[i] == '(') {
+		closing := s[i]
+		if closing == '(' {
+			closing = ')'
+		}
+		end := strings.IndexByte(s[i+1:], closing)
+		if end < 0 {
+			return "", 0, false
+		}
+		i += end + 2
+		skip()
+	}
+	if i >= len(s) || s[i] != ')' {
+		return "", 0, false
+	}
+	return html.UnescapeString(unescapeMarkdown(dest)), i + 1, true
+}
+
+func (p *markdownInlineParser) angle() {
+	rest := p.src[p.pos:]
+	if m := inlineAutolinkRe.FindStringSubmatch(rest); m != nil {
+		p.push(markdownInline{text: m[1], style: TextStyle{Link: m[1]}})
+		p.pos += len(m[0])
+		return
+	}
+	if m := inlineEmailRe.FindStringSubmatch(rest); m != nil {
+		p.push(markdownInline{text: m[1], style: TextStyle{Link: "mailto:" + m[1]}})
+		p.pos += len(m[0])
+		return
+	}
+	if m := inlineHTMLTagRe.FindStringSubmatch(rest); m != nil {
+		if strings.EqualFold(m[1], "br") {
+			p.push(markdownInline{text: "\v"})
+		}
+		p.pos += len(m[0])
+		return
+	}
+	p.text("<")
+	p.pos++
+}
+
+// lineBreak handles a newline: two trailing spaces make a hard break (a Docs
+// line break), anything else is a soft break rendered as a space.
+func (p *markdownInlineParser) lineBreak() {
+	hard := p.trimTrailingSpaces() >= 2
+	if hard {
+		p.push(markdownInline{text: "\v"})
+	} else {
+		p.text(" ")
+	}
+	p.pos++
+	p.skipLeadingSpaces()
+}
+
+func (p *markdownInlineParser) trimTrailingSpaces() int {
+	n := len(p.nodes)
+	if n == 0 || !p.nodes[n-1].plain {
+		return 0
+	}
+	text := p.nodes[n-1].text
+	trimmed := strings.TrimRight(text, " ")
+	p.nodes[n-1].text = trimmed
+	return len(text) - len(trimmed)
+}
+
+func (p *markdownInlineParser) skipLeadingSpaces() {
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *markdownInlineParser) footnote(label string) int {
+	if p.refs == nil {
+		return 0
+	}
+	return p.refs.footnotes[label]
+}
+
+// flatten concatenates the nodes and returns merged style spans.
+func (p *markdownInlineParser) flatten() ([]TextStyle, string) {
+	var sb strings.Builder
+	var styles []TextStyle
+	var offset int64
+	for _, n := range p.nodes {
+		if n.text == "" {
+			continue
+		}
+		length := utf16Len(n.text)
+		st := n.style
+		if st.Bold || st.Italic || st.Code || st.Strikethrough || st.Superscript || st.Link != "" {
+			st.Start, st.End = offset, offset+length
+			if last := len(styles) - 1; last >= 0 && styles[last].End == st.Start && sameTextStyle(styles[last], st) {
+				styles[last].End = st.End
+			} else {
+				styles = append(styles, st)
+			}
+		}
+		sb.WriteString(n.text)
+		offset += length
+	}
+	return styles, sb.String()
+}
+
+func sameTextStyle(a, b TextStyle) bool {
+	return a.Bold == b.Bold && a.Italic == b.Italic && a.Code == b.Code &&
+		a.Strikethrough == b.Strikethrough && a.Superscript == b.Superscript && a.Link == b.Link
+}
+
+func runLength(s string, i int, c byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == c {
+		n++
+	}
+	return n
+}
+
+func isMarkdownPunct(r rune) bool {
+	return unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
+
+func unescapeMarkdown(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && s[i+1] < utf8.RuneSelf && isMarkdownPunct(rune(s[i+1])) {
+			i++
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
@@ -181,3 +181,84 @@ func TestParseMarkdown_TableDoesNotSkipFollowingLine(t *testing.T) {
 		t.Fatalf("second element = %#v, want paragraph 'After table'", got[1])
 	}
 }
+
+func TestParseMarkdown_CommonMarkBlocks(t *testing.T) {
+	md := "Title\n=====\n\n" +
+		"- one\n  - nested **bold**\n    1. deep\n- [x] done\n\n" +
+		"> quoted\nlazy line\n\n" +
+		"```go\nfunc main() {}\n```\n\n" +
+		"See [docs][ref] and https://example.com/a_b. Note[^n]\n\n" +
+		"[ref]: https://example.com/docs \"Docs\"\n" +
+		"[^n]: A note.\n"
+	elements := ParseMarkdown(md)
+
+	var got []MarkdownElementType
+	for _, el := range elements {
+		got = append(got, el.Type)
+	}
+	want := []MarkdownElementType{MDHeading1, MDListItem, MDListItem, MDNumberedList, MDTaskList, MDBlockquote, MDCodeBlock, MDParagraph, MDFootnote}
+	if len(got) != len(want) {
+		t.Fatalf("types = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("types = %v, want %v", got, want)
+		}
+	}
+
+	if elements[2].Level != 1 || elements[3].Level != 2 || elements[1].ListID != elements[3].ListID {
+		t.Fatalf("unexpected list nesting: %+v", elements[1:5])
+	}
+	if !elements[4].Checked || elements[4].Content != "done" {
+		t.Fatalf("unexpected task item: %+v", elements[4])
+	}
+	if elements[5].Content != "quoted\nlazy line" || elements[5].Quote != 1 {
+		t.Fatalf("unexpected quote: %+v", elements[5])
+	}
+	if elements[6].Language != "go" || elements[6].Content != "func main() {}" {
+		t.Fatalf("unexpected code block: %+v", elements[6])
+	}
+
+	styles, text := elements[7].inline()
+	if text != "See docs and https://example.com/a_b. Note1" {
+		t.Fatalf("paragraph text = %q", text)
+	}
+	var links []string
+	var superscript bool
+	for _, s := range styles {
+		if s.Link != "" {
+			links = append(links, s.Link)
+		}
+		superscript = superscript || s.Superscript
+	}
+	if len(links) != 2 || links[0] != "https://example.com/docs" || links[1] != "https://example.com/a_b" || !superscript {
+		t.Fatalf("unexpected styles: %+v", styles)
+	}
+	if elements[8].Level != 1 || elements[8].Content != "A note." {
+		t.Fatalf("unexpected footnote: %+v", elements[8])
+	}
+}
+
+func TestParseInlineFormatting_CommonMark(t *testing.T) {
+	tests := []struct {
+		in   string
+		text string
+	}{
+		{"~~gone~~ and ***both***", "gone and both"},
+		{"snake_case_name stays", "snake_case_name stays"},
+		{"a \\*literal\\* &amp; `*code*`", "a *literal* & *code*"},
+		{"<https://example.com> line  \nbreak", "https://example.com line\vbreak"},
+		{"![alt](img.png) <span>x</span>", "alt x"},
+	}
+	for _, tt := range tests {
+		_, text := ParseInlineFormatting(tt.in)
+		if text != tt.text {
+			t.Errorf("ParseInlineFormatting(%q) = %q, want %q", tt.in, text, tt.text)
+		}
+	}
+
+	styles, _ := ParseInlineFormatting("~~gone~~ and ***both***")
+	if len(styles) != 2 || !styles[0].Strikethrough || !styles[1].Bold || !styles[1].Italic {
+		t.Fatalf("unexpected styles: %+v", styles)
+	}
+}
@@ -0,0 +1,369 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// DocsRangesCmd manages named ranges, which let scripts update a known region
+// of a document by name instead of by UTF-16 index.
+type DocsRangesCmd struct {
+	List           DocsRangesListCmd           `cmd:"" name:"list" aliases:"ls" help:"List named ranges"`
+	Create         DocsRangesCreateCmd         `cmd:"" name:"create" aliases:"add,new" help:"Name a range of text (by --text match or --start/--end)"`
+	ReplaceContent DocsRangesReplaceContentCmd `cmd:"" name:"replace-content" aliases:"replace,set" help:"Replace the text of a named range"`
+	Delete         DocsRangesDeleteCmd         `cmd:"" name:"delete" aliases:"rm,del,remove" help:"Delete a named range (the text stays)"`
+}
+
+type docsNamedRangeInfo struct {
+	Name   string        `json:"name"`
+	ID     string        `json:"namedRangeId"`
+	Ranges []*docs.Range `json:"ranges"`
+	Text   string        `json:"text"`
+}
+
+type DocsRangesListCmd struct {
+	DocID string `arg:"" name:"docId" help:"Doc ID"`
+}
+
+func (c *DocsRangesListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	docID := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	if docID == "" {
+		return usage("empty docId")
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newDocsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	doc, err := svc.Documents.Get(docID).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	ranges := docsNamedRanges(doc)
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"documentId": docID, "namedRanges": ranges})
+	}
+	if len(ranges) == 0 {
+		u.Err().Println("No named ranges")
+		return nil
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "NAME\tID\tRANGES\tTEXT")
+	for _, r := range ranges {
+		spans := make([]string, 0, len(r.Ranges))
+		for _, rg := range r.Ranges {
+			spans = append(spans, fmt.Sprintf("%d-%d", rg.StartIndex, rg.EndIndex))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Name, r.ID, strings.Join(spans, ","), truncateString(oneLineTSV(r.Text), 50))
+	}
+	return nil
+}
+
+type DocsRangesCreateCmd struct {
+	DocID      string `arg:"" name:"docId" help:"Doc ID"`
+	Name       string `arg:"" name:"name" help:"Range name (names need not be unique)"`
+	Text       string `name:"text" help:"Name this exact text (within one paragraph; see --occurrence)"`
+	Occurrence int    `name:"occurrence" help:"With --text: which occurrence to name (1-based)" default:"1"`
+	Start      int64  `name:"start" help:"Start index (>= 1), instead of --text"`
+	End        int64  `name:"end" help:"End index (> start), instead of --text"`
+}
+
+func (c *DocsRangesCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	docID := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	if docID == "" {
+		return usage("empty docId")
+	}
+	name := strings.TrimSpace(c.Name)
+	if name == "" {
+		return usage("empty name")
+	}
+	byIndex := c.Start != 0 || c.End != 0
+	if (c.Text == "") == !byIndex {
+		return usage("use either --text or --start/--end")
+	}
+	if byIndex && (c.Start < 1 || c.End <= c.Start) {
+		return usage("--start must be >= 1 and --end > --start")
+	}
+	if c.Occurrence < 1 {
+		return usage("--occurrence must be >= 1")
+	}
+
+	if err := dryRunExit(ctx, flags, "docs.ranges.create", map[string]any{
+		"documentId": docID,
+		"name":       name,
+		"text":       c.Text,
+		"occurrence": c.Occurrence,
+		"startIndex": c.Start,
+		"endIndex":   c.End,
+	}); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newDocsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	start, end := c.Start, c.End
+	if !byIndex {
+		doc, err := svc.Documents.Get(docID).Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+		var ok bool
+		if start, end, ok = docsFindText(doc, c.Text, c.Occurrence); !ok {
+			return usagef("text %q (occurrence %d) not found", c.Text, c.Occurrence)
+		}
+	}
+
+	resp, err := svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{Requests: []*docs.Request{{
+		CreateNamedRange: &docs.CreateNamedRangeRequest{
+			Name:  name,
+			Range: &docs.Range{StartIndex: start, EndIndex: end},
+		},
+	}}}).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("creating named range: %w", err)
+	}
+	id := ""
+	if len(resp.Replies) > 0 && resp.Replies[0].CreateNamedRange != nil {
+		id = resp.Replies[0].CreateNamedRange.NamedRangeId
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"documentId":   docID,
+			"name":         name,
+			"namedRangeId": id,
+			"startIndex":   start,
+			"endIndex":     end,
+		})
+	}
+	u.Out().Printf("documentId\t%s", docID)
+	u.Out().Printf("name\t%s", name)
+	u.Out().Printf("namedRangeId\t%s", id)
+	u.Out().Printf("range\t%d-%d", start, end)
+	return nil
+}
+
+type DocsRangesReplaceContentCmd struct {
+	DocID   string `arg:"" name:"docId" help:"Doc ID"`
+	Name    string `arg:"" name:"name" help:"Range name (every range with this name is replaced)"`
+	Content string `arg:"" optional:"" name:"content" help:"Replacement text (or use --file / stdin)"`
+	File    string `name:"file" short:"f" help:"Read content from file (use - for stdin)"`
+}
+
+func (c *DocsRangesReplaceContentCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	docID := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	if docID == "" {
+		return usage("empty docId")
+	}
+	name := strings.TrimSpace(c.Name)
+	if name == "" {
+		return usage("empty name")
+	}
+	content, err := resolveContentInput(c.Content, c.File)
+	if err != nil {
+		return err
+	}
+
+	if err = dryRunExit(ctx, flags, "docs.ranges.replace_content", map[string]any{
+		"documentId": docID,
+		"name":       name,
+		"content":    content,
+	}); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newDocsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	doc, err := svc.Documents.Get(docID).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	if _, ok := doc.NamedRanges[name]; !ok {
+		return usagef("named range %q not found (see: gog docs ranges list %s)", name, docID)
+	}
+
+	if _, err = svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{Requests: []*docs.Request{{
+		ReplaceNamedRangeContent: &docs.ReplaceNamedRangeContentRequest{NamedRangeName: name, Text: content},
+	}}}).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("replacing named range content: %w", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"documentId": docID,
+			"name":       name,
+			"ranges":     len(doc.NamedRanges[name].NamedRanges),
+			"length":     utf16Len(content),
+		})
+	}
+	u.Out().Printf("documentId\t%s", docID)
+	u.Out().Printf("name\t%s", name)
+	u.Out().Printf("ranges\t%d", len(doc.NamedRanges[name].NamedRanges))
+	return nil
+}
+
+type DocsRangesDeleteCmd struct {
+	DocID string `arg:"" name:"docId" help:"Doc ID"`
+	Name  string `arg:"" name:"name" help:"Range name or named range ID"`
+}
+
+func (c *DocsRangesDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	docID := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	if docID == "" {
+		return usage("empty docId")
+	}
+	name := strings.TrimSpace(c.Name)
+	if name == "" {
+		return usage("empty name")
+	}
+
+	if err := dryRunExit(ctx, flags, "docs.ranges.delete", map[string]any{
+		"documentId": docID,
+		"name":       name,
+	}); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newDocsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	doc, err := svc.Documents.Get(docID).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	req := &docs.DeleteNamedRangeRequest{Name: name}
+	if _, ok := doc.NamedRanges[name]; !ok {
+		found := false
+		for _, r := range docsNamedRanges(doc) {
+			if r.ID == name {
+				req = &docs.DeleteNamedRangeRequest{NamedRangeId: name}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return usagef("named range %q not found (see: gog docs ranges list %s)", name, docID)
+		}
+	}
+
+	if _, err = svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{
+		Requests: []*docs.Request{{DeleteNamedRange: req}},
+	}).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("deleting named range: %w", err)
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"documentId": docID, "deleted": name})
+	}
+	u.Out().Printf("documentId\t%s", docID)
+	u.Out().Printf("deleted\t%s", name)
+	return nil
+}
+
+// docsNamedRanges flattens doc.NamedRanges, sorted by position.
+func docsNamedRanges(doc *docs.Document) []docsNamedRangeInfo {
+	var out []docsNamedRangeInfo
+	for _, name := range sortedKeys(doc.NamedRanges) {
+		for _, nr := range doc.NamedRanges[name].NamedRanges {
+			if nr == nil {
+				continue
+			}
+			info := docsNamedRangeInfo{Name: nr.Name, ID: nr.NamedRangeId, Ranges: nr.Ranges}
+			var parts []string
+			for _, rg := range nr.Ranges {
+				if rg != nil && rg.SegmentId == "" {
+					parts = append(parts, docsTextBetween(doc, rg.StartIndex, rg.EndIndex))
+				}
+			}
+			info.Text = strings.Join(parts, "")
+			out = append(out, info)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return docsNamedRangeStart(out[i]) < docsNamedRangeStart(out[j])
+	})
+	return out
+}
+
+func docsNamedRangeStart(r docsNamedRangeInfo) int64 {
+	if len(r.Ranges) == 0 || r.Ranges[0] == nil {
+		return 0
+	}
+	return r.Ranges[0].StartIndex
+}
+
+// docsTextBetween returns the body text in [start, end).
+func docsTextBetween(doc *docs.Document, start, end int64) string {
+	var sb strings.Builder
+	docsEachParagraph(doc, false, func(p docsTextParagraph) {
+		if p.end <= start || p.start >= end {
+			return
+		}
+		units := utf16.Encode([]rune(p.text))
+		from, to := max(start-p.start, 0), min(end-p.start, int64(len(units)))
+		if from < to {
+			sb.WriteString(string(utf16.Decode(units[from:to])))
+		}
+	})
+	return sb.String()
+}
+
+// docsFindText locates the nth occurrence (1-based) of text inside a single
+// body paragraph.
+func docsFindText(doc *docs.Document, text string, nth int) (int64, int64, bool) {
+	var start, end int64
+	seen := 0
+	docsEachParagraph(doc, false, func(p docsTextParagraph) {
+		if seen >= nth {
+			return
+		}
+		for from := 0; ; {
+			i := strings.Index(p.text[from:], text)
+			if i < 0 {
+				return
+			}
+			seen++
+			if seen == nth {
+				start = p.offset(from + i)
+				end = start + utf16Len(text)
+				return
+			}
+			from += i + len(text)
+		}
+	})
+	return start, end, seen >= nth
+}
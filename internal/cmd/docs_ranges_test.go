@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+)
+
+func TestDocsRanges_CreateListReplace(t *testing.T) {
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	doc := syncTestDoc(
+		syncTestPara{style: "HEADING_1", text: "Status"},                            // 1-8
+		syncTestPara{style: "NORMAL_TEXT", text: "Build is green, deploy is green"}, // 8-40
+	)
+	doc.NamedRanges = map[string]docs.NamedRanges{
+		"status": {Name: "status", NamedRanges: []*docs.NamedRange{{
+			Name: "status", NamedRangeId: "kix.nr1", Ranges: []*docs.Range{{StartIndex: 17, EndIndex: 22}},
+		}}},
+	}
+	var got []*docs.Request
+	docSvc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/documents/"):
+			_ = json.NewEncoder(w).Encode(doc)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":batchUpdate"):
+			var req docs.BatchUpdateDocumentRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode batchUpdate: %v", err)
+			}
+			got = req.Requests
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"documentId": "doc1",
+				"replies":    []any{map[string]any{"createNamedRange": map[string]any{"namedRangeId": "kix.nr2"}}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docSvc, nil }
+	flags := &RootFlags{Account: "a@b.com"}
+
+	if err := runKong(t, &DocsRangesCmd{}, []string{"create", "doc1", "deploy", "--text", "green", "--occurrence", "2"}, newDocsCmdContext(t), flags); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if cr := got[0].CreateNamedRange; cr == nil || cr.Name != "deploy" || cr.Range.StartIndex != 34 || cr.Range.EndIndex != 39 {
+		t.Fatalf("unexpected create request: %#v", got[0])
+	}
+
+	out := captureStdout(t, func() {
+		ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+		if err := runKong(t, &DocsRangesCmd{}, []string{"list", "doc1"}, ctx, flags); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	})
+	var listed struct {
+		NamedRanges []docsNamedRangeInfo `json:"namedRanges"`
+	}
+	if err := json.Unmarshal([]byte(out), &listed); err != nil {
+		t.Fatalf("decode list: %v\n%s", err, out)
+	}
+	if len(listed.NamedRanges) != 1 || listed.NamedRanges[0].Text != "green" || listed.NamedRanges[0].ID != "kix.nr1" {
+		t.Fatalf("unexpected list: %+v", listed.NamedRanges)
+	}
+
+	if err := runKong(t, &DocsRangesCmd{}, []string{"replace-content", "doc1", "status", "red"}, newDocsCmdContext(t), flags); err != nil {
+		t.Fatalf("replace-content: %v", err)
+	}
+	if rep := got[0].ReplaceNamedRangeContent; rep == nil || rep.NamedRangeName != "status" || rep.Text != "red" {
+		t.Fatalf("unexpected replace request: %#v", got[0])
+	}
+
+	err := runKong(t, &DocsRangesCmd{}, []string{"replace-content", "doc1", "missing", "x"}, newDocsCmdContext(t), flags)
+	if err == nil || ExitCode(err) != 2 {
+		t.Fatalf("expected usage error for unknown range, got %v", err)
+	}
+}
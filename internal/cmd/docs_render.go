@@ -0,0 +1,790 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/config"
+)
+
+// DocsRenderCmd copies a template Doc once per data record and fills its
+// placeholders:
+//
+//	{{key}}, {{client.name}}       text (nested JSON keys use dots)
+//	{{image:logo}}                 inline image from a URL or local file
+//	{{#if key}} ... {{/if}}        paragraphs kept only when key is truthy
+//	{{#unless key}} ... {{/unless}} paragraphs kept only when key is falsy
+//	{{items.name}} in a table row  row repeated once per element of items
+type DocsRenderCmd struct {
+	TemplateID string `arg:"" name:"templateDocId" help:"Template Doc ID"`
+	Data       string `name:"data" required:"" help:"Values: JSON object, JSON array of objects, or CSV with a header row (one doc per record; - for stdin)"`
+	OutFolder  string `name:"out-folder" help:"Destination folder ID or path (default: next to the template)"`
+	Name       string `name:"name" help:"Name for each doc, may use {{placeholders}} (default: template title, numbered for several records)"`
+	PDF        bool   `name:"pdf" help:"Also export each generated doc as PDF"`
+	PDFDir     string `name:"pdf-dir" help:"Directory for --pdf files (default: Drive downloads dir)"`
+	Strict     bool   `name:"strict" help:"Fail before copying when a placeholder has no value"`
+}
+
+var (
+	docsRenderPlaceholderRe = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+	docsRenderOpenRe        = regexp.MustCompile(`^\{\{\s*#(if|unless)\s+([^{}]+?)\s*\}\}$`)
+	docsRenderCloseRe       = regexp.MustCompile(`^\{\{\s*/(if|unless)\s*\}\}$`)
+)
+
+const docsRenderImagePrefix = "image:"
+
+type docsRenderRecord map[string]any
+
+func (c *DocsRenderCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return runTemplateRender(ctx, flags, templateRenderOptions{
+		Op:          "docs.render",
+		TemplateID:  c.TemplateID,
+		TemplateArg: "templateDocId",
+		Data:        c.Data,
+		OutFolder:   c.OutFolder,
+		Name:        c.Name,
+		PDF:         c.PDF,
+		PDFDir:      c.PDFDir,
+		Strict:      c.Strict,
+		Export:      exportViaDriveOptions{ExpectedMime: driveMimeGoogleDoc, KindLabel: "Google Doc"},
+		ResultsKey:  "documents",
+	}, c.openTemplate)
+}
+
+func (c *DocsRenderCmd) openTemplate(ctx context.Context, account string, driveSvc *drive.Service, templateID, dataDir string) (*templateRender, error) {
+	docsSvc, err := newDocsService(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	template, err := docsSvc.Documents.Get(templateID).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return &templateRender{
+		title: template.Title,
+		keys:  docsRenderPlaceholders(template),
+		render: func(ctx context.Context, rec docsRenderRecord, name, parent string) (*drive.File, error) {
+			return copyTemplateAndFill(ctx, driveSvc, copyViaDriveOptions{
+				ExpectedMime: driveMimeGoogleDoc,
+				KindLabel:    "Google Doc",
+			}, templateID, name, parent, func(id string) error {
+				return renderDocsTemplate(ctx, docsSvc, driveSvc, id, rec, dataDir)
+			})
+		},
+	}, nil
+}
+
+// loadDocsRenderData reads records from a JSON object, a JSON array of
+// objects, or a CSV file with a header row.
+func loadDocsRenderData(path string) ([]docsRenderRecord, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, usage("empty --data")
+	}
+	content, err := resolveContentInput("", path)
+	if err != nil {
+		return nil, err
+	}
+	trimmed := strings.TrimSpace(content)
+	if trimmed == "" {
+		return nil, usagef("--data %s is empty", path)
+	}
+
+	var records []docsRenderRecord
+	if strings.EqualFold(filepath.Ext(path), ".csv") || (trimmed[0] != '{' && trimmed[0] != '[') {
+		records, err = parseDocsRenderCSV(content)
+	} else {
+		records, err = parseDocsRenderJSON(trimmed)
+	}
+	if err != nil {
+		return nil, usagef("--data %s: %v", path, err)
+	}
+	if len(records) == 0 {
+		return nil, usagef("--data %s has no records", path)
+	}
+	return records, nil
+}
+
+func parseDocsRenderJSON(content string) ([]docsRenderRecord, error) {
+	dec := json.NewDecoder(strings.NewReader(content))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	switch t := v.(type) {
+	case map[string]any:
+		return []docsRenderRecord{t}, nil
+	case []any:
+		records := make([]docsRenderRecord, 0, len(t))
+		for i, item := range t {
+			m, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("element %d is not an object", i+1)
+			}
+			records = append(records, m)
+		}
+		return records, nil
+	default:
+		return nil, errors.New("expected an object or an array of objects")
+	}
+}
+
+func parseDocsRenderCSV(content string) ([]docsRenderRecord, error) {
+	r := csv.NewReader(strings.NewReader(content))
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))
+	}
+	var records []docsRenderRecord
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(row) == 1 && strings.TrimSpace(row[0]) == "" {
+			continue
+		}
+		rec := docsRenderRecord{}
+		for i, key := range header {
+			if key == "" {
+				continue
+			}
+			if i < len(row) {
+				rec[key] = row[i]
+			} else {
+				rec[key] = ""
+			}
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// lookup resolves key as a literal key first (CSV headers may contain dots),
+// then as a dotted path into nested objects.
+func (r docsRenderRecord) lookup(key string) (any, bool) {
+	if v, ok := r[key]; ok {
+		return v, true
+	}
+	var cur any = map[string]any(r)
+	for _, part := range strings.Split(key, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// list returns the list and field for a key like "items.name" when a prefix
+// of the key is an array.
+func (r docsRenderRecord) list(key string) (string, []any, string, bool) {
+	parts := strings.Split(key, ".")
+	for i := len(parts) - 1; i > 0; i-- {
+		prefix := strings.Join(parts[:i], ".")
+		if v, ok := r.lookup(prefix); ok {
+			if items, ok := v.([]any); ok {
+				return prefix, items, strings.Join(parts[i:], "."), true
+			}
+		}
+	}
+	return "", nil, "", false
+}
+
+func (r docsRenderRecord) truthy(key string) bool {
+	v, ok := r.lookup(key)
+	if !ok || v == nil {
+		return false
+	}
+	switch t := v.(type) {
+	case bool:
+		return t
+	case []any:
+		return len(t) > 0
+	case map[string]any:
+		return len(t) > 0
+	}
+	switch strings.ToLower(strings.TrimSpace(docsRenderText(v))) {
+	case "", "0", "false", "no":
+		return false
+	}
+	return true
+}
+
+// render substitutes the placeholders in s; unknown keys become empty.
+func (r docsRenderRecord) render(s string) string {
+	return docsRenderPlaceholderRe.ReplaceAllStringFunc(s, func(m string) string {
+		key := docsRenderPlaceholderRe.FindStringSubmatch(m)[1]
+		v, _ := r.lookup(key)
+		return docsRenderText(v)
+	})
+}
+
+// missing lists the placeholder keys that have no value in r.
+func (r docsRenderRecord) missing(keys []string) []string {
+	var out []string
+	for _, key := range keys {
+		name := strings.TrimPrefix(key, docsRenderImagePrefix)
+		if _, ok := r.lookup(name); ok {
+			continue
+		}
+		if _, _, _, ok := r.list(name); ok {
+			continue
+		}
+		out = append(out, name)
+	}
+	return out
+}
+
+func docsRenderText(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case json.Number:
+		return t.String()
+	case bool:
+		return strconv.FormatBool(t)
+	case []any:
+		parts := make([]string, 0, len(t))
+		for _, item := range t {
+			parts = append(parts, docsRenderText(item))
+		}
+		return strings.Join(parts, ", ")
+	default:
+		b, _ := json.Marshal(t)
+		return string(b)
+	}
+}
+
+// docsRenderPlaceholders returns the distinct value keys used in doc (control
+// markers excluded), in order of first appearance.
+func docsRenderPlaceholders(doc *docs.Document) []string {
+	seen := map[string]bool{}
+	var keys []string
+	docsEachParagraph(doc, true, func(p docsTextParagraph) {
+		for _, m := range docsRenderPlaceholderRe.FindAllStringSubmatch(p.text, -1) {
+			key := m[1]
+			if key == "" || strings.HasPrefix(key, "#") || strings.HasPrefix(key, "/") || seen[key] {
+				continue
+			}
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	})
+	return keys
+}
+
+// docsRenderEdit is an index-based request; edits run from the end of the
+// document backwards so earlier indices stay valid.
+type docsRenderEdit struct {
+	index    int64
+	requests []*docs.Request
+}
+
+func docsRenderApply(ctx context.Context, svc *docs.Service, docID string, edits []docsRenderEdit, tail []*docs.Request) error {
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].index > edits[j].index })
+	var reqs []*docs.Request
+	for _, e := range edits {
+		reqs = append(reqs, e.requests...)
+	}
+	reqs = append(reqs, tail...)
+	if len(reqs) == 0 {
+		return nil
+	}
+	_, err := svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{Requests: reqs}).Context(ctx).Do()
+	return err
+}
+
+// renderDocsTemplate fills the copied doc in two batches: first conditional
+// sections and repeated table rows change the structure, then cell text,
+// images, and placeholder values are written into the re-read document.
+func renderDocsTemplate(ctx context.Context, docsSvc *docs.Service, driveSvc *drive.Service, docID string, rec docsRenderRecord, dataDir string) error {
+	doc, err := docsSvc.Documents.Get(docID).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	edits, err := docsRenderSections(doc, rec)
+	if err != nil {
+		return err
+	}
+	edits = append(edits, docsRenderRowInserts(doc, rec)...)
+	if len(edits) > 0 {
+		if err = docsRenderApply(ctx, docsSvc, docID, edits, nil); err != nil {
+			return err
+		}
+		if doc, err = docsSvc.Documents.Get(docID).Context(ctx).Do(); err != nil {
+			return err
+		}
+	}
+
+	images := &docsRenderImageSource{driveSvc: driveSvc, dataDir: dataDir, urls: map[string]string{}}
+	defer func() { cleanupDriveFileIDsBestEffort(ctx, driveSvc, images.uploaded) }()
+	edits, rows, err := docsRenderRowCells(ctx, doc, rec, images)
+	if err != nil {
+		return err
+	}
+	imageEdits, err := docsRenderImages(ctx, doc, rec, images, rows)
+	if err != nil {
+		return err
+	}
+	edits = append(edits, imageEdits...)
+	return docsRenderApply(ctx, docsSvc, docID, edits, docsRenderReplacements(doc, rec))
+}
+
+// docsRenderSections removes the marker paragraphs of {{#if}}/{{#unless}}
+// sections, and the whole section when its condition does not hold.
+func docsRenderSections(doc *docs.Document, rec docsRenderRecord) ([]docsRenderEdit, error) {
+	if doc.Body == nil {
+		return nil, nil
+	}
+	type marker struct {
+		kind string
+		key  string
+		p    docsTextParagraph
+	}
+	var (
+		stack  []marker
+		ranges [][2]int64
+	)
+	for _, el := range doc.Body.Content {
+		if el == nil || el.Paragraph == nil {
+			continue
+		}
+		p := docsTextParagraphFrom(el)
+		text := strings.TrimSpace(p.text)
+		if m := docsRenderOpenRe.FindStringSubmatch(text); m != nil {
+			stack = append(stack, marker{kind: m[1], key: m[2], p: p})
+			continue
+		}
+		m := docsRenderCloseRe.FindStringSubmatch(text)
+		if m == nil {
+			continue
+		}
+		if len(stack) == 0 {
+			return nil, usagef("template: {{/%s}} without a matching {{#%s}}", m[1], m[1])
+		}
+		open := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if open.kind != m[1] {
+			return nil, usagef("template: {{#%s %s}} closed by {{/%s}}", open.kind, open.key, m[1])
+		}
+		keep := rec.truthy(open.key) == (open.kind == "if")
+		if keep {
+			ranges = append(ranges, [2]int64{open.p.start, open.p.end}, [2]int64{p.start, p.end})
+			continue
+		}
+		// Drop ranges of nested sections; the whole section goes.
+		for len(ranges) > 0 && ranges[len(ranges)-1][0] >= open.p.start {
+			ranges = ranges[:len(ranges)-1]
+		}
+		ranges = append(ranges, [2]int64{open.p.start, p.end})
+	}
+	if len(stack) > 0 {
+		open := stack[len(stack)-1]
+		return nil, usagef("template: {{#%s %s}} is never closed", open.kind, open.key)
+	}
+
+	bodyEnd := docsBodyEndIndex(doc)
+	paragraphEnds := map[int64]bool{}
+	for _, el := range doc.Body.Content {
+		if el != nil && el.Paragraph != nil {
+			paragraphEnds[el.EndIndex] = true
+		}
+	}
+	for i, r := range ranges {
+		// The final newline of the body cannot be deleted: take the one
+		// before the range when a paragraph precedes it, otherwise keep the
+		// last newline as an empty paragraph.
+		if r[1] >= bodyEnd {
+			if r[0] > 1 && paragraphEnds[r[0]] {
+				r[0], r[1] = r[0]-1, r[1]-1
+			} else {
+				r[1] = bodyEnd - 1
+			}
+		}
+		ranges[i] = r
+	}
+	// A shifted range may overlap the one before it; merge them so no
+	// deletion runs over text another one already removed.
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+	var merged [][2]int64
+	for _, r := range ranges {
+		if r[1] <= r[0] {
+			continue
+		}
+		if n := len(merged); n > 0 && r[0] < merged[n-1][1] {
+			merged[n-1][1] = max(merged[n-1][1], r[1])
+			continue
+		}
+		merged = append(merged, r)
+	}
+	edits := make([]docsRenderEdit, 0, len(merged))
+	for _, r := range merged {
+		edits = append(edits, docsRenderEdit{index: r[0], requests: []*docs.Request{{
+			DeleteContentRange: &docs.DeleteContentRangeRequest{Range: &docs.Range{StartIndex: r[0], EndIndex: r[1]}},
+		}}})
+	}
+	return edits, nil
+}
+
+func docsBodyEndIndex(doc *docs.Document) int64 {
+	if doc.Body == nil || len(doc.Body.Content) == 0 {
+		return 1
+	}
+	return doc.Body.Content[len(doc.Body.Content)-1].EndIndex
+}
+
+// docsRenderRow is a template table row whose placeholders refer to a list.
+type docsRenderRow struct {
+	tableStart int64
+	rowStart   int64
+	row        int
+	list       string
+	items      []any
+	cells      []string
+}
+
+func docsRenderRows(doc *docs.Document, rec docsRenderRecord) []docsRenderRow {
+	if doc.Body == nil {
+		return nil
+	}
+	var rows []docsRenderRow
+	for _, el := range doc.Body.Content {
+		if el == nil || el.Table == nil {
+			continue
+		}
+		for r, row := range el.Table.TableRows {
+			if row == nil {
+				continue
+			}
+			cells := make([]string, len(row.TableCells))
+			for c, cell := range row.TableCells {
+				var parts []string
+				if cell != nil {
+					for _, ce := range cell.Content {
+						if ce != nil && ce.Paragraph != nil {
+							parts = append(parts, strings.TrimSuffix(docsTextParagraphFrom(ce).text, "\n"))
+						}
+					}
+				}
+				cells[c] = strings.Join(parts, "\n")
+			}
+			for _, m := range docsRenderPlaceholderRe.FindAllStringSubmatch(strings.Join(cells, "\n"), -1) {
+				if list, items, _, ok := rec.list(m[1]); ok {
+					rows = append(rows, docsRenderRow{
+						tableStart: el.StartIndex,
+						rowStart:   row.StartIndex,
+						row:        r,
+						list:       list,
+						items:      items,
+						cells:      cells,
+					})
+					break
+				}
+			}
+		}
+	}
+	return rows
+}
+
+// docsRenderRowInserts adds an empty row below each template row for every
+// list element after the first, or removes the row for an empty list.
+func docsRenderRowInserts(doc *docs.Document, rec docsRenderRecord) []docsRenderEdit {
+	var edits []docsRenderEdit
+	for _, row := range docsRenderRows(doc, rec) {
+		loc := &docs.TableCellLocation{
+			TableStartLocation: &docs.Location{Index: row.tableStart},
+			RowIndex:           int64(row.row),
+		}
+		var reqs []*docs.Request
+		if len(row.items) == 0 {
+			reqs = append(reqs, &docs.Request{DeleteTableRow: &docs.DeleteTableRowRequest{TableCellLocation: loc}})
+		}
+		for i := 1; i < len(row.items); i++ {
+			reqs = append(reqs, &docs.Request{InsertTableRow: &docs.InsertTableRowRequest{TableCellLocation: loc, InsertBelow: true}})
+		}
+		if len(reqs) > 0 {
+			edits = append(edits, docsRenderEdit{index: row.rowStart, requests: reqs})
+		}
+	}
+	return edits
+}
+
+// docsRenderRowCells fills the repeated table rows: the template row's
+// placeholders are replaced in place with the first element, and the rendered
+// template cells are written into the rows added by docsRenderRowInserts.
+// Values are written by index so they stay within the rows; the returned
+// ranges cover each template row and its copies.
+func docsRenderRowCells(ctx context.Context, doc *docs.Document, rec docsRenderRecord, images *docsRenderImageSource) ([]docsRenderEdit, [][2]int64, error) {
+	if doc.Body == nil {
+		return nil, nil, nil
+	}
+	var (
+		edits []docsRenderEdit
+		spans [][2]int64
+	)
+	tables := map[int64]*docs.Table{}
+	for _, el := range doc.Body.Content {
+		if el != nil && el.Table != nil {
+			tables[el.StartIndex] = el.Table
+		}
+	}
+	for _, row := range docsRenderRows(doc, rec) {
+		table := tables[row.tableStart]
+		last := min(row.row+len(row.items), len(table.TableRows)) - 1
+		spans = append(spans, [2]int64{table.TableRows[row.row].StartIndex, table.TableRows[last].EndIndex})
+		for i, item := range row.items {
+			r := row.row + i
+			if r >= len(table.TableRows) {
+				break
+			}
+			itemRec := docsRenderItem(rec, row.list, item)
+			for c, cell := range table.TableRows[r].TableCells {
+				if c >= len(row.cells) || cell == nil || len(cell.Content) == 0 {
+					continue
+				}
+				var (
+					cellEdits []docsRenderEdit
+					err       error
+				)
+				if i == 0 {
+					cellEdits, err = docsRenderCellInPlace(ctx, cell, itemRec, images)
+				} else {
+					cellEdits, err = docsRenderCellCopy(ctx, cell.Content[0].StartIndex, row.cells[c], itemRec, images)
+				}
+				if err != nil {
+					return nil, nil, err
+				}
+				edits = append(edits, cellEdits...)
+			}
+		}
+	}
+	return edits, spans, nil
+}
+
+// docsRenderCellInPlace replaces each placeholder in a template cell with its
+// value.
+func docsRenderCellInPlace(ctx context.Context, cell *docs.TableCell, rec docsRenderRecord, images *docsRenderImageSource) ([]docsRenderEdit, error) {
+	var edits []docsRenderEdit
+	for _, ce := range cell.Content {
+		if ce == nil || ce.Paragraph == nil {
+			continue
+		}
+		p := docsTextParagraphFrom(ce)
+		for _, loc := range docsRenderPlaceholderRe.FindAllStringSubmatchIndex(p.text, -1) {
+			start, end := p.offset(loc[0]), p.offset(loc[1])
+			reqs := []*docs.Request{{
+				DeleteContentRange: &docs.DeleteContentRangeRequest{Range: &docs.Range{StartIndex: start, EndIndex: end}},
+			}}
+			req, err := docsRenderValueRequest(ctx, images, rec, p.text[loc[2]:loc[3]], start)
+			if err != nil {
+				return nil, err
+			}
+			if req != nil {
+				reqs = append(reqs, req)
+			}
+			edits = append(edits, docsRenderEdit{index: start, requests: reqs})
+		}
+	}
+	return edits, nil
+}
+
+// docsRenderCellCopy writes the template cell text, rendered against rec,
+// into an empty cell starting at index.
+func docsRenderCellCopy(ctx context.Context, index int64, template string, rec docsRenderRecord, images *docsRenderImageSource) ([]docsRenderEdit, error) {
+	var reqs []*docs.Request
+	literal := func(text string) {
+		if text != "" {
+			reqs = append(reqs, &docs.Request{InsertText: &docs.InsertTextRequest{Location: &docs.Location{Index: index}, Text: text}})
+		}
+	}
+	pos := 0
+	for _, loc := range docsRenderPlaceholderRe.FindAllStringSubmatchIndex(template, -1) {
+		literal(template[pos:loc[0]])
+		pos = loc[1]
+		req, err := docsRenderValueRequest(ctx, images, rec, template[loc[2]:loc[3]], index)
+		if err != nil {
+			return nil, err
+		}
+		if req != nil {
+			reqs = append(reqs, req)
+		}
+	}
+	literal(template[pos:])
+	if len(reqs) == 0 {
+		return nil, nil
+	}
+	// Every piece goes in at the cell start, so insert the last one first.
+	slices.Reverse(reqs)
+	return []docsRenderEdit{{index: index, requests: reqs}}, nil
+}
+
+// docsRenderItem returns rec with list replaced by one of its elements, so
+// {{items.name}} resolves against that element.
+func docsRenderItem(rec docsRenderRecord, list string, item any) docsRenderRecord {
+	out := make(docsRenderRecord, len(rec)+1)
+	for k, v := range rec {
+		out[k] = v
+	}
+	out[list] = item
+	if m, ok := item.(map[string]any); ok {
+		for k, v := range m {
+			out[list+"."+k] = v
+		}
+	}
+	return out
+}
+
+// docsRenderImageSource resolves {{image:key}} values to URLs the Docs API
+// can fetch. Local files are uploaded to Drive once and their IDs kept for
+// cleanup.
+type docsRenderImageSource struct {
+	driveSvc *drive.Service
+	dataDir  string
+	urls     map[string]string
+	uploaded []string
+}
+
+func (s *docsRenderImageSource) url(ctx context.Context, src string) (string, error) {
+	if url, ok := s.urls[src]; ok {
+		return url, nil
+	}
+	url, err := docsRenderImageURL(ctx, s.driveSvc, src, s.dataDir, &s.uploaded)
+	if err != nil {
+		return "", err
+	}
+	s.urls[src] = url
+	return url, nil
+}
+
+// docsRenderValueRequest inserts the value of placeholder key at index: an
+// inline image for image: keys, text otherwise. Empty values insert nothing.
+func docsRenderValueRequest(ctx context.Context, images *docsRenderImageSource, rec docsRenderRecord, key string, index int64) (*docs.Request, error) {
+	if name, ok := strings.CutPrefix(key, docsRenderImagePrefix); ok {
+		v, _ := rec.lookup(name)
+		src := strings.TrimSpace(docsRenderText(v))
+		if src == "" {
+			return nil, nil
+		}
+		url, err := images.url(ctx, src)
+		if err != nil {
+			return nil, err
+		}
+		return &docs.Request{InsertInlineImage: &docs.InsertInlineImageRequest{
+			Uri:      url,
+			Location: &docs.Location{Index: index},
+		}}, nil
+	}
+	v, _ := rec.lookup(key)
+	text := docsRenderText(v)
+	if text == "" {
+		return nil, nil
+	}
+	return &docs.Request{InsertText: &docs.InsertTextRequest{Location: &docs.Location{Index: index}, Text: text}}, nil
+}
+
+// docsRenderImages replaces {{image:key}} placeholders outside the repeated
+// rows in skip with inline images.
+func docsRenderImages(ctx context.Context, doc *docs.Document, rec docsRenderRecord, images *docsRenderImageSource, skip [][2]int64) ([]docsRenderEdit, error) {
+	var (
+		edits []docsRenderEdit
+		err   error
+	)
+	docsEachParagraph(doc, false, func(p docsTextParagraph) {
+		if err != nil {
+			return
+		}
+		for _, r := range skip {
+			if p.start >= r[0] && p.start < r[1] {
+				return
+			}
+		}
+		for _, loc := range docsRenderPlaceholderRe.FindAllStringSubmatchIndex(p.text, -1) {
+			key := p.text[loc[2]:loc[3]]
+			if !strings.HasPrefix(key, docsRenderImagePrefix) {
+				continue
+			}
+			start, end := p.offset(loc[0]), p.offset(loc[1])
+			reqs := []*docs.Request{{
+				DeleteContentRange: &docs.DeleteContentRangeRequest{Range: &docs.Range{StartIndex: start, EndIndex: end}},
+			}}
+			var req *docs.Request
+			if req, err = docsRenderValueRequest(ctx, images, rec, key, start); err != nil {
+				return
+			}
+			if req != nil {
+				reqs = append(reqs, req)
+			}
+			edits = append(edits, docsRenderEdit{index: start, requests: reqs})
+		}
+	})
+	return edits, err
+}
+
+func docsRenderImageURL(ctx context.Context, driveSvc *drive.Service, src, dataDir string, uploaded *[]string) (string, error) {
+	lower := strings.ToLower(src)
+	if strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "http://") {
+		return src, nil
+	}
+	path, err := config.ExpandPath(src)
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dataDir, path)
+	}
+	url, fileID, err := uploadLocalImage(ctx, driveSvc, path)
+	if err != nil {
+		return "", err
+	}
+	*uploaded = append(*uploaded, fileID)
+	return url, nil
+}
+
+// docsRenderReplacements builds one replaceAllText per distinct placeholder
+// spelling left in the document (body, headers, and footers). Repeated rows
+// are filled by index beforehand, so their placeholders are already gone.
+func docsRenderReplacements(doc *docs.Document, rec docsRenderRecord) []*docs.Request {
+	seen := map[string]bool{}
+	var reqs []*docs.Request
+	docsEachParagraph(doc, true, func(p docsTextParagraph) {
+		for _, m := range docsRenderPlaceholderRe.FindAllStringSubmatch(p.text, -1) {
+			if seen[m[0]] || strings.HasPrefix(m[1], docsRenderImagePrefix) {
+				continue
+			}
+			seen[m[0]] = true
+			v, _ := rec.lookup(m[1])
+			reqs = append(reqs, &docs.Request{ReplaceAllText: &docs.ReplaceAllTextRequest{
+				ContainsText: &docs.SubstringMatchCriteria{Text: m[0], MatchCase: true},
+				ReplaceText:  docsRenderText(v),
+			}})
+		}
+	})
+	return reqs
+}
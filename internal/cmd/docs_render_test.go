@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+// renderTestDoc builds a body of paragraphs followed by a two-row table
+// whose cells hold header and row.
+func renderTestDoc(paras []string, header, row []string) *docs.Document {
+	doc := &docs.Document{Title: "Contract", Body: &docs.Body{Content: []*docs.StructuralElement{{EndIndex: 1, SectionBreak: &docs.SectionBreak{}}}}}
+	idx := int64(1)
+	para := func(text string) *docs.StructuralElement {
+		text += "\n"
+		end := idx + utf16Len(text)
+		el := &docs.StructuralElement{StartIndex: idx, EndIndex: end, Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{
+			{StartIndex: idx, EndIndex: end, TextRun: &docs.TextRun{Content: text}},
+		}}}
+		idx = end
+		return el
+	}
+	for _, p := range paras {
+		doc.Body.Content = append(doc.Body.Content, para(p))
+	}
+	if header != nil {
+		tableStart := idx
+		idx++
+		table := &docs.Table{}
+		for _, cells := range [][]string{header, row} {
+			tr := &docs.TableRow{StartIndex: idx}
+			for _, c := range cells {
+				idx++
+				tr.TableCells = append(tr.TableCells, &docs.TableCell{Content: []*docs.StructuralElement{para(c)}})
+			}
+			tr.EndIndex = idx
+			table.TableRows = append(table.TableRows, tr)
+		}
+		idx++
+		doc.Body.Content = append(doc.Body.Content, &docs.StructuralElement{StartIndex: tableStart, EndIndex: idx, Table: table})
+		doc.Body.Content = append(doc.Body.Content, para(""))
+	}
+	return doc
+}
+
+func TestDocsRender_PlansSectionsRowsAndValues(t *testing.T) {
+	doc := renderTestDoc([]string{
+		"Dear {{ client.name }},", // 1-25
+		"{{#if vip}}",             // 25-37
+		"Priority support",        // 37-54
+		"{{/if}}",                 // 54-62
+		"{{#unless vip}}",         // 62-78
+		"Standard support",        // 78-95
+		"{{/unless}}",             // 95-107
+	}, []string{"Item", "Qty"}, []string{"{{items.name}}", "{{items.qty}}"})
+
+	records, err := parseDocsRenderJSON(`{"client":{"name":"Acme"},"vip":true,"items":[{"name":"Widget","qty":2},{"name":"Gadget","qty":5}]}`)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rec := records[0]
+
+	sections, err := docsRenderSections(doc, rec)
+	if err != nil {
+		t.Fatalf("sections: %v", err)
+	}
+	var ranges [][2]int64
+	for _, e := range sections {
+		rg := e.requests[0].DeleteContentRange.Range
+		ranges = append(ranges, [2]int64{rg.StartIndex, rg.EndIndex})
+	}
+	want := [][2]int64{{25, 37}, {54, 62}, {62, 107}}
+	if len(ranges) != len(want) {
+		t.Fatalf("ranges = %v, want %v", ranges, want)
+	}
+	for i := range want {
+		if ranges[i] != want[i] {
+			t.Fatalf("ranges = %v, want %v", ranges, want)
+		}
+	}
+
+	rows := docsRenderRowInserts(doc, rec)
+	if len(rows) != 1 || len(rows[0].requests) != 1 || rows[0].requests[0].InsertTableRow == nil || !rows[0].requests[0].InsertTableRow.InsertBelow {
+		t.Fatalf("expected one inserted row, got %#v", rows)
+	}
+
+	// Pretend the row was inserted: the copy is empty. The template row is
+	// filled in place with the first item, the copy with the second.
+	filled := renderTestDoc(nil, []string{"{{items.name}}", "{{items.qty}}"}, []string{"", ""})
+	cells, spans, err := docsRenderRowCells(context.Background(), filled, rec, nil)
+	if err != nil {
+		t.Fatalf("row cells: %v", err)
+	}
+	var texts []string
+	for _, e := range cells {
+		for _, r := range e.requests {
+			if r.InsertText != nil {
+				texts = append(texts, r.InsertText.Text)
+			}
+		}
+	}
+	if strings.Join(texts, ",") != "Widget,2,Gadget,5" {
+		t.Fatalf("unexpected cell texts: %v", texts)
+	}
+	if del := cells[0].requests[0].DeleteContentRange; del == nil || del.Range.StartIndex != 3 || del.Range.EndIndex != 17 {
+		t.Fatalf("expected the template placeholder to be deleted in place, got %#v", cells[0].requests)
+	}
+	if len(spans) != 1 || spans[0] != [2]int64{2, filled.Body.Content[1].EndIndex - 1} {
+		t.Fatalf("unexpected row spans: %v", spans)
+	}
+
+	repl := docsRenderReplacements(doc, rec)
+	got := map[string]string{}
+	for _, r := range repl {
+		got[r.ReplaceAllText.ContainsText.Text] = r.ReplaceAllText.ReplaceText
+	}
+	if got["{{ client.name }}"] != "Acme" {
+		t.Fatalf("unexpected replacements: %v", got)
+	}
+
+	if _, err := docsRenderSections(renderTestDoc([]string{"{{#if a}}", "x"}, nil, nil), rec); err == nil || ExitCode(err) != 2 {
+		t.Fatalf("expected usage error for unclosed section, got %v", err)
+	}
+}
+
+func TestDocsRender_RepeatedRowsKeepImagesAndValuesInTheRow(t *testing.T) {
+	records, err := parseDocsRenderJSON(`{"logo":"https://example.com/logo.png","items":[{"name":"Widget","photo":"https://example.com/w.png"},{"name":"Gadget","photo":"https://example.com/g.png"}]}`)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rec := records[0]
+	// The copy row is already inserted and empty.
+	doc := renderTestDoc([]string{"{{image:logo}} Featured: {{items.name}}"}, []string{"{{items.name}}", "{{image:items.photo}}"}, []string{"", ""})
+	images := &docsRenderImageSource{urls: map[string]string{}}
+
+	cells, spans, err := docsRenderRowCells(context.Background(), doc, rec, images)
+	if err != nil {
+		t.Fatalf("row cells: %v", err)
+	}
+	var got []string
+	for _, e := range cells {
+		for _, r := range e.requests {
+			switch {
+			case r.InsertText != nil:
+				got = append(got, r.InsertText.Text)
+			case r.InsertInlineImage != nil:
+				got = append(got, r.InsertInlineImage.Uri)
+			}
+		}
+	}
+	if want := "Widget,https://example.com/w.png,Gadget,https://example.com/g.png"; strings.Join(got, ",") != want {
+		t.Fatalf("row values = %v, want %s", got, want)
+	}
+
+	imageEdits, err := docsRenderImages(context.Background(), doc, rec, images, spans)
+	if err != nil {
+		t.Fatalf("images: %v", err)
+	}
+	if len(imageEdits) != 1 || imageEdits[0].index != 1 || imageEdits[0].requests[1].InsertInlineImage.Uri != "https://example.com/logo.png" {
+		t.Fatalf("expected only the body image outside the row, got %#v", imageEdits)
+	}
+
+	for _, r := range docsRenderReplacements(doc, rec) {
+		if r.ReplaceAllText.ContainsText.Text == "{{items.name}}" && r.ReplaceAllText.ReplaceText != "" {
+			t.Fatalf("placeholder outside the row got the first item's value %q", r.ReplaceAllText.ReplaceText)
+		}
+	}
+}
+
+func TestDocsRender_SectionsAtBodyBoundaries(t *testing.T) {
+	doc := renderTestDoc([]string{
+		"{{#if vip}}", // 1-13
+		"Priority",    // 13-22
+		"{{/if}}",     // 22-30, last paragraph of the body
+	}, nil, nil)
+	deleted := func(vip bool) [][2]int64 {
+		t.Helper()
+		edits, err := docsRenderSections(doc, docsRenderRecord{"vip": vip})
+		if err != nil {
+			t.Fatalf("sections: %v", err)
+		}
+		var out [][2]int64
+		for _, e := range edits {
+			rg := e.requests[0].DeleteContentRange.Range
+			out = append(out, [2]int64{rg.StartIndex, rg.EndIndex})
+		}
+		return out
+	}
+
+	// The whole body is the section: everything but the final newline goes.
+	if got := deleted(false); len(got) != 1 || got[0] != [2]int64{1, 29} {
+		t.Fatalf("dropped section = %v, want [[1 29]]", got)
+	}
+	// Kept: the markers go, the closing one via the newline before it.
+	if got := deleted(true); len(got) != 2 || got[0] != [2]int64{1, 13} || got[1] != [2]int64{21, 29} {
+		t.Fatalf("kept section = %v, want [[1 13] [21 29]]", got)
+	}
+}
+
+func TestDocsRender_CSVCreatesOneDocPerRow(t *testing.T) {
+	origDrive, origDocs := newDriveService, newDocsService
+	t.Cleanup(func() {
+		newDriveService = origDrive
+		newDocsService = origDocs
+	})
+
+	var (
+		mu      sync.Mutex
+		names   []string
+		batches = map[string][]*docs.Request{}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, "/drive/v3")
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/documents/"):
+			doc := renderTestDoc([]string{"Hello {{name}}, due {{date}}"}, nil, nil)
+			doc.DocumentId = strings.TrimPrefix(r.URL.Path, "/v1/documents/")
+			_ = json.NewEncoder(w).Encode(doc)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":batchUpdate"):
+			var req docs.BatchUpdateDocumentRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode batchUpdate: %v", err)
+			}
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/documents/"), ":batchUpdate")
+			batches[id] = append(batches[id], req.Requests...)
+			_ = json.NewEncoder(w).Encode(map[string]any{"documentId": id})
+		case r.Method == http.MethodGet && path == "/files/tmpl":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "tmpl", "name": "Contract", "mimeType": driveMimeGoogleDoc})
+		case r.Method == http.MethodPost && path == "/files/tmpl/copy":
+			var f drive.File
+			_ = json.NewDecoder(r.Body).Decode(&f)
+			names = append(names, f.Name)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "copy" + string(rune('0'+len(names))), "name": f.Name, "mimeType": driveMimeGoogleDoc})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	driveSvc, err := drive.NewService(context.Background(), option.WithoutAuthentication(), option.WithHTTPClient(srv.Client()), option.WithEndpoint(srv.URL+"/"))
+	if err != nil {
+		t.Fatalf("drive.NewService: %v", err)
+	}
+	docSvc, err := docs.NewService(context.Background(), option.WithoutAuthentication(), option.WithHTTPClient(srv.Client()), option.WithEndpoint(srv.URL+"/"))
+	if err != nil {
+		t.Fatalf("docs.NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return driveSvc, nil }
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docSvc, nil }
+
+	data := filepath.Join(t.TempDir(), "rows.csv")
+	if err := os.WriteFile(data, []byte("name,date\nAda,May 1\nGrace,\n"), 0o600); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	flags := &RootFlags{Account: "a@b.com"}
+	if err := runKong(t, &DocsRenderCmd{}, []string{"tmpl", "--data", data, "--name", "{{name}} contract"}, newDocsCmdContext(t), flags); err != nil {
+		t.Fatalf("docs render: %v", err)
+	}
+
+	if strings.Join(names, "|") != "Ada contract|Grace contract" {
+		t.Fatalf("unexpected copy names: %v", names)
+	}
+	for id, want := range map[string]string{"copy1": "Ada", "copy2": "Grace"} {
+		reqs := batches[id]
+		if len(reqs) != 2 || reqs[0].ReplaceAllText.ContainsText.Text != "{{name}}" || reqs[0].ReplaceAllText.ReplaceText != want {
+			t.Fatalf("%s: unexpected requests %#v", id, reqs)
+		}
+	}
+
+	err = runKong(t, &DocsRenderCmd{}, []string{"tmpl", "--data", data, "--strict"}, newDocsCmdContext(t), flags)
+	if err != nil {
+		t.Fatalf("strict with empty value should pass: %v", err)
+	}
+	if err := os.WriteFile(data, []byte("name\nAda\n"), 0o600); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	err = runKong(t, &DocsRenderCmd{}, []string{"tmpl", "--data", data, "--strict"}, newDocsCmdContext(t), flags)
+	if err == nil || ExitCode(err) != 2 || !strings.Contains(err.Error(), "date") {
+		t.Fatalf("expected strict usage error, got %v", err)
+	}
+}
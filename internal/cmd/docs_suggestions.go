@@ -0,0 +1,461 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// DocsSuggestionsCmd reviews suggestions (tracked changes). The Docs API
+// reports suggestions but cannot accept or reject them, and does not expose
+// their authors; accept/reject therefore rewrite the suggested text as a
+// regular edit, which clears the suggestion. Suggested images, smart chips
+// and other non-text elements can be removed but not rewritten, so
+// suggestions that would have to keep one are skipped.
+type DocsSuggestionsCmd struct {
+	List   DocsSuggestionsListCmd   `cmd:"" name:"list" aliases:"ls" help:"List pending suggestions (tracked changes) in a Google Doc"`
+	Accept DocsSuggestionsAcceptCmd `cmd:"" name:"accept" help:"Accept suggested insertions/deletions by ID or --all"`
+	Reject DocsSuggestionsRejectCmd `cmd:"" name:"reject" help:"Reject suggested insertions/deletions by ID or --all"`
+}
+
+type DocsSuggestionsListCmd struct {
+	DocID     string `arg:"" name:"docId" help:"Google Doc ID or URL"`
+	FailEmpty bool   `name:"fail-empty" aliases:"non-empty,require-results" help:"Exit with code 3 if no results"`
+	Author    string `name:"author" hidden:"" help:"Not supported: the Docs API does not report suggestion authors"`
+}
+
+func (c *DocsSuggestionsListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	docID := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	if docID == "" {
+		return usage("empty docId")
+	}
+	if err := docsSuggestionsAuthorUnsupported(c.Author); err != nil {
+		return err
+	}
+
+	doc, err := fetchDocsSuggestions(ctx, flags, docID)
+	if err != nil {
+		return err
+	}
+	suggestions := collectDocsSuggestions(doc)
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"docId":       docID,
+			"suggestions": suggestions,
+		}); err != nil {
+			return err
+		}
+		if len(suggestions) == 0 {
+			return failEmptyExit(c.FailEmpty)
+		}
+		return nil
+	}
+
+	if len(suggestions) == 0 {
+		u.Err().Println("No suggestions")
+		return failEmptyExit(c.FailEmpty)
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tKIND\tINSERTED\tDELETED\tSTYLE\tCONTEXT")
+	for _, s := range suggestions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.ID,
+			s.Kind,
+			truncateString(oneLineTSV(s.Inserted), 30),
+			truncateString(oneLineTSV(s.Deleted), 30),
+			strings.Join(s.Style, ","),
+			truncateString(oneLineTSV(s.Context), 50),
+		)
+	}
+	return nil
+}
+
+type DocsSuggestionsAcceptCmd struct {
+	DocID  string   `arg:"" name:"docId" help:"Google Doc ID or URL"`
+	IDs    []string `arg:"" optional:"" name:"suggestionId" help:"Suggestion IDs (see: docs suggestions list)"`
+	All    bool     `name:"all" help:"Accept every pending insertion/deletion"`
+	Author string   `name:"author" hidden:"" help:"Not supported: the Docs API does not report suggestion authors"`
+}
+
+func (c *DocsSuggestionsAcceptCmd) Run(ctx context.Context, flags *RootFlags) error {
+	if err := docsSuggestionsAuthorUnsupported(c.Author); err != nil {
+		return err
+	}
+	return resolveDocsSuggestions(ctx, flags, c.DocID, c.IDs, c.All, true)
+}
+
+type DocsSuggestionsRejectCmd struct {
+	DocID  string   `arg:"" name:"docId" help:"Google Doc ID or URL"`
+	IDs    []string `arg:"" optional:"" name:"suggestionId" help:"Suggestion IDs (see: docs suggestions list)"`
+	All    bool     `name:"all" help:"Reject every pending insertion/deletion"`
+	Author string   `name:"author" hidden:"" help:"Not supported: the Docs API does not report suggestion authors"`
+}
+
+func (c *DocsSuggestionsRejectCmd) Run(ctx context.Context, flags *RootFlags) error {
+	if err := docsSuggestionsAuthorUnsupported(c.Author); err != nil {
+		return err
+	}
+	return resolveDocsSuggestions(ctx, flags, c.DocID, c.IDs, c.All, false)
+}
+
+// docsSuggestionsAuthorUnsupported turns --author into a clear usage error
+// instead of silently listing or resolving everyone's suggestions.
+func docsSuggestionsAuthorUnsupported(author string) error {
+	if strings.TrimSpace(author) == "" {
+		return nil
+	}
+	return usage("--author is not supported: the Docs API does not report who made a suggestion")
+}
+
+// docsSuggestion groups everything a suggestion ID touches. A replacement
+// is one suggestion with both inserted and deleted text.
+type docsSuggestion struct {
+	ID       string   `json:"id"`
+	Kind     string   `json:"kind"` // insert, delete, replace, style
+	Inserted string   `json:"inserted,omitempty"`
+	Deleted  string   `json:"deleted,omitempty"`
+	Style    []string `json:"style,omitempty"`
+	Objects  []string `json:"objects,omitempty"` // non-text elements, e.g. "image"
+	Context  string   `json:"context"`
+	Start    int64    `json:"startIndex"`
+
+	insertions []docsSuggestionRun
+	deletions  []docsSuggestionRun
+}
+
+// docsSuggestionRun is one text run carrying a suggestion. Non-text
+// elements have object set and a placeholder text.
+type docsSuggestionRun struct {
+	start  int64
+	end    int64
+	text   string
+	style  *docs.TextStyle
+	object string
+}
+
+// keepsObject reports whether resolving s would have to keep a non-text
+// element, which cannot be rewritten through the API.
+func (s *docsSuggestion) keepsObject(accept bool) bool {
+	kept := s.deletions
+	if accept {
+		kept = s.insertions
+	}
+	for _, r := range kept {
+		if r.object != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func fetchDocsSuggestions(ctx context.Context, flags *RootFlags, docID string) (*docs.Document, error) {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := newDocsService(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	return svc.Documents.Get(docID).SuggestionsViewMode("SUGGESTIONS_INLINE").Context(ctx).Do()
+}
+
+// collectDocsSuggestions walks the body (including table cells) in document
+// order.
+func collectDocsSuggestions(doc *docs.Document) []*docsSuggestion {
+	byID := map[string]*docsSuggestion{}
+	var order []*docsSuggestion
+	get := func(id, context string, start int64) *docsSuggestion {
+		s, ok := byID[id]
+		if !ok {
+			s = &docsSuggestion{ID: id, Context: context, Start: start}
+			byID[id] = s
+			order = append(order, s)
+		}
+		return s
+	}
+
+	var walk func([]*docs.StructuralElement)
+	walk = func(content []*docs.StructuralElement) {
+		for _, el := range content {
+			switch {
+			case el == nil:
+			case el.Table != nil:
+				for _, row := range el.Table.TableRows {
+					if row == nil {
+						continue
+					}
+					for _, cell := range row.TableCells {
+						if cell != nil {
+							walk(cell.Content)
+						}
+					}
+				}
+			case el.Paragraph != nil:
+				context := strings.TrimSpace(docsParagraphRawText(el.Paragraph))
+				for _, id := range sortedKeys(el.Paragraph.SuggestedParagraphStyleChanges) {
+					s := get(id, context, el.StartIndex)
+					s.Style = appendUnique(s.Style, "paragraph")
+				}
+				for _, pe := range el.Paragraph.Elements {
+					if pe == nil {
+						continue
+					}
+					if pe.TextRun == nil {
+						object, label, insertIDs, deleteIDs := docsSuggestionObject(pe)
+						if object == "" {
+							continue
+						}
+						run := docsSuggestionRun{start: pe.StartIndex, end: pe.EndIndex, text: label, object: object}
+						for _, id := range insertIDs {
+							s := get(id, context, pe.StartIndex)
+							s.insertions = append(s.insertions, run)
+							s.Inserted += run.text
+							s.Objects = appendUnique(s.Objects, object)
+						}
+						for _, id := range deleteIDs {
+							s := get(id, context, pe.StartIndex)
+							s.deletions = append(s.deletions, run)
+							s.Deleted += run.text
+							s.Objects = appendUnique(s.Objects, object)
+						}
+						continue
+					}
+					run := docsSuggestionRun{start: pe.StartIndex, end: pe.EndIndex, text: pe.TextRun.Content, style: pe.TextRun.TextStyle}
+					for _, id := range pe.TextRun.SuggestedInsertionIds {
+						s := get(id, context, pe.StartIndex)
+						s.insertions = append(s.insertions, run)
+						s.Inserted += run.text
+					}
+					for _, id := range pe.TextRun.SuggestedDeletionIds {
+						s := get(id, context, pe.StartIndex)
+						s.deletions = append(s.deletions, run)
+						s.Deleted += run.text
+					}
+					for _, id := range sortedKeys(pe.TextRun.SuggestedTextStyleChanges) {
+						s := get(id, context, pe.StartIndex)
+						for _, field := range docsSuggestedStyleFields(pe.TextRun.SuggestedTextStyleChanges[id].TextStyleSuggestionState) {
+							s.Style = appendUnique(s.Style, field)
+						}
+					}
+				}
+			}
+		}
+	}
+	if doc.Body != nil {
+		walk(doc.Body.Content)
+	}
+
+	for _, s := range order {
+		switch {
+		case len(s.insertions) > 0 && len(s.deletions) > 0:
+			s.Kind = "replace"
+		case len(s.insertions) > 0:
+			s.Kind = "insert"
+		case len(s.deletions) > 0:
+			s.Kind = "delete"
+		default:
+			s.Kind = "style"
+		}
+	}
+	return order
+}
+
+// docsSuggestionObject describes a non-text paragraph element that can carry
+// suggestions: its kind, a placeholder for listings, and its suggestion IDs.
+func docsSuggestionObject(pe *docs.ParagraphElement) (string, string, []string, []string) {
+	switch {
+	case pe.InlineObjectElement != nil:
+		return "image", "[image]", pe.InlineObjectElement.SuggestedInsertionIds, pe.InlineObjectElement.SuggestedDeletionIds
+	case pe.RichLink != nil:
+		label := "[link]"
+		if p := pe.RichLink.RichLinkProperties; p != nil && p.Title != "" {
+			label = "[link: " + p.Title + "]"
+		}
+		return "richLink", label, pe.RichLink.SuggestedInsertionIds, pe.RichLink.SuggestedDeletionIds
+	case pe.Person != nil:
+		label := "[person]"
+		if p := pe.Person.PersonProperties; p != nil {
+			label = "[person: " + orEmpty(p.Email, p.Name) + "]"
+		}
+		return "person", label, pe.Person.SuggestedInsertionIds, pe.Person.SuggestedDeletionIds
+	case pe.AutoText != nil:
+		return "autoText", "[auto text]", pe.AutoText.SuggestedInsertionIds, pe.AutoText.SuggestedDeletionIds
+	case pe.Equation != nil:
+		return "equation", "[equation]", pe.Equation.SuggestedInsertionIds, pe.Equation.SuggestedDeletionIds
+	case pe.FootnoteReference != nil:
+		return "footnote", "[footnote]", pe.FootnoteReference.SuggestedInsertionIds, pe.FootnoteReference.SuggestedDeletionIds
+	case pe.PageBreak != nil:
+		return "pageBreak", "[page break]", pe.PageBreak.SuggestedInsertionIds, pe.PageBreak.SuggestedDeletionIds
+	case pe.ColumnBreak != nil:
+		return "columnBreak", "[column break]", pe.ColumnBreak.SuggestedInsertionIds, pe.ColumnBreak.SuggestedDeletionIds
+	case pe.HorizontalRule != nil:
+		return "horizontalRule", "[horizontal rule]", pe.HorizontalRule.SuggestedInsertionIds, pe.HorizontalRule.SuggestedDeletionIds
+	default:
+		return "", "", nil, nil
+	}
+}
+
+func docsSuggestedStyleFields(st *docs.TextStyleSuggestionState) []string {
+	if st == nil {
+		return nil
+	}
+	var fields []string
+	for _, f := range []struct {
+		name string
+		on   bool
+	}{
+		{"bold", st.BoldSuggested},
+		{"italic", st.ItalicSuggested},
+		{"underline", st.UnderlineSuggested},
+		{"strikethrough", st.StrikethroughSuggested},
+		{"smallCaps", st.SmallCapsSuggested},
+		{"baselineOffset", st.BaselineOffsetSuggested},
+		{"fontSize", st.FontSizeSuggested},
+		{"font", st.WeightedFontFamilySuggested},
+		{"foregroundColor", st.ForegroundColorSuggested},
+		{"backgroundColor", st.BackgroundColorSuggested},
+		{"link", st.LinkSuggested},
+	} {
+		if f.on {
+			fields = append(fields, f.name)
+		}
+	}
+	return fields
+}
+
+// resolveDocsSuggestions accepts or rejects suggestions. Text that should
+// go is deleted; text that should stay is deleted and inserted again with
+// its style, turning it into regular content. Style-only suggestions, and
+// ones that would have to keep a non-text element, cannot be resolved
+// through the API and are reported as skipped.
+func resolveDocsSuggestions(ctx context.Context, flags *RootFlags, rawDocID string, ids []string, all, accept bool) error {
+	u := ui.FromContext(ctx)
+	docID := normalizeGoogleID(strings.TrimSpace(rawDocID))
+	if docID == "" {
+		return usage("empty docId")
+	}
+	if all == (len(ids) > 0) {
+		return usage("pass suggestion IDs or --all (not both)")
+	}
+	op := "docs.suggestions.reject"
+	if accept {
+		op = "docs.suggestions.accept"
+	}
+	if err := dryRunExit(ctx, flags, op, map[string]any{
+		"doc_id":         docID,
+		"suggestion_ids": ids,
+		"all":            all,
+	}); err != nil {
+		return err
+	}
+
+	doc, err := fetchDocsSuggestions(ctx, flags, docID)
+	if err != nil {
+		return err
+	}
+	suggestions := collectDocsSuggestions(doc)
+	selected := suggestions
+	if !all {
+		byID := map[string]*docsSuggestion{}
+		for _, s := range suggestions {
+			byID[s.ID] = s
+		}
+		selected = nil
+		for _, id := range ids {
+			s, ok := byID[strings.TrimSpace(id)]
+			if !ok {
+				return usagef("suggestion %q not found (see: gog docs suggestions list %s)", id, docID)
+			}
+			selected = append(selected, s)
+		}
+	}
+
+	var resolved, skipped []string
+	var reqs []*docs.Request
+	type edit struct {
+		run  docsSuggestionRun
+		keep bool
+	}
+	var edits []edit
+	for _, s := range selected {
+		if s.Kind == "style" || s.keepsObject(accept) {
+			skipped = append(skipped, s.ID)
+			continue
+		}
+		resolved = append(resolved, s.ID)
+		for _, r := range s.insertions {
+			edits = append(edits, edit{run: r, keep: accept})
+		}
+		for _, r := range s.deletions {
+			edits = append(edits, edit{run: r, keep: !accept})
+		}
+	}
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].run.start > edits[j].run.start })
+	for i, e := range edits {
+		if i > 0 && edits[i-1].run.start == e.run.start {
+			continue // run shared by several selected suggestions
+		}
+		reqs = append(reqs, &docs.Request{DeleteContentRange: &docs.DeleteContentRangeRequest{
+			Range: &docs.Range{StartIndex: e.run.start, EndIndex: e.run.end},
+		}})
+		if !e.keep {
+			continue
+		}
+		reqs = append(reqs, &docs.Request{InsertText: &docs.InsertTextRequest{
+			Location: &docs.Location{Index: e.run.start},
+			Text:     e.run.text,
+		}})
+		style := e.run.style
+		if style == nil {
+			style = &docs.TextStyle{}
+		}
+		reqs = append(reqs, &docs.Request{UpdateTextStyle: &docs.UpdateTextStyleRequest{
+			Range:     &docs.Range{StartIndex: e.run.start, EndIndex: e.run.start + utf16Len(e.run.text)},
+			TextStyle: style,
+			Fields:    "*",
+		}})
+	}
+
+	if len(reqs) > 0 {
+		account, err := requireAccount(flags)
+		if err != nil {
+			return err
+		}
+		svc, err := newDocsService(ctx, account)
+		if err != nil {
+			return err
+		}
+		if _, err := svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{
+			Requests:     reqs,
+			WriteControl: &docs.WriteControl{RequiredRevisionId: doc.RevisionId},
+		}).Context(ctx).Do(); err != nil {
+			return err
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"documentId": docID,
+			"resolved":   resolved,
+			"skipped":    skipped,
+		})
+	}
+	u.Out().Printf("documentId\t%s", docID)
+	u.Out().Printf("resolved\t%d", len(resolved))
+	if len(skipped) > 0 {
+		u.Err().Printf("skipped style-only or non-text suggestions (resolve them in Docs): %s", strings.Join(skipped, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+)
+
+// suggestionsTestDoc: "Pay net 30 days." where "net 30" is a suggested
+// deletion (del1), "45" a suggested insertion (ins1), and "days" has a
+// suggested bold change (sty1).
+func suggestionsTestDoc() *docs.Document {
+	run := func(start int64, text string, mod func(*docs.TextRun)) *docs.ParagraphElement {
+		tr := &docs.TextRun{Content: text, TextStyle: &docs.TextStyle{Italic: true}}
+		if mod != nil {
+			mod(tr)
+		}
+		return &docs.ParagraphElement{StartIndex: start, EndIndex: start + utf16Len(text), TextRun: tr}
+	}
+	return &docs.Document{
+		DocumentId: "doc1",
+		RevisionId: "rev-3",
+		Body: &docs.Body{Content: []*docs.StructuralElement{
+			{EndIndex: 1, SectionBreak: &docs.SectionBreak{}},
+			{StartIndex: 1, EndIndex: 20, Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{
+				run(1, "Pay ", nil),
+				run(5, "net 30", func(tr *docs.TextRun) { tr.SuggestedDeletionIds = []string{"del1"} }),
+				run(11, "45", func(tr *docs.TextRun) { tr.SuggestedInsertionIds = []string{"ins1"} }),
+				run(13, " ", nil),
+				run(14, "days", func(tr *docs.TextRun) {
+					tr.SuggestedTextStyleChanges = map[string]docs.SuggestedTextStyle{
+						"sty1": {TextStyleSuggestionState: &docs.TextStyleSuggestionState{BoldSuggested: true}},
+					}
+				}),
+				run(18, ".\n", nil),
+			}}},
+		}},
+	}
+}
+
+func TestDocsSuggestions_ListAndAccept(t *testing.T) {
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	var got docs.BatchUpdateDocumentRequest
+	var viewMode string
+	docSvc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/documents/"):
+			viewMode = r.URL.Query().Get("suggestionsViewMode")
+			_ = json.NewEncoder(w).Encode(suggestionsTestDoc())
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":batchUpdate"):
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Fatalf("decode batchUpdate: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"documentId": "doc1"})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docSvc, nil }
+
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DocsSuggestionsListCmd{}, []string{"doc1"}, ctx, flags); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	})
+	if viewMode != "SUGGESTIONS_INLINE" {
+		t.Fatalf("suggestionsViewMode = %q", viewMode)
+	}
+	var listed struct {
+		Suggestions []docsSuggestion `json:"suggestions"`
+	}
+	if err := json.Unmarshal([]byte(out), &listed); err != nil {
+		t.Fatalf("decode list output: %v\n%s", err, out)
+	}
+	if len(listed.Suggestions) != 3 {
+		t.Fatalf("expected 3 suggestions, got %+v", listed.Suggestions)
+	}
+	if s := listed.Suggestions[0]; s.ID != "del1" || s.Kind != "delete" || s.Deleted != "net 30" || s.Context != "Pay net 3045 days." {
+		t.Fatalf("unexpected deletion: %+v", s)
+	}
+	if s := listed.Suggestions[1]; s.ID != "ins1" || s.Kind != "insert" || s.Inserted != "45" {
+		t.Fatalf("unexpected insertion: %+v", s)
+	}
+	if s := listed.Suggestions[2]; s.ID != "sty1" || s.Kind != "style" || len(s.Style) != 1 || s.Style[0] != "bold" {
+		t.Fatalf("unexpected style change: %+v", s)
+	}
+
+	if err := runKong(t, &DocsSuggestionsAcceptCmd{}, []string{"doc1", "--all"}, newDocsCmdContext(t), flags); err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	if got.WriteControl == nil || got.WriteControl.RequiredRevisionId != "rev-3" {
+		t.Fatalf("expected revision guard, got %#v", got.WriteControl)
+	}
+	// Accepting keeps "45" (rewritten as plain text, later index first) and
+	// drops "net 30"; the style suggestion is skipped.
+	reqs := got.Requests
+	if len(reqs) != 4 {
+		t.Fatalf("expected 4 requests, got %d: %#v", len(reqs), reqs)
+	}
+	if d := reqs[0].DeleteContentRange; d == nil || d.Range.StartIndex != 11 || d.Range.EndIndex != 13 {
+		t.Fatalf("unexpected first request: %#v", reqs[0])
+	}
+	if ins := reqs[1].InsertText; ins == nil || ins.Location.Index != 11 || ins.Text != "45" {
+		t.Fatalf("unexpected re-insert: %#v", reqs[1])
+	}
+	if st := reqs[2].UpdateTextStyle; st == nil || !st.TextStyle.Italic || st.Fields != "*" {
+		t.Fatalf("unexpected style restore: %#v", reqs[2])
+	}
+	if d := reqs[3].DeleteContentRange; d == nil || d.Range.StartIndex != 5 || d.Range.EndIndex != 11 {
+		t.Fatalf("unexpected deletion: %#v", reqs[3])
+	}
+
+	got = docs.BatchUpdateDocumentRequest{}
+	if err := runKong(t, &DocsSuggestionsRejectCmd{}, []string{"doc1", "ins1"}, newDocsCmdContext(t), flags); err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	if len(got.Requests) != 1 || got.Requests[0].DeleteContentRange == nil || got.Requests[0].DeleteContentRange.Range.StartIndex != 11 {
+		t.Fatalf("unexpected reject requests: %#v", got.Requests)
+	}
+
+	err := runKong(t, &DocsSuggestionsRejectCmd{}, []string{"doc1", "nope"}, newDocsCmdContext(t), flags)
+	if err == nil || ExitCode(err) != 2 {
+		t.Fatalf("expected usage error for unknown ID, got %v", err)
+	}
+	err = runKong(t, &DocsSuggestionsAcceptCmd{}, []string{"doc1"}, newDocsCmdContext(t), flags)
+	if err == nil || ExitCode(err) != 2 {
+		t.Fatalf("expected usage error without IDs, got %v", err)
+	}
+}
+
+func TestCollectDocsSuggestions_NonTextElements(t *testing.T) {
+	doc := &docs.Document{Body: &docs.Body{Content: []*docs.StructuralElement{
+		{StartIndex: 1, EndIndex: 6, Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{
+			{StartIndex: 1, EndIndex: 2, InlineObjectElement: &docs.InlineObjectElement{InlineObjectId: "img", SuggestedInsertionIds: []string{"img1"}}},
+			{StartIndex: 2, EndIndex: 3, Person: &docs.Person{PersonProperties: &docs.PersonProperties{Email: "ana@example.com"}, SuggestedDeletionIds: []string{"chip1"}}},
+			{StartIndex: 3, EndIndex: 4, RichLink: &docs.RichLink{RichLinkProperties: &docs.RichLinkProperties{Title: "Roadmap"}, SuggestedInsertionIds: []string{"link1"}}},
+			{StartIndex: 4, EndIndex: 6, TextRun: &docs.TextRun{Content: "!\n"}},
+		}}},
+	}}}
+
+	got := collectDocsSuggestions(doc)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 suggestions, got %+v", got)
+	}
+	if s := got[0]; s.ID != "img1" || s.Kind != "insert" || s.Inserted != "[image]" || len(s.Objects) != 1 || s.Objects[0] != "image" {
+		t.Fatalf("unexpected image suggestion: %+v", s)
+	}
+	if s := got[1]; s.ID != "chip1" || s.Kind != "delete" || s.Deleted != "[person: ana@example.com]" {
+		t.Fatalf("unexpected person suggestion: %+v", s)
+	}
+	if s := got[2]; s.ID != "link1" || s.Inserted != "[link: Roadmap]" {
+		t.Fatalf("unexpected rich link suggestion: %+v", s)
+	}
+
+	// Accepting an image insertion would have to keep it; rejecting removes it.
+	if !got[0].keepsObject(true) || got[0].keepsObject(false) {
+		t.Fatalf("unexpected keepsObject for image insertion")
+	}
+	// Accepting a chip deletion removes it; rejecting would have to keep it.
+	if got[1].keepsObject(true) || !got[1].keepsObject(false) {
+		t.Fatalf("unexpected keepsObject for chip deletion")
+	}
+}
+
+func TestDocsSuggestions_AuthorIsUsageError(t *testing.T) {
+	flags := &RootFlags{Account: "a@b.com"}
+	for _, tc := range []struct {
+		cmd  any
+		args []string
+	}{
+		{&DocsSuggestionsListCmd{}, []string{"doc1", "--author", "ana@example.com"}},
+		{&DocsSuggestionsAcceptCmd{}, []string{"doc1", "--all", "--author", "ana@example.com"}},
+		{&DocsSuggestionsRejectCmd{}, []string{"doc1", "--all", "--author", "ana@example.com"}},
+	} {
+		err := runKong(t, tc.cmd, tc.args, newDocsCmdContext(t), flags)
+		if err == nil || ExitCode(err) != 2 || !strings.Contains(err.Error(), "--author") {
+			t.Fatalf("%T: expected --author usage error, got %v", tc.cmd, err)
+		}
+	}
+}
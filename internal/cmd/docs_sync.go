@@ -0,0 +1,689 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// DocsSyncCmd patches a document to match a Markdown file. Unlike
+// `docs update --format markdown` it only touches paragraphs that changed, so
+// comments, suggestions, and cursors on untouched text survive.
+type DocsSyncCmd struct {
+	DocID   string `arg:"" name:"docId" help:"Doc ID"`
+	File    string `arg:"" name:"file" help:"Markdown file to sync from (use - for stdin)"`
+	Section string `name:"section" help:"Only sync the content under this heading (up to the next heading of the same or higher level)"`
+}
+
+// docsSyncResetFields clears the inline styles sync manages (and the link
+// styling Docs adds) before applying Markdown formatting.
+const docsSyncResetFields = "bold,italic,strikethrough,underline,weightedFontFamily,link,backgroundColor,foregroundColor"
+
+// docsSyncBlock is one paragraph or table, either read from the document or
+// parsed from Markdown. Blank paragraphs are spacing and never become blocks.
+type docsSyncBlock struct {
+	Table bool
+	Text  string // paragraph text without its newline; tables: cell text
+	Style string // named style type
+	List  string // "", "bullet", "number", or "task"
+	Quote bool
+	Spans []TextStyle // inline formatting, UTF-16 offsets into Text
+	Cells [][]string  // tables parsed from Markdown
+
+	// Document blocks only.
+	Start       int64
+	End         int64
+	KeepNewline bool // followed by a table or the end of the body
+}
+
+// docsSyncScope is the part of the document being synced.
+type docsSyncScope struct {
+	Blocks []docsSyncBlock
+	// AppendAt is where content after the last block goes: the start of the
+	// element following the scope, or (when Leading) the newline of the last
+	// element of the body.
+	AppendAt int64
+	Leading  bool
+}
+
+type docsSyncPlan struct {
+	Requests  []*docs.Request
+	Tables    []TableData
+	Inserted  int
+	Deleted   int
+	Restyled  int
+	Unchanged int
+
+	shift int64
+}
+
+func (c *DocsSyncCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	id := strings.TrimSpace(c.DocID)
+	if id == "" {
+		return usage("empty docId")
+	}
+	if strings.TrimSpace(c.File) == "" {
+		return usage("empty file")
+	}
+	content, err := resolveContentInput("", c.File)
+	if err != nil {
+		return err
+	}
+
+	svc, err := newDocsService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	doc, err := svc.Documents.Get(id).
+		Context(ctx).
+		Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return fmt.Errorf("doc not found or not a Google Doc (id=%s)", id)
+		}
+		return err
+	}
+	if doc == nil || doc.Body == nil {
+		return errors.New("doc not found")
+	}
+
+	scope, err := docsSyncScopeFor(doc, c.Section)
+	if err != nil {
+		return err
+	}
+	want := docsSyncBlocksFromMarkdown(content)
+	if c.Section != "" && len(want) > 0 && docsMarkdownHeadingLevel(want[0].Style) > 0 &&
+		strings.EqualFold(strings.TrimSpace(want[0].Text), strings.TrimSpace(c.Section)) {
+		want = want[1:]
+	}
+	plan := planDocsSync(scope, want)
+
+	if err = dryRunExit(ctx, flags, "docs.sync", map[string]any{
+		"docId":     id,
+		"section":   c.Section,
+		"inserted":  plan.Inserted,
+		"deleted":   plan.Deleted,
+		"restyled":  plan.Restyled,
+		"unchanged": plan.Unchanged,
+		"requests":  plan.Requests,
+		"tables":    plan.Tables,
+	}); err != nil {
+		return err
+	}
+
+	if len(plan.Requests) > 0 {
+		_, err = svc.Documents.BatchUpdate(id, &docs.BatchUpdateDocumentRequest{
+			Requests:     plan.Requests,
+			WriteControl: &docs.WriteControl{RequiredRevisionId: doc.RevisionId},
+		}).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("sync document: %w", err)
+		}
+	}
+	if err = insertDocsTables(ctx, svc, id, plan.Tables); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"documentId": id,
+			"section":    c.Section,
+			"inserted":   plan.Inserted,
+			"deleted":    plan.Deleted,
+			"restyled":   plan.Restyled,
+			"unchanged":  plan.Unchanged,
+			"requests":   len(plan.Requests),
+		})
+	}
+
+	u.Out().Printf("documentId\t%s", id)
+	if c.Section != "" {
+		u.Out().Printf("section\t%s", c.Section)
+	}
+	if len(plan.Requests) == 0 && len(plan.Tables) == 0 {
+		u.Out().Printf("status\tup to date")
+	}
+	u.Out().Printf("inserted\t%d", plan.Inserted)
+	u.Out().Printf("deleted\t%d", plan.Deleted)
+	u.Out().Printf("restyled\t%d", plan.Restyled)
+	u.Out().Printf("unchanged\t%d", plan.Unchanged)
+	return nil
+}
+
+// docsSyncScopeFor collects the blocks of the document body, or of the
+// section under the heading named section.
+func docsSyncScopeFor(doc *docs.Document, section string) (docsSyncScope, error) {
+	content := doc.Body.Content
+	from, to := 0, len(content)
+
+	if section = strings.TrimSpace(section); section != "" {
+		found, level := -1, 0
+		for i, el := range content {
+			if el == nil || el.Paragraph == nil {
+				continue
+			}
+			lvl := docsMarkdownHeadingLevel(docsSyncNamedStyle(el.Paragraph))
+			if lvl == 0 || !strings.EqualFold(strings.TrimSpace(docsParagraphRawText(el.Paragraph)), section) {
+				continue
+			}
+			if found >= 0 {
+				return docsSyncScope{}, usagef("section %q matches more than one heading", section)
+			}
+			found, level = i, lvl
+		}
+		if found < 0 {
+			return docsSyncScope{}, usagef("section %q not found", section)
+		}
+		from = found + 1
+		for i := from; i < len(content); i++ {
+			el := content[i]
+			if el == nil || el.Paragraph == nil {
+				continue
+			}
+			if lvl := docsMarkdownHeadingLevel(docsSyncNamedStyle(el.Paragraph)); lvl > 0 && lvl <= level {
+				to = i
+				break
+			}
+		}
+	}
+
+	var scope docsSyncScope
+	for i := from; i < to; i++ {
+		el := content[i]
+		if el == nil {
+			continue
+		}
+		var block docsSyncBlock
+		switch {
+		case el.Paragraph != nil:
+			block = docsSyncBlockFromParagraph(el.Paragraph, doc.Lists, el.StartIndex)
+			if strings.TrimSpace(block.Text) == "" {
+				continue
+			}
+		case el.Table != nil:
+			block = docsSyncBlock{Table: true, Text: docsSyncTableText(docsSyncTableCells(el.Table))}
+		case el.SectionBreak != nil:
+			continue
+		default:
+			// Tables of contents and other structures never match Markdown.
+			block = docsSyncBlock{Text: "\ufffc"}
+		}
+		block.Start, block.End = el.StartIndex, el.EndIndex
+		block.KeepNewline = i+1 >= len(content) || (content[i+1] != nil && content[i+1].Table != nil)
+		scope.Blocks = append(scope.Blocks, block)
+	}
+
+	if to < len(content) {
+		scope.AppendAt = content[to].StartIndex
+		return scope, nil
+	}
+	last := content[len(content)-1]
+	if last.Paragraph != nil && strings.TrimSpace(docsParagraphRawText(last.Paragraph)) == "" && to-1 >= from {
+		scope.AppendAt = last.StartIndex
+		return scope, nil
+	}
+	scope.AppendAt = last.EndIndex - 1
+	scope.Leading = true
+	return scope, nil
+}
+
+func docsSyncBlockFromParagraph(p *docs.Paragraph, lists map[string]docs.List, start int64) docsSyncBlock {
+	var sb strings.Builder
+	var spans []TextStyle
+	for _, pe := range p.Elements {
+		if pe == nil {
+			continue
+		}
+		if pe.TextRun == nil {
+			sb.WriteString("\ufffc")
+			continue
+		}
+		sb.WriteString(pe.TextRun.Content)
+		st := pe.TextRun.TextStyle
+		if st == nil {
+			continue
+		}
+		span := TextStyle{
+			Bold:          st.Bold,
+			Italic:        st.Italic,
+			Strikethrough: st.Strikethrough,
+			Superscript:   st.BaselineOffset == "SUPERSCRIPT",
+			Code:          docsTextIsMonospace(st),
+			Start:         pe.StartIndex - start,
+			End:           pe.EndIndex - start,
+		}
+		if st.Link != nil {
+			span.Link = st.Link.Url
+		}
+		spans = append(spans, span)
+	}
+	text := strings.TrimSuffix(sb.String(), "\n")
+
+	block := docsSyncBlock{Text: text, Style: docsSyncNamedStyle(p), Spans: docsSyncNormalizeSpans(spans, utf16Len(text))}
+	if p.Bullet != nil {
+		block.List = "bullet"
+		if docsListOrdered(lists, p.Bullet.ListId, int(p.Bullet.NestingLevel)) {
+			block.List = "number"
+		}
+	} else if ps := p.ParagraphStyle; ps != nil && ps.IndentStart != nil && ps.IndentStart.Magnitude > 0 {
+		block.Quote = true
+	}
+	return block
+}
+
+func docsSyncNamedStyle(p *docs.Paragraph) string {
+	if p.ParagraphStyle == nil || p.ParagraphStyle.NamedStyleType == "" {
+		return "NORMAL_TEXT"
+	}
+	return p.ParagraphStyle.NamedStyleType
+}
+
+func docsSyncTableCells(t *docs.Table) [][]string {
+	rows := make([][]string, 0, len(t.TableRows))
+	for _, row := range t.TableRows {
+		if row == nil {
+			continue
+		}
+		cells := make([]string, 0, len(row.TableCells))
+		for _, cell := range row.TableCells {
+			var parts []string
+			if cell != nil {
+				for _, el := range cell.Content {
+					if el != nil && el.Paragraph != nil {
+						if text := strings.TrimSpace(docsParagraphRawText(el.Paragraph)); text != "" {
+							parts = append(parts, text)
+						}
+					}
+				}
+			}
+			cells = append(cells, strings.Join(parts, " "))
+		}
+		rows = append(rows, cells)
+	}
+	return rows
+}
+
+func docsSyncTableText(cells [][]string) string {
+	rows := make([]string, 0, len(cells))
+	for _, row := range cells {
+		rows = append(rows, strings.Join(row, "\t"))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// docsSyncBlocksFromMarkdown parses content with the same Markdown parser as
+// `docs update --format markdown`. Lists become native bullets; nesting is
+// flattened.
+func docsSyncBlocksFromMarkdown(content string) []docsSyncBlock {
+	var blocks []docsSyncBlock
+	add := func(styles []TextStyle, text, style, list string, quote bool) {
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		blocks = append(blocks, docsSyncBlock{
+			Text:  text,
+			Style: style,
+			List:  list,
+			Quote: quote,
+			Spans: docsSyncNormalizeSpans(styles, utf16Len(text)),
+		})
+	}
+
+	for _, el := range ParseMarkdown(content) {
+		styles, text := el.inline()
+		switch el.Type {
+		case MDHeading1, MDHeading2, MDHeading3, MDHeading4, MDHeading5, MDHeading6:
+			add(styles, text, getHeadingStyle(el.Type), "", el.Quote > 0)
+		case MDBlockquote:
+			add(styles, text, "NORMAL_TEXT", "", true)
+		case MDListItem, MDNumberedList, MDTaskList:
+			if el.Checked {
+				styles = append(styles, TextStyle{Strikethrough: true, Start: 0, End: utf16Len(text)})
+			}
+			add(styles, text, "NORMAL_TEXT", docsListKind(el.Type), false)
+		case MDHorizontalRule:
+			add(nil, strings.Repeat("-", 40), "NORMAL_TEXT", "", false)
+		case MDParagraph:
+			add(styles, text, "NORMAL_TEXT", "", el.Quote+el.Indent > 0)
+		case MDFootnote:
+			number := strconv.Itoa(el.Level)
+			shifted := []TextStyle{{Superscript: true, Start: 0, End: utf16Len(number)}}
+			for _, st := range styles {
+				st.Start += utf16Len(number + " ")
+				st.End += utf16Len(number + " ")
+				shifted = append(shifted, st)
+			}
+			add(shifted, number+" "+text, "NORMAL_TEXT", "", false)
+		case MDCodeBlock:
+			for _, line := range strings.Split(el.Content, "\n") {
+				if strings.TrimSpace(line) == "" {
+					continue
+				}
+				blocks = append(blocks, docsSyncBlock{
+					Text:  line,
+					Style: "NORMAL_TEXT",
+					Spans: []TextStyle{{Code: true, Start: 0, End: utf16Len(line)}},
+				})
+			}
+		case MDTable:
+			// Cells keep their Markdown for insertion; the comparison uses
+			// the plain text the document shows.
+			stripped := make([][]string, 0, len(el.TableCells))
+			for _, row := range el.TableCells {
+				cells := make([]string, 0, len(row))
+				for _, cell := range row {
+					_, text := ParseInlineFormatting(cell)
+					cells = append(cells, strings.TrimSpace(text))
+				}
+				stripped = append(stripped, cells)
+			}
+			blocks = append(blocks, docsSyncBlock{Table: true, Text: docsSyncTableText(stripped), Cells: el.TableCells})
+		}
+	}
+	return blocks
+}
+
+// docsSyncNormalizeSpans clips spans to the text, drops unstyled ones, and
+// merges neighbours with identical formatting so document runs and Markdown
+// spans compare equal.
+func docsSyncNormalizeSpans(spans []TextStyle, textLen int64) []TextStyle {
+	sorted := append([]TextStyle(nil), spans...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+
+	var out []TextStyle
+	for _, s := range sorted {
+		if s.End > textLen {
+			s.End = textLen
+		}
+		if s.Start < 0 || s.End <= s.Start || (!s.Bold && !s.Italic && !s.Strikethrough && !s.Superscript && !s.Code && s.Link == "") {
+			continue
+		}
+		if n := len(out); n > 0 {
+			prev := &out[n-1]
+			if prev.End == s.Start && sameTextStyle(*prev, s) {
+				prev.End = s.End
+				continue
+			}
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func (b docsSyncBlock) key() string {
+	if b.Table {
+		return "table\x00" + b.Text
+	}
+	return "text\x00" + b.Text
+}
+
+func (b docsSyncBlock) spanKey() string {
+	var sb strings.Builder
+	for _, s := range b.Spans {
+		fmt.Fprintf(&sb, "%d:%d:%t:%t:%t:%t:%t:%s;", s.Start, s.End, s.Bold, s.Italic, s.Strikethrough, s.Superscript, s.Code, s.Link)
+	}
+	return sb.String()
+}
+
+// docsSyncMatch returns the longest common subsequence of blocks with equal
+// text as index pairs, followed by a sentinel pair at the end of both lists.
+func docsSyncMatch(have, want []docsSyncBlock) [][2]int {
+	n, m := len(have), len(want)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case have[i].key() == want[j].key():
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var pairs [][2]int
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case have[i].key() == want[j].key():
+			pairs = append(pairs, [2]int{i, j})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return append(pairs, [2]int{n, m})
+}
+
+// planDocsSync builds batchUpdate requests that turn scope into want. Requests
+// are emitted in document order; shift tracks how earlier edits moved the
+// original indices.
+func planDocsSync(scope docsSyncScope, want []docsSyncBlock) *docsSyncPlan {
+	plan := &docsSyncPlan{}
+	have := scope.Blocks
+	hi, wi := 0, 0
+	for _, pair := range docsSyncMatch(have, want) {
+		deleted, inserted := have[hi:pair[0]], want[wi:pair[1]]
+
+		switch {
+		case len(deleted) > 0:
+			first, last := deleted[0], deleted[len(deleted)-1]
+			start, end := first.Start+plan.shift, last.End+plan.shift
+			if last.KeepNewline {
+				// The newline before a table or at the end of the body must
+				// stay; the emptied paragraph is reused or reset below.
+				end--
+			}
+			if end > start {
+				plan.delete(start, end)
+			}
+			plan.Deleted += len(deleted)
+			switch {
+			case !last.KeepNewline:
+				plan.insert(start, inserted, false, false)
+			case len(inserted) > 0:
+				plan.insert(start, inserted, false, true)
+			default:
+				plan.resetParagraph(start)
+			}
+		case pair[0] < len(have) && have[pair[0]].Table:
+			plan.insert(have[pair[0]].Start-1+plan.shift, inserted, true, false)
+		case pair[0] < len(have):
+			plan.insert(have[pair[0]].Start+plan.shift, inserted, false, false)
+		default:
+			plan.insert(scope.AppendAt+plan.shift, inserted, scope.Leading, false)
+		}
+
+		if pair[0] < len(have) {
+			plan.restyle(have[pair[0]], want[pair[1]])
+		}
+		hi, wi = pair[0]+1, pair[1]+1
+	}
+	return plan
+}
+
+func (p *docsSyncPlan) delete(start, end int64) {
+	p.Requests = append(p.Requests, &docs.Request{
+		DeleteContentRange: &docs.DeleteContentRangeRequest{
+			Range: &docs.Range{StartIndex: start, EndIndex: end},
+		},
+	})
+	p.shift -= end - start
+}
+
+// insert adds blocks as new paragraphs at index. leading inserts after the
+// newline at index (appending to a kept paragraph); fill reuses the emptied
+// paragraph at index for the last block.
+func (p *docsSyncPlan) insert(index int64, blocks []docsSyncBlock, leading, fill bool) {
+	if len(blocks) == 0 {
+		return
+	}
+	texts := make([]string, len(blocks))
+	for i, b := range blocks {
+		if !b.Table {
+			texts[i] = b.Text
+		}
+	}
+	text := strings.Join(texts, "\n")
+	start := index
+	switch {
+	case leading:
+		text = "\n" + text
+		start++
+	case !fill:
+		text += "\n"
+	}
+	p.Requests = append(p.Requests, &docs.Request{
+		InsertText: &docs.InsertTextRequest{Location: &docs.Location{Index: index}, Text: text},
+	})
+
+	bounds := make([]int64, len(blocks)+1)
+	bounds[0] = start
+	for i, t := range texts {
+		bounds[i+1] = bounds[i] + utf16Len(t) + 1
+	}
+	end := bounds[len(blocks)]
+
+	// Inserted text inherits the neighbouring paragraph's formatting, so set
+	// everything explicitly.
+	p.Requests = append(p.Requests,
+		&docs.Request{DeleteParagraphBullets: &docs.DeleteParagraphBulletsRequest{Range: &docs.Range{StartIndex: start, EndIndex: end}}},
+		&docs.Request{UpdateTextStyle: &docs.UpdateTextStyleRequest{
+			Range:     &docs.Range{StartIndex: start, EndIndex: end},
+			TextStyle: &docs.TextStyle{},
+			Fields:    docsSyncResetFields,
+		}},
+	)
+	for i, b := range blocks {
+		p.paragraphStyle(bounds[i], bounds[i+1], b)
+		for _, span := range b.Spans {
+			if req := buildTextStyleRequest(span, bounds[i]); req != nil {
+				p.Requests = append(p.Requests, req)
+			}
+		}
+		if b.Table {
+			p.Tables = append(p.Tables, TableData{StartIndex: bounds[i], Cells: b.Cells})
+		}
+	}
+	for i := 0; i < len(blocks); {
+		j := i + 1
+		for j < len(blocks) && blocks[j].List == blocks[i].List {
+			j++
+		}
+		if blocks[i].List != "" {
+			p.bullets(bounds[i], bounds[j], blocks[i].List)
+		}
+		i = j
+	}
+
+	p.Inserted += len(blocks)
+	p.shift += utf16Len(text)
+}
+
+// restyle updates a kept paragraph whose formatting differs from the Markdown.
+func (p *docsSyncPlan) restyle(have, want docsSyncBlock) {
+	if have.Table {
+		p.Unchanged++
+		return
+	}
+	start, end := have.Start+p.shift, have.End+p.shift
+	changed := false
+	listChanged := docsSyncListKind(have.List) != docsSyncListKind(want.List)
+	if listChanged {
+		if have.List != "" {
+			p.Requests = append(p.Requests, &docs.Request{DeleteParagraphBullets: &docs.DeleteParagraphBulletsRequest{
+				Range: &docs.Range{StartIndex: start, EndIndex: end},
+			}})
+		}
+		changed = true
+	}
+	if changed || have.Quote != want.Quote || docsMarkdownHeadingLevel(have.Style) != docsMarkdownHeadingLevel(want.Style) {
+		p.paragraphStyle(start, end, want)
+		changed = true
+	}
+	if listChanged && want.List != "" {
+		p.bullets(start, end, want.List)
+	}
+	if have.spanKey() != want.spanKey() {
+		if end-1 > start {
+			p.Requests = append(p.Requests, &docs.Request{UpdateTextStyle: &docs.UpdateTextStyleRequest{
+				Range:     &docs.Range{StartIndex: start, EndIndex: end - 1},
+				TextStyle: &docs.TextStyle{},
+				Fields:    docsSyncResetFields,
+			}})
+		}
+		for _, span := range want.Spans {
+			if req := buildTextStyleRequest(span, start); req != nil {
+				p.Requests = append(p.Requests, req)
+			}
+		}
+		changed = true
+	}
+	if changed {
+		p.Restyled++
+	} else {
+		p.Unchanged++
+	}
+}
+
+func (p *docsSyncPlan) paragraphStyle(start, end int64, b docsSyncBlock) {
+	style := &docs.ParagraphStyle{NamedStyleType: b.Style}
+	if style.NamedStyleType == "" {
+		style.NamedStyleType = "NORMAL_TEXT"
+	}
+	if b.Quote {
+		style.IndentStart = &docs.Dimension{Magnitude: 36, Unit: "PT"}
+	}
+	p.Requests = append(p.Requests, &docs.Request{UpdateParagraphStyle: &docs.UpdateParagraphStyleRequest{
+		Range:          &docs.Range{StartIndex: start, EndIndex: end},
+		ParagraphStyle: style,
+		Fields:         "namedStyleType,indentStart,indentFirstLine",
+	}})
+}
+
+func (p *docsSyncPlan) bullets(start, end int64, list string) {
+	p.Requests = append(p.Requests, &docs.Request{CreateParagraphBullets: &docs.CreateParagraphBulletsRequest{
+		Range:        &docs.Range{StartIndex: start, EndIndex: end},
+		BulletPreset: docsBulletPreset(list),
+	}})
+}
+
+// docsSyncListKind folds task lists into bullets: checkbox lists read back
+// from a document are indistinguishable from plain bulleted ones.
+func docsSyncListKind(list string) string {
+	if list == "task" {
+		return "bullet"
+	}
+	return list
+}
+
+// resetParagraph turns the emptied paragraph at index into plain text.
+func (p *docsSyncPlan) resetParagraph(index int64) {
+	p.Requests = append(p.Requests,
+		&docs.Request{DeleteParagraphBullets: &docs.DeleteParagraphBulletsRequest{Range: &docs.Range{StartIndex: index, EndIndex: index + 1}}},
+	)
+	p.paragraphStyle(index, index+1, docsSyncBlock{})
+}
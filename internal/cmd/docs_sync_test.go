@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+type syncTestPara struct {
+	style  string
+	text   string
+	bold   string // substring set in bold
+	listID string
+}
+
+// syncTestDoc lays out paragraphs with real indices after the leading
+// section break, the way the Docs API returns them.
+func syncTestDoc(paras ...syncTestPara) *docs.Document {
+	doc := &docs.Document{
+		DocumentId: "doc1",
+		RevisionId: "rev-7",
+		Body:       &docs.Body{Content: []*docs.StructuralElement{{EndIndex: 1, SectionBreak: &docs.SectionBreak{}}}},
+		Lists: map[string]docs.List{
+			"bul": {ListProperties: &docs.ListProperties{NestingLevels: []*docs.NestingLevel{{GlyphSymbol: "●"}}}},
+		},
+	}
+	idx := int64(1)
+	for _, p := range paras {
+		text := p.text + "\n"
+		end := idx + utf16Len(text)
+		var elements []*docs.ParagraphElement
+		before, after, found := strings.Cut(text, p.bold)
+		if p.bold != "" && found {
+			pos := idx
+			for _, part := range []struct {
+				s    string
+				bold bool
+			}{{before, false}, {p.bold, true}, {after, false}} {
+				if part.s == "" {
+					continue
+				}
+				n := utf16Len(part.s)
+				elements = append(elements, &docs.ParagraphElement{StartIndex: pos, EndIndex: pos + n, TextRun: &docs.TextRun{Content: part.s, TextStyle: &docs.TextStyle{Bold: part.bold}}})
+				pos += n
+			}
+		} else {
+			elements = []*docs.ParagraphElement{{StartIndex: idx, EndIndex: end, TextRun: &docs.TextRun{Content: text, TextStyle: &docs.TextStyle{}}}}
+		}
+		para := &docs.Paragraph{Elements: elements, ParagraphStyle: &docs.ParagraphStyle{NamedStyleType: p.style}}
+		if p.listID != "" {
+			para.Bullet = &docs.Bullet{ListId: p.listID}
+		}
+		doc.Body.Content = append(doc.Body.Content, &docs.StructuralElement{StartIndex: idx, EndIndex: end, Paragraph: para})
+		idx = end
+	}
+	return doc
+}
+
+func TestPlanDocsSync_PatchesOnlyChangedParagraphs(t *testing.T) {
+	doc := syncTestDoc(
+		syncTestPara{style: "HEADING_1", text: "Status"},                  // 1-8
+		syncTestPara{style: "NORMAL_TEXT", text: "All green."},            // 8-19
+		syncTestPara{style: "NORMAL_TEXT", text: "api ok", listID: "bul"}, // 19-26
+		syncTestPara{style: "NORMAL_TEXT", text: "db ok", listID: "bul"},  // 26-32
+		syncTestPara{style: "NORMAL_TEXT", text: ""},                      // 32-33
+	)
+	scope, err := docsSyncScopeFor(doc, "")
+	if err != nil {
+		t.Fatalf("scope: %v", err)
+	}
+
+	same := planDocsSync(scope, docsSyncBlocksFromMarkdown("# Status\n\nAll green.\n\n- api ok\n- db ok\n"))
+	if len(same.Requests) != 0 || same.Unchanged != 4 {
+		t.Fatalf("expected no-op plan, got %d requests (%+v)", len(same.Requests), same)
+	}
+
+	plan := planDocsSync(scope, docsSyncBlocksFromMarkdown("# Status\n\nAll **green**.\n\n- api ok\n- db degraded\n"))
+	if plan.Inserted != 1 || plan.Deleted != 1 || plan.Restyled != 1 || plan.Unchanged != 2 {
+		t.Fatalf("unexpected counts: %+v", plan)
+	}
+	var sawBold, sawDelete, sawInsert, sawBullets bool
+	for _, r := range plan.Requests {
+		switch {
+		case r.UpdateTextStyle != nil && r.UpdateTextStyle.TextStyle.Bold:
+			rg := r.UpdateTextStyle.Range
+			sawBold = rg.StartIndex == 12 && rg.EndIndex == 17
+		case r.DeleteContentRange != nil:
+			rg := r.DeleteContentRange.Range
+			sawDelete = rg.StartIndex == 26 && rg.EndIndex == 32
+		case r.InsertText != nil:
+			sawInsert = r.InsertText.Location.Index == 26 && r.InsertText.Text == "db degraded\n"
+		case r.CreateParagraphBullets != nil:
+			rg := r.CreateParagraphBullets.Range
+			sawBullets = rg.StartIndex == 26 && rg.EndIndex == 38 && r.CreateParagraphBullets.BulletPreset == "BULLET_DISC_CIRCLE_SQUARE"
+		}
+		if rg := docsSyncRequestRange(r); rg != nil && rg.StartIndex < 8 {
+			t.Fatalf("request touches unchanged heading: %#v", r)
+		}
+	}
+	if !sawBold || !sawDelete || !sawInsert || !sawBullets {
+		t.Fatalf("missing requests (bold=%v delete=%v insert=%v bullets=%v)", sawBold, sawDelete, sawInsert, sawBullets)
+	}
+}
+
+func TestPlanDocsSync_ReplacesLastBodyParagraph(t *testing.T) {
+	doc := syncTestDoc(
+		syncTestPara{style: "NORMAL_TEXT", text: "keep"}, // 1-6
+		syncTestPara{style: "NORMAL_TEXT", text: "old"},  // 6-10
+	)
+	scope, err := docsSyncScopeFor(doc, "")
+	if err != nil {
+		t.Fatalf("scope: %v", err)
+	}
+	plan := planDocsSync(scope, docsSyncBlocksFromMarkdown("keep\n\nnew line\n"))
+	if len(plan.Requests) < 2 {
+		t.Fatalf("expected delete+insert, got %#v", plan.Requests)
+	}
+	del, ins := plan.Requests[0].DeleteContentRange, plan.Requests[1].InsertText
+	if del == nil || del.Range.StartIndex != 6 || del.Range.EndIndex != 9 {
+		t.Fatalf("expected the final newline to be kept, got %#v", plan.Requests[0])
+	}
+	if ins == nil || ins.Location.Index != 6 || ins.Text != "new line" {
+		t.Fatalf("expected insert into emptied paragraph, got %#v", plan.Requests[1])
+	}
+
+	appended := planDocsSync(scope, docsSyncBlocksFromMarkdown("keep\n\nold\n\nmore\n"))
+	if len(appended.Requests) == 0 || appended.Requests[0].InsertText == nil ||
+		appended.Requests[0].InsertText.Location.Index != 9 || appended.Requests[0].InsertText.Text != "\nmore" {
+		t.Fatalf("expected append after last paragraph, got %#v", appended.Requests)
+	}
+}
+
+func TestDocsSync_SectionOnly(t *testing.T) {
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	doc := syncTestDoc(
+		syncTestPara{style: "TITLE", text: "Weekly"},           // 1-8
+		syncTestPara{style: "HEADING_2", text: "Status"},       // 8-15
+		syncTestPara{style: "NORMAL_TEXT", text: "Yellow"},     // 15-22
+		syncTestPara{style: "HEADING_2", text: "Notes"},        // 22-28
+		syncTestPara{style: "NORMAL_TEXT", text: "Hand notes"}, // 28-39
+	)
+	var got docs.BatchUpdateDocumentRequest
+	docSvc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/documents/"):
+			_ = json.NewEncoder(w).Encode(doc)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":batchUpdate"):
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Fatalf("decode batchUpdate: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"documentId": "doc1"})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docSvc, nil }
+
+	md := filepath.Join(t.TempDir(), "status.md")
+	if err := os.WriteFile(md, []byte("## Status\n\nGreen\n\nShipped 1.2\n"), 0o600); err != nil {
+		t.Fatalf("write md: %v", err)
+	}
+	flags := &RootFlags{Account: "a@b.com"}
+	if err := runKong(t, &DocsSyncCmd{}, []string{"doc1", md, "--section", "status"}, newDocsCmdContext(t), flags); err != nil {
+		t.Fatalf("docs sync: %v", err)
+	}
+
+	if got.WriteControl == nil || got.WriteControl.RequiredRevisionId != "rev-7" {
+		t.Fatalf("expected revision guard, got %#v", got.WriteControl)
+	}
+	if len(got.Requests) == 0 {
+		t.Fatal("expected requests")
+	}
+	for _, r := range got.Requests {
+		if rg := docsSyncRequestRange(r); rg != nil && (rg.StartIndex < 15 || rg.EndIndex > 34) {
+			t.Fatalf("request outside section: %#v", rg)
+		}
+	}
+	if del := got.Requests[0].DeleteContentRange; del == nil || del.Range.StartIndex != 15 || del.Range.EndIndex != 22 {
+		t.Fatalf("expected Yellow deleted, got %#v", got.Requests[0])
+	}
+	if ins := got.Requests[1].InsertText; ins == nil || ins.Location.Index != 15 || ins.Text != "Green\nShipped 1.2\n" {
+		t.Fatalf("unexpected insert: %#v", got.Requests[1])
+	}
+
+	err := runKong(t, &DocsSyncCmd{}, []string{"doc1", md, "--section", "Missing"}, newDocsCmdContext(t), flags)
+	if err == nil || ExitCode(err) != 2 {
+		t.Fatalf("expected usage error for missing section, got %v", err)
+	}
+}
+
+func docsSyncRequestRange(r *docs.Request) *docs.Range {
+	switch {
+	case r.DeleteContentRange != nil:
+		return r.DeleteContentRange.Range
+	case r.InsertText != nil:
+		return &docs.Range{StartIndex: r.InsertText.Location.Index, EndIndex: r.InsertText.Location.Index}
+	case r.UpdateTextStyle != nil:
+		return r.UpdateTextStyle.Range
+	case r.UpdateParagraphStyle != nil:
+		return r.UpdateParagraphStyle.Range
+	case r.CreateParagraphBullets != nil:
+		return r.CreateParagraphBullets.Range
+	case r.DeleteParagraphBullets != nil:
+		return r.DeleteParagraphBullets.Range
+	}
+	return nil
+}
@@ -20,6 +20,27 @@ func NewTableInserter(svc *docs.Service, docID string) *TableInserter {
 	}
 }
 
+// insertDocsTables inserts native tables at their placeholder indices in
+// ascending order, shifting later placeholders by the size of earlier tables.
+func insertDocsTables(ctx context.Context, svc *docs.Service, docID string, tables []TableData) error {
+	if len(tables) == 0 {
+		return nil
+	}
+	tableInserter := NewTableInserter(svc, docID)
+	tableOffset := int64(0)
+	for _, table := range tables {
+		tableIndex := table.StartIndex + tableOffset
+		tableEnd, err := tableInserter.InsertNativeTable(ctx, tableIndex, table.Cells)
+		if err != nil {
+			return fmt.Errorf("insert native table: %w", err)
+		}
+		if tableEnd > tableIndex {
+			tableOffset += (tableEnd - tableIndex) - 1
+		}
+	}
+	return nil
+}
+
 // InsertNativeTable inserts a native Google Docs table and populates it with content
 // Returns the end index of the table after insertion
 func (ti *TableInserter) InsertNativeTable(ctx context.Context, tableIndex int64, cells [][]string) (int64, error) {
@@ -63,7 +84,8 @@ func (ti *TableInserter) InsertNativeTable(ctx context.Context, tableIndex int64
 	// Step 4: Insert text into each cell
 	for rowIdx := 0; rowIdx < len(cells); rowIdx++ {
 		for colIdx := 0; colIdx < len(cells[rowIdx]); colIdx++ {
-			cellContent := cells[rowIdx][colIdx]
+			// Cells carry inline Markdown (bold, links, code, ...).
+			styles, cellContent := ParseInlineFormatting(cells[rowIdx][colIdx])
 			if cellContent == "" {
 				continue
 			}
@@ -104,6 +126,11 @@ func (ti *TableInserter) InsertNativeTable(ctx context.Context, tableIndex int64
 			if boldReq != nil {
 				requests = append(requests, boldReq)
 			}
+			for _, style := range styles {
+				if req := buildTextStyleRequest(style, cellIdx); req != nil {
+					requests = append(requests, req)
+				}
+			}
 
 			_, err := ti.svc.Documents.BatchUpdate(ti.docID, &docs.BatchUpdateDocumentRequest{
 				Requests: requests,
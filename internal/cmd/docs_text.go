@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+)
+
+// Plain-text views of Docs paragraphs shared by the render, ranges, sync,
+// export and suggestions commands.
+
+type docsTextParagraph struct {
+	text  string
+	start int64
+	end   int64
+}
+
+// offset converts a byte offset in text to a document index.
+func (p docsTextParagraph) offset(i int) int64 {
+	return p.start + utf16Len(p.text[:i])
+}
+
+// docsEachParagraph visits the body paragraphs, including those in
+// table cells, and with headers set also the header and footer paragraphs.
+// Non-text elements appear as U+FFFC so offsets line up with indices.
+func docsEachParagraph(doc *docs.Document, headers bool, fn func(docsTextParagraph)) {
+	var walk func([]*docs.StructuralElement)
+	walk = func(content []*docs.StructuralElement) {
+		for _, el := range content {
+			switch {
+			case el == nil:
+			case el.Paragraph != nil:
+				fn(docsTextParagraphFrom(el))
+			case el.Table != nil:
+				for _, row := range el.Table.TableRows {
+					if row == nil {
+						continue
+					}
+					for _, cell := range row.TableCells {
+						if cell != nil {
+							walk(cell.Content)
+						}
+					}
+				}
+			}
+		}
+	}
+	if doc.Body != nil {
+		walk(doc.Body.Content)
+	}
+	if headers {
+		for _, id := range sortedKeys(doc.Headers) {
+			walk(doc.Headers[id].Content)
+		}
+		for _, id := range sortedKeys(doc.Footers) {
+			walk(doc.Footers[id].Content)
+		}
+	}
+}
+
+func docsTextParagraphFrom(el *docs.StructuralElement) docsTextParagraph {
+	var sb strings.Builder
+	for _, pe := range el.Paragraph.Elements {
+		if pe == nil {
+			continue
+		}
+		if pe.TextRun != nil {
+			sb.WriteString(pe.TextRun.Content)
+		} else if n := pe.EndIndex - pe.StartIndex; n > 0 {
+			sb.WriteString(strings.Repeat("\ufffc", int(n)))
+		}
+	}
+	return docsTextParagraph{text: sb.String(), start: el.StartIndex, end: el.EndIndex}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func docsParagraphRawText(p *docs.Paragraph) string {
+	var sb strings.Builder
+	for _, pe := range p.Elements {
+		if pe != nil && pe.TextRun != nil {
+			sb.WriteString(strings.ReplaceAll(pe.TextRun.Content, "\v", "\n"))
+		}
+	}
+	return sb.String()
+}
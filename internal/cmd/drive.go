@@ -37,6 +37,8 @@ const (
 	driveMimeGoogleSheet   = "application/vnd.google-apps.spreadsheet"
 	driveMimeGoogleSlides  = "application/vnd.google-apps.presentation"
 	driveMimeGoogleDrawing = "application/vnd.google-apps.drawing"
+	driveMimeFolder        = "application/vnd.google-apps.folder"
+	driveMimeGooglePrefix  = "application/vnd.google-apps."
 	mimePDF                = "application/pdf"
 	mimeCSV                = "text/csv"
 	mimeDocx               = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
@@ -76,14 +78,23 @@ type DriveCmd struct {
 	Permissions DrivePermissionsCmd `cmd:"" name:"permissions" help:"List permissions on a file"`
 	URL         DriveURLCmd         `cmd:"" name:"url" help:"Print web URLs for files"`
 	Comments    DriveCommentsCmd    `cmd:"" name:"comments" help:"Manage comments on files"`
+	Revisions   DriveRevisionsCmd   `cmd:"" name:"revisions" help:"List, download, pin, and restore file revisions"`
 	Drives      DriveDrivesCmd      `cmd:"" name:"drives" help:"List shared drives (Team Drives)"`
+	Sync        DriveSyncCmd        `cmd:"" name:"sync" help:"Sync a local directory with a Drive folder (push, pull, or both)"`
+	Audit       DriveAuditCmd       `cmd:"" name:"audit" help:"Audit sharing across files and shared drives"`
+	Du          DriveDuCmd          `cmd:"" name:"du" aliases:"usage" help:"Storage usage by folder, owner, and MIME type"`
+	Dupes       DriveDupesCmd       `cmd:"" name:"dupes" aliases:"duplicates" help:"Find duplicate files by checksum (optionally trash extra copies)"`
+	Changes     DriveChangesCmd     `cmd:"" name:"changes" help:"Emit file changes since the last run (JSONL, uses stored page tokens)"`
+	Watch       DriveWatchCmd       `cmd:"" name:"watch" help:"Receive push notifications for Drive changes"`
+	Shell       DriveShellCmd       `cmd:"" name:"shell" help:"Interactive shell with cd/ls/get/put/mv/rm/share/open and tab completion"`
 }
 
 type DriveLsCmd struct {
+	Folder    string `arg:"" name:"folder" optional:"" help:"Folder ID or path (e.g. /Projects, shared:Team/Docs; default: root)"`
 	Max       int64  `name:"max" aliases:"limit" help:"Max results" default:"20"`
 	Page      string `name:"page" aliases:"cursor" help:"Page token"`
 	Query     string `name:"query" help:"Drive query filter"`
-	Parent    string `name:"parent" help:"Folder ID to list (default: root)"`
+	Parent    string `name:"parent" help:"Folder ID or path to list (default: root)"`
 	AllDrives bool   `name:"all-drives" help:"Include shared drives (default: true; use --no-all-drives for My Drive only)" default:"true" negatable:"_"`
 }
 
@@ -95,6 +106,12 @@ func (c *DriveLsCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 
 	folderID := strings.TrimSpace(c.Parent)
+	if arg := strings.TrimSpace(c.Folder); arg != "" {
+		if folderID != "" {
+			return usage("use either a folder argument or --parent, not both")
+		}
+		folderID = arg
+	}
 	if folderID == "" {
 		folderID = "root"
 	}
@@ -103,6 +120,10 @@ func (c *DriveLsCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	folderID, err = resolveDriveID(ctx, svc, folderID)
+	if err != nil {
+		return err
+	}
 
 	q := buildDriveListQuery(folderID, c.Query)
 
@@ -221,7 +242,7 @@ func (c *DriveSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveGetCmd struct {
-	FileID string `arg:"" name:"fileId" help:"File ID"`
+	FileID string `arg:"" name:"fileId" help:"File ID or path"`
 }
 
 func (c *DriveGetCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -239,6 +260,10 @@ func (c *DriveGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	fileID, err = resolveDriveID(ctx, svc, fileID)
+	if err != nil {
+		return err
+	}
 
 	f, err := svc.Files.Get(fileID).
 		SupportsAllDrives(true).
@@ -270,9 +295,12 @@ func (c *DriveGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveDownloadCmd struct {
-	FileID string         `arg:"" name:"fileId" help:"File ID"`
-	Output OutputPathFlag `embed:""`
-	Format string         `name:"format" help:"Export format for Google Docs files: pdf|csv|xlsx|pptx|txt|png|docx (default: inferred)"`
+	FileID    string         `arg:"" name:"fileId" help:"File ID or path"`
+	Output    OutputPathFlag `embed:""`
+	Format    string         `name:"format" help:"Export format for Google Docs files: pdf|csv|xlsx|pptx|txt|png|docx (default: inferred)"`
+	Recursive bool           `name:"recursive" short:"r" help:"Download a folder tree (--out is the destination directory)"`
+	Export    []string       `name:"export" help:"Per-type export format for --recursive: doc|sheet|slides|drawing=<format> (e.g. doc=docx,sheet=xlsx)"`
+	Parallel  int            `name:"parallel" help:"Concurrent transfers for --recursive" default:"4"`
 }
 
 func (c *DriveDownloadCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -294,10 +322,14 @@ func (c *DriveDownloadCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	fileID, err = resolveDriveID(ctx, svc, fileID)
+	if err != nil {
+		return err
+	}
 
 	meta, err := svc.Files.Get(fileID).
 		SupportsAllDrives(true).
-		Fields("id, name, mimeType").
+		Fields("id, name, mimeType, md5Checksum").
 		Context(ctx).
 		Do()
 	if err != nil {
@@ -306,6 +338,16 @@ func (c *DriveDownloadCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if meta.Name == "" {
 		return errors.New("file has no name")
 	}
+	media, err := newDriveTransferService(ctx, account)
+	if err != nil {
+		return err
+	}
+	if meta.MimeType == driveMimeFolder {
+		if !c.Recursive {
+			return usage("fileId is a folder (use --recursive)")
+		}
+		return c.runRecursive(ctx, u, svc, media, meta)
+	}
 	if fileFormatErr := validateDriveDownloadFormatForFile(meta, c.Format); fileFormatErr != nil {
 		return fileFormatErr
 	}
@@ -315,7 +357,7 @@ func (c *DriveDownloadCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
-	downloadedPath, size, err := downloadDriveFile(ctx, svc, meta, destPath, c.Format)
+	downloadedPath, size, err := downloadDriveFile(ctx, media, meta, destPath, c.Format)
 	if err != nil {
 		return err
 	}
@@ -333,9 +375,9 @@ func (c *DriveDownloadCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveCopyCmd struct {
-	FileID string `arg:"" name:"fileId" help:"File ID"`
+	FileID string `arg:"" name:"fileId" help:"File ID or path"`
 	Name   string `arg:"" name:"name" help:"New file name"`
-	Parent string `name:"parent" help:"Destination folder ID"`
+	Parent string `name:"parent" help:"Destination folder ID or path"`
 }
 
 func (c *DriveCopyCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -347,12 +389,17 @@ func (c *DriveCopyCmd) Run(ctx context.Context, flags *RootFlags) error {
 type DriveUploadCmd struct {
 	LocalPath           string `arg:"" name:"localPath" help:"Path to local file"`
 	Name                string `name:"name" help:"Override filename (create) or rename target (replace)"`
-	Parent              string `name:"parent" help:"Destination folder ID (create only)"`
-	ReplaceFileID       string `name:"replace" help:"Replace the content of an existing Drive file ID (preserves shared link/permissions)"`
+	Parent              string `name:"parent" help:"Destination folder ID or path (create only)"`
+	ReplaceFileID       string `name:"replace" help:"Replace the content of an existing Drive file ID or path (preserves shared link/permissions)"`
 	MimeType            string `name:"mime-type" help:"Override MIME type inference"`
 	KeepRevisionForever bool   `name:"keep-revision-forever" help:"Keep the new head revision forever (binary files only)"`
 	Convert             bool   `name:"convert" help:"Auto-convert to native Google format based on file extension (create only)"`
 	ConvertTo           string `name:"convert-to" help:"Convert to a specific Google format: doc|sheet|slides (create only)"`
+	Recursive           bool   `name:"recursive" short:"r" help:"Upload a directory as a new folder (--name renames the top folder)"`
+	Parallel            int    `name:"parallel" help:"Concurrent uploads for --recursive" default:"4"`
+	Resumable           bool   `name:"resumable" help:"Use a resumable upload session (automatic for files larger than --chunk-size)"`
+	Resume              bool   `name:"resume" help:"Continue an interrupted resumable upload of the same file"`
+	ChunkSize           int64  `name:"chunk-size" help:"Resumable upload chunk size in MiB" default:"16"`
 }
 
 func (c *DriveUploadCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -371,11 +418,35 @@ func (c *DriveUploadCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
+	if st, statErr := os.Stat(localPath); statErr == nil && st.IsDir() {
+		if !c.Recursive {
+			return usage("localPath is a directory (use --recursive)")
+		}
+		svc, svcErr := newDriveService(ctx, account)
+		if svcErr != nil {
+			return svcErr
+		}
+		media, svcErr := newDriveTransferService(ctx, account)
+		if svcErr != nil {
+			return svcErr
+		}
+		return c.runRecursive(ctx, u, svc, media, account, localPath)
+	}
+
 	f, err := os.Open(localPath) //nolint:gosec // user-provided path
 	if err != nil {
 		return err
 	}
 	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	chunkSize, err := driveChunkSize(c.ChunkSize)
+	if err != nil {
+		return err
+	}
+	resumable := c.Resume || c.Resumable || info.Size() > chunkSize
 
 	replaceFileID := strings.TrimSpace(c.ReplaceFileID)
 	parent := strings.TrimSpace(c.Parent)
@@ -409,6 +480,14 @@ func (c *DriveUploadCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	parent, err = resolveDriveID(ctx, svc, parent)
+	if err != nil {
+		return err
+	}
+	replaceFileID, err = resolveDriveID(ctx, svc, replaceFileID)
+	if err != nil {
+		return err
+	}
 
 	if replaceFileID == "" {
 		if fileName == "" {
@@ -426,16 +505,26 @@ func (c *DriveUploadCmd) Run(ctx context.Context, flags *RootFlags) error {
 			}
 		}
 
-		createCall := svc.Files.Create(meta).
-			SupportsAllDrives(true).
-			Media(f, gapi.ContentType(mimeType)).
-			Fields("id, name, mimeType, size, webViewLink").
-			Context(ctx)
-		if c.KeepRevisionForever {
-			createCall = createCall.KeepRevisionForever(true)
+		var (
+			created   *drive.File
+			createErr error
+		)
+		if resumable {
+			created, createErr = uploadDriveResumable(ctx, u, svc, driveUploadRequest{
+				Account: account, LocalPath: localPath, File: f, Info: info, Meta: meta,
+				MimeType: mimeType, ChunkSize: chunkSize, Resume: c.Resume, KeepForever: c.KeepRevisionForever,
+			})
+		} else {
+			createCall := svc.Files.Create(meta).
+				SupportsAllDrives(true).
+				Media(f, gapi.ContentType(mimeType)).
+				Fields(driveUploadFields).
+				Context(ctx)
+			if c.KeepRevisionForever {
+				createCall = createCall.KeepRevisionForever(true)
+			}
+			created, createErr = createCall.Do()
 		}
-
-		created, createErr := createCall.Do()
 		if createErr != nil {
 			return createErr
 		}
@@ -471,15 +560,23 @@ func (c *DriveUploadCmd) Run(ctx context.Context, flags *RootFlags) error {
 		meta.Name = fileName
 	}
 
-	call := svc.Files.Update(replaceFileID, meta).
-		SupportsAllDrives(true).
-		Media(f, gapi.ContentType(mimeType)).
-		Fields("id, name, mimeType, size, webViewLink").
-		Context(ctx)
-	if c.KeepRevisionForever {
-		call = call.KeepRevisionForever(true)
+	var updated *drive.File
+	if resumable {
+		updated, err = uploadDriveResumable(ctx, u, svc, driveUploadRequest{
+			Account: account, LocalPath: localPath, File: f, Info: info, Meta: meta, ReplaceID: replaceFileID,
+			MimeType: mimeType, ChunkSize: chunkSize, Resume: c.Resume, KeepForever: c.KeepRevisionForever,
+		})
+	} else {
+		call := svc.Files.Update(replaceFileID, meta).
+			SupportsAllDrives(true).
+			Media(f, gapi.ContentType(mimeType)).
+			Fields(driveUploadFields).
+			Context(ctx)
+		if c.KeepRevisionForever {
+			call = call.KeepRevisionForever(true)
+		}
+		updated, err = call.Do()
 	}
-	updated, err := call.Do()
 	if err != nil {
 		return err
 	}
@@ -503,7 +600,7 @@ func (c *DriveUploadCmd) Run(ctx context.Context, flags *RootFlags) error {
 
 type DriveMkdirCmd struct {
 	Name   string `arg:"" name:"name" help:"Folder name"`
-	Parent string `name:"parent" help:"Parent folder ID"`
+	Parent string `name:"parent" help:"Parent folder ID or path"`
 }
 
 func (c *DriveMkdirCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -523,19 +620,12 @@ func (c *DriveMkdirCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
-	f := &drive.File{
-		Name:     name,
-		MimeType: "application/vnd.google-apps.folder",
-	}
-	if strings.TrimSpace(c.Parent) != "" {
-		f.Parents = []string{strings.TrimSpace(c.Parent)}
+	parent, err := resolveDriveID(ctx, svc, c.Parent)
+	if err != nil {
+		return err
 	}
 
-	created, err := svc.Files.Create(f).
-		SupportsAllDrives(true).
-		Fields("id, name, webViewLink").
-		Context(ctx).
-		Do()
+	created, err := createDriveFolder(ctx, svc, name, parent, "id, name, webViewLink")
 	if err != nil {
 		return err
 	}
@@ -553,7 +643,7 @@ func (c *DriveMkdirCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveDeleteCmd struct {
-	FileID    string `arg:"" name:"fileId" help:"File ID"`
+	FileID    string `arg:"" name:"fileId" help:"File ID or path"`
 	Permanent bool   `name:"permanent" help:"Permanently delete instead of moving to trash" default:"false"`
 }
 
@@ -580,6 +670,10 @@ func (c *DriveDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	fileID, err = resolveDriveID(ctx, svc, fileID)
+	if err != nil {
+		return err
+	}
 
 	trashed := !c.Permanent
 	deleted := c.Permanent
@@ -605,9 +699,25 @@ func (c *DriveDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 	)
 }
 
+// createDriveFolder creates a folder under parentID (or My Drive root when empty).
+func createDriveFolder(ctx context.Context, svc *drive.Service, name, parentID, fields string) (*drive.File, error) {
+	f := &drive.File{
+		Name:     name,
+		MimeType: driveMimeFolder,
+	}
+	if parentID != "" {
+		f.Parents = []string{parentID}
+	}
+	return svc.Files.Create(f).
+		SupportsAllDrives(true).
+		Fields(gapi.Field(fields)).
+		Context(ctx).
+		Do()
+}
+
 type DriveMoveCmd struct {
-	FileID string `arg:"" name:"fileId" help:"File ID"`
-	Parent string `name:"parent" help:"New parent folder ID (required)"`
+	FileID string `arg:"" name:"fileId" help:"File ID or path"`
+	Parent string `name:"parent" help:"New parent folder ID or path (required)"`
 }
 
 func (c *DriveMoveCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -629,6 +739,14 @@ func (c *DriveMoveCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	fileID, err = resolveDriveID(ctx, svc, fileID)
+	if err != nil {
+		return err
+	}
+	parent, err = resolveDriveID(ctx, svc, parent)
+	if err != nil {
+		return err
+	}
 
 	meta, err := svc.Files.Get(fileID).
 		SupportsAllDrives(true).
@@ -662,7 +780,7 @@ func (c *DriveMoveCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveRenameCmd struct {
-	FileID  string `arg:"" name:"fileId" help:"File ID"`
+	FileID  string `arg:"" name:"fileId" help:"File ID or path"`
 	NewName string `arg:"" name:"newName" help:"New name"`
 }
 
@@ -685,6 +803,10 @@ func (c *DriveRenameCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	fileID, err = resolveDriveID(ctx, svc, fileID)
+	if err != nil {
+		return err
+	}
 
 	updated, err := svc.Files.Update(fileID, &drive.File{Name: newName}).
 		SupportsAllDrives(true).
@@ -705,7 +827,7 @@ func (c *DriveRenameCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveShareCmd struct {
-	FileID       string `arg:"" name:"fileId" help:"File ID"`
+	FileID       string `arg:"" name:"fileId" help:"File ID or path"`
 	To           string `name:"to" help:"Share target: anyone|user|domain"`
 	Anyone       bool   `name:"anyone" hidden:"" help:"(deprecated) Use --to=anyone"`
 	Email        string `name:"email" help:"User email (for --to=user)"`
@@ -784,6 +906,10 @@ func (c *DriveShareCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	fileID, err = resolveDriveID(ctx, svc, fileID)
+	if err != nil {
+		return err
+	}
 
 	perm := &drive.Permission{Role: role}
 	switch to {
@@ -828,7 +954,7 @@ func (c *DriveShareCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveUnshareCmd struct {
-	FileID       string `arg:"" name:"fileId" help:"File ID"`
+	FileID       string `arg:"" name:"fileId" help:"File ID or path"`
 	PermissionID string `arg:"" name:"permissionId" help:"Permission ID"`
 }
 
@@ -855,8 +981,12 @@ func (c *DriveUnshareCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	fileID, err = resolveDriveID(ctx, svc, fileID)
+	if err != nil {
+		return err
+	}
 
-	if err := svc.Permissions.Delete(fileID, permissionID).SupportsAllDrives(true).Context(ctx).Do(); err != nil {
+	if err := removeDrivePermission(ctx, svc, fileID, permissionID); err != nil {
 		return err
 	}
 
@@ -867,8 +997,12 @@ func (c *DriveUnshareCmd) Run(ctx context.Context, flags *RootFlags) error {
 	)
 }
 
+func removeDrivePermission(ctx context.Context, svc *drive.Service, fileID, permissionID string) error {
+	return svc.Permissions.Delete(fileID, permissionID).SupportsAllDrives(true).Context(ctx).Do()
+}
+
 type DrivePermissionsCmd struct {
-	FileID string `arg:"" name:"fileId" help:"File ID"`
+	FileID string `arg:"" name:"fileId" help:"File ID or path"`
 	Max    int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
 	Page   string `name:"page" aliases:"cursor" help:"Page token"`
 }
@@ -888,6 +1022,10 @@ func (c *DrivePermissionsCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	fileID, err = resolveDriveID(ctx, svc, fileID)
+	if err != nil {
+		return err
+	}
 
 	call := svc.Permissions.List(fileID).
 		SupportsAllDrives(true).
@@ -935,7 +1073,7 @@ func (c *DrivePermissionsCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveURLCmd struct {
-	FileIDs []string `arg:"" name:"fileId" help:"File IDs"`
+	FileIDs []string `arg:"" name:"fileId" help:"File IDs or paths"`
 }
 
 func (c *DriveURLCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -950,7 +1088,16 @@ func (c *DriveURLCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
-	for _, id := range c.FileIDs {
+	ids := make([]string, 0, len(c.FileIDs))
+	for _, raw := range c.FileIDs {
+		id, err := resolveDriveID(ctx, svc, raw)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+
+	for _, id := range ids {
 		link, err := driveWebLink(ctx, svc, id)
 		if err != nil {
 			return err
@@ -1040,7 +1187,7 @@ func escapeDriveQueryString(s string) string {
 }
 
 func driveType(mimeType string) string {
-	if mimeType == "application/vnd.google-apps.folder" {
+	if mimeType == driveMimeFolder {
 		return "folder"
 	}
 	return strFile
@@ -1127,29 +1274,26 @@ func downloadDriveFile(ctx context.Context, svc *drive.Service, meta *drive.File
 		return "", 0, fileFormatErr
 	}
 
-	var (
-		resp    *http.Response
-		outPath string
-		err     error
-	)
-
-	if isGoogleDoc {
-		var exportMimeType string
-		if format == "" {
-			exportMimeType = driveExportMimeType(meta.MimeType)
-		} else {
-			var mimeErr error
-			exportMimeType, mimeErr = driveExportMimeTypeForFormat(meta.MimeType, format)
-			if mimeErr != nil {
-				return "", 0, mimeErr
-			}
+	if !isGoogleDoc {
+		n, err := downloadDriveMedia(ctx, svc, meta, destPath)
+		if err != nil {
+			return "", 0, err
 		}
-		outPath = replaceExt(destPath, driveExportExtension(exportMimeType))
-		resp, err = driveExportDownload(ctx, svc, meta.Id, exportMimeType)
+		return destPath, n, nil
+	}
+
+	var exportMimeType string
+	if format == "" {
+		exportMimeType = driveExportMimeType(meta.MimeType)
 	} else {
-		outPath = destPath
-		resp, err = driveDownload(ctx, svc, meta.Id)
+		var mimeErr error
+		exportMimeType, mimeErr = driveExportMimeTypeForFormat(meta.MimeType, format)
+		if mimeErr != nil {
+			return "", 0, mimeErr
+		}
 	}
+	outPath := replaceExt(destPath, driveExportExtension(exportMimeType))
+	resp, err := driveExportDownload(ctx, svc, meta.Id, exportMimeType)
 	if err != nil {
 		return "", 0, err
 	}
@@ -0,0 +1,546 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	driveAuditIssueAnyone         = "anyone"
+	driveAuditIssueExternalDomain = "external-domain"
+	driveAuditIssueExternalUser   = "external-user"
+	driveAuditIssueExternalWriter = "external-writer"
+
+	driveAuditFixRemove    = "remove"
+	driveAuditFixDowngrade = "downgrade"
+
+	driveAuditFileFields       = "nextPageToken, files(id, name, mimeType, driveId, webViewLink)"
+	driveAuditPermissionFields = "nextPageToken, permissions(id, type, role, emailAddress, domain, allowFileDiscovery, permissionDetails(inherited))"
+	driveAuditParallel         = 8
+)
+
+type DriveAuditCmd struct {
+	Sharing DriveAuditSharingCmd `cmd:"" name:"sharing" help:"Report (and optionally fix) sharing outside your domain"`
+}
+
+type DriveAuditSharingCmd struct {
+	Folder           string   `name:"folder" help:"Audit everything below this folder (ID or path)"`
+	All              bool     `name:"all" help:"Audit all files you own plus every shared drive you can access"`
+	Domain           string   `name:"domain" help:"Comma-separated internal domains (default: the account's domain)"`
+	Issue            []string `name:"issue" help:"Only report these issues: anyone|external-domain|external-user|external-writer (can be repeated)" enum:"anyone,external-domain,external-user,external-writer"`
+	IncludeInherited bool     `name:"include-inherited" help:"Also report permissions inherited from a parent folder or shared drive"`
+	Format           string   `name:"format" help:"Text output format: table|csv (use --json for JSON)" enum:"table,csv" default:"table"`
+	Fix              bool     `name:"fix" help:"Remove or downgrade the flagged permissions (asks for confirmation)"`
+	FixAction        string   `name:"fix-action" help:"What --fix does: remove|downgrade (downgrade sets role to reader)" enum:"remove,downgrade" default:"remove"`
+}
+
+// driveAuditFile is a file (or folder) whose permissions will be inspected.
+type driveAuditFile struct {
+	ID       string
+	Name     string
+	Path     string
+	MimeType string
+	DriveID  string
+	Link     string
+}
+
+type driveAuditFinding struct {
+	FileID       string   `json:"fileId"`
+	Name         string   `json:"name"`
+	Path         string   `json:"path,omitempty"`
+	MimeType     string   `json:"mimeType,omitempty"`
+	DriveID      string   `json:"driveId,omitempty"`
+	Link         string   `json:"link,omitempty"`
+	PermissionID string   `json:"permissionId"`
+	Type         string   `json:"type"`
+	Role         string   `json:"role"`
+	Email        string   `json:"emailAddress,omitempty"`
+	Domain       string   `json:"domain,omitempty"`
+	Discoverable bool     `json:"discoverable,omitempty"`
+	Inherited    bool     `json:"inherited,omitempty"`
+	Issues       []string `json:"issues"`
+	Fix          string   `json:"fix,omitempty"`
+	FixError     string   `json:"fixError,omitempty"`
+}
+
+// driveAuditUnreadable is a file whose permissions could not be listed
+// (typically a 403 on files the caller can only view); the audit goes on.
+type driveAuditUnreadable struct {
+	FileID string `json:"fileId"`
+	Name   string `json:"name"`
+	Path   string `json:"path,omitempty"`
+	Error  string `json:"error"`
+}
+
+// classifyDrivePermission returns the audit issues for a single permission.
+func classifyDrivePermission(p *drive.Permission, internal map[string]bool) []string {
+	if p == nil {
+		return nil
+	}
+	var issues []string
+	switch p.Type {
+	case "anyone":
+		issues = append(issues, driveAuditIssueAnyone)
+	case "domain":
+		if !internal[strings.ToLower(p.Domain)] {
+			issues = append(issues, driveAuditIssueExternalDomain)
+		}
+	case "user", "group":
+		if d := emailDomain(p.EmailAddress); d != "" && !internal[d] {
+			issues = append(issues, driveAuditIssueExternalUser)
+			if driveRoleCanWrite(p.Role) {
+				issues = append(issues, driveAuditIssueExternalWriter)
+			}
+		}
+	}
+	return issues
+}
+
+func driveRoleCanWrite(role string) bool {
+	switch role {
+	case "writer", "fileOrganizer", "organizer", "owner":
+		return true
+	default:
+		return false
+	}
+}
+
+func drivePermissionInherited(p *drive.Permission) bool {
+	if len(p.PermissionDetails) == 0 {
+		return false
+	}
+	for _, d := range p.PermissionDetails {
+		if d != nil && !d.Inherited {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *DriveAuditSharingCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	folderID := strings.TrimSpace(c.Folder)
+	if (folderID == "") == !c.All {
+		return usage("specify exactly one of --folder or --all")
+	}
+
+	internal := reportInternalDomains(c.Domain)
+	if len(internal) == 0 {
+		if d := emailDomain(account); d != "" {
+			internal[d] = true
+		}
+	}
+	wanted := map[string]bool{}
+	for _, issue := range c.Issue {
+		wanted[issue] = true
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	folderID, err = resolveDriveID(ctx, svc, folderID)
+	if err != nil {
+		return err
+	}
+
+	var files []driveAuditFile
+	if folderID != "" {
+		files, err = listDriveAuditFolder(ctx, svc, folderID)
+	} else {
+		files, err = listDriveAuditAll(ctx, svc)
+	}
+	if err != nil {
+		return err
+	}
+	u.Err().Printf("scanning permissions on %d file(s)", len(files))
+
+	findings, unreadable, err := collectDriveAuditFindings(ctx, svc, files, internal, wanted, c.IncludeInherited)
+	if err != nil {
+		return err
+	}
+	for _, f := range unreadable {
+		u.Err().Printf("warning: cannot list permissions for %s: %s", orEmpty(f.Path, orEmpty(f.Name, f.FileID)), f.Error)
+	}
+
+	if c.Fix && len(findings) > 0 {
+		if err := c.fix(ctx, u, flags, svc, findings); err != nil {
+			return err
+		}
+	}
+
+	return writeDriveAuditReport(ctx, u, c.Format, len(files), findings, unreadable)
+}
+
+// listDriveAuditFolder returns the folder itself and everything below it.
+func listDriveAuditFolder(ctx context.Context, svc *drive.Service, folderID string) ([]driveAuditFile, error) {
+	root, err := svc.Files.Get(folderID).
+		SupportsAllDrives(true).
+		Fields("id, name, mimeType, driveId, webViewLink").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+	files := []driveAuditFile{{ID: root.Id, Name: root.Name, Path: "", MimeType: root.MimeType, DriveID: root.DriveId, Link: root.WebViewLink}}
+
+	type pending struct{ id, rel string }
+	queue := []pending{{id: root.Id}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		children, err := listDriveFolderChildren(ctx, svc, cur.id)
+		if err != nil {
+			return nil, fmt.Errorf("list folder %s: %w", orEmpty(cur.rel, "/"), err)
+		}
+		for _, child := range children {
+			if child == nil {
+				continue
+			}
+			rel := path.Join(cur.rel, child.Name)
+			files = append(files, driveAuditFile{ID: child.Id, Name: child.Name, Path: rel, MimeType: child.MimeType, DriveID: root.DriveId})
+			if child.MimeType == driveMimeFolder {
+				queue = append(queue, pending{id: child.Id, rel: rel})
+			}
+		}
+	}
+	return files, nil
+}
+
+// listDriveAuditAll returns every file the account owns plus the contents of
+// every shared drive it can see.
+func listDriveAuditAll(ctx context.Context, svc *drive.Service) ([]driveAuditFile, error) {
+	toAudit := func(fs []*drive.File) []driveAuditFile {
+		out := make([]driveAuditFile, 0, len(fs))
+		for _, f := range fs {
+			if f != nil {
+				out = append(out, driveAuditFile{ID: f.Id, Name: f.Name, MimeType: f.MimeType, DriveID: f.DriveId, Link: f.WebViewLink})
+			}
+		}
+		return out
+	}
+
+	owned, err := collectAllPages("", func(pageToken string) ([]*drive.File, string, error) {
+		call := svc.Files.List().
+			Q("'me' in owners and trashed = false").
+			PageSize(1000).
+			Fields(driveAuditFileFields).
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Files, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	files := toAudit(owned)
+
+	drives, err := collectAllPages("", func(pageToken string) ([]*drive.Drive, string, error) {
+		call := svc.Drives.List().PageSize(100).Fields("nextPageToken, drives(id, name)").Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Drives, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range drives {
+		if d == nil {
+			continue
+		}
+		items, err := collectAllPages("", func(pageToken string) ([]*drive.File, string, error) {
+			call := svc.Files.List().
+				Q("trashed = false").
+				Corpora("drive").
+				DriveId(d.Id).
+				SupportsAllDrives(true).
+				IncludeItemsFromAllDrives(true).
+				PageSize(1000).
+				Fields(driveAuditFileFields).
+				Context(ctx)
+			if pageToken != "" {
+				call = call.PageToken(pageToken)
+			}
+			resp, err := call.Do()
+			if err != nil {
+				return nil, "", err
+			}
+			return resp.Files, resp.NextPageToken, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list shared drive %s: %w", d.Name, err)
+		}
+		files = append(files, toAudit(items)...)
+	}
+	return files, nil
+}
+
+func listDrivePermissions(ctx context.Context, svc *drive.Service, fileID string) ([]*drive.Permission, error) {
+	return collectAllPages("", func(pageToken string) ([]*drive.Permission, string, error) {
+		call := svc.Permissions.List(fileID).
+			SupportsAllDrives(true).
+			PageSize(100).
+			Fields(driveAuditPermissionFields).
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Permissions, resp.NextPageToken, nil
+	})
+}
+
+func collectDriveAuditFindings(ctx context.Context, svc *drive.Service, files []driveAuditFile, internal, wanted map[string]bool, includeInherited bool) ([]driveAuditFinding, []driveAuditUnreadable, error) {
+	var (
+		mu         sync.Mutex
+		wg         sync.WaitGroup
+		sem        = make(chan struct{}, driveAuditParallel)
+		findings   []driveAuditFinding
+		unreadable []driveAuditUnreadable
+	)
+	for _, f := range files {
+		wg.Add(1)
+		go func(f driveAuditFile) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			perms, err := listDrivePermissions(ctx, svc, f.ID)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				unreadable = append(unreadable, driveAuditUnreadable{FileID: f.ID, Name: f.Name, Path: f.Path, Error: err.Error()})
+				return
+			}
+			for _, p := range perms {
+				issues := classifyDrivePermission(p, internal)
+				if len(wanted) > 0 {
+					kept := issues[:0]
+					for _, issue := range issues {
+						if wanted[issue] {
+							kept = append(kept, issue)
+						}
+					}
+					issues = kept
+				}
+				inherited := drivePermissionInherited(p)
+				if len(issues) == 0 || (inherited && !includeInherited) {
+					continue
+				}
+				findings = append(findings, driveAuditFinding{
+					FileID:       f.ID,
+					Name:         f.Name,
+					Path:         f.Path,
+					MimeType:     f.MimeType,
+					DriveID:      f.DriveID,
+					Link:         f.Link,
+					PermissionID: p.Id,
+					Type:         p.Type,
+					Role:         p.Role,
+					Email:        p.EmailAddress,
+					Domain:       p.Domain,
+					Discoverable: p.AllowFileDiscovery,
+					Inherited:    inherited,
+					Issues:       issues,
+				})
+			}
+		}(f)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(unreadable, func(i, j int) bool {
+		if unreadable[i].Path != unreadable[j].Path {
+			return unreadable[i].Path < unreadable[j].Path
+		}
+		return unreadable[i].FileID < unreadable[j].FileID
+	})
+	sort.Slice(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.FileID != b.FileID {
+			return a.FileID < b.FileID
+		}
+		return a.PermissionID < b.PermissionID
+	})
+	return findings, unreadable, nil
+}
+
+// driveAuditFixFor decides what --fix does with one finding; an empty action
+// means it is left alone (with the reason).
+func driveAuditFixFor(f driveAuditFinding, action string) (string, string) {
+	switch {
+	case f.Role == "owner":
+		return "", "owner permissions cannot be removed"
+	case f.Inherited:
+		return "", "inherited; fix it on the parent folder or shared drive"
+	case action == driveAuditFixDowngrade && (f.Role == "reader" || f.Role == "commenter"):
+		return "", "already read-only"
+	}
+	return action, ""
+}
+
+func (c *DriveAuditSharingCmd) fix(ctx context.Context, u *ui.UI, flags *RootFlags, svc *drive.Service, findings []driveAuditFinding) error {
+	type planned struct {
+		index  int
+		action string
+	}
+	var plan []planned
+	for i := range findings {
+		action, reason := driveAuditFixFor(findings[i], c.FixAction)
+		if action == "" {
+			findings[i].Fix = "skipped: " + reason
+			continue
+		}
+		plan = append(plan, planned{index: i, action: action})
+	}
+	if len(plan) == 0 {
+		u.Err().Println("Nothing to fix")
+		return nil
+	}
+
+	preview := make([]map[string]any, 0, len(plan))
+	for _, p := range plan {
+		f := findings[p.index]
+		who := orEmpty(f.Email, orEmpty(f.Domain, f.Type))
+		u.Err().Printf("%s %s (%s) on %s", p.action, who, f.Role, orEmpty(f.Path, f.Name))
+		preview = append(preview, map[string]any{"action": p.action, "fileId": f.FileID, "permissionId": f.PermissionID, "who": who, "role": f.Role})
+	}
+	if err := dryRunExit(ctx, flags, "drive.audit.sharing.fix", map[string]any{"fixes": preview}); err != nil {
+		return err
+	}
+	if err := confirmDestructive(ctx, flags, fmt.Sprintf("%s %d permission(s)", c.FixAction, len(plan))); err != nil {
+		return err
+	}
+
+	for _, p := range plan {
+		f := &findings[p.index]
+		var err error
+		if p.action == driveAuditFixDowngrade {
+			_, err = svc.Permissions.Update(f.FileID, f.PermissionID, &drive.Permission{Role: "reader"}).
+				SupportsAllDrives(true).
+				Context(ctx).
+				Do()
+		} else {
+			err = removeDrivePermission(ctx, svc, f.FileID, f.PermissionID)
+		}
+		if err != nil {
+			f.Fix, f.FixError = "failed", err.Error()
+			continue
+		}
+		if p.action == driveAuditFixDowngrade {
+			f.Fix = "downgraded"
+		} else {
+			f.Fix = "removed"
+		}
+	}
+	return nil
+}
+
+func writeDriveAuditReport(ctx context.Context, u *ui.UI, format string, scanned int, findings []driveAuditFinding, unreadable []driveAuditUnreadable) error {
+	counts := map[string]int{}
+	failed := 0
+	for _, f := range findings {
+		for _, issue := range f.Issues {
+			counts[issue]++
+		}
+		if f.FixError != "" {
+			failed++
+		}
+	}
+
+	switch {
+	case outfmt.IsJSON(ctx):
+		if findings == nil {
+			findings = []driveAuditFinding{}
+		}
+		payload := map[string]any{
+			"scanned":  scanned,
+			"findings": findings,
+			"counts":   counts,
+		}
+		if len(unreadable) > 0 {
+			payload["unreadable"] = unreadable
+		}
+		if err := outfmt.WriteJSON(ctx, os.Stdout, payload); err != nil {
+			return err
+		}
+	case format == "csv":
+		if err := writeDriveAuditCSV(findings); err != nil {
+			return err
+		}
+	default:
+		if len(findings) == 0 {
+			u.Err().Printf("No sharing issues found in %d file(s)", scanned-len(unreadable))
+			return nil
+		}
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "FILE\tTYPE\tROLE\tWHO\tISSUES\tFIX")
+		for _, f := range findings {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				sanitizeTab(orEmpty(f.Path, f.Name)), f.Type, f.Role,
+				sanitizeTab(orEmpty(f.Email, orEmpty(f.Domain, "-"))),
+				strings.Join(f.Issues, ","), orEmpty(f.Fix, "-"))
+		}
+		flush()
+		u.Err().Printf("%d finding(s) in %d file(s): %d anyone, %d external domain, %d external user, %d external writer",
+			len(findings), scanned, counts[driveAuditIssueAnyone], counts[driveAuditIssueExternalDomain],
+			counts[driveAuditIssueExternalUser], counts[driveAuditIssueExternalWriter])
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d fix(es) failed", failed)
+	}
+	return nil
+}
+
+func writeDriveAuditCSV(findings []driveAuditFinding) error {
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write([]string{"file_id", "name", "path", "mime_type", "drive_id", "permission_id", "type", "role", "email", "domain", "discoverable", "inherited", "issues", "fix"}); err != nil {
+		return err
+	}
+	for _, f := range findings {
+		if err := w.Write([]string{
+			f.FileID, f.Name, f.Path, f.MimeType, f.DriveID, f.PermissionID, f.Type, f.Role, f.Email, f.Domain,
+			strconv.FormatBool(f.Discoverable), strconv.FormatBool(f.Inherited), strings.Join(f.Issues, ";"), f.Fix,
+		}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
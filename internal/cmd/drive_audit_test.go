@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+)
+
+func TestClassifyDrivePermission(t *testing.T) {
+	internal := map[string]bool{"example.com": true}
+	cases := []struct {
+		perm *drive.Permission
+		want string
+	}{
+		{&drive.Permission{Type: "anyone", Role: "reader"}, "anyone"},
+		{&drive.Permission{Type: "domain", Domain: "Partner.io", Role: "reader"}, "external-domain"},
+		{&drive.Permission{Type: "domain", Domain: "example.com", Role: "reader"}, ""},
+		{&drive.Permission{Type: "user", EmailAddress: "x@partner.io", Role: "commenter"}, "external-user"},
+		{&drive.Permission{Type: "group", EmailAddress: "ops@partner.io", Role: "writer"}, "external-user,external-writer"},
+		{&drive.Permission{Type: "user", EmailAddress: "me@example.com", Role: "owner"}, ""},
+	}
+	for _, tc := range cases {
+		if got := strings.Join(classifyDrivePermission(tc.perm, internal), ","); got != tc.want {
+			t.Fatalf("classify %#v = %q, want %q", tc.perm, got, tc.want)
+		}
+	}
+}
+
+func TestDriveAuditFixFor(t *testing.T) {
+	if action, _ := driveAuditFixFor(driveAuditFinding{Role: "owner"}, driveAuditFixRemove); action != "" {
+		t.Fatalf("owner must not be fixed")
+	}
+	if action, _ := driveAuditFixFor(driveAuditFinding{Role: "writer", Inherited: true}, driveAuditFixRemove); action != "" {
+		t.Fatalf("inherited must not be fixed")
+	}
+	if action, _ := driveAuditFixFor(driveAuditFinding{Role: "reader"}, driveAuditFixDowngrade); action != "" {
+		t.Fatalf("reader cannot be downgraded")
+	}
+	if action, _ := driveAuditFixFor(driveAuditFinding{Role: "writer"}, driveAuditFixDowngrade); action != driveAuditFixDowngrade {
+		t.Fatalf("expected downgrade, got %q", action)
+	}
+}
+
+func TestDriveAuditSharing_FolderFixDowngrade(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		updates []string
+	)
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files/F1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "F1", "name": "Clients", "mimeType": driveMimeFolder})
+		case r.Method == http.MethodGet && r.URL.Path == "/files" && strings.Contains(q.Get("q"), "'F1' in parents"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{
+				{"id": "d1", "name": "Contract.pdf", "mimeType": "application/pdf"},
+			}})
+		case r.Method == http.MethodGet && r.URL.Path == "/files/F1/permissions":
+			_ = json.NewEncoder(w).Encode(map[string]any{"permissions": []map[string]any{
+				{"id": "p-owner", "type": "user", "role": "owner", "emailAddress": "me@example.com"},
+			}})
+		case r.Method == http.MethodGet && r.URL.Path == "/files/d1/permissions":
+			_ = json.NewEncoder(w).Encode(map[string]any{"permissions": []map[string]any{
+				{"id": "p-owner", "type": "user", "role": "owner", "emailAddress": "me@example.com"},
+				{"id": "p-link", "type": "anyone", "role": "reader"},
+				{"id": "p-ext", "type": "user", "role": "writer", "emailAddress": "vendor@partner.io"},
+				{"id": "p-inh", "type": "user", "role": "writer", "emailAddress": "old@partner.io", "permissionDetails": []map[string]any{{"inherited": true}}},
+			}})
+		case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/files/d1/permissions/"):
+			mu.Lock()
+			updates = append(updates, strings.TrimPrefix(r.URL.Path, "/files/d1/permissions/")+":"+readBody(t, r))
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "x", "role": "reader"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			http.NotFound(w, r)
+		}
+	}))
+	defer closeSrv()
+
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	var runErr error
+	out := captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			runErr = Execute([]string{"--json", "--force", "--account", "me@example.com", "drive", "audit", "sharing", "--folder", "F1", "--fix", "--fix-action", "downgrade"})
+		})
+	})
+	if runErr != nil {
+		t.Fatalf("Execute: %v", runErr)
+	}
+
+	var parsed struct {
+		Scanned  int                 `json:"scanned"`
+		Findings []driveAuditFinding `json:"findings"`
+		Counts   map[string]int      `json:"counts"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json parse: %v\nout=%q", err, out)
+	}
+	if parsed.Scanned != 2 || len(parsed.Findings) != 2 {
+		t.Fatalf("unexpected report: %#v", parsed)
+	}
+	if parsed.Counts[driveAuditIssueExternalWriter] != 1 || parsed.Counts[driveAuditIssueAnyone] != 1 {
+		t.Fatalf("unexpected counts: %v", parsed.Counts)
+	}
+	fixes := map[string]string{}
+	for _, f := range parsed.Findings {
+		fixes[f.PermissionID] = f.Fix
+	}
+	if fixes["p-ext"] != "downgraded" || !strings.HasPrefix(fixes["p-link"], "skipped") {
+		t.Fatalf("unexpected fixes: %v", fixes)
+	}
+	if len(updates) != 1 || !strings.HasPrefix(updates[0], "p-ext:") || !strings.Contains(updates[0], `"role":"reader"`) {
+		t.Fatalf("unexpected updates: %v", updates)
+	}
+}
+
+func TestDriveAuditSharing_UnreadableFileDoesNotAbort(t *testing.T) {
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files/F1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "F1", "name": "Shared", "mimeType": driveMimeFolder})
+		case r.Method == http.MethodGet && r.URL.Path == "/files" && strings.Contains(q.Get("q"), "'F1' in parents"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{
+				{"id": "d1", "name": "Open.pdf", "mimeType": "application/pdf"},
+				{"id": "d2", "name": "ViewOnly.pdf", "mimeType": "application/pdf"},
+			}})
+		case r.Method == http.MethodGet && r.URL.Path == "/files/F1/permissions":
+			_ = json.NewEncoder(w).Encode(map[string]any{"permissions": []map[string]any{}})
+		case r.Method == http.MethodGet && r.URL.Path == "/files/d1/permissions":
+			_ = json.NewEncoder(w).Encode(map[string]any{"permissions": []map[string]any{
+				{"id": "p-link", "type": "anyone", "role": "reader"},
+			}})
+		case r.Method == http.MethodGet && r.URL.Path == "/files/d2/permissions":
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 403, "message": "insufficientFilePermissions"}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			http.NotFound(w, r)
+		}
+	}))
+	defer closeSrv()
+
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	var runErr error
+	var stderr string
+	out := captureStdout(t, func() {
+		stderr = captureStderr(t, func() {
+			runErr = Execute([]string{"--json", "--account", "me@example.com", "drive", "audit", "sharing", "--folder", "F1"})
+		})
+	})
+	if runErr != nil {
+		t.Fatalf("Execute: %v", runErr)
+	}
+
+	var parsed struct {
+		Scanned    int                    `json:"scanned"`
+		Findings   []driveAuditFinding    `json:"findings"`
+		Unreadable []driveAuditUnreadable `json:"unreadable"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json parse: %v\nout=%q", err, out)
+	}
+	if parsed.Scanned != 3 || len(parsed.Findings) != 1 || parsed.Findings[0].FileID != "d1" {
+		t.Fatalf("unexpected report: %#v", parsed)
+	}
+	if len(parsed.Unreadable) != 1 || parsed.Unreadable[0].FileID != "d2" || !strings.Contains(parsed.Unreadable[0].Error, "403") {
+		t.Fatalf("unexpected unreadable files: %#v", parsed.Unreadable)
+	}
+	if !strings.Contains(stderr, "cannot list permissions for ViewOnly.pdf") {
+		t.Fatalf("expected a warning, got %q", stderr)
+	}
+}
+
+func TestDriveAuditSharing_RequiresScope(t *testing.T) {
+	err := Execute([]string{"--account", "me@example.com", "drive", "audit", "sharing"})
+	if err == nil || !strings.Contains(err.Error(), "--folder or --all") {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
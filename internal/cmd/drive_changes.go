@@ -0,0 +1,485 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	driveChangeAdded       = "added"
+	driveChangeModified    = "modified"
+	driveChangePermissions = "permissions"
+	driveChangeTrashed     = "trashed"
+	driveChangeRemoved     = "removed"
+
+	driveChangeFileFields = "id,name,mimeType,parents,trashed,createdTime,modifiedTime,size,md5Checksum,webViewLink,driveId,permissionIds,permissions(id,type,role,emailAddress,domain)"
+	driveChangeListFields = "nextPageToken,newStartPageToken,changes(changeType,time,removed,fileId,driveId,file(" + driveChangeFileFields + "))"
+
+	// Bound ancestor walks for --folder so cyclic or very deep trees stay cheap.
+	driveChangeMaxDepth = 64
+)
+
+// driveChangesSleep waits between --follow polls; tests replace it.
+var driveChangesSleep = func(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+type DriveChangesCmd struct {
+	Drive    string   `name:"drive" help:"Shared drive ID (default: your My Drive change feed)"`
+	Folder   string   `name:"folder" help:"Only emit changes inside this folder ID or path (any depth)"`
+	Mime     []string `name:"mime" help:"Only emit these MIME types (repeatable; 'image/*' or 'image/' matches a prefix)"`
+	Reset    bool     `name:"reset" help:"Discard the stored page token and record a new baseline"`
+	Follow   bool     `name:"follow" short:"f" help:"Keep polling and stream changes as they happen"`
+	Interval string   `name:"interval" help:"Polling interval for --follow (seconds or Go duration)" default:"30s"`
+}
+
+type driveChange struct {
+	Type               string      `json:"type"`
+	FileID             string      `json:"fileId"`
+	Name               string      `json:"name,omitempty"`
+	MimeType           string      `json:"mimeType,omitempty"`
+	DriveID            string      `json:"driveId,omitempty"`
+	Time               string      `json:"time,omitempty"`
+	ModifiedTime       string      `json:"modifiedTime,omitempty"`
+	Parents            []string    `json:"parents,omitempty"`
+	PermissionsChanged bool        `json:"permissionsChanged,omitempty"`
+	File               *drive.File `json:"file,omitempty"`
+}
+
+type driveChangesResult struct {
+	Changes  []driveChange
+	Baseline bool
+}
+
+// driveChangeFilter restricts emitted changes; the page token still advances
+// past filtered-out changes.
+type driveChangeFilter struct {
+	FolderID string
+	Mimes    []string
+}
+
+func (c *DriveChangesCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	interval, err := parseDurationSeconds(c.Interval)
+	if err != nil {
+		return usagef("invalid --interval: %v", err)
+	}
+	if c.Follow {
+		if interval <= 0 {
+			return usage("--interval must be > 0")
+		}
+		if outfmt.IsJSON(ctx) {
+			return usage("--follow streams JSONL; drop --json")
+		}
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	driveID := strings.TrimSpace(c.Drive)
+	filter, err := resolveDriveChangeFilter(ctx, svc, c.Folder, c.Mime)
+	if err != nil {
+		return err
+	}
+
+	store, err := openDriveWatchStore(account)
+	if err != nil {
+		return err
+	}
+	if c.Reset {
+		if err := store.Update(func(s *driveWatchState) error {
+			st := s.drive(driveID)
+			st.PageToken = ""
+			st.Files = nil
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	result, err := collectDriveChanges(ctx, svc, store, driveID, filter)
+	if err != nil {
+		return err
+	}
+
+	if !c.Follow && outfmt.IsJSON(ctx) {
+		changes := result.Changes
+		if changes == nil {
+			changes = []driveChange{}
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"driveId":  driveWatchKey(driveID),
+			"baseline": result.Baseline,
+			"changes":  changes,
+		})
+	}
+
+	// Default output is JSONL: one change per line, suitable for piping.
+	enc := json.NewEncoder(os.Stdout)
+	if err := writeDriveChanges(enc, result.Changes); err != nil {
+		return err
+	}
+	switch {
+	case result.Baseline:
+		u.Err().Printf("Recorded start page token for %s", driveWatchKey(driveID))
+	case len(result.Changes) == 0 && !c.Follow:
+		u.Err().Println("No changes")
+	}
+	if !c.Follow {
+		return nil
+	}
+
+	for {
+		if err := driveChangesSleep(ctx, interval); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return err
+		}
+		result, err := collectDriveChanges(ctx, svc, store, driveID, filter)
+		if err != nil {
+			// Transient API failures should not end a long-running follow.
+			u.Err().Printf("changes: poll failed: %v", err)
+			continue
+		}
+		if err := writeDriveChanges(enc, result.Changes); err != nil {
+			return err
+		}
+	}
+}
+
+func writeDriveChanges(enc *json.Encoder, changes []driveChange) error {
+	for _, ch := range changes {
+		if err := enc.Encode(ch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resolveDriveChangeFilter(ctx context.Context, svc *drive.Service, folder string, mimes []string) (driveChangeFilter, error) {
+	filter := driveChangeFilter{}
+	for _, m := range mimes {
+		for _, part := range splitCSV(m) {
+			filter.Mimes = append(filter.Mimes, strings.ToLower(part))
+		}
+	}
+	folder = strings.TrimSpace(folder)
+	if folder == "" {
+		return filter, nil
+	}
+	folder, err := resolveDriveID(ctx, svc, folder)
+	if err != nil {
+		return filter, err
+	}
+	// Resolve aliases like "root" to the real ID so ancestor walks match.
+	f, err := svc.Files.Get(folder).SupportsAllDrives(true).Fields("id,mimeType").Context(ctx).Do()
+	if err != nil {
+		return filter, err
+	}
+	if f.MimeType != driveMimeFolder {
+		return filter, usagef("--folder %s is not a folder", folder)
+	}
+	filter.FolderID = f.Id
+	return filter, nil
+}
+
+func (f driveChangeFilter) matchesMime(mimeType string) bool {
+	if len(f.Mimes) == 0 {
+		return true
+	}
+	mimeType = strings.ToLower(mimeType)
+	for _, want := range f.Mimes {
+		prefix := strings.TrimSuffix(want, "*")
+		if strings.HasSuffix(prefix, "/") {
+			if strings.HasPrefix(mimeType, prefix) {
+				return true
+			}
+			continue
+		}
+		if mimeType == want {
+			return true
+		}
+	}
+	return false
+}
+
+// driveAncestry answers "is this file under folder X" with cached parent
+// lookups, so a batch of changes in one folder costs a single walk.
+type driveAncestry struct {
+	svc     *drive.Service
+	parents map[string][]string
+}
+
+func newDriveAncestry(svc *drive.Service) *driveAncestry {
+	return &driveAncestry{svc: svc, parents: map[string][]string{}}
+}
+
+func (a *driveAncestry) within(ctx context.Context, parents []string, folderID string) (bool, error) {
+	seen := map[string]bool{}
+	queue := append([]string(nil), parents...)
+	for depth := 0; len(queue) > 0 && depth < driveChangeMaxDepth; depth++ {
+		next := []string{}
+		for _, id := range queue {
+			if id == folderID {
+				return true, nil
+			}
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			up, err := a.lookup(ctx, id)
+			if err != nil {
+				return false, err
+			}
+			next = append(next, up...)
+		}
+		queue = next
+	}
+	return false, nil
+}
+
+func (a *driveAncestry) lookup(ctx context.Context, id string) ([]string, error) {
+	if up, ok := a.parents[id]; ok {
+		return up, nil
+	}
+	f, err := a.svc.Files.Get(id).SupportsAllDrives(true).Fields("id,parents").Context(ctx).Do()
+	if err != nil {
+		if isNotFoundAPIError(err) {
+			a.parents[id] = nil
+			return nil, nil
+		}
+		return nil, err
+	}
+	a.parents[id] = f.Parents
+	return f.Parents, nil
+}
+
+// collectDriveChanges fetches changes since the stored page token and
+// persists the new token. Without a stored token it only records a baseline:
+// the Drive changes feed cannot replay history.
+func collectDriveChanges(ctx context.Context, svc *drive.Service, store *driveWatchStore, driveID string, filter driveChangeFilter) (driveChangesResult, error) {
+	prev := store.DriveState(driveID)
+	if prev.PageToken == "" {
+		token, err := driveStartPageToken(ctx, svc, driveID)
+		if err != nil {
+			return driveChangesResult{}, err
+		}
+		if err := store.Update(func(s *driveWatchState) error {
+			st := s.drive(driveID)
+			st.PageToken = token
+			st.UpdatedAtMs = time.Now().UnixMilli()
+			return nil
+		}); err != nil {
+			return driveChangesResult{}, err
+		}
+		return driveChangesResult{Baseline: true}, nil
+	}
+
+	raw, nextToken, err := fetchDriveChanges(ctx, svc, driveID, prev.PageToken)
+	if err != nil {
+		return driveChangesResult{}, err
+	}
+
+	var since time.Time
+	if prev.UpdatedAtMs > 0 {
+		since = time.UnixMilli(prev.UpdatedAtMs)
+	}
+	ancestry := newDriveAncestry(svc)
+	seen := map[string]*driveWatchFile{}
+	removed := map[string]bool{}
+	changes := make([]driveChange, 0, len(raw))
+	for _, ch := range raw {
+		if ch == nil || (ch.ChangeType != "" && ch.ChangeType != "file") {
+			continue
+		}
+		known := seen[ch.FileId]
+		if known == nil {
+			known = prev.Files[ch.FileId]
+		}
+		change := classifyDriveChange(ch, known, since)
+
+		parents := change.Parents
+		if ch.File != nil {
+			seen[ch.FileId] = driveFileFingerprint(ch.File)
+			delete(removed, ch.FileId)
+		} else {
+			removed[ch.FileId] = true
+			if known != nil {
+				parents = known.Parents
+			}
+		}
+
+		if ch.File != nil && !filter.matchesMime(ch.File.MimeType) {
+			continue
+		}
+		if ch.File == nil && len(filter.Mimes) > 0 {
+			// The file's type is unknown once it is gone.
+			continue
+		}
+		if filter.FolderID != "" {
+			ok, err := ancestry.within(ctx, parents, filter.FolderID)
+			if err != nil {
+				return driveChangesResult{}, err
+			}
+			if !ok {
+				continue
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	if err := store.Update(func(s *driveWatchState) error {
+		now := time.Now()
+		st := s.drive(driveID)
+		st.PageToken = nextToken
+		st.UpdatedAtMs = now.UnixMilli()
+		if len(seen) > 0 && st.Files == nil {
+			st.Files = map[string]*driveWatchFile{}
+		}
+		for id, fp := range seen {
+			fp.SeenAtMs = now.UnixMilli()
+			st.Files[id] = fp
+		}
+		for id := range removed {
+			delete(st.Files, id)
+		}
+		st.pruneFiles(now)
+		return nil
+	}); err != nil {
+		return driveChangesResult{}, err
+	}
+	return driveChangesResult{Changes: changes}, nil
+}
+
+func driveStartPageToken(ctx context.Context, svc *drive.Service, driveID string) (string, error) {
+	call := svc.Changes.GetStartPageToken().SupportsAllDrives(true).Context(ctx)
+	if driveID != "" {
+		call = call.DriveId(driveID)
+	}
+	resp, err := call.Do()
+	if err != nil {
+		return "", err
+	}
+	return resp.StartPageToken, nil
+}
+
+func fetchDriveChanges(ctx context.Context, svc *drive.Service, driveID, pageToken string) ([]*drive.Change, string, error) {
+	var out []*drive.Change
+	token := pageToken
+	for {
+		call := svc.Changes.List(token).
+			IncludeRemoved(true).
+			SupportsAllDrives(true).
+			PageSize(1000).
+			Fields(driveChangeListFields).
+			Context(ctx)
+		if driveID != "" {
+			call = call.DriveId(driveID).IncludeItemsFromAllDrives(true)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		out = append(out, resp.Changes...)
+		if resp.NewStartPageToken != "" {
+			return out, resp.NewStartPageToken, nil
+		}
+		if resp.NextPageToken == "" {
+			return nil, "", errors.New("changes list ended without a new start page token")
+		}
+		token = resp.NextPageToken
+	}
+}
+
+// classifyDriveChange maps a raw change to added/modified/permissions/
+// trashed/removed. The API does not say what changed, so the previous
+// fingerprint (when the file was seen before) and the last poll time fill in.
+func classifyDriveChange(ch *drive.Change, prev *driveWatchFile, since time.Time) driveChange {
+	out := driveChange{
+		FileID:  ch.FileId,
+		DriveID: ch.DriveId,
+		Time:    ch.Time,
+	}
+	f := ch.File
+	if ch.Removed || f == nil {
+		out.Type = driveChangeRemoved
+		return out
+	}
+	out.Name = f.Name
+	out.MimeType = f.MimeType
+	out.ModifiedTime = f.ModifiedTime
+	out.Parents = f.Parents
+	out.File = f
+
+	fp := driveFileFingerprint(f)
+	if prev != nil {
+		out.PermissionsChanged = fp.Permissions != prev.Permissions
+	}
+	switch {
+	case f.Trashed:
+		out.Type = driveChangeTrashed
+	case prev == nil && driveFileCreatedSince(f, since):
+		out.Type = driveChangeAdded
+	case prev != nil && out.PermissionsChanged && fp.ModifiedTime == prev.ModifiedTime && !prev.Trashed:
+		out.Type = driveChangePermissions
+	default:
+		out.Type = driveChangeModified
+	}
+	return out
+}
+
+func driveFileCreatedSince(f *drive.File, since time.Time) bool {
+	created, err := time.Parse(time.RFC3339, f.CreatedTime)
+	if err != nil {
+		return false
+	}
+	if !since.IsZero() && !created.Before(since) {
+		return true
+	}
+	modified, err := time.Parse(time.RFC3339, f.ModifiedTime)
+	return err == nil && modified.Sub(created) < time.Second
+}
+
+func driveFileFingerprint(f *drive.File) *driveWatchFile {
+	perms := make([]string, 0, len(f.Permissions))
+	for _, p := range f.Permissions {
+		if p == nil {
+			continue
+		}
+		perms = append(perms, strings.Join([]string{p.Type, p.Role, strings.ToLower(p.EmailAddress), strings.ToLower(p.Domain)}, ":"))
+	}
+	// Shared-drive items only expose permission IDs to most callers.
+	if len(perms) == 0 {
+		perms = append(perms, f.PermissionIds...)
+	}
+	sort.Strings(perms)
+	return &driveWatchFile{
+		ModifiedTime: f.ModifiedTime,
+		Permissions:  strings.Join(perms, ","),
+		Parents:      f.Parents,
+		Trashed:      f.Trashed,
+	}
+}
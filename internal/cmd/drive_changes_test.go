@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+)
+
+func TestClassifyDriveChange(t *testing.T) {
+	since := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	file := func(created, modified string, perms ...string) *drive.File {
+		f := &drive.File{Id: "f1", CreatedTime: created, ModifiedTime: modified}
+		for _, p := range perms {
+			f.Permissions = append(f.Permissions, &drive.Permission{Type: "user", Role: p, EmailAddress: p + "@example.com"})
+		}
+		return f
+	}
+	prev := driveFileFingerprint(file("2025-01-01T00:00:00Z", "2025-01-01T10:00:00Z", "owner"))
+
+	cases := []struct {
+		name string
+		ch   *drive.Change
+		prev *driveWatchFile
+		want string
+	}{
+		{"removed", &drive.Change{FileId: "f1", Removed: true}, prev, driveChangeRemoved},
+		{"trashed", &drive.Change{FileId: "f1", File: &drive.File{Trashed: true}}, prev, driveChangeTrashed},
+		{"added", &drive.Change{FileId: "f1", File: file("2025-01-03T00:00:00Z", "2025-01-03T00:05:00Z")}, nil, driveChangeAdded},
+		{"unknown old file", &drive.Change{FileId: "f1", File: file("2024-06-01T00:00:00Z", "2025-01-03T00:00:00Z")}, nil, driveChangeModified},
+		{"sharing", &drive.Change{FileId: "f1", File: file("2025-01-01T00:00:00Z", "2025-01-01T10:00:00Z", "owner", "writer")}, prev, driveChangePermissions},
+		{"edited", &drive.Change{FileId: "f1", File: file("2025-01-01T00:00:00Z", "2025-01-03T00:00:00Z", "owner")}, prev, driveChangeModified},
+	}
+	for _, tc := range cases {
+		if got := classifyDriveChange(tc.ch, tc.prev, since); got.Type != tc.want {
+			t.Fatalf("%s: got %q, want %q", tc.name, got.Type, tc.want)
+		}
+	}
+}
+
+func TestDriveChangeFilterMatchesMime(t *testing.T) {
+	f := driveChangeFilter{Mimes: []string{"image/*", "application/pdf"}}
+	for mime, want := range map[string]bool{"image/png": true, "application/pdf": true, "text/plain": false} {
+		if got := f.matchesMime(mime); got != want {
+			t.Fatalf("matchesMime(%q) = %v, want %v", mime, got, want)
+		}
+	}
+}
+
+func TestDriveChangesCmd_FollowFiltersFolder(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+
+	polls := 0
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query()
+		switch {
+		case r.URL.Path == "/files/F1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "F1", "mimeType": driveMimeFolder, "parents": []string{"root"}})
+		case r.URL.Path == "/files/sub":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "sub", "parents": []string{"F1"}})
+		case r.URL.Path == "/files/elsewhere":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "elsewhere"})
+		case r.URL.Path == "/changes/startPageToken":
+			_ = json.NewEncoder(w).Encode(map[string]any{"startPageToken": "t1"})
+		case r.URL.Path == "/changes" && q.Get("pageToken") == "t1":
+			polls++
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"newStartPageToken": "t2",
+				"changes": []map[string]any{
+					{"changeType": "file", "fileId": "a", "file": map[string]any{"id": "a", "name": "a.pdf", "mimeType": "application/pdf", "parents": []string{"sub"}, "createdTime": "2025-01-01T00:00:00Z", "modifiedTime": "2025-01-05T00:00:00Z"}},
+					{"changeType": "file", "fileId": "b", "file": map[string]any{"id": "b", "name": "b.pdf", "mimeType": "application/pdf", "parents": []string{"elsewhere"}}},
+				},
+			})
+		case r.URL.Path == "/changes" && q.Get("pageToken") == "t2":
+			polls++
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"newStartPageToken": "t3",
+				"changes":           []map[string]any{{"changeType": "file", "fileId": "a", "removed": true}},
+			})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			http.NotFound(w, r)
+		}
+	}))
+	defer closeSrv()
+
+	origNew, origSleep := newDriveService, driveChangesSleep
+	t.Cleanup(func() { newDriveService, driveChangesSleep = origNew, origSleep })
+	newDriveService = stubDriveService(svc)
+	sleeps := 0
+	driveChangesSleep = func(context.Context, time.Duration) error {
+		sleeps++
+		if sleeps > 1 {
+			return context.Canceled
+		}
+		return nil
+	}
+
+	run := func(args ...string) string {
+		var runErr error
+		out := captureStdout(t, func() {
+			_ = captureStderr(t, func() {
+				runErr = Execute(append([]string{"--account", "a@example.com", "drive", "changes", "--folder", "F1"}, args...))
+			})
+		})
+		if runErr != nil {
+			t.Fatalf("Execute: %v", runErr)
+		}
+		return out
+	}
+
+	if out := run(); out != "" {
+		t.Fatalf("baseline run should not emit changes, got %q", out)
+	}
+	out := run("--follow", "--interval", "1s")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || polls != 2 {
+		t.Fatalf("unexpected follow output (polls=%d): %q", polls, out)
+	}
+	var first, second driveChange
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if first.FileID != "a" || first.Type != driveChangeModified || second.FileID != "a" || second.Type != driveChangeRemoved {
+		t.Fatalf("unexpected changes: %#v %#v", first, second)
+	}
+
+	store, err := openDriveWatchStore("a@example.com")
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	st := store.DriveState("")
+	if st.PageToken != "t3" || len(st.Files) != 1 || st.Files["b"] == nil {
+		t.Fatalf("unexpected stored state: %#v", st)
+	}
+}
@@ -12,8 +12,21 @@ import (
 	"github.com/steipete/gogcli/internal/ui"
 )
 
-// DriveDrivesCmd lists all shared drives the user has access to.
+// DriveDrivesCmd is the parent command for shared drive subcommands; bare
+// `drive drives` lists shared drives.
 type DriveDrivesCmd struct {
+	List    DriveDrivesListCmd    `cmd:"" default:"withargs" aliases:"ls" help:"List shared drives"`
+	Get     DriveDrivesGetCmd     `cmd:"" name:"get" aliases:"info,show" help:"Get a shared drive, including restrictions"`
+	Create  DriveDrivesCreateCmd  `cmd:"" name:"create" aliases:"new" help:"Create a shared drive"`
+	Update  DriveDrivesUpdateCmd  `cmd:"" name:"update" aliases:"edit,set" help:"Rename a shared drive or change its restrictions"`
+	Delete  DriveDrivesDeleteCmd  `cmd:"" name:"delete" aliases:"rm,del" help:"Delete a shared drive"`
+	Hide    DriveDrivesHideCmd    `cmd:"" name:"hide" help:"Hide a shared drive from the default view"`
+	Unhide  DriveDrivesUnhideCmd  `cmd:"" name:"unhide" help:"Restore a hidden shared drive to the default view"`
+	Members DriveDrivesMembersCmd `cmd:"" name:"members" help:"Manage shared drive members"`
+}
+
+// DriveDrivesListCmd lists all shared drives the user has access to.
+type DriveDrivesListCmd struct {
 	Max       int64  `name:"max" aliases:"limit" help:"Max results (max allowed: 100)" default:"100"`
 	Page      string `name:"page" aliases:"cursor" help:"Page token"`
 	All       bool   `name:"all" aliases:"all-pages,allpages" help:"Fetch all pages"`
@@ -21,7 +34,7 @@ type DriveDrivesCmd struct {
 	Query     string `name:"query" short:"q" help:"Search query for filtering shared drives"`
 }
 
-func (c *DriveDrivesCmd) Run(ctx context.Context, flags *RootFlags) error {
+func (c *DriveDrivesListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	u := ui.FromContext(ctx)
 	account, err := requireAccount(flags)
 	if err != nil {
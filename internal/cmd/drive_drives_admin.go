@@ -0,0 +1,540 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	driveSharedDriveFields = "id, name, createdTime, hidden, colorRgb, themeId, restrictions, capabilities(canManageMembers, canDeleteDrive, canRename)"
+	driveMemberFields      = "id, type, role, emailAddress, domain, displayName"
+)
+
+// driveDomainAdminFlag toggles useDomainAdminAccess. When unset it is enabled
+// automatically for accounts that authenticate with a service account, since
+// those act as a Workspace admin rather than as a drive member.
+type driveDomainAdminFlag struct {
+	DomainAdmin *bool `name:"domain-admin" help:"Act as a Workspace admin (useDomainAdminAccess; default: on when using a service account)"`
+}
+
+func (f driveDomainAdminFlag) enabled(account string) bool {
+	if f.DomainAdmin != nil {
+		return *f.DomainAdmin
+	}
+	p, err := config.ServiceAccountPath(account)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(p)
+	return err == nil
+}
+
+// driveRestrictionFlags sets shared drive restrictions; unset flags keep the
+// current value.
+type driveRestrictionFlags struct {
+	CopyRequiresWriter     *bool `name:"copy-requires-writer" help:"Only writers can copy, print, or download files"`
+	RestrictDownload       *bool `name:"restrict-download" help:"Block downloads, printing, and copying for readers and commenters"`
+	DomainOnly             *bool `name:"domain-only" help:"Only users in the drive's domain can access files"`
+	MembersOnly            *bool `name:"members-only" help:"Only drive members can access files"`
+	AdminManaged           *bool `name:"admin-managed" help:"Only admins can change restrictions (requires --domain-admin to change)"`
+	OrganizerSharesFolders *bool `name:"organizer-shares-folders" help:"Only organizers can share folders"`
+}
+
+// build returns the restrictions patch, or nil when no flag was set.
+func (f driveRestrictionFlags) build() *drive.DriveRestrictions {
+	r := &drive.DriveRestrictions{}
+	set := func(v *bool, field string, dst *bool) {
+		if v == nil {
+			return
+		}
+		*dst = *v
+		r.ForceSendFields = append(r.ForceSendFields, field)
+	}
+	set(f.CopyRequiresWriter, "CopyRequiresWriterPermission", &r.CopyRequiresWriterPermission)
+	set(f.DomainOnly, "DomainUsersOnly", &r.DomainUsersOnly)
+	set(f.MembersOnly, "DriveMembersOnly", &r.DriveMembersOnly)
+	set(f.AdminManaged, "AdminManagedRestrictions", &r.AdminManagedRestrictions)
+	set(f.OrganizerSharesFolders, "SharingFoldersRequiresOrganizerPermission", &r.SharingFoldersRequiresOrganizerPermission)
+	if f.RestrictDownload != nil {
+		r.DownloadRestriction = &drive.DownloadRestriction{
+			RestrictedForReaders: *f.RestrictDownload,
+			ForceSendFields:      []string{"RestrictedForReaders"},
+		}
+	}
+	if len(r.ForceSendFields) == 0 && r.DownloadRestriction == nil {
+		return nil
+	}
+	return r
+}
+
+type DriveDrivesGetCmd struct {
+	DriveID string `arg:"" name:"driveId" help:"Shared drive ID"`
+	driveDomainAdminFlag
+}
+
+func (c *DriveDrivesGetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	driveID := strings.TrimSpace(c.DriveID)
+	if driveID == "" {
+		return usage("empty driveId")
+	}
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	d, err := svc.Drives.Get(driveID).
+		UseDomainAdminAccess(c.enabled(account)).
+		Fields(driveSharedDriveFields).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	return writeSharedDrive(ctx, d)
+}
+
+type DriveDrivesCreateCmd struct {
+	Name      string `arg:"" name:"name" help:"Shared drive name"`
+	Theme     string `name:"theme" help:"Theme ID (see Drive about.driveThemes)"`
+	Color     string `name:"color-rgb" help:"Color as #RRGGBB"`
+	RequestID string `name:"request-id" help:"Request ID for safe retries; repeating it returns the same drive (default: random)"`
+	driveRestrictionFlags
+}
+
+func (c *DriveDrivesCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	name := strings.TrimSpace(c.Name)
+	if name == "" {
+		return usage("empty name")
+	}
+	if strings.TrimSpace(c.Theme) != "" && strings.TrimSpace(c.Color) != "" {
+		return usage("use either --theme or --color-rgb")
+	}
+	restrictions := c.build()
+	requestID := strings.TrimSpace(c.RequestID)
+
+	if err := dryRunExit(ctx, flags, "drive.drives.create", map[string]any{
+		"name":         name,
+		"theme":        c.Theme,
+		"color":        c.Color,
+		"restrictions": restrictions,
+		"request_id":   requestID,
+	}); err != nil {
+		return err
+	}
+
+	// Repeating a create with the same request ID returns the drive it made
+	// instead of a second one; without --request-id every run creates a drive.
+	if requestID == "" {
+		if requestID, err = randomRequestID(); err != nil {
+			return err
+		}
+	}
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	d, err := svc.Drives.Create(requestID, &drive.Drive{
+		Name:     name,
+		ThemeId:  strings.TrimSpace(c.Theme),
+		ColorRgb: strings.TrimSpace(c.Color),
+	}).Fields(driveSharedDriveFields).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	// Restrictions cannot be set at creation time.
+	if restrictions != nil {
+		d, err = svc.Drives.Update(d.Id, &drive.Drive{Restrictions: restrictions}).
+			Fields(driveSharedDriveFields).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return fmt.Errorf("created shared drive but failed to set restrictions: %w", err)
+		}
+	}
+	return writeSharedDrive(ctx, d)
+}
+
+type DriveDrivesUpdateCmd struct {
+	DriveID string `arg:"" name:"driveId" help:"Shared drive ID"`
+	Name    string `name:"name" help:"New name"`
+	Theme   string `name:"theme" help:"Theme ID (see Drive about.driveThemes)"`
+	Color   string `name:"color-rgb" help:"Color as #RRGGBB"`
+	driveRestrictionFlags
+	driveDomainAdminFlag
+}
+
+func (c *DriveDrivesUpdateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	driveID := strings.TrimSpace(c.DriveID)
+	if driveID == "" {
+		return usage("empty driveId")
+	}
+	if strings.TrimSpace(c.Theme) != "" && strings.TrimSpace(c.Color) != "" {
+		return usage("use either --theme or --color-rgb")
+	}
+	patch := &drive.Drive{
+		Name:         strings.TrimSpace(c.Name),
+		ThemeId:      strings.TrimSpace(c.Theme),
+		ColorRgb:     strings.TrimSpace(c.Color),
+		Restrictions: c.build(),
+	}
+	if patch.Name == "" && patch.ThemeId == "" && patch.ColorRgb == "" && patch.Restrictions == nil {
+		return usage("nothing to update (use --name, --theme, --color-rgb, or a restriction flag)")
+	}
+
+	if err := dryRunExit(ctx, flags, "drive.drives.update", map[string]any{
+		"drive_id": driveID,
+		"drive":    patch,
+	}); err != nil {
+		return err
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	d, err := svc.Drives.Update(driveID, patch).
+		UseDomainAdminAccess(c.enabled(account)).
+		Fields(driveSharedDriveFields).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	return writeSharedDrive(ctx, d)
+}
+
+type DriveDrivesDeleteCmd struct {
+	DriveID           string `arg:"" name:"driveId" help:"Shared drive ID"`
+	AllowItemDeletion bool   `name:"allow-item-deletion" help:"Also delete all items in the drive (requires --domain-admin)"`
+	driveDomainAdminFlag
+}
+
+func (c *DriveDrivesDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	driveID := strings.TrimSpace(c.DriveID)
+	if driveID == "" {
+		return usage("empty driveId")
+	}
+	admin := c.enabled(account)
+	if c.AllowItemDeletion && !admin {
+		return usage("--allow-item-deletion requires --domain-admin")
+	}
+
+	action := "delete shared drive " + driveID
+	if c.AllowItemDeletion {
+		action += " and everything in it"
+	}
+	if confirmErr := confirmDestructive(ctx, flags, action); confirmErr != nil {
+		return confirmErr
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	call := svc.Drives.Delete(driveID).UseDomainAdminAccess(admin).Context(ctx)
+	if c.AllowItemDeletion {
+		call = call.AllowItemDeletion(true)
+	}
+	if err := call.Do(); err != nil {
+		return err
+	}
+	return writeResult(ctx, u,
+		kv("deleted", true),
+		kv("id", driveID),
+	)
+}
+
+type DriveDrivesHideCmd struct {
+	DriveID string `arg:"" name:"driveId" help:"Shared drive ID"`
+}
+
+func (c *DriveDrivesHideCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return setSharedDriveHidden(ctx, flags, "drive.drives.hide", c.DriveID, true)
+}
+
+type DriveDrivesUnhideCmd struct {
+	DriveID string `arg:"" name:"driveId" help:"Shared drive ID"`
+}
+
+func (c *DriveDrivesUnhideCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return setSharedDriveHidden(ctx, flags, "drive.drives.unhide", c.DriveID, false)
+}
+
+func setSharedDriveHidden(ctx context.Context, flags *RootFlags, op, driveID string, hidden bool) error {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	driveID = strings.TrimSpace(driveID)
+	if driveID == "" {
+		return usage("empty driveId")
+	}
+
+	if err := dryRunExit(ctx, flags, op, map[string]any{
+		"drive_id": driveID,
+	}); err != nil {
+		return err
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	var d *drive.Drive
+	if hidden {
+		d, err = svc.Drives.Hide(driveID).Fields(driveSharedDriveFields).Context(ctx).Do()
+	} else {
+		d, err = svc.Drives.Unhide(driveID).Fields(driveSharedDriveFields).Context(ctx).Do()
+	}
+	if err != nil {
+		return err
+	}
+	return writeSharedDrive(ctx, d)
+}
+
+func writeSharedDrive(ctx context.Context, d *drive.Drive) error {
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"drive": d})
+	}
+	u := ui.FromContext(ctx)
+	u.Out().Printf("id\t%s", d.Id)
+	u.Out().Printf("name\t%s", d.Name)
+	if d.CreatedTime != "" {
+		u.Out().Printf("created\t%s", d.CreatedTime)
+	}
+	u.Out().Printf("hidden\t%t", d.Hidden)
+	if r := d.Restrictions; r != nil {
+		u.Out().Printf("copy_requires_writer\t%t", r.CopyRequiresWriterPermission)
+		u.Out().Printf("restrict_download\t%t", r.DownloadRestriction != nil && r.DownloadRestriction.RestrictedForReaders)
+		u.Out().Printf("domain_only\t%t", r.DomainUsersOnly)
+		u.Out().Printf("members_only\t%t", r.DriveMembersOnly)
+		u.Out().Printf("admin_managed\t%t", r.AdminManagedRestrictions)
+		u.Out().Printf("organizer_shares_folders\t%t", r.SharingFoldersRequiresOrganizerPermission)
+	}
+	return nil
+}
+
+// DriveDrivesMembersCmd manages shared drive membership (permissions on the
+// drive itself).
+type DriveDrivesMembersCmd struct {
+	List   DriveDrivesMembersListCmd   `cmd:"" default:"withargs" aliases:"ls" help:"List members of a shared drive"`
+	Add    DriveDrivesMembersAddCmd    `cmd:"" name:"add" help:"Add a user or group to a shared drive"`
+	Remove DriveDrivesMembersRemoveCmd `cmd:"" name:"remove" aliases:"rm,del" help:"Remove a member from a shared drive"`
+}
+
+type DriveDrivesMembersListCmd struct {
+	DriveID string `arg:"" name:"driveId" help:"Shared drive ID"`
+	driveDomainAdminFlag
+}
+
+func (c *DriveDrivesMembersListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	driveID := strings.TrimSpace(c.DriveID)
+	if driveID == "" {
+		return usage("empty driveId")
+	}
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	members, err := listSharedDriveMembers(ctx, svc, driveID, c.enabled(account))
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if members == nil {
+			members = []*drive.Permission{}
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"driveId": driveID, "members": members})
+	}
+	if len(members) == 0 {
+		u.Err().Println("No members")
+		return nil
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tTYPE\tROLE\tMEMBER")
+	for _, p := range members {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", p.Id, p.Type, p.Role, orEmpty(p.EmailAddress, p.Domain))
+	}
+	return nil
+}
+
+func listSharedDriveMembers(ctx context.Context, svc *drive.Service, driveID string, admin bool) ([]*drive.Permission, error) {
+	return collectAllPages("", func(pageToken string) ([]*drive.Permission, string, error) {
+		call := svc.Permissions.List(driveID).
+			SupportsAllDrives(true).
+			UseDomainAdminAccess(admin).
+			PageSize(100).
+			Fields("nextPageToken, permissions(" + driveMemberFields + ")").
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Permissions, resp.NextPageToken, nil
+	})
+}
+
+type DriveDrivesMembersAddCmd struct {
+	DriveID string `arg:"" name:"driveId" help:"Shared drive ID"`
+	Email   string `arg:"" name:"email" help:"User or group email"`
+	Role    string `name:"role" help:"Role: organizer|fileOrganizer|writer|commenter|reader" default:"writer" enum:"organizer,fileOrganizer,writer,commenter,reader"`
+	Group   bool   `name:"group" help:"The email is a Google Group"`
+	Notify  bool   `name:"notify" help:"Send a notification email" default:"true" negatable:""`
+	Message string `name:"message" help:"Message to include in the notification email"`
+	driveDomainAdminFlag
+}
+
+func (c *DriveDrivesMembersAddCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	driveID := strings.TrimSpace(c.DriveID)
+	email := strings.TrimSpace(c.Email)
+	if driveID == "" {
+		return usage("empty driveId")
+	}
+	if email == "" {
+		return usage("empty email")
+	}
+	permType := "user"
+	if c.Group {
+		permType = "group"
+	}
+	perm := &drive.Permission{Type: permType, Role: c.Role, EmailAddress: email}
+
+	if err := dryRunExit(ctx, flags, "drive.drives.members.add", map[string]any{
+		"drive_id":   driveID,
+		"permission": perm,
+	}); err != nil {
+		return err
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	call := svc.Permissions.Create(driveID, perm).
+		SupportsAllDrives(true).
+		UseDomainAdminAccess(c.enabled(account)).
+		SendNotificationEmail(c.Notify).
+		Fields(driveMemberFields).
+		Context(ctx)
+	if c.Notify && strings.TrimSpace(c.Message) != "" {
+		call = call.EmailMessage(c.Message)
+	}
+	created, err := call.Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"driveId": driveID, "member": created})
+	}
+	u.Out().Printf("permission_id\t%s", created.Id)
+	u.Out().Printf("member\t%s", created.EmailAddress)
+	u.Out().Printf("role\t%s", created.Role)
+	return nil
+}
+
+type DriveDrivesMembersRemoveCmd struct {
+	DriveID string `arg:"" name:"driveId" help:"Shared drive ID"`
+	Member  string `arg:"" name:"member" help:"Member email or permission ID"`
+	driveDomainAdminFlag
+}
+
+func (c *DriveDrivesMembersRemoveCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	driveID := strings.TrimSpace(c.DriveID)
+	member := strings.TrimSpace(c.Member)
+	if driveID == "" {
+		return usage("empty driveId")
+	}
+	if member == "" {
+		return usage("empty member")
+	}
+
+	if confirmErr := confirmDestructive(ctx, flags, fmt.Sprintf("remove %s from shared drive %s", member, driveID)); confirmErr != nil {
+		return confirmErr
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	admin := c.enabled(account)
+	permissionID := member
+	if strings.Contains(member, "@") {
+		members, err := listSharedDriveMembers(ctx, svc, driveID, admin)
+		if err != nil {
+			return err
+		}
+		permissionID = ""
+		for _, p := range members {
+			if strings.EqualFold(p.EmailAddress, member) {
+				permissionID = p.Id
+				break
+			}
+		}
+		if permissionID == "" {
+			return usagef("%s is not a member of shared drive %s", member, driveID)
+		}
+	}
+
+	err = svc.Permissions.Delete(driveID, permissionID).
+		SupportsAllDrives(true).
+		UseDomainAdminAccess(admin).
+		Context(ctx).
+		Do()
+	if err != nil {
+		if isNotFoundAPIError(err) {
+			return errors.New("member not found (already removed?)")
+		}
+		return err
+	}
+	return writeResult(ctx, u,
+		kv("removed", true),
+		kv("driveId", driveID),
+		kv("permissionId", permissionID),
+	)
+}
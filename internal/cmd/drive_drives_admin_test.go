@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDriveRestrictionFlagsBuild(t *testing.T) {
+	if r := (driveRestrictionFlags{}).build(); r != nil {
+		t.Fatalf("expected nil restrictions without flags, got %#v", r)
+	}
+	off, on := false, true
+	r := driveRestrictionFlags{DomainOnly: &off, RestrictDownload: &on}.build()
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	got := string(b)
+	if !strings.Contains(got, `"domainUsersOnly":false`) || !strings.Contains(got, `"restrictedForReaders":true`) || strings.Contains(got, "driveMembersOnly") {
+		t.Fatalf("unexpected restrictions payload: %s", got)
+	}
+}
+
+func TestDriveDrivesUpdate_RestrictionsAsDomainAdmin(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+
+	var body, admin string
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodPatch || r.URL.Path != "/drives/D1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			http.NotFound(w, r)
+			return
+		}
+		admin = r.URL.Query().Get("useDomainAdminAccess")
+		body = readBody(t, r)
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "D1", "name": "Finance", "restrictions": map[string]any{"copyRequiresWriterPermission": true}})
+	}))
+	defer closeSrv()
+
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = stubDriveService(svc)
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = Execute([]string{"--account", "a@example.com", "drive", "drives", "update", "D1", "--copy-requires-writer", "--domain-admin"})
+	})
+	if runErr != nil {
+		t.Fatalf("Execute: %v", runErr)
+	}
+	if admin != "true" {
+		t.Fatalf("expected useDomainAdminAccess=true, got %q", admin)
+	}
+	if !strings.Contains(body, `"copyRequiresWriterPermission":true`) || strings.Contains(body, `"name"`) {
+		t.Fatalf("unexpected patch body: %s", body)
+	}
+	if !strings.Contains(out, "copy_requires_writer\ttrue") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestDriveDrivesMembersRemove_ByEmail(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+
+	var deleted string
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files/D1/permissions":
+			if r.URL.Query().Get("useDomainAdminAccess") != "false" {
+				t.Errorf("expected member access without a service account: %s", r.URL.RawQuery)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"permissions": []map[string]any{
+				{"id": "p1", "type": "user", "role": "organizer", "emailAddress": "boss@example.com"},
+				{"id": "p2", "type": "user", "role": "writer", "emailAddress": "Contractor@partner.io"},
+			}})
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/files/D1/permissions/"):
+			deleted = strings.TrimPrefix(r.URL.Path, "/files/D1/permissions/")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			http.NotFound(w, r)
+		}
+	}))
+	defer closeSrv()
+
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = stubDriveService(svc)
+
+	var runErr error
+	_ = captureStdout(t, func() {
+		runErr = Execute([]string{"--force", "--account", "a@example.com", "drive", "drives", "members", "remove", "D1", "contractor@partner.io"})
+	})
+	if runErr != nil {
+		t.Fatalf("Execute: %v", runErr)
+	}
+	if deleted != "p2" {
+		t.Fatalf("expected p2 removed, got %q", deleted)
+	}
+}
+
+func TestDriveDrivesCreate_UsesGivenRequestID(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+
+	var requestIDs []string
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodPost || r.URL.Path != "/drives" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			http.NotFound(w, r)
+			return
+		}
+		requestIDs = append(requestIDs, r.URL.Query().Get("requestId"))
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "D1", "name": "Finance"})
+	}))
+	defer closeSrv()
+
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = stubDriveService(svc)
+
+	for _, args := range [][]string{{"--request-id", "retry-1"}, nil, nil} {
+		var runErr error
+		_ = captureStdout(t, func() {
+			runErr = Execute(append([]string{"--account", "a@example.com", "drive", "drives", "create", "Finance"}, args...))
+		})
+		if runErr != nil {
+			t.Fatalf("Execute: %v", runErr)
+		}
+	}
+	if len(requestIDs) != 3 || requestIDs[0] != "retry-1" || requestIDs[1] == "" || requestIDs[1] == requestIDs[2] {
+		t.Fatalf("unexpected request IDs: %q", requestIDs)
+	}
+}
+
+func TestDriveDrivesHide_DryRunSkipsAPI(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+		http.NotFound(w, r)
+	}))
+	defer closeSrv()
+	newDriveService = stubDriveService(svc)
+
+	for _, sub := range []string{"hide", "unhide"} {
+		var runErr error
+		out := captureStdout(t, func() {
+			runErr = Execute([]string{"--account", "a@example.com", "--dry-run", "--json", "drive", "drives", sub, "D1"})
+		})
+		if runErr != nil {
+			t.Fatalf("%s: %v", sub, runErr)
+		}
+		if !strings.Contains(out, `"drive.drives.`+sub+`"`) || !strings.Contains(out, `"D1"`) {
+			t.Fatalf("%s: unexpected dry-run output %q", sub, out)
+		}
+	}
+}
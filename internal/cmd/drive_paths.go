@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/drive/v3"
+)
+
+const (
+	drivePathPrefixMyDrive = "drive:"
+	drivePathPrefixShared  = "shared:"
+)
+
+var errDrivePathNotFound = errors.New("not found")
+
+// drivePathCaches keeps one resolver cache per Drive service so repeated
+// lookups in the same process (e.g. a shell session) skip the API.
+var drivePathCaches sync.Map // *drive.Service -> *drivePathCache
+
+type drivePathCache struct {
+	mu     sync.Mutex
+	byPath map[string]string // "<rootID>/<cleaned path>" -> file ID
+	drives map[string][]string
+}
+
+// isDrivePath reports whether input uses path addressing instead of a file ID:
+// `drive:/Projects/Plan.docx`, `shared:TeamDrive/Folder/file`, or `/Projects`.
+func isDrivePath(input string) bool {
+	s := strings.TrimSpace(input)
+	return strings.HasPrefix(s, drivePathPrefixMyDrive) ||
+		strings.HasPrefix(s, drivePathPrefixShared) ||
+		strings.HasPrefix(s, "/")
+}
+
+// resolveDriveID turns user input into a Drive file ID. IDs and Drive URLs are
+// normalized as before; paths are resolved one segment at a time (exact,
+// case-sensitive names, trashed items ignored) and error on duplicates.
+func resolveDriveID(ctx context.Context, svc *drive.Service, input string) (string, error) {
+	in := strings.TrimSpace(input)
+	if !isDrivePath(in) {
+		return normalizeGoogleID(in), nil
+	}
+
+	rootID := "root"
+	rest := strings.TrimPrefix(in, drivePathPrefixMyDrive)
+	if strings.HasPrefix(in, drivePathPrefixShared) {
+		rest = strings.TrimLeft(strings.TrimPrefix(in, drivePathPrefixShared), "/")
+		driveName, tail, _ := strings.Cut(rest, "/")
+		if strings.TrimSpace(driveName) == "" {
+			return "", usagef("drive path %q: missing shared drive name", in)
+		}
+		id, err := resolveSharedDriveName(ctx, svc, driveName)
+		if err != nil {
+			return "", err
+		}
+		rootID, rest = id, tail
+	}
+	return resolveDrivePathFrom(ctx, svc, in, rootID, rest)
+}
+
+// resolveDrivePathFrom walks rest below the folder rootID; input is only used
+// in error messages.
+func resolveDrivePathFrom(ctx context.Context, svc *drive.Service, input, rootID, rest string) (string, error) {
+	segments := drivePathSegments(rest)
+	cache := drivePathCacheFor(svc)
+	parentID := rootID
+	for i, seg := range segments {
+		key := rootID + "/" + strings.Join(segments[:i+1], "/")
+		if id, ok := cache.get(key); ok {
+			parentID = id
+			continue
+		}
+		folderOnly := i < len(segments)-1
+		id, err := resolveDrivePathSegment(ctx, svc, input, parentID, seg, folderOnly)
+		if err != nil {
+			return "", err
+		}
+		cache.put(key, id)
+		parentID = id
+	}
+	return parentID, nil
+}
+
+// drivePathSegments splits a path into cleaned segments; "." and ".." are
+// applied lexically, so the result never escapes the root.
+func drivePathSegments(p string) []string {
+	cleaned := strings.Trim(path.Clean("/"+strings.TrimSpace(p)), "/")
+	if cleaned == "" {
+		return nil
+	}
+	return strings.Split(cleaned, "/")
+}
+
+func resolveDrivePathSegment(ctx context.Context, svc *drive.Service, input, parentID, name string, folderOnly bool) (string, error) {
+	q := fmt.Sprintf("'%s' in parents and name = '%s' and trashed = false", escapeDriveQueryString(parentID), escapeDriveQueryString(name))
+	if folderOnly {
+		q += fmt.Sprintf(" and mimeType = '%s'", driveMimeFolder)
+	}
+	fetch := func(pageToken string) ([]*drive.File, string, error) {
+		call := svc.Files.List().
+			Q(q).
+			PageSize(100).
+			Fields("nextPageToken, files(id, name, mimeType, modifiedTime)").
+			Context(ctx)
+		call = driveFilesListCallWithDriveSupport(call, true)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Files, resp.NextPageToken, nil
+	}
+	matches, err := collectAllPages("", fetch)
+	if err != nil {
+		return "", fmt.Errorf("resolve drive path %q: %w", input, err)
+	}
+	switch len(matches) {
+	case 0:
+		if folderOnly {
+			return "", usagef("drive path %q: folder %q %w", input, name, errDrivePathNotFound)
+		}
+		return "", usagef("drive path %q: %q %w", input, name, errDrivePathNotFound)
+	case 1:
+		return matches[0].Id, nil
+	default:
+		return "", ambiguousDrivePathError(input, name, matches)
+	}
+}
+
+func ambiguousDrivePathError(input, name string, matches []*drive.File) error {
+	parts := make([]string, 0, len(matches))
+	for _, f := range matches {
+		parts = append(parts, fmt.Sprintf("%s (%s, modified %s)", f.Id, driveType(f.MimeType), formatDateTime(f.ModifiedTime)))
+	}
+	sort.Strings(parts)
+	return usagef("ambiguous drive path %q: %d items named %q; use an ID instead: %s", input, len(matches), name, strings.Join(parts, ", "))
+}
+
+func resolveSharedDriveName(ctx context.Context, svc *drive.Service, name string) (string, error) {
+	cache := drivePathCacheFor(svc)
+	cache.mu.Lock()
+	drives := cache.drives
+	cache.mu.Unlock()
+
+	if drives == nil {
+		fetch := func(pageToken string) ([]*drive.Drive, string, error) {
+			call := svc.Drives.List().PageSize(100).Fields("nextPageToken, drives(id, name)").Context(ctx)
+			if pageToken != "" {
+				call = call.PageToken(pageToken)
+			}
+			resp, err := call.Do()
+			if err != nil {
+				return nil, "", err
+			}
+			return resp.Drives, resp.NextPageToken, nil
+		}
+		all, err := collectAllPages("", fetch)
+		if err != nil {
+			return "", fmt.Errorf("list shared drives: %w", err)
+		}
+		drives = map[string][]string{}
+		for _, d := range all {
+			if d == nil || d.Id == "" {
+				continue
+			}
+			key := strings.ToLower(strings.TrimSpace(d.Name))
+			drives[key] = append(drives[key], d.Id)
+		}
+		cache.mu.Lock()
+		cache.drives = drives
+		cache.mu.Unlock()
+	}
+
+	ids := drives[strings.ToLower(strings.TrimSpace(name))]
+	switch len(ids) {
+	case 0:
+		return "", usagef("shared drive %q not found (see: gog drive drives)", name)
+	case 1:
+		return ids[0], nil
+	default:
+		sorted := append([]string(nil), ids...)
+		sort.Strings(sorted)
+		return "", usagef("ambiguous shared drive %q; matches: %s", name, strings.Join(sorted, ", "))
+	}
+}
+
+// forgetDrivePaths drops cached path lookups for svc after files were moved,
+// renamed, or deleted.
+func forgetDrivePaths(svc *drive.Service) {
+	cache := drivePathCacheFor(svc)
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.byPath = map[string]string{}
+}
+
+func drivePathCacheFor(svc *drive.Service) *drivePathCache {
+	v, _ := drivePathCaches.LoadOrStore(svc, &drivePathCache{byPath: map[string]string{}})
+	return v.(*drivePathCache)
+}
+
+func (c *drivePathCache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	id, ok := c.byPath[key]
+	return id, ok
+}
+
+func (c *drivePathCache) put(key, id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.byPath[key] = id
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDrivePathSegments(t *testing.T) {
+	cases := map[string][]string{
+		"":                  nil,
+		"/":                 nil,
+		"/Projects//2026/":  {"Projects", "2026"},
+		"a/./b/../c":        {"a", "c"},
+		"/../../etc/passwd": {"etc", "passwd"},
+	}
+	for in, want := range cases {
+		if got := drivePathSegments(in); !reflect.DeepEqual(got, want) {
+			t.Fatalf("drivePathSegments(%q) = %#v, want %#v", in, got, want)
+		}
+	}
+	if isDrivePath("1AbcDEF") || !isDrivePath("/Projects") || !isDrivePath("drive:Plan") || !isDrivePath("shared:Team") {
+		t.Fatalf("unexpected isDrivePath results")
+	}
+}
+
+func TestResolveDriveID_PathSegmentsAndCache(t *testing.T) {
+	var lists int32
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/files" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			http.NotFound(w, r)
+			return
+		}
+		atomic.AddInt32(&lists, 1)
+		q := r.URL.Query().Get("q")
+		var files []map[string]any
+		switch {
+		case strings.Contains(q, "'root' in parents") && strings.Contains(q, "name = 'Projects'") && strings.Contains(q, driveMimeFolder):
+			files = []map[string]any{{"id": "fProjects", "mimeType": driveMimeFolder}}
+		case strings.Contains(q, "'fProjects' in parents") && strings.Contains(q, "name = 'Bob\\'s Plan.docx'"):
+			files = []map[string]any{{"id": "fPlan", "mimeType": mimeDocx}}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"files": files})
+	}))
+	defer closeSrv()
+
+	ctx := context.Background()
+	id, err := resolveDriveID(ctx, svc, "drive:/Projects/Bob's Plan.docx")
+	if err != nil || id != "fPlan" {
+		t.Fatalf("resolve = %q, %v", id, err)
+	}
+	if id, err = resolveDriveID(ctx, svc, "/Projects/"); err != nil || id != "fProjects" {
+		t.Fatalf("resolve folder = %q, %v", id, err)
+	}
+	if n := atomic.LoadInt32(&lists); n != 2 {
+		t.Fatalf("expected cached second lookup (2 list calls), got %d", n)
+	}
+	if id, err = resolveDriveID(ctx, svc, "https://drive.google.com/file/d/abc123/view"); err != nil || id != "abc123" {
+		t.Fatalf("expected URL passthrough, got %q, %v", id, err)
+	}
+
+	_, err = resolveDriveID(ctx, svc, "/Projects/missing.txt")
+	if err == nil || ExitCode(err) != 2 || !strings.Contains(err.Error(), `"missing.txt" not found`) {
+		t.Fatalf("expected not-found usage error, got %v", err)
+	}
+}
+
+func TestResolveDriveID_AmbiguousName(t *testing.T) {
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{
+			{"id": "dup2", "mimeType": "text/plain", "modifiedTime": "2026-02-01T10:00:00Z"},
+			{"id": "dup1", "mimeType": "text/plain", "modifiedTime": "2026-01-01T10:00:00Z"},
+		}})
+	}))
+	defer closeSrv()
+
+	_, err := resolveDriveID(context.Background(), svc, "/notes.txt")
+	if err == nil || ExitCode(err) != 2 {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "ambiguous drive path") || !strings.Contains(msg, "dup1 (file, modified 2026-01-01 10:00)") || !strings.Contains(msg, "dup2") {
+		t.Fatalf("unexpected error: %s", msg)
+	}
+}
+
+func TestExecute_DriveLs_SharedDrivePath(t *testing.T) {
+	var listedParent string
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query().Get("q")
+		switch {
+		case r.URL.Path == "/drives":
+			_ = json.NewEncoder(w).Encode(map[string]any{"drives": []map[string]any{
+				{"id": "0AteamDrive", "name": "TeamDrive"},
+				{"id": "0Aother", "name": "Other"},
+			}})
+		case r.URL.Path == "/files" && strings.Contains(q, "name = 'Specs'"):
+			if !strings.Contains(q, "'0AteamDrive' in parents") {
+				t.Errorf("expected lookup inside shared drive root: %s", q)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{{"id": "fSpecs", "mimeType": driveMimeFolder}}})
+		case r.URL.Path == "/files":
+			listedParent = q
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{{"id": "f1", "name": "api.md", "mimeType": "text/markdown"}}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			http.NotFound(w, r)
+		}
+	}))
+	defer closeSrv()
+
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = stubDriveService(svc)
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = Execute([]string{"--account", "a@example.com", "ls", "shared:teamdrive/Specs"})
+	})
+	if runErr != nil {
+		t.Fatalf("Execute: %v", runErr)
+	}
+	if !strings.Contains(listedParent, "'fSpecs' in parents") || !strings.Contains(out, "api.md") {
+		t.Fatalf("unexpected listing: q=%q out=%q", listedParent, out)
+	}
+}
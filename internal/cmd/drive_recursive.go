@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/drive/v3"
+	gapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	driveTransferOK      = "ok"
+	driveTransferSkipped = "skipped"
+	driveTransferFailed  = "failed"
+)
+
+// driveTransferItem is one manifest entry of a recursive download or upload.
+type driveTransferItem struct {
+	Path     string `json:"path"`
+	ID       string `json:"id,omitempty"`
+	MimeType string `json:"mimeType,omitempty"`
+	Size     int64  `json:"size,omitempty"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+// driveExportKinds maps the short names accepted by --export to Google MIME types.
+var driveExportKinds = map[string]string{
+	"doc":     driveMimeGoogleDoc,
+	"sheet":   driveMimeGoogleSheet,
+	"slides":  driveMimeGoogleSlides,
+	"drawing": driveMimeGoogleDrawing,
+}
+
+// parseDriveExportFormats turns repeated kind=format pairs (e.g. doc=docx)
+// into a Google MIME type -> format map.
+func parseDriveExportFormats(values []string) (map[string]string, error) {
+	out := map[string]string{}
+	for _, raw := range values {
+		kind, format, ok := strings.Cut(raw, "=")
+		kind = strings.ToLower(strings.TrimSpace(kind))
+		format = strings.ToLower(strings.TrimSpace(format))
+		mimeType, known := driveExportKinds[kind]
+		if !ok || !known || format == "" {
+			return nil, usagef("invalid --export %q (use doc|sheet|slides|drawing=<format>)", raw)
+		}
+		if _, err := driveExportMimeTypeForFormat(mimeType, format); err != nil {
+			return nil, usage(err.Error())
+		}
+		out[mimeType] = format
+	}
+	return out, nil
+}
+
+// runDriveTransfers runs fn for every item with at most parallel workers,
+// printing one progress line per finished item to stderr.
+func runDriveTransfers(ctx context.Context, u *ui.UI, parallel int, items []driveTransferItem, fn func(context.Context, *driveTransferItem) error) {
+	if parallel < 1 {
+		parallel = 1
+	}
+	var (
+		mu   sync.Mutex
+		done int
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, parallel)
+	)
+	for i := range items {
+		wg.Add(1)
+		go func(item *driveTransferItem) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				item.Status, item.Error = driveTransferFailed, ctx.Err().Error()
+				return
+			}
+			if err := fn(ctx, item); err != nil {
+				item.Status, item.Error = driveTransferFailed, err.Error()
+			} else if item.Status == "" {
+				item.Status = driveTransferOK
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			done++
+			if u != nil {
+				if item.Error != "" {
+					u.Err().Printf("[%d/%d] %s: %s", done, len(items), item.Path, item.Error)
+				} else {
+					u.Err().Printf("[%d/%d] %s", done, len(items), item.Path)
+				}
+			}
+		}(&items[i])
+	}
+	wg.Wait()
+}
+
+func writeDriveTransferManifest(ctx context.Context, u *ui.UI, root map[string]any, items []driveTransferItem) error {
+	counts := map[string]int{}
+	for _, item := range items {
+		counts[item.Status]++
+	}
+	if outfmt.IsJSON(ctx) {
+		payload := map[string]any{
+			"files":   items,
+			"ok":      counts[driveTransferOK],
+			"skipped": counts[driveTransferSkipped],
+			"failed":  counts[driveTransferFailed],
+		}
+		for k, v := range root {
+			payload[k] = v
+		}
+		if err := outfmt.WriteJSON(ctx, os.Stdout, payload); err != nil {
+			return err
+		}
+	} else {
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "STATUS\tPATH\tID")
+		for _, item := range items {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", item.Status, item.Path, item.ID)
+		}
+		flush()
+		u.Err().Printf("%d ok, %d skipped, %d failed", counts[driveTransferOK], counts[driveTransferSkipped], counts[driveTransferFailed])
+	}
+	if counts[driveTransferFailed] > 0 {
+		return fmt.Errorf("%d of %d file(s) failed", counts[driveTransferFailed], len(items))
+	}
+	return nil
+}
+
+// runRecursive downloads every file below the folder meta into a local
+// directory, mirroring the folder structure.
+func (c *DriveDownloadCmd) runRecursive(ctx context.Context, u *ui.UI, svc, media *drive.Service, meta *drive.File) error {
+	if strings.TrimSpace(c.Format) != "" {
+		return usage("--format cannot be combined with --recursive (use --export doc=docx ...)")
+	}
+	formats, err := parseDriveExportFormats(c.Export)
+	if err != nil {
+		return err
+	}
+
+	destDir := strings.TrimSpace(c.Output.Path)
+	if destDir == "" {
+		base, dirErr := config.EnsureDriveDownloadsDir()
+		if dirErr != nil {
+			return dirErr
+		}
+		destDir = filepath.Join(base, fmt.Sprintf("%s_%s", meta.Id, sanitizeDriveTreeName(meta.Name)))
+	} else if destDir, err = config.ExpandPath(destDir); err != nil {
+		return err
+	}
+
+	tree, err := walkDriveTree(ctx, svc, meta.Id)
+	if err != nil {
+		return err
+	}
+
+	folders := make([]string, 0, len(tree.Folders))
+	for rel := range tree.Folders {
+		folders = append(folders, rel)
+	}
+	sort.Strings(folders)
+	for _, rel := range folders {
+		if err := os.MkdirAll(filepath.Join(destDir, filepath.FromSlash(rel)), 0o750); err != nil {
+			return err
+		}
+	}
+
+	paths := make([]string, 0, len(tree.Files))
+	for rel := range tree.Files {
+		paths = append(paths, rel)
+	}
+	sort.Strings(paths)
+	items := make([]driveTransferItem, 0, len(paths)+len(tree.Skipped))
+	for _, rel := range paths {
+		f := tree.Files[rel]
+		items = append(items, driveTransferItem{Path: rel, ID: f.ID, MimeType: f.MimeType})
+	}
+
+	runDriveTransfers(ctx, u, c.Parallel, items, func(ctx context.Context, item *driveTransferItem) error {
+		f := tree.Files[item.Path]
+		dest := filepath.Join(destDir, filepath.FromSlash(item.Path))
+		written, size, dlErr := downloadDriveFile(ctx, media, &drive.File{Id: f.ID, Name: f.Name, MimeType: f.MimeType}, dest, formats[f.MimeType])
+		if dlErr != nil {
+			return dlErr
+		}
+		if rel, relErr := filepath.Rel(destDir, written); relErr == nil {
+			item.Path = filepath.ToSlash(rel)
+		}
+		item.Size = size
+		return nil
+	})
+	for _, rel := range tree.Skipped {
+		items = append(items, driveTransferItem{Path: rel, Status: driveTransferSkipped, Error: "duplicate name or non-exportable Google file"})
+	}
+
+	return writeDriveTransferManifest(ctx, u, map[string]any{"folderId": meta.Id, "path": destDir}, items)
+}
+
+// runRecursive uploads a local directory as a new Drive folder, recreating
+// its subdirectories before uploading files in parallel through media.
+func (c *DriveUploadCmd) runRecursive(ctx context.Context, u *ui.UI, svc, media *drive.Service, account, localPath string) error {
+	if strings.TrimSpace(c.ReplaceFileID) != "" {
+		return usage("--replace cannot be combined with --recursive")
+	}
+	if c.Resume {
+		return usage("--resume cannot be combined with --recursive")
+	}
+	if strings.TrimSpace(c.ConvertTo) != "" {
+		return usage("--convert-to cannot be combined with --recursive (use --convert)")
+	}
+	if strings.TrimSpace(c.MimeType) != "" {
+		return usage("--mime-type cannot be combined with --recursive")
+	}
+
+	var dirs, files []string
+	err := filepath.WalkDir(localPath, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if p == localPath {
+			return nil
+		}
+		rel, relErr := filepath.Rel(localPath, p)
+		if relErr != nil {
+			return relErr
+		}
+		switch {
+		case d.IsDir():
+			dirs = append(dirs, filepath.ToSlash(rel))
+		case d.Type().IsRegular():
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Strings(dirs)
+	sort.Strings(files)
+	chunkSize, err := driveChunkSize(c.ChunkSize)
+	if err != nil {
+		return err
+	}
+
+	name := strings.TrimSpace(c.Name)
+	if name == "" {
+		name = filepath.Base(localPath)
+	}
+	parent, err := resolveDriveID(ctx, svc, c.Parent)
+	if err != nil {
+		return err
+	}
+	root, err := createDriveFolder(ctx, svc, name, parent, "id, name, webViewLink")
+	if err != nil {
+		return err
+	}
+	u.Err().Printf("created folder %s (%s)", root.Name, root.Id)
+
+	tree := &driveRemoteTree{Folders: map[string]string{"": root.Id}}
+	folders := make([]driveTransferItem, 0, len(dirs))
+	for _, rel := range dirs {
+		id, folderErr := ensureDriveFolderPath(ctx, svc, tree, rel)
+		if folderErr != nil {
+			return folderErr
+		}
+		folders = append(folders, driveTransferItem{Path: rel + "/", ID: id, MimeType: driveMimeFolder, Status: driveTransferOK})
+	}
+
+	items := make([]driveTransferItem, 0, len(files))
+	for _, rel := range files {
+		items = append(items, driveTransferItem{Path: rel})
+	}
+	runDriveTransfers(ctx, u, c.Parallel, items, func(ctx context.Context, item *driveTransferItem) error {
+		created, upErr := uploadDriveTreeFile(ctx, media, driveUploadRequest{
+			Account:     account,
+			LocalPath:   filepath.Join(localPath, filepath.FromSlash(item.Path)),
+			Meta:        &drive.File{Parents: []string{tree.Folders[driveTreeParent(item.Path)]}},
+			ChunkSize:   chunkSize,
+			KeepForever: c.KeepRevisionForever,
+		}, c.Convert, c.Resumable)
+		if upErr != nil {
+			return upErr
+		}
+		item.ID, item.MimeType, item.Size = created.Id, created.MimeType, created.Size
+		return nil
+	})
+
+	return writeDriveTransferManifest(ctx, u, map[string]any{
+		"folder":  root,
+		"folders": folders,
+	}, items)
+}
+
+// driveTreeParent returns the relative folder path of rel ("" for the root).
+func driveTreeParent(rel string) string {
+	if dir := path.Dir(rel); dir != "." {
+		return dir
+	}
+	return ""
+}
+
+// uploadDriveTreeFile uploads req.LocalPath into req.Meta's parent. Files
+// larger than one chunk (or all files with resumable) go through a resumable
+// session, like a single-file upload; per-chunk progress is not printed.
+func uploadDriveTreeFile(ctx context.Context, media *drive.Service, req driveUploadRequest, convert, resumable bool) (*drive.File, error) {
+	f, err := os.Open(req.LocalPath) //nolint:gosec // walked path under user-provided root
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	req.File, req.Info = f, info
+	req.MimeType = guessMimeType(req.LocalPath)
+	req.Meta.Name = filepath.Base(req.LocalPath)
+	if convert {
+		// Files without a Google equivalent are uploaded as-is.
+		if convertMimeType, ok := googleConvertMimeType(req.LocalPath); ok {
+			req.Meta.MimeType = convertMimeType
+			req.Meta.Name = stripOfficeExt(req.Meta.Name)
+		}
+	}
+
+	if resumable || info.Size() > req.ChunkSize {
+		return uploadDriveResumable(ctx, nil, media, req)
+	}
+	call := media.Files.Create(req.Meta).
+		SupportsAllDrives(true).
+		Media(f, gapi.ContentType(req.MimeType)).
+		Fields(driveUploadFields).
+		Context(ctx)
+	if req.KeepForever {
+		call = call.KeepRevisionForever(true)
+	}
+	return call.Do()
+}
@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseDriveExportFormats(t *testing.T) {
+	got, err := parseDriveExportFormats([]string{"doc=docx", "Sheet=XLSX"})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got[driveMimeGoogleDoc] != "docx" || got[driveMimeGoogleSheet] != "xlsx" {
+		t.Fatalf("unexpected formats: %v", got)
+	}
+	for _, bad := range []string{"doc", "form=pdf", "slides=docx"} {
+		if _, err := parseDriveExportFormats([]string{bad}); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestDriveDownloadCmd_RecursiveFolder(t *testing.T) {
+	outDir := filepath.Join(t.TempDir(), "out")
+	srv, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query()
+		switch {
+		case r.URL.Path == "/files/root1" && q.Get("alt") != "media":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "root1", "name": "Project", "mimeType": driveMimeFolder})
+		case r.URL.Path == "/files" && strings.Contains(q.Get("q"), "'root1' in parents"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{
+				{"id": "sub1", "name": "notes", "mimeType": driveMimeFolder},
+				{"id": "doc1", "name": "Plan", "mimeType": driveMimeGoogleDoc},
+				{"id": "form1", "name": "Survey", "mimeType": "application/vnd.google-apps.form"},
+			}})
+		case r.URL.Path == "/files" && strings.Contains(q.Get("q"), "'sub1' in parents"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{
+				{"id": "bin1", "name": "a.txt", "mimeType": "text/plain"},
+			}})
+		case r.URL.Path == "/files/doc1/export":
+			if q.Get("mimeType") != mimeDocx {
+				t.Errorf("unexpected export mime %q", q.Get("mimeType"))
+			}
+			_, _ = w.Write([]byte("docx-bytes"))
+		case r.URL.Path == "/files/bin1" && q.Get("alt") == "media":
+			_, _ = w.Write([]byte("hello"))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			http.NotFound(w, r)
+		}
+	}))
+	defer closeSrv()
+
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = stubDriveService(srv)
+
+	var runErr error
+	out := captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			runErr = Execute([]string{"--json", "--account", "a@example.com", "drive", "download", "root1", "--recursive", "--out", outDir, "--export", "doc=docx"})
+		})
+	})
+	if runErr != nil {
+		t.Fatalf("Execute: %v", runErr)
+	}
+
+	var parsed struct {
+		OK      int                 `json:"ok"`
+		Skipped int                 `json:"skipped"`
+		Files   []driveTransferItem `json:"files"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json parse: %v\nout=%q", err, out)
+	}
+	if parsed.OK != 2 || parsed.Skipped != 1 {
+		t.Fatalf("unexpected manifest: %#v", parsed)
+	}
+	if b, err := os.ReadFile(filepath.Join(outDir, "Plan.docx")); err != nil || string(b) != "docx-bytes" {
+		t.Fatalf("expected exported doc, got %q err=%v", b, err)
+	}
+	if b, err := os.ReadFile(filepath.Join(outDir, "notes", "a.txt")); err != nil || string(b) != "hello" {
+		t.Fatalf("expected nested file, got %q err=%v", b, err)
+	}
+}
+
+func TestDriveDownloadCmd_FolderRequiresRecursive(t *testing.T) {
+	srv, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "root1", "name": "Project", "mimeType": driveMimeFolder})
+	}))
+	defer closeSrv()
+
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = stubDriveService(srv)
+
+	err := Execute([]string{"--account", "a@example.com", "drive", "download", "root1"})
+	if err == nil || !strings.Contains(err.Error(), "--recursive") {
+		t.Fatalf("expected --recursive hint, got %v", err)
+	}
+}
+
+func TestDriveUploadCmd_RecursiveDirectory(t *testing.T) {
+	localDir := filepath.Join(t.TempDir(), "site")
+	for rel, body := range map[string]string{"index.html": "<p>hi</p>", "css/app.css": "body{}"} {
+		p := filepath.Join(localDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o600); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	var (
+		mu      sync.Mutex
+		folders = map[string]string{}
+		uploads = map[string]string{}
+	)
+	srv, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/files":
+			var f struct {
+				Name    string   `json:"name"`
+				Parents []string `json:"parents"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&f)
+			id := "folder-" + f.Name
+			folders[f.Name] = strings.Join(f.Parents, ",")
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": id, "name": f.Name})
+		case r.Method == http.MethodPost && r.URL.Path == "/upload/drive/v3/files":
+			body := readBody(t, r)
+			name := "index.html"
+			parent := "folder-site"
+			if strings.Contains(body, `"name":"app.css"`) {
+				name, parent = "app.css", "folder-css"
+			}
+			if !strings.Contains(body, `"parents":["`+parent+`"]`) {
+				t.Errorf("upload %s missing parent %s: %s", name, parent, body)
+			}
+			uploads[name] = parent
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "file-" + name, "name": name})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			http.NotFound(w, r)
+		}
+	}))
+	defer closeSrv()
+
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = stubDriveService(srv)
+
+	var runErr error
+	out := captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			runErr = Execute([]string{"--json", "--account", "a@example.com", "drive", "upload", localDir, "--recursive", "--parent", "P1", "--parallel", "2"})
+		})
+	})
+	if runErr != nil {
+		t.Fatalf("Execute: %v", runErr)
+	}
+	if folders["site"] != "P1" || folders["css"] != "folder-site" {
+		t.Fatalf("unexpected folders: %v", folders)
+	}
+	if len(uploads) != 2 {
+		t.Fatalf("unexpected uploads: %v", uploads)
+	}
+	var parsed struct {
+		OK     int `json:"ok"`
+		Folder struct {
+			ID string `json:"id"`
+		} `json:"folder"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json parse: %v\nout=%q", err, out)
+	}
+	if parsed.OK != 2 || parsed.Folder.ID != "folder-site" {
+		t.Fatalf("unexpected manifest: %s", out)
+	}
+}
+
+func TestDriveUploadCmd_RecursiveUsesTransferService(t *testing.T) {
+	localDir := filepath.Join(t.TempDir(), "site")
+	if err := os.MkdirAll(localDir, 0o750); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(localDir, "big.bin"), []byte("payload"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	meta, closeMeta := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost && r.URL.Path == "/files" {
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "folder-site", "name": "site"})
+			return
+		}
+		t.Errorf("unexpected metadata request %s %s", r.Method, r.URL.String())
+		http.NotFound(w, r)
+	}))
+	defer closeMeta()
+	var (
+		mu      sync.Mutex
+		uploads int
+	)
+	media, closeMedia := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost && r.URL.Path == "/upload/drive/v3/files" {
+			mu.Lock()
+			uploads++
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "file-big", "name": "big.bin"})
+			return
+		}
+		t.Errorf("unexpected transfer request %s %s", r.Method, r.URL.String())
+		http.NotFound(w, r)
+	}))
+	defer closeMedia()
+
+	origNew, origTransfer := newDriveService, newDriveTransferService
+	t.Cleanup(func() { newDriveService, newDriveTransferService = origNew, origTransfer })
+	newDriveService = stubDriveService(meta)
+	newDriveTransferService = stubDriveService(media)
+
+	_ = captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{"--json", "--account", "a@example.com", "drive", "upload", localDir, "--recursive"}); err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+		})
+	})
+	if uploads != 1 {
+		t.Fatalf("expected the file upload on the transfer service, got %d", uploads)
+	}
+
+	err := Execute([]string{"--account", "a@example.com", "drive", "upload", localDir, "--recursive", "--resume"})
+	if err == nil || ExitCode(err) != 2 {
+		t.Fatalf("expected usage error for --resume with --recursive, got %v", err)
+	}
+}
@@ -0,0 +1,522 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+	gapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const driveRevisionFields = "id,mimeType,modifiedTime,keepForever,published,size,md5Checksum,originalFilename,lastModifyingUser(displayName,emailAddress),exportLinks"
+
+// DriveRevisionsCmd is the parent command for revisions subcommands
+type DriveRevisionsCmd struct {
+	List     DriveRevisionsListCmd     `cmd:"" name:"list" aliases:"ls" help:"List revisions of a file"`
+	Get      DriveRevisionsGetCmd      `cmd:"" name:"get" aliases:"info,show" help:"Get revision metadata"`
+	Download DriveRevisionsDownloadCmd `cmd:"" name:"download" help:"Download a revision (exports Google Docs formats)"`
+	Keep     DriveRevisionsKeepCmd     `cmd:"" name:"keep" aliases:"pin" help:"Keep a binary revision forever (or --off to allow pruning)"`
+	Delete   DriveRevisionsDeleteCmd   `cmd:"" name:"delete" aliases:"rm,del" help:"Delete a binary revision"`
+	Restore  DriveRevisionsRestoreCmd  `cmd:"" name:"restore" help:"Upload a binary revision as the new head revision"`
+}
+
+type DriveRevisionsListCmd struct {
+	FileID string `arg:"" name:"fileId" help:"File ID or path"`
+}
+
+func (c *DriveRevisionsListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	fileID := normalizeGoogleID(strings.TrimSpace(c.FileID))
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	fileID, err = resolveDriveID(ctx, svc, fileID)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := collectAllPages("", func(pageToken string) ([]*drive.Revision, string, error) {
+		call := svc.Revisions.List(fileID).
+			PageSize(1000).
+			Fields("nextPageToken,revisions(" + driveRevisionFields + ")").
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Revisions, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if revisions == nil {
+			revisions = []*drive.Revision{}
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"fileId":    fileID,
+			"revisions": revisions,
+		})
+	}
+
+	if len(revisions) == 0 {
+		u.Err().Println("No revisions")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tMODIFIED\tSIZE\tKEEP\tAUTHOR")
+	for _, r := range revisions {
+		size := "-"
+		if r.Size > 0 {
+			size = formatDriveSize(r.Size)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\n",
+			r.Id,
+			formatDateTime(r.ModifiedTime),
+			size,
+			r.KeepForever,
+			driveRevisionAuthor(r),
+		)
+	}
+	return nil
+}
+
+type DriveRevisionsGetCmd struct {
+	FileID     string `arg:"" name:"fileId" help:"File ID or path"`
+	RevisionID string `arg:"" name:"revisionId" help:"Revision ID"`
+}
+
+func (c *DriveRevisionsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	fileID, revisionID, err := driveRevisionArgs(c.FileID, c.RevisionID)
+	if err != nil {
+		return err
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	fileID, err = resolveDriveID(ctx, svc, fileID)
+	if err != nil {
+		return err
+	}
+	rev, err := getDriveRevision(ctx, svc, fileID, revisionID)
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"fileId": fileID, "revision": rev})
+	}
+	u.Out().Printf("id\t%s", rev.Id)
+	u.Out().Printf("modified\t%s", rev.ModifiedTime)
+	u.Out().Printf("mime\t%s", rev.MimeType)
+	if rev.Size > 0 {
+		u.Out().Printf("size\t%s", formatDriveSize(rev.Size))
+	}
+	if rev.Md5Checksum != "" {
+		u.Out().Printf("md5\t%s", rev.Md5Checksum)
+	}
+	if rev.OriginalFilename != "" {
+		u.Out().Printf("original_filename\t%s", rev.OriginalFilename)
+	}
+	if author := driveRevisionAuthor(rev); author != "" {
+		u.Out().Printf("author\t%s", author)
+	}
+	u.Out().Printf("keep_forever\t%t", rev.KeepForever)
+	return nil
+}
+
+type DriveRevisionsDownloadCmd struct {
+	FileID     string         `arg:"" name:"fileId" help:"File ID or path"`
+	RevisionID string         `arg:"" name:"revisionId" help:"Revision ID"`
+	Output     OutputPathFlag `embed:""`
+	Format     string         `name:"format" help:"Export format for Google Docs revisions: pdf|csv|xlsx|pptx|txt|png|docx (default: inferred)"`
+}
+
+func (c *DriveRevisionsDownloadCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	fileID, revisionID, err := driveRevisionArgs(c.FileID, c.RevisionID)
+	if err != nil {
+		return err
+	}
+	if formatErr := validateDriveDownloadFormatFlag(c.Format); formatErr != nil {
+		return formatErr
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	fileID, err = resolveDriveID(ctx, svc, fileID)
+	if err != nil {
+		return err
+	}
+	meta, err := svc.Files.Get(fileID).
+		SupportsAllDrives(true).
+		Fields("id, name, mimeType").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	if meta.MimeType == driveMimeFolder {
+		return usage("fileId is a folder")
+	}
+	if fileFormatErr := validateDriveDownloadFormatForFile(meta, c.Format); fileFormatErr != nil {
+		return fileFormatErr
+	}
+	rev, err := getDriveRevision(ctx, svc, fileID, revisionID)
+	if err != nil {
+		return err
+	}
+
+	destPath, err := resolveDriveDownloadDestPath(&drive.File{Id: meta.Id, Name: driveRevisionFileName(meta, rev.Id)}, c.Output.Path)
+	if err != nil {
+		return err
+	}
+	media, err := newDriveTransferService(ctx, account)
+	if err != nil {
+		return err
+	}
+	outPath, size, err := downloadDriveRevision(ctx, media, account, meta, rev, destPath, c.Format)
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"path":       outPath,
+			"size":       size,
+			"revisionId": rev.Id,
+		})
+	}
+	u.Out().Printf("path\t%s", outPath)
+	u.Out().Printf("size\t%s", formatDriveSize(size))
+	u.Out().Printf("revision\t%s", rev.Id)
+	return nil
+}
+
+type DriveRevisionsKeepCmd struct {
+	FileID     string `arg:"" name:"fileId" help:"File ID or path"`
+	RevisionID string `arg:"" name:"revisionId" help:"Revision ID"`
+	Off        bool   `name:"off" help:"Stop keeping the revision forever (Drive may prune it)"`
+}
+
+func (c *DriveRevisionsKeepCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	fileID, revisionID, err := driveRevisionArgs(c.FileID, c.RevisionID)
+	if err != nil {
+		return err
+	}
+	keep := !c.Off
+
+	if err := dryRunExit(ctx, flags, "drive.revisions.keep", map[string]any{
+		"file_id":      fileID,
+		"revision_id":  revisionID,
+		"keep_forever": keep,
+	}); err != nil {
+		return err
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	fileID, err = resolveDriveID(ctx, svc, fileID)
+	if err != nil {
+		return err
+	}
+	// ForceSendFields so --off actually sends keepForever=false.
+	rev, err := svc.Revisions.Update(fileID, revisionID, &drive.Revision{KeepForever: keep, ForceSendFields: []string{"KeepForever"}}).
+		Fields(driveRevisionFields).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"fileId": fileID, "revision": rev})
+	}
+	u.Out().Printf("id\t%s", rev.Id)
+	u.Out().Printf("keep_forever\t%t", rev.KeepForever)
+	return nil
+}
+
+type DriveRevisionsDeleteCmd struct {
+	FileID     string `arg:"" name:"fileId" help:"File ID or path"`
+	RevisionID string `arg:"" name:"revisionId" help:"Revision ID"`
+}
+
+func (c *DriveRevisionsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	fileID, revisionID, err := driveRevisionArgs(c.FileID, c.RevisionID)
+	if err != nil {
+		return err
+	}
+
+	if confirmErr := confirmDestructive(ctx, flags, fmt.Sprintf("permanently delete revision %s of drive file %s", revisionID, fileID)); confirmErr != nil {
+		return confirmErr
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	fileID, err = resolveDriveID(ctx, svc, fileID)
+	if err != nil {
+		return err
+	}
+	if err := svc.Revisions.Delete(fileID, revisionID).Context(ctx).Do(); err != nil {
+		return err
+	}
+	return writeResult(ctx, u,
+		kv("deleted", true),
+		kv("fileId", fileID),
+		kv("revisionId", revisionID),
+	)
+}
+
+type DriveRevisionsRestoreCmd struct {
+	FileID              string `arg:"" name:"fileId" help:"File ID or path"`
+	RevisionID          string `arg:"" name:"revisionId" help:"Revision ID to restore"`
+	KeepRevisionForever bool   `name:"keep-revision-forever" help:"Keep the restored head revision forever"`
+}
+
+func (c *DriveRevisionsRestoreCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	fileID, revisionID, err := driveRevisionArgs(c.FileID, c.RevisionID)
+	if err != nil {
+		return err
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	fileID, err = resolveDriveID(ctx, svc, fileID)
+	if err != nil {
+		return err
+	}
+	meta, err := svc.Files.Get(fileID).
+		SupportsAllDrives(true).
+		Fields("id, name, mimeType, headRevisionId").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(meta.MimeType, driveMimeGooglePrefix) {
+		return usage("restore only supports binary files; for Google Docs use the editor's version history, or `drive revisions download` and `drive upload --replace`")
+	}
+	if meta.HeadRevisionId != "" && meta.HeadRevisionId == revisionID {
+		return usagef("revision %s is already the head revision", revisionID)
+	}
+	rev, err := getDriveRevision(ctx, svc, fileID, revisionID)
+	if err != nil {
+		return err
+	}
+
+	if confirmErr := confirmDestructive(ctx, flags, fmt.Sprintf("replace the current content of %s with revision %s", meta.Name, revisionID)); confirmErr != nil {
+		return confirmErr
+	}
+
+	media, err := newDriveTransferService(ctx, account)
+	if err != nil {
+		return err
+	}
+	resp, err := media.Revisions.Get(fileID, revisionID).Context(ctx).Download()
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	mimeType := rev.MimeType
+	if mimeType == "" {
+		mimeType = meta.MimeType
+	}
+	call := media.Files.Update(fileID, &drive.File{}).
+		Media(resp.Body, gapi.ContentType(mimeType)).
+		SupportsAllDrives(true).
+		Fields("id, name, mimeType, size, md5Checksum, headRevisionId, modifiedTime").
+		Context(ctx)
+	if c.KeepRevisionForever {
+		call = call.KeepRevisionForever(true)
+	}
+	updated, err := call.Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"file":             updated,
+			"restoredRevision": revisionID,
+		})
+	}
+	u.Out().Printf("id\t%s", updated.Id)
+	u.Out().Printf("restored_revision\t%s", revisionID)
+	u.Out().Printf("head_revision\t%s", updated.HeadRevisionId)
+	return nil
+}
+
+func driveRevisionArgs(fileID, revisionID string) (string, string, error) {
+	fileID = normalizeGoogleID(strings.TrimSpace(fileID))
+	revisionID = strings.TrimSpace(revisionID)
+	if fileID == "" {
+		return "", "", usage("empty fileId")
+	}
+	if revisionID == "" {
+		return "", "", usage("empty revisionId")
+	}
+	return fileID, revisionID, nil
+}
+
+func getDriveRevision(ctx context.Context, svc *drive.Service, fileID, revisionID string) (*drive.Revision, error) {
+	return svc.Revisions.Get(fileID, revisionID).
+		Fields(driveRevisionFields).
+		Context(ctx).
+		Do()
+}
+
+func driveRevisionAuthor(r *drive.Revision) string {
+	if r.LastModifyingUser == nil {
+		return ""
+	}
+	if r.LastModifyingUser.EmailAddress != "" {
+		return r.LastModifyingUser.EmailAddress
+	}
+	return r.LastModifyingUser.DisplayName
+}
+
+// driveRevisionFileName tags a download with its revision ID so older copies
+// sit next to the current file without clobbering it.
+func driveRevisionFileName(meta *drive.File, revisionID string) string {
+	tag := ".rev" + revisionID
+	// Google Docs names carry no extension; the export one is appended later.
+	if strings.HasPrefix(meta.MimeType, driveMimeGooglePrefix) {
+		return meta.Name + tag
+	}
+	// Only split off an extension that matches the file's type, so names
+	// like "v2.0 Plan" stay intact.
+	ext := filepath.Ext(meta.Name)
+	if guessed := guessMimeType(meta.Name); ext == "" || guessed == "application/octet-stream" || guessed != meta.MimeType {
+		return meta.Name + tag
+	}
+	return strings.TrimSuffix(meta.Name, ext) + tag + ext
+}
+
+// downloadDriveRevision saves a binary revision's content, or exports a Google
+// Docs revision through its exportLinks (the export endpoint only serves the
+// head revision).
+func downloadDriveRevision(ctx context.Context, svc *drive.Service, account string, meta *drive.File, rev *drive.Revision, destPath, format string) (string, int64, error) {
+	if !strings.HasPrefix(meta.MimeType, driveMimeGooglePrefix) {
+		resp, err := svc.Revisions.Get(meta.Id, rev.Id).Context(ctx).Download()
+		if err != nil {
+			return "", 0, err
+		}
+		defer resp.Body.Close()
+		n, err := writeDriveRevisionBody(resp, destPath)
+		return destPath, n, err
+	}
+
+	exportMimeType := driveExportMimeType(meta.MimeType)
+	if strings.TrimSpace(format) != "" {
+		var err error
+		exportMimeType, err = driveExportMimeTypeForFormat(meta.MimeType, strings.ToLower(strings.TrimSpace(format)))
+		if err != nil {
+			return "", 0, err
+		}
+	}
+	link := rev.ExportLinks[exportMimeType]
+	if link == "" {
+		return "", 0, fmt.Errorf("revision %s cannot be exported as %s", rev.Id, exportMimeType)
+	}
+
+	client, err := newDriveHTTPClient(ctx, account)
+	if err != nil {
+		return "", 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Body.Close()
+
+	// Append rather than replace: revision names already end in ".rev<id>".
+	outPath := destPath
+	if ext := driveExportExtension(exportMimeType); !strings.EqualFold(filepath.Ext(outPath), ext) {
+		outPath += ext
+	}
+	n, err := writeDriveRevisionBody(resp, outPath)
+	return outPath, n, err
+}
+
+func writeDriveRevisionBody(resp *http.Response, outPath string) (int64, error) {
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("download failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	f, err := os.Create(outPath) //nolint:gosec // user-provided path
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(f, resp.Body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(outPath)
+		return 0, err
+	}
+	return n, nil
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+)
+
+func TestDriveRevisionFileName(t *testing.T) {
+	cases := []struct {
+		name, mime, want string
+	}{
+		{"Budget.xlsx", mimeXlsx, "Budget.rev42.xlsx"},
+		{"Notes", "application/octet-stream", "Notes.rev42"},
+		{"v2.0 Plan", driveMimeGoogleDoc, "v2.0 Plan.rev42"},
+		{"report v2.0", "application/octet-stream", "report v2.0.rev42"},
+	}
+	for _, tc := range cases {
+		if got := driveRevisionFileName(&drive.File{Name: tc.name, MimeType: tc.mime}, "42"); got != tc.want {
+			t.Fatalf("%q: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestDriveRevisionsDownload_ExportsDocRevision(t *testing.T) {
+	outDir := t.TempDir()
+	var srvURL string
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/files/doc1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "doc1", "name": "Plan", "mimeType": driveMimeGoogleDoc})
+		case r.URL.Path == "/files/doc1/revisions/12":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "12", "exportLinks": map[string]string{
+				"application/pdf": srvURL + "/export/doc1/12/pdf",
+				mimeDocx:          srvURL + "/export/doc1/12/docx",
+			}})
+		case r.URL.Path == "/export/doc1/12/docx":
+			_, _ = w.Write([]byte("old-docx"))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			http.NotFound(w, r)
+		}
+	}))
+	defer closeSrv()
+	srvURL = strings.TrimSuffix(svc.BasePath, "/")
+
+	origNew, origHTTP := newDriveService, newDriveHTTPClient
+	t.Cleanup(func() { newDriveService, newDriveHTTPClient = origNew, origHTTP })
+	newDriveService = stubDriveService(svc)
+	newDriveHTTPClient = func(context.Context, string) (*http.Client, error) { return http.DefaultClient, nil }
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = Execute([]string{"--json", "--account", "a@example.com", "drive", "revisions", "download", "doc1", "12", "--format", "docx", "--out", outDir})
+	})
+	if runErr != nil {
+		t.Fatalf("Execute: %v", runErr)
+	}
+	want := filepath.Join(outDir, "doc1_Plan.rev12.docx")
+	if b, err := os.ReadFile(want); err != nil || string(b) != "old-docx" {
+		t.Fatalf("expected exported revision at %s, got %q err=%v (out=%s)", want, b, err, out)
+	}
+}
+
+func TestDriveRevisionsRestore_UploadsBinaryRevision(t *testing.T) {
+	var uploaded string
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files/x1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "x1", "name": "export.csv", "mimeType": "text/csv", "headRevisionId": "r3"})
+		case r.Method == http.MethodGet && r.URL.Path == "/files/x1/revisions/r1" && r.URL.Query().Get("alt") == "media":
+			_, _ = w.Write([]byte("a,b\n1,2\n"))
+		case r.Method == http.MethodGet && r.URL.Path == "/files/x1/revisions/r1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "r1", "mimeType": "text/csv"})
+		case r.Method == http.MethodPatch && r.URL.Path == "/upload/drive/v3/files/x1":
+			uploaded = readBody(t, r)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "x1", "headRevisionId": "r4"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			http.NotFound(w, r)
+		}
+	}))
+	defer closeSrv()
+
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = stubDriveService(svc)
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = Execute([]string{"--json", "--force", "--account", "a@example.com", "drive", "revisions", "restore", "x1", "r1"})
+	})
+	if runErr != nil {
+		t.Fatalf("Execute: %v", runErr)
+	}
+	if !strings.Contains(uploaded, "a,b\n1,2\n") {
+		t.Fatalf("expected revision content uploaded, got %q", uploaded)
+	}
+	if !strings.Contains(out, `"restoredRevision": "r1"`) || !strings.Contains(out, `"r4"`) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+}
+
+func TestDriveRevisionsRestore_RejectsGoogleDocs(t *testing.T) {
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "s1", "name": "Budget", "mimeType": driveMimeGoogleSheet})
+	}))
+	defer closeSrv()
+
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = stubDriveService(svc)
+
+	err := Execute([]string{"--force", "--account", "a@example.com", "drive", "revisions", "restore", "s1", "5"})
+	if err == nil || !strings.Contains(err.Error(), "binary files") {
+		t.Fatalf("expected binary-only error, got %v", err)
+	}
+}
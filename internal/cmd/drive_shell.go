@@ -0,0 +1,591 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/errfmt"
+	"github.com/steipete/gogcli/internal/input"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+var (
+	driveShellExecute     = executeContext
+	openDriveShellBrowser = func(url string) error { return openProposeTimeBrowser(url) }
+
+	errDriveShellExit = errors.New("exit")
+)
+
+const driveShellHelp = `Commands (paths are relative to the current folder; /, drive:, shared:Name/ and id:<fileId> are absolute):
+  ls [path] [flags]          List a folder (flags as in 'drive ls')
+  cd [path]                  Change folder (no path: My Drive root)
+  pwd                        Print the current folder and its ID
+  get <path> [local] [flags] Download a file or folder (flags as in 'drive download')
+  put <local> [path] [flags] Upload into the current folder or path (flags as in 'drive upload')
+  mv <path> <dest>           Move into folder <dest>, or rename/move to <dest>
+  rm <path> [flags]          Move to trash (--permanent to delete)
+  share <path> [flags]       Share (flags as in 'drive share')
+  open <path>                Open in the browser
+  <drive subcommand> ...     Run any other 'gog drive' subcommand unchanged (except shell)
+  help, exit`
+
+// driveShellPassthrough holds the 'gog drive' subcommands (and aliases) the
+// shell runs unchanged; the shell itself is left out so it cannot nest.
+var driveShellPassthrough = func() map[string]bool {
+	out := map[string]bool{}
+	t := reflect.TypeOf(DriveCmd{})
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag
+		name := tag.Get("name")
+		if name == "" || name == "shell" {
+			continue
+		}
+		out[name] = true
+		for _, alias := range strings.Split(tag.Get("aliases"), ",") {
+			if alias = strings.TrimSpace(alias); alias != "" {
+				out[alias] = true
+			}
+		}
+	}
+	return out
+}()
+
+var driveShellCommands = []string{"cd", "exit", "get", "help", "ls", "mv", "open", "put", "pwd", "quit", "rm", "share"}
+
+type DriveShellCmd struct {
+	Cd string `name:"cd" help:"Starting folder (ID or path; default: My Drive root)"`
+}
+
+// driveShellLocation is a folder path below an anchor (My Drive root, a shared
+// drive, or a folder ID given with id:).
+type driveShellLocation struct {
+	RootID    string
+	RootLabel string
+	Dir       string
+}
+
+func (l driveShellLocation) String() string {
+	if l.RootLabel == drivePathPrefixMyDrive {
+		return l.RootLabel + l.Dir
+	}
+	if l.Dir == "/" {
+		return l.RootLabel
+	}
+	return l.RootLabel + l.Dir
+}
+
+type driveShell struct {
+	ctx      context.Context
+	flags    *RootFlags
+	account  string
+	svc      *drive.Service
+	cwd      driveShellLocation
+	cwdID    string
+	children map[string][]*drive.File
+}
+
+func (c *DriveShellCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	sh := &driveShell{
+		ctx:      ctx,
+		flags:    flags,
+		account:  account,
+		svc:      svc,
+		cwd:      driveShellLocation{RootID: "root", RootLabel: drivePathPrefixMyDrive, Dir: "/"},
+		cwdID:    "root",
+		children: map[string][]*drive.File{},
+	}
+	if start := strings.TrimSpace(c.Cd); start != "" {
+		if !isDrivePath(start) && !strings.HasPrefix(start, "id:") {
+			start = "id:" + normalizeGoogleID(start)
+		}
+		if err := sh.cd([]string{start}); err != nil {
+			return err
+		}
+	}
+
+	reader := input.NewLineReader(os.Stdin, os.Stderr)
+	reader.SetCompleter(sh.complete)
+	defer sh.attach(reader)()
+	for {
+		line, err := reader.ReadLine(sh.cwd.String() + "> ")
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		args, err := splitShellWords(line)
+		if err == nil {
+			err = sh.run(args)
+		}
+		if errors.Is(err, errDriveShellExit) {
+			return nil
+		}
+		var reported driveShellReportedError
+		if err != nil && !errors.As(err, &reported) {
+			u.Err().Error(strings.TrimSpace(errfmt.Format(err)))
+		}
+	}
+}
+
+// attach makes commands the shell runs through the regular CLI use this
+// session's Drive service, so path lookups stay cached for the whole
+// session, and read confirmations through reader, so a prompt does not
+// compete with the shell for stdin. The returned func restores both.
+func (s *driveShell) attach(reader *input.LineReader) func() {
+	origDrive, origPrompt := newDriveService, confirmPrompt
+	newDriveService = func(ctx context.Context, email string) (*drive.Service, error) {
+		if email == s.account {
+			return s.svc, nil
+		}
+		return origDrive(ctx, email)
+	}
+	confirmPrompt = func(_ context.Context, prompt string) (string, error) {
+		return reader.ReadLine(prompt)
+	}
+	return func() { newDriveService, confirmPrompt = origDrive, origPrompt }
+}
+
+func (s *driveShell) run(args []string) error {
+	name, rest := args[0], args[1:]
+	switch name {
+	case "exit", "quit":
+		return errDriveShellExit
+	case "help", "?":
+		_, _ = fmt.Fprintln(os.Stderr, driveShellHelp)
+		return nil
+	case "pwd":
+		ui.FromContext(s.ctx).Out().Printf("%s\t%s", s.cwd, s.cwdID)
+		return nil
+	case "cd":
+		return s.cd(rest)
+	case "ls":
+		target, extra, err := s.positional(rest, 0, 1)
+		if err != nil {
+			return err
+		}
+		folderID := s.cwdID
+		if len(target) == 1 {
+			if folderID, err = s.resolve(target[0]); err != nil {
+				return err
+			}
+		}
+		if !hasFlag(extra, "--max", "--limit") {
+			extra = append(extra, "--max", "100")
+		}
+		return s.drive(append([]string{"ls", folderID}, extra...))
+	case "get":
+		target, extra, err := s.positional(rest, 1, 2)
+		if err != nil {
+			return err
+		}
+		id, err := s.resolve(target[0])
+		if err != nil {
+			return err
+		}
+		argv := []string{"download", id}
+		if len(target) == 2 {
+			argv = append(argv, "--out", target[1])
+		}
+		if !hasFlag(extra, "--recursive", "-r") {
+			if meta, metaErr := s.svc.Files.Get(id).SupportsAllDrives(true).Fields("mimeType").Context(s.ctx).Do(); metaErr == nil && meta.MimeType == driveMimeFolder {
+				argv = append(argv, "--recursive")
+			}
+		}
+		return s.drive(append(argv, extra...))
+	case "put":
+		target, extra, err := s.positional(rest, 1, 2)
+		if err != nil {
+			return err
+		}
+		parent := s.cwdID
+		if len(target) == 2 {
+			if parent, err = s.resolve(target[1]); err != nil {
+				return err
+			}
+		}
+		argv := []string{"upload", target[0], "--parent", parent}
+		if st, statErr := os.Stat(target[0]); statErr == nil && st.IsDir() && !hasFlag(extra, "--recursive", "-r") {
+			argv = append(argv, "--recursive")
+		}
+		return s.mutate(append(argv, extra...))
+	case "mv":
+		target, extra, err := s.positional(rest, 2, 2)
+		if err != nil {
+			return err
+		}
+		return s.mv(target[0], target[1], extra)
+	case "rm":
+		target, extra, err := s.positional(rest, 1, 1)
+		if err != nil {
+			return err
+		}
+		id, err := s.resolve(target[0])
+		if err != nil {
+			return err
+		}
+		return s.mutate(append([]string{"delete", id}, extra...))
+	case "share":
+		target, extra, err := s.positional(rest, 1, 1)
+		if err != nil {
+			return err
+		}
+		id, err := s.resolve(target[0])
+		if err != nil {
+			return err
+		}
+		return s.drive(append([]string{"share", id}, extra...))
+	case "open":
+		target, _, err := s.positional(rest, 1, 1)
+		if err != nil {
+			return err
+		}
+		id, err := s.resolve(target[0])
+		if err != nil {
+			return err
+		}
+		link, err := driveWebLink(s.ctx, s.svc, id)
+		if err != nil {
+			return err
+		}
+		ui.FromContext(s.ctx).Out().Printf("%s", link)
+		return openDriveShellBrowser(link)
+	default:
+		if !driveShellPassthrough[name] {
+			return usagef("unknown command %q; see help", name)
+		}
+		return s.mutate(args)
+	}
+}
+
+func (s *driveShell) cd(args []string) error {
+	if len(args) > 1 {
+		return usage("cd takes at most one path")
+	}
+	target := "~"
+	if len(args) == 1 {
+		target = args[0]
+	}
+	loc, err := s.locate(target)
+	if err != nil {
+		return err
+	}
+	id, err := resolveDrivePathFrom(s.ctx, s.svc, loc.String(), loc.RootID, loc.Dir)
+	if err != nil {
+		return err
+	}
+	meta, err := s.svc.Files.Get(id).SupportsAllDrives(true).Fields("id, mimeType").Context(s.ctx).Do()
+	if err != nil {
+		return err
+	}
+	if meta.MimeType != driveMimeFolder {
+		return usagef("%s is not a folder", loc)
+	}
+	s.cwd, s.cwdID = loc, id
+	return nil
+}
+
+// mv moves src into dest when dest is an existing folder; otherwise dest names
+// the new location and file name.
+func (s *driveShell) mv(src, dest string, extra []string) error {
+	srcID, err := s.resolve(src)
+	if err != nil {
+		return err
+	}
+	destID, destErr := s.resolve(dest)
+	if destErr != nil && !errors.Is(destErr, errDrivePathNotFound) {
+		return destErr
+	}
+	if destErr == nil {
+		meta, metaErr := s.svc.Files.Get(destID).SupportsAllDrives(true).Fields("id, mimeType").Context(s.ctx).Do()
+		if metaErr != nil {
+			return metaErr
+		}
+		if meta.MimeType != driveMimeFolder {
+			return usagef("%s already exists", dest)
+		}
+		return s.mutate(append([]string{"move", srcID, "--parent", destID}, extra...))
+	}
+
+	srcLoc, err := s.locate(src)
+	if err != nil {
+		return err
+	}
+	destLoc, err := s.locate(dest)
+	if err != nil {
+		return err
+	}
+	newName := path.Base(destLoc.Dir)
+	if newName == "/" {
+		return usagef("invalid destination %q", dest)
+	}
+	destDir := destLoc
+	destDir.Dir = path.Dir(destLoc.Dir)
+	srcDir := srcLoc
+	srcDir.Dir = path.Dir(srcLoc.Dir)
+	if destDir != srcDir {
+		parentID, err := resolveDrivePathFrom(s.ctx, s.svc, destDir.String(), destDir.RootID, destDir.Dir)
+		if err != nil {
+			return err
+		}
+		if err := s.mutate([]string{"move", srcID, "--parent", parentID}); err != nil {
+			return err
+		}
+	}
+	return s.mutate(append([]string{"rename", srcID, newName}, extra...))
+}
+
+// locate turns a shell argument into an absolute location.
+func (s *driveShell) locate(arg string) (driveShellLocation, error) {
+	arg = strings.TrimSpace(arg)
+	switch {
+	case arg == "~" || strings.HasPrefix(arg, "~/"):
+		return driveShellLocation{RootID: "root", RootLabel: drivePathPrefixMyDrive, Dir: cleanShellDir(strings.TrimPrefix(arg, "~"))}, nil
+	case strings.HasPrefix(arg, "/"), strings.HasPrefix(arg, drivePathPrefixMyDrive):
+		return driveShellLocation{RootID: "root", RootLabel: drivePathPrefixMyDrive, Dir: cleanShellDir(strings.TrimPrefix(arg, drivePathPrefixMyDrive))}, nil
+	case strings.HasPrefix(arg, drivePathPrefixShared):
+		name, rest, _ := strings.Cut(strings.TrimLeft(strings.TrimPrefix(arg, drivePathPrefixShared), "/"), "/")
+		if strings.TrimSpace(name) == "" {
+			return driveShellLocation{}, usagef("missing shared drive name in %q", arg)
+		}
+		id, err := resolveSharedDriveName(s.ctx, s.svc, name)
+		if err != nil {
+			return driveShellLocation{}, err
+		}
+		return driveShellLocation{RootID: id, RootLabel: drivePathPrefixShared + name, Dir: cleanShellDir(rest)}, nil
+	case strings.HasPrefix(arg, "id:"):
+		id, rest, _ := strings.Cut(strings.TrimPrefix(arg, "id:"), "/")
+		id = normalizeGoogleID(id)
+		if id == "" {
+			return driveShellLocation{}, usagef("missing file ID in %q", arg)
+		}
+		return driveShellLocation{RootID: id, RootLabel: "id:" + id, Dir: cleanShellDir(rest)}, nil
+	default:
+		loc := s.cwd
+		loc.Dir = cleanShellDir(s.cwd.Dir + "/" + arg)
+		return loc, nil
+	}
+}
+
+func (s *driveShell) resolve(arg string) (string, error) {
+	loc, err := s.locate(arg)
+	if err != nil {
+		return "", err
+	}
+	return resolveDrivePathFrom(s.ctx, s.svc, loc.String(), loc.RootID, loc.Dir)
+}
+
+// positional splits leading path arguments from pass-through flags.
+func (s *driveShell) positional(args []string, minArgs, maxArgs int) ([]string, []string, error) {
+	n := 0
+	for n < len(args) && n < maxArgs && !strings.HasPrefix(args[n], "-") {
+		n++
+	}
+	if n < minArgs {
+		return nil, nil, usagef("expected at least %d argument(s); see help", minArgs)
+	}
+	return args[:n], args[n:], nil
+}
+
+func (s *driveShell) mutate(args []string) error {
+	defer s.forget()
+	return s.drive(args)
+}
+
+// driveShellReportedError is a failed CLI command whose error Execute has
+// already printed.
+type driveShellReportedError struct{ error }
+
+func (e driveShellReportedError) Unwrap() error { return e.error }
+
+// drive runs `gog drive <args>` with the shell's global flags.
+func (s *driveShell) drive(args []string) error {
+	argv := []string{"--account", s.account}
+	if s.flags.Client != "" {
+		argv = append(argv, "--client", s.flags.Client)
+	}
+	for _, f := range []struct {
+		on   bool
+		flag string
+	}{
+		{s.flags.JSON, "--json"},
+		{s.flags.Plain, "--plain"},
+		{s.flags.Force, "--force"},
+		{s.flags.DryRun, "--dry-run"},
+		{s.flags.NoInput, "--no-input"},
+		{s.flags.Verbose, "--verbose"},
+	} {
+		if f.on {
+			argv = append(argv, f.flag)
+		}
+	}
+	argv = append(argv, "drive")
+	if err := driveShellExecute(context.Background(), append(argv, args...)); err != nil {
+		return driveShellReportedError{err}
+	}
+	return nil
+}
+
+func (s *driveShell) forget() {
+	s.children = map[string][]*drive.File{}
+	forgetDrivePaths(s.svc)
+}
+
+// complete offers command names for the first word and child names (folders
+// with a trailing slash) for later words.
+func (s *driveShell) complete(head string) []string {
+	start, partial, ok := lastShellWord(head)
+	if !ok {
+		return nil
+	}
+	if strings.TrimSpace(head[:start]) == "" {
+		var out []string
+		for _, name := range driveShellCommands {
+			out = append(out, head[:start]+name+" ")
+		}
+		return out
+	}
+
+	dir, base := "", partial
+	if i := strings.LastIndex(partial, "/"); i >= 0 {
+		dir, base = partial[:i+1], partial[i+1:]
+	}
+	folderID := s.cwdID
+	if dir != "" {
+		id, err := s.resolve(dir)
+		if err != nil {
+			return nil
+		}
+		folderID = id
+	}
+	children, ok := s.children[folderID]
+	if !ok {
+		var err error
+		children, err = listDriveFolderChildrenFields(s.ctx, s.svc, folderID, "nextPageToken, files(id, name, mimeType)")
+		if err != nil {
+			return nil
+		}
+		s.children[folderID] = children
+	}
+
+	var out []string
+	for _, f := range children {
+		if f == nil || !strings.HasPrefix(f.Name, base) {
+			continue
+		}
+		word := escapeShellWord(dir + f.Name)
+		if f.MimeType == driveMimeFolder {
+			word += "/"
+		}
+		out = append(out, head[:start]+word)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func cleanShellDir(p string) string {
+	return path.Clean("/" + strings.TrimSpace(p))
+}
+
+func hasFlag(args []string, names ...string) bool {
+	for _, a := range args {
+		for _, n := range names {
+			if a == n || strings.HasPrefix(a, n+"=") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitShellWords splits a line into words with POSIX-like quoting: single
+// quotes, double quotes, and backslash escapes.
+func splitShellWords(line string) ([]string, error) {
+	var (
+		words   []string
+		cur     strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range line {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		default:
+			cur.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, usage("unterminated quote or escape")
+	}
+	if inWord {
+		words = append(words, cur.String())
+	}
+	return words, nil
+}
+
+// lastShellWord returns the byte offset where the word under the cursor starts
+// and its unquoted value. ok is false inside an unterminated quote.
+func lastShellWord(head string) (int, string, bool) {
+	start := len(head)
+	for i := len(head) - 1; i >= 0; i-- {
+		if head[i] == ' ' && (i == 0 || head[i-1] != '\\') {
+			break
+		}
+		start = i
+	}
+	words, err := splitShellWords(head[start:])
+	if err != nil {
+		return 0, "", false
+	}
+	if len(words) == 0 {
+		return start, "", true
+	}
+	return start, words[0], true
+}
+
+func escapeShellWord(s string) string {
+	return strings.NewReplacer(`\`, `\\`, " ", `\ `, "'", `\'`, `"`, `\"`).Replace(s)
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/input"
+)
+
+func TestSplitShellWords(t *testing.T) {
+	got, err := splitShellWords(`mv "Old Notes.txt" Archive\ 2026/ 'it''s' -r`)
+	if err != nil {
+		t.Fatalf("splitShellWords: %v", err)
+	}
+	want := []string{"mv", "Old Notes.txt", "Archive 2026/", "its", "-r"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if _, err := splitShellWords(`ls "open`); err == nil {
+		t.Fatalf("expected unterminated quote error")
+	}
+	if start, word, ok := lastShellWord(`get Report\ 20`); !ok || start != 4 || word != "Report 20" {
+		t.Fatalf("lastShellWord = %d %q %v", start, word, ok)
+	}
+}
+
+func TestDriveShell_NavigateMoveRenameRemove(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		patches []string
+	)
+	lookups := map[string]map[string]any{
+		"'root' in parents and name = 'Projects'":           {"id": "fProjects", "mimeType": driveMimeFolder},
+		"'fProjects' in parents and name = 'Plan.docx'":     {"id": "fPlan", "mimeType": mimeDocx},
+		"'fProjects' in parents and name = 'Archive'":       {"id": "fArchive", "mimeType": driveMimeFolder},
+		"'fProjects' in parents and name = 'Notes.txt'":     {"id": "fNotes", "mimeType": mimeTextPlain},
+		"'fProjects' in parents and name = 'Old Notes.txt'": {"id": "fOld", "mimeType": mimeTextPlain},
+	}
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query().Get("q")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files" && strings.Contains(q, " and name = "):
+			var files []map[string]any
+			for prefix, f := range lookups {
+				if strings.HasPrefix(q, prefix) {
+					files = append(files, f)
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": files})
+		case r.Method == http.MethodGet && r.URL.Path == "/files":
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{{"id": "fPlan", "name": "Plan.docx", "mimeType": mimeDocx}}})
+		case r.Method == http.MethodGet && (r.URL.Path == "/files/fProjects" || r.URL.Path == "/files/fArchive"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": strings.TrimPrefix(r.URL.Path, "/files/"), "mimeType": driveMimeFolder})
+		case r.Method == http.MethodGet && r.URL.Path == "/files/fPlan":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "fPlan", "name": "Plan.docx", "parents": []string{"fProjects"}})
+		case r.Method == http.MethodPatch:
+			id := strings.TrimPrefix(r.URL.Path, "/files/")
+			entry := id + " " + strings.TrimSpace(readBody(t, r))
+			if add := r.URL.Query().Get("addParents"); add != "" {
+				entry += " +" + add
+			}
+			mu.Lock()
+			patches = append(patches, entry)
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": id})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			http.NotFound(w, r)
+		}
+	}))
+	defer closeSrv()
+
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = stubDriveService(svc)
+
+	origStdin := os.Stdin
+	t.Cleanup(func() { os.Stdin = origStdin })
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	os.Stdin = pr
+	_, _ = pw.WriteString("cd Projects\npwd\nls\nmv Plan.docx Archive\nmv Notes.txt Ideas.txt\nrm \"Old Notes.txt\"\nexit\n")
+	_ = pw.Close()
+
+	var runErr error
+	out := captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			runErr = Execute([]string{"--force", "--account", "a@example.com", "drive", "shell"})
+		})
+	})
+	if runErr != nil {
+		t.Fatalf("Execute: %v", runErr)
+	}
+	if !strings.Contains(out, "drive:/Projects\tfProjects") || !strings.Contains(out, "Plan.docx") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+	want := []string{
+		"fPlan {} +fArchive",
+		`fNotes {"name":"Ideas.txt"}`,
+		`fOld {"trashed":true}`,
+	}
+	if !reflect.DeepEqual(patches, want) {
+		t.Fatalf("unexpected patches:\n got %q\nwant %q", patches, want)
+	}
+}
+
+func TestDriveShell_RejectsUnknownAndNestedCommands(t *testing.T) {
+	var executed [][]string
+	origExec := driveShellExecute
+	t.Cleanup(func() { driveShellExecute = origExec })
+	driveShellExecute = func(_ context.Context, args []string) error {
+		executed = append(executed, args)
+		return nil
+	}
+
+	sh := &driveShell{ctx: context.Background(), flags: &RootFlags{}, account: "a@example.com", svc: &drive.Service{}, children: map[string][]*drive.File{}}
+	for _, line := range [][]string{{"shell"}, {"frobnicate", "x"}} {
+		if err := sh.run(line); err == nil || !strings.Contains(err.Error(), "unknown command") {
+			t.Fatalf("%v: expected unknown command error, got %v", line, err)
+		}
+	}
+	if err := sh.run([]string{"permissions", "f1"}); err != nil {
+		t.Fatalf("permissions: %v", err)
+	}
+	if len(executed) != 1 || executed[0][len(executed[0])-2] != "permissions" {
+		t.Fatalf("unexpected executed commands: %q", executed)
+	}
+}
+
+func TestDriveShell_KeepsRunningAfterFailedCommands(t *testing.T) {
+	origNew, origExec := newDriveService, driveShellExecute
+	t.Cleanup(func() {
+		newDriveService = origNew
+		driveShellExecute = origExec
+	})
+	newDriveService = stubDriveService(&drive.Service{})
+	var executed []string
+	driveShellExecute = func(_ context.Context, args []string) error {
+		executed = append(executed, args[len(args)-2])
+		if len(executed) == 1 {
+			return &ExitError{Code: 1, Err: errors.New("boom")}
+		}
+		return nil
+	}
+
+	origStdin := os.Stdin
+	t.Cleanup(func() { os.Stdin = origStdin })
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	os.Stdin = pr
+	_, _ = pw.WriteString("permissions f1\nfrobnicate\nrevisions f2\nexit\n")
+	_ = pw.Close()
+
+	var runErr error
+	stderr := captureStderr(t, func() {
+		runErr = Execute([]string{"--account", "a@example.com", "drive", "shell"})
+	})
+	if runErr != nil {
+		t.Fatalf("Execute: %v", runErr)
+	}
+	if strings.Join(executed, ",") != "permissions,revisions" {
+		t.Fatalf("shell stopped after a failed command: %q", executed)
+	}
+	if !strings.Contains(stderr, "unknown command") {
+		t.Fatalf("expected the shell's own error on stderr, got %q", stderr)
+	}
+	if strings.Contains(stderr, "boom") {
+		t.Fatalf("errors reported by the command must not be printed twice: %q", stderr)
+	}
+}
+
+func TestDriveShell_AttachRoutesServiceAndPrompts(t *testing.T) {
+	origNew, origPrompt := newDriveService, confirmPrompt
+	t.Cleanup(func() {
+		newDriveService = origNew
+		confirmPrompt = origPrompt
+	})
+	other := &drive.Service{}
+	newDriveService = stubDriveService(other)
+
+	svc := &drive.Service{}
+	sh := &driveShell{account: "a@example.com", svc: svc}
+	reader := input.NewLineReader(strings.NewReader("y\n"), io.Discard)
+	restore := sh.attach(reader)
+
+	if got, err := newDriveService(context.Background(), "a@example.com"); err != nil || got != svc {
+		t.Fatalf("expected the session service, got %p (%v)", got, err)
+	}
+	if got, _ := newDriveService(context.Background(), "b@example.com"); got != other {
+		t.Fatalf("session service must not leak to other accounts")
+	}
+	if line, err := confirmPrompt(context.Background(), "Proceed? "); err != nil || line != "y" {
+		t.Fatalf("expected the prompt to read from the shell reader, got %q (%v)", line, err)
+	}
+
+	restore()
+	if got, _ := newDriveService(context.Background(), "a@example.com"); got != other {
+		t.Fatalf("newDriveService not restored after the shell")
+	}
+}
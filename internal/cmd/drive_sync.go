@@ -0,0 +1,815 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	gapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	driveSyncPush = "push"
+	driveSyncPull = "pull"
+	driveSyncBoth = "both"
+
+	driveSyncTrash  = "trash"
+	driveSyncDelete = "delete"
+	driveSyncKeep   = "keep"
+
+	driveSyncConflictNewer  = "newer"
+	driveSyncConflictLocal  = "local"
+	driveSyncConflictRemote = "remote"
+	driveSyncConflictSkip   = "skip"
+
+	driveSyncOpNone         = "unchanged"
+	driveSyncOpUpload       = "upload"
+	driveSyncOpUpdate       = "update"
+	driveSyncOpDownload     = "download"
+	driveSyncOpRenameRemote = "rename-remote"
+	driveSyncOpRenameLocal  = "rename-local"
+	driveSyncOpDeleteRemote = "delete-remote"
+	driveSyncOpDeleteLocal  = "delete-local"
+	driveSyncOpSkip         = "skip"
+
+	driveSyncFileFields = "id, name, md5Checksum, modifiedTime, size, parents"
+
+	// Locally deleted files are moved into a sibling of the sync root so they
+	// stay on the same device and outside the synced tree.
+	driveSyncTrashSuffix = ".gog-trash"
+)
+
+type DriveSyncCmd struct {
+	LocalDir    string   `arg:"" name:"localDir" help:"Local directory"`
+	FolderID    string   `arg:"" name:"folderId" help:"Drive folder ID or path"`
+	Direction   string   `name:"direction" help:"push (local -> Drive), pull (Drive -> local), or both" enum:"push,pull,both" default:"both"`
+	TrashPolicy string   `name:"trash-policy" help:"How deletions propagate: trash (Drive trash / <localDir>.gog-trash), delete (permanent), keep (never delete)" enum:"trash,delete,keep" default:"trash"`
+	Conflict    string   `name:"conflict" help:"When both sides changed (direction=both): newer|local|remote|skip" enum:"newer,local,remote,skip" default:"newer"`
+	Exclude     []string `name:"exclude" help:"Glob pattern to ignore (matched against relative path and file name; can be repeated)"`
+}
+
+// driveSyncFileState records what both sides looked like after the last
+// successful sync of a path; it drives change, delete, and rename detection.
+type driveSyncFileState struct {
+	FileID         string `json:"fileId"`
+	RemoteMD5      string `json:"remoteMd5,omitempty"`
+	RemoteModified string `json:"remoteModified,omitempty"`
+	LocalMD5       string `json:"localMd5"`
+	Native         bool   `json:"native,omitempty"`
+}
+
+type driveSyncState struct {
+	Account     string                         `json:"account"`
+	FolderID    string                         `json:"folderId"`
+	LocalDir    string                         `json:"localDir"`
+	Files       map[string]*driveSyncFileState `json:"files"`
+	UpdatedAtMs int64                          `json:"updatedAtMs,omitempty"`
+}
+
+type driveSyncStore struct {
+	path  string
+	state driveSyncState
+}
+
+func driveSyncStatePath(account, folderID, localDir string) (string, error) {
+	dir, err := config.EnsureDriveSyncDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(localDir))
+	name := sanitizeAccountForPath(account) + "_" + sanitizeAccountForPath(folderID) + "_" + hex.EncodeToString(sum[:6])
+	return filepath.Join(dir, name+".json"), nil
+}
+
+func loadDriveSyncStore(account, folderID, localDir string) (*driveSyncStore, error) {
+	p, err := driveSyncStatePath(account, folderID, localDir)
+	if err != nil {
+		return nil, err
+	}
+	store := &driveSyncStore{path: p}
+	data, err := os.ReadFile(p) //nolint:gosec // state file under config dir
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &store.state); err != nil {
+			return nil, fmt.Errorf("read drive sync state %s: %w", p, err)
+		}
+	}
+	if store.state.Files == nil {
+		store.state.Files = map[string]*driveSyncFileState{}
+	}
+	store.state.Account = account
+	store.state.FolderID = folderID
+	store.state.LocalDir = localDir
+	return store, nil
+}
+
+func (s *driveSyncStore) Save() error {
+	s.state.UpdatedAtMs = time.Now().UnixMilli()
+	payload, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, append(payload, '\n'), 0o600)
+}
+
+type driveSyncOptions struct {
+	Direction   string
+	TrashPolicy string
+	Conflict    string
+}
+
+func (o driveSyncOptions) push() bool { return o.Direction != driveSyncPull }
+func (o driveSyncOptions) pull() bool { return o.Direction != driveSyncPush }
+
+type driveSyncAction struct {
+	Op     string `json:"op"`
+	Path   string `json:"path"`
+	From   string `json:"from,omitempty"`
+	FileID string `json:"fileId,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func driveSyncLocalChanged(l *driveLocalFile, s *driveSyncFileState) bool {
+	return s == nil || l.MD5 != s.LocalMD5
+}
+
+func driveSyncRemoteChanged(r *driveRemoteFile, s *driveSyncFileState) bool {
+	if s == nil || r.ID != s.FileID {
+		return true
+	}
+	if r.Native || r.MD5 == "" {
+		return r.ModifiedTime != s.RemoteModified
+	}
+	return r.MD5 != s.RemoteMD5
+}
+
+// planDriveSync compares both trees against the last-synced state and decides
+// what to do with every path. It performs no I/O.
+func planDriveSync(local map[string]*driveLocalFile, remote *driveRemoteTree, state map[string]*driveSyncFileState, opts driveSyncOptions) []driveSyncAction {
+	var actions []driveSyncAction
+	handled := map[string]bool{}
+	remoteByID := remote.byID()
+
+	trackedPaths := make([]string, 0, len(state))
+	for p := range state {
+		trackedPaths = append(trackedPaths, p)
+	}
+	sort.Strings(trackedPaths)
+
+	// Remote renames/moves: a tracked file ID now lives at another path.
+	for _, p := range trackedPaths {
+		s := state[p]
+		r := remoteByID[s.FileID]
+		l := local[p]
+		if r == nil || r.Path == p || l == nil || handled[p] || handled[r.Path] {
+			continue
+		}
+		if _, taken := local[r.Path]; taken || driveSyncLocalChanged(l, s) {
+			continue
+		}
+		if opts.pull() {
+			actions = append(actions, driveSyncAction{Op: driveSyncOpRenameLocal, Path: r.Path, From: p, FileID: r.ID})
+		} else {
+			actions = append(actions, driveSyncAction{Op: driveSyncOpRenameRemote, Path: p, From: r.Path, FileID: r.ID})
+		}
+		handled[p], handled[r.Path] = true, true
+	}
+
+	// Local renames/moves: a tracked path vanished locally and an untracked
+	// local file with the same content appeared.
+	if opts.push() {
+		for _, p := range trackedPaths {
+			s := state[p]
+			r := remote.Files[p]
+			if handled[p] || local[p] != nil || r == nil || r.Native || driveSyncRemoteChanged(r, s) {
+				continue
+			}
+			for _, q := range sortedDriveLocalPaths(local) {
+				if handled[q] || state[q] != nil || remote.Files[q] != nil || local[q].MD5 != s.LocalMD5 {
+					continue
+				}
+				actions = append(actions, driveSyncAction{Op: driveSyncOpRenameRemote, Path: q, From: p, FileID: r.ID})
+				handled[p], handled[q] = true, true
+				break
+			}
+		}
+	}
+
+	paths := map[string]bool{}
+	for p := range local {
+		paths[p] = true
+	}
+	for p := range remote.Files {
+		paths[p] = true
+	}
+	ordered := make([]string, 0, len(paths))
+	for p := range paths {
+		if !handled[p] {
+			ordered = append(ordered, p)
+		}
+	}
+	sort.Strings(ordered)
+
+	for _, p := range ordered {
+		l, r, s := local[p], remote.Files[p], state[p]
+		if s != nil && s.FileID != "" && r != nil && r.ID != s.FileID {
+			// Same path, different file (replaced remotely): compare as untracked.
+			s = nil
+		}
+		switch {
+		case l != nil && r != nil:
+			actions = append(actions, planDriveSyncBoth(p, l, r, s, opts))
+		case l != nil:
+			actions = append(actions, planDriveSyncLocalOnly(p, l, s, opts))
+		default:
+			actions = append(actions, planDriveSyncRemoteOnly(p, r, s, opts))
+		}
+	}
+	return actions
+}
+
+func planDriveSyncBoth(p string, l *driveLocalFile, r *driveRemoteFile, s *driveSyncFileState, opts driveSyncOptions) driveSyncAction {
+	if r.Native {
+		return planDriveSyncNative(p, l, r, s, opts)
+	}
+	if l.MD5 == r.MD5 {
+		return driveSyncAction{Op: driveSyncOpNone, Path: p, FileID: r.ID}
+	}
+	localChanged := driveSyncLocalChanged(l, s)
+	remoteChanged := driveSyncRemoteChanged(r, s)
+	push := driveSyncAction{Op: driveSyncOpUpdate, Path: p, FileID: r.ID, Reason: "changed locally"}
+	pull := driveSyncAction{Op: driveSyncOpDownload, Path: p, FileID: r.ID, Reason: "changed on Drive"}
+
+	switch {
+	case !localChanged && !remoteChanged:
+		return driveSyncAction{Op: driveSyncOpNone, Path: p, FileID: r.ID}
+	case opts.Direction == driveSyncPush:
+		return push
+	case opts.Direction == driveSyncPull:
+		return pull
+	case localChanged && !remoteChanged:
+		return push
+	case remoteChanged && !localChanged:
+		return pull
+	}
+
+	push.Reason, pull.Reason = "conflict: local wins", "conflict: Drive wins"
+	switch opts.Conflict {
+	case driveSyncConflictLocal:
+		return push
+	case driveSyncConflictRemote:
+		return pull
+	case driveSyncConflictNewer:
+		remoteTime, err := time.Parse(time.RFC3339, r.ModifiedTime)
+		if err == nil && remoteTime.After(l.ModTime) {
+			pull.Reason = "conflict: Drive copy is newer"
+			return pull
+		}
+		push.Reason = "conflict: local copy is newer"
+		return push
+	default:
+		return driveSyncAction{Op: driveSyncOpSkip, Path: p, FileID: r.ID, Reason: "conflict: changed on both sides"}
+	}
+}
+
+// planDriveSyncNative handles Google-native files, which are only ever
+// exported. A local edit to the exported copy cannot be uploaded, so it is
+// reported as a skip (or a conflict) and left out of the sync state.
+func planDriveSyncNative(p string, l *driveLocalFile, r *driveRemoteFile, s *driveSyncFileState, opts driveSyncOptions) driveSyncAction {
+	localChanged := driveSyncLocalChanged(l, s)
+	remoteChanged := driveSyncRemoteChanged(r, s)
+	pull := driveSyncAction{Op: driveSyncOpDownload, Path: p, FileID: r.ID, Reason: "changed on Drive"}
+	skip := driveSyncAction{Op: driveSyncOpSkip, Path: p, FileID: r.ID}
+
+	switch {
+	case !localChanged && !remoteChanged:
+		return driveSyncAction{Op: driveSyncOpNone, Path: p, FileID: r.ID}
+	case !remoteChanged:
+		skip.Reason = "edited locally; google-native files are never uploaded"
+		return skip
+	case !opts.pull():
+		skip.Reason = "google-native file is read-only locally"
+		return skip
+	case !localChanged || opts.Direction == driveSyncPull:
+		return pull
+	}
+
+	switch opts.Conflict {
+	case driveSyncConflictRemote:
+		pull.Reason = "conflict: Drive wins"
+		return pull
+	case driveSyncConflictNewer:
+		remoteTime, err := time.Parse(time.RFC3339, r.ModifiedTime)
+		if err == nil && remoteTime.After(l.ModTime) {
+			pull.Reason = "conflict: Drive copy is newer"
+			return pull
+		}
+	}
+	skip.Reason = "conflict: edited locally and changed on Drive"
+	return skip
+}
+
+func planDriveSyncLocalOnly(p string, l *driveLocalFile, s *driveSyncFileState, opts driveSyncOptions) driveSyncAction {
+	if s == nil {
+		if opts.push() {
+			return driveSyncAction{Op: driveSyncOpUpload, Path: p, Reason: "new local file"}
+		}
+		return driveSyncAction{Op: driveSyncOpSkip, Path: p, Reason: "local only"}
+	}
+	// Tracked before, so it was removed from Drive.
+	if opts.Direction == driveSyncPush || (opts.push() && driveSyncLocalChanged(l, s)) {
+		return driveSyncAction{Op: driveSyncOpUpload, Path: p, Reason: "removed from Drive; restoring local copy"}
+	}
+	if opts.TrashPolicy == driveSyncKeep {
+		return driveSyncAction{Op: driveSyncOpSkip, Path: p, Reason: "removed from Drive (trash policy: keep)"}
+	}
+	return driveSyncAction{Op: driveSyncOpDeleteLocal, Path: p, FileID: s.FileID, Reason: "removed from Drive"}
+}
+
+func planDriveSyncRemoteOnly(p string, r *driveRemoteFile, s *driveSyncFileState, opts driveSyncOptions) driveSyncAction {
+	if s == nil {
+		if opts.pull() {
+			return driveSyncAction{Op: driveSyncOpDownload, Path: p, FileID: r.ID, Reason: "new on Drive"}
+		}
+		return driveSyncAction{Op: driveSyncOpSkip, Path: p, FileID: r.ID, Reason: "Drive only"}
+	}
+	// Tracked before, so it was removed locally.
+	if opts.Direction == driveSyncPull || (opts.pull() && driveSyncRemoteChanged(r, s)) {
+		return driveSyncAction{Op: driveSyncOpDownload, Path: p, FileID: r.ID, Reason: "removed locally; restoring Drive copy"}
+	}
+	if opts.TrashPolicy == driveSyncKeep {
+		return driveSyncAction{Op: driveSyncOpSkip, Path: p, FileID: r.ID, Reason: "removed locally (trash policy: keep)"}
+	}
+	return driveSyncAction{Op: driveSyncOpDeleteRemote, Path: p, FileID: r.ID, Reason: "removed locally"}
+}
+
+func sortedDriveLocalPaths(local map[string]*driveLocalFile) []string {
+	out := make([]string, 0, len(local))
+	for p := range local {
+		out = append(out, p)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (c *DriveSyncCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	localDir := strings.TrimSpace(c.LocalDir)
+	folderID := strings.TrimSpace(c.FolderID)
+	if localDir == "" {
+		return usage("empty localDir")
+	}
+	if folderID == "" {
+		return usage("empty folderId")
+	}
+	localDir, err = config.ExpandPath(localDir)
+	if err != nil {
+		return err
+	}
+	if localDir, err = filepath.Abs(localDir); err != nil {
+		return err
+	}
+	if st, statErr := os.Stat(localDir); statErr != nil || !st.IsDir() {
+		if statErr == nil || !errors.Is(statErr, os.ErrNotExist) || c.Direction == driveSyncPush {
+			return usagef("localDir %q is not a directory", localDir)
+		}
+		if !flags.DryRun {
+			if mkErr := os.MkdirAll(localDir, 0o750); mkErr != nil {
+				return mkErr
+			}
+		}
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	folderID, err = resolveDriveID(ctx, svc, folderID)
+	if err != nil {
+		return err
+	}
+	store, err := loadDriveSyncStore(account, folderID, localDir)
+	if err != nil {
+		return err
+	}
+
+	remote, err := walkDriveTree(ctx, svc, folderID)
+	if err != nil {
+		return err
+	}
+	local := map[string]*driveLocalFile{}
+	if _, statErr := os.Stat(localDir); statErr == nil {
+		if local, err = walkLocalTree(localDir, c.Exclude); err != nil {
+			return err
+		}
+	}
+	for p := range remote.Files {
+		if driveTreeExcluded(p, c.Exclude) {
+			delete(remote.Files, p)
+		}
+	}
+	for _, p := range remote.Skipped {
+		u.Err().Printf("skipping %s (duplicate name or non-exportable Google file)", p)
+	}
+
+	opts := driveSyncOptions{Direction: c.Direction, TrashPolicy: c.TrashPolicy, Conflict: c.Conflict}
+	plan := planDriveSync(local, remote, store.state.Files, opts)
+
+	changes := make([]driveSyncAction, 0, len(plan))
+	deletes := 0
+	for _, a := range plan {
+		if a.Op == driveSyncOpNone {
+			continue
+		}
+		changes = append(changes, a)
+		if a.Op == driveSyncOpDeleteLocal || a.Op == driveSyncOpDeleteRemote {
+			deletes++
+		}
+	}
+
+	if err := dryRunExit(ctx, flags, "drive.sync", map[string]any{
+		"localDir":  localDir,
+		"folderId":  folderID,
+		"direction": c.Direction,
+		"actions":   changes,
+	}); err != nil {
+		return err
+	}
+	if deletes > 0 && c.TrashPolicy == driveSyncDelete {
+		if err := confirmDestructive(ctx, flags, fmt.Sprintf("permanently delete %d file(s) during sync", deletes)); err != nil {
+			return err
+		}
+	}
+
+	transfer, err := newDriveTransferService(ctx, account)
+	if err != nil {
+		return err
+	}
+	syncer := &driveSyncer{
+		svc:      svc,
+		transfer: transfer,
+		localDir: localDir,
+		remote:   remote,
+		local:    local,
+		state:    store.state.Files,
+		policy:   c.TrashPolicy,
+		trashDir: localDir + driveSyncTrashSuffix,
+	}
+	results := syncer.apply(ctx, plan)
+	syncer.forgetMissing()
+	if err := store.Save(); err != nil {
+		return err
+	}
+
+	return writeDriveSyncResults(ctx, u, results)
+}
+
+func writeDriveSyncResults(ctx context.Context, u *ui.UI, results []driveSyncAction) error {
+	counts := map[string]int{}
+	failed := 0
+	changed := make([]driveSyncAction, 0, len(results))
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+		} else {
+			counts[r.Op]++
+		}
+		if r.Op != driveSyncOpNone {
+			changed = append(changed, r)
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"actions":    changed,
+			"uploaded":   counts[driveSyncOpUpload] + counts[driveSyncOpUpdate],
+			"downloaded": counts[driveSyncOpDownload],
+			"renamed":    counts[driveSyncOpRenameLocal] + counts[driveSyncOpRenameRemote],
+			"deleted":    counts[driveSyncOpDeleteLocal] + counts[driveSyncOpDeleteRemote],
+			"skipped":    counts[driveSyncOpSkip],
+			"unchanged":  counts[driveSyncOpNone],
+			"failed":     failed,
+		}); err != nil {
+			return err
+		}
+	} else {
+		if len(changed) > 0 {
+			w, flush := tableWriter(ctx)
+			fmt.Fprintln(w, "OP\tPATH\tDETAIL")
+			for _, r := range changed {
+				detail := r.Reason
+				if r.From != "" {
+					detail = "from " + r.From
+				}
+				if r.Error != "" {
+					detail = "error: " + r.Error
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\n", r.Op, r.Path, detail)
+			}
+			flush()
+		}
+		u.Err().Printf("%d uploaded, %d downloaded, %d renamed, %d deleted, %d skipped, %d unchanged, %d failed",
+			counts[driveSyncOpUpload]+counts[driveSyncOpUpdate], counts[driveSyncOpDownload],
+			counts[driveSyncOpRenameLocal]+counts[driveSyncOpRenameRemote],
+			counts[driveSyncOpDeleteLocal]+counts[driveSyncOpDeleteRemote],
+			counts[driveSyncOpSkip], counts[driveSyncOpNone], failed)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d file(s) failed to sync", failed)
+	}
+	return nil
+}
+
+type driveSyncer struct {
+	svc      *drive.Service
+	transfer *drive.Service // media uploads and downloads
+	localDir string
+	remote   *driveRemoteTree
+	local    map[string]*driveLocalFile
+	state    map[string]*driveSyncFileState
+	policy   string
+	trashDir string
+}
+
+func (s *driveSyncer) apply(ctx context.Context, plan []driveSyncAction) []driveSyncAction {
+	results := make([]driveSyncAction, 0, len(plan))
+	for _, a := range plan {
+		if err := s.applyOne(ctx, a); err != nil {
+			a.Error = err.Error()
+		}
+		results = append(results, a)
+	}
+	return results
+}
+
+func (s *driveSyncer) localPath(rel string) string {
+	return filepath.Join(s.localDir, filepath.FromSlash(rel))
+}
+
+func (s *driveSyncer) applyOne(ctx context.Context, a driveSyncAction) error {
+	switch a.Op {
+	case driveSyncOpNone:
+		s.record(a.Path, s.remote.Files[a.Path], s.local[a.Path].MD5)
+		return nil
+	case driveSyncOpSkip:
+		return nil
+	case driveSyncOpUpload, driveSyncOpUpdate:
+		return s.upload(ctx, a)
+	case driveSyncOpDownload:
+		return s.download(ctx, a)
+	case driveSyncOpRenameRemote:
+		return s.renameRemote(ctx, a)
+	case driveSyncOpRenameLocal:
+		return s.renameLocal(a)
+	case driveSyncOpDeleteRemote:
+		return s.deleteRemote(ctx, a)
+	case driveSyncOpDeleteLocal:
+		return s.deleteLocal(a)
+	default:
+		return fmt.Errorf("unknown sync op %q", a.Op)
+	}
+}
+
+func (s *driveSyncer) record(rel string, r *driveRemoteFile, localMD5 string) {
+	if r == nil {
+		return
+	}
+	s.state[rel] = &driveSyncFileState{
+		FileID:         r.ID,
+		RemoteMD5:      r.MD5,
+		RemoteModified: r.ModifiedTime,
+		LocalMD5:       localMD5,
+		Native:         r.Native,
+	}
+}
+
+func remoteFromDriveFile(rel, parentID string, f *drive.File) *driveRemoteFile {
+	return &driveRemoteFile{
+		Path:         rel,
+		ID:           f.Id,
+		Name:         f.Name,
+		MD5:          f.Md5Checksum,
+		ModifiedTime: f.ModifiedTime,
+		Size:         f.Size,
+		ParentID:     parentID,
+	}
+}
+
+// upload creates a new Drive file or replaces an existing file's content in
+// place (preserving its ID, links, and permissions).
+func (s *driveSyncer) upload(ctx context.Context, a driveSyncAction) error {
+	l := s.local[a.Path]
+	f, err := os.Open(s.localPath(a.Path)) //nolint:gosec // path under user-provided sync root
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	media := gapi.ContentType(guessMimeType(a.Path))
+
+	if existing := s.remote.Files[a.Path]; a.Op == driveSyncOpUpdate && existing != nil {
+		updated, err := s.transfer.Files.Update(existing.ID, &drive.File{}).
+			SupportsAllDrives(true).
+			Media(f, media).
+			Fields(driveSyncFileFields).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return err
+		}
+		r := remoteFromDriveFile(a.Path, existing.ParentID, updated)
+		s.remote.Files[a.Path] = r
+		s.record(a.Path, r, l.MD5)
+		return nil
+	}
+
+	parentID, err := ensureDriveFolderPath(ctx, s.svc, s.remote, path.Dir(a.Path))
+	if err != nil {
+		return err
+	}
+	created, err := s.transfer.Files.Create(&drive.File{Name: path.Base(a.Path), Parents: []string{parentID}}).
+		SupportsAllDrives(true).
+		Media(f, media).
+		Fields(driveSyncFileFields).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	r := remoteFromDriveFile(a.Path, parentID, created)
+	s.remote.Files[a.Path] = r
+	s.record(a.Path, r, l.MD5)
+	return nil
+}
+
+// download fetches (or exports) a Drive file into the local tree and stamps
+// it with the remote modification time.
+func (s *driveSyncer) download(ctx context.Context, a driveSyncAction) error {
+	r := s.remote.Files[a.Path]
+	dest := s.localPath(a.Path)
+	if err := os.MkdirAll(filepath.Dir(dest), 0o750); err != nil {
+		return err
+	}
+	tmp := dest + ".gog-sync.tmp"
+	meta := &drive.File{Id: r.ID, Name: r.Name, MimeType: r.MimeType}
+	written, _, err := downloadDriveFile(ctx, s.transfer, meta, tmp, "")
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(written, dest); err != nil {
+		_ = os.Remove(written)
+		return err
+	}
+	if t, parseErr := time.Parse(time.RFC3339, r.ModifiedTime); parseErr == nil {
+		_ = os.Chtimes(dest, t, t)
+	}
+	sum, err := fileMD5(dest)
+	if err != nil {
+		return err
+	}
+	s.record(a.Path, r, sum)
+	return nil
+}
+
+func (s *driveSyncer) renameRemote(ctx context.Context, a driveSyncAction) error {
+	r := s.remote.Files[a.From]
+	if r == nil {
+		return fmt.Errorf("remote file %s not found", a.From)
+	}
+	newParent, err := ensureDriveFolderPath(ctx, s.svc, s.remote, path.Dir(a.Path))
+	if err != nil {
+		return err
+	}
+	call := s.svc.Files.Update(r.ID, &drive.File{Name: path.Base(a.Path)}).
+		SupportsAllDrives(true).
+		Fields(driveSyncFileFields).
+		Context(ctx)
+	if newParent != r.ParentID {
+		call = call.AddParents(newParent).RemoveParents(r.ParentID)
+	}
+	updated, err := call.Do()
+	if err != nil {
+		return err
+	}
+	moved := remoteFromDriveFile(a.Path, newParent, updated)
+	moved.MimeType, moved.Native = r.MimeType, r.Native
+	delete(s.remote.Files, a.From)
+	delete(s.state, a.From)
+	s.remote.Files[a.Path] = moved
+	s.record(a.Path, moved, s.local[a.Path].MD5)
+	return nil
+}
+
+func (s *driveSyncer) renameLocal(a driveSyncAction) error {
+	dest := s.localPath(a.Path)
+	if err := os.MkdirAll(filepath.Dir(dest), 0o750); err != nil {
+		return err
+	}
+	if err := os.Rename(s.localPath(a.From), dest); err != nil {
+		return err
+	}
+	l := s.local[a.From]
+	delete(s.local, a.From)
+	delete(s.state, a.From)
+	l.Path = a.Path
+	s.local[a.Path] = l
+	s.record(a.Path, s.remote.Files[a.Path], l.MD5)
+	return nil
+}
+
+func (s *driveSyncer) deleteRemote(ctx context.Context, a driveSyncAction) error {
+	var err error
+	if s.policy == driveSyncDelete {
+		err = s.svc.Files.Delete(a.FileID).SupportsAllDrives(true).Context(ctx).Do()
+	} else {
+		_, err = s.svc.Files.Update(a.FileID, &drive.File{Trashed: true}).
+			SupportsAllDrives(true).
+			Fields("id, trashed").
+			Context(ctx).
+			Do()
+	}
+	if err != nil {
+		return err
+	}
+	delete(s.remote.Files, a.Path)
+	delete(s.state, a.Path)
+	return nil
+}
+
+// deleteLocal removes a local file, or moves it into the sync trash directory
+// (next to the sync root) when the policy is "trash".
+func (s *driveSyncer) deleteLocal(a driveSyncAction) error {
+	src := s.localPath(a.Path)
+	if s.policy == driveSyncDelete {
+		if err := os.Remove(src); err != nil {
+			return err
+		}
+	} else {
+		dest := filepath.Join(s.trashDir, time.Now().UTC().Format("20060102T150405Z"), filepath.FromSlash(a.Path))
+		if err := os.MkdirAll(filepath.Dir(dest), 0o700); err != nil {
+			return err
+		}
+		if err := moveFile(src, dest); err != nil {
+			return err
+		}
+	}
+	delete(s.local, a.Path)
+	delete(s.state, a.Path)
+	return nil
+}
+
+// forgetMissing drops state for paths that no longer exist on either side.
+func (s *driveSyncer) forgetMissing() {
+	for p := range s.state {
+		if s.local[p] == nil && s.remote.Files[p] == nil {
+			delete(s.state, p)
+		}
+	}
+}
+
+// moveFile renames src to dest, falling back to copy+remove across devices.
+func moveFile(src, dest string) error {
+	if err := os.Rename(src, dest); err == nil {
+		return nil
+	}
+	in, err := os.Open(src) //nolint:gosec // path under user-provided sync root
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600) //nolint:gosec // sync trash next to the sync root
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Remove(src)
+}
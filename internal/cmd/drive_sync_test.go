@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func syncPlanOps(actions []driveSyncAction) map[string]string {
+	out := map[string]string{}
+	for _, a := range actions {
+		out[a.Path] = a.Op
+	}
+	return out
+}
+
+func TestPlanDriveSync_NewFilesFollowDirection(t *testing.T) {
+	local := map[string]*driveLocalFile{"a.txt": {Path: "a.txt", MD5: "aa"}}
+	remote := &driveRemoteTree{Files: map[string]*driveRemoteFile{
+		"b.txt": {Path: "b.txt", ID: "fb", MD5: "bb"},
+	}}
+
+	both := syncPlanOps(planDriveSync(local, remote, nil, driveSyncOptions{Direction: driveSyncBoth}))
+	if both["a.txt"] != driveSyncOpUpload || both["b.txt"] != driveSyncOpDownload {
+		t.Fatalf("unexpected both plan: %v", both)
+	}
+	push := syncPlanOps(planDriveSync(local, remote, nil, driveSyncOptions{Direction: driveSyncPush}))
+	if push["a.txt"] != driveSyncOpUpload || push["b.txt"] != driveSyncOpSkip {
+		t.Fatalf("unexpected push plan: %v", push)
+	}
+}
+
+func TestPlanDriveSync_DeletesOnlyUnchangedTrackedFiles(t *testing.T) {
+	state := map[string]*driveSyncFileState{
+		"gone-local.txt":  {FileID: "f1", RemoteMD5: "11", LocalMD5: "11"},
+		"gone-remote.txt": {FileID: "f2", RemoteMD5: "22", LocalMD5: "22"},
+		"edited.txt":      {FileID: "f3", RemoteMD5: "33", LocalMD5: "33"},
+	}
+	local := map[string]*driveLocalFile{
+		"gone-remote.txt": {Path: "gone-remote.txt", MD5: "22"},
+	}
+	remote := &driveRemoteTree{Files: map[string]*driveRemoteFile{
+		"gone-local.txt": {Path: "gone-local.txt", ID: "f1", MD5: "11"},
+		"edited.txt":     {Path: "edited.txt", ID: "f3", MD5: "34"},
+	}}
+
+	ops := syncPlanOps(planDriveSync(local, remote, state, driveSyncOptions{Direction: driveSyncBoth, TrashPolicy: driveSyncTrash}))
+	if ops["gone-local.txt"] != driveSyncOpDeleteRemote || ops["gone-remote.txt"] != driveSyncOpDeleteLocal {
+		t.Fatalf("expected deletions to propagate: %v", ops)
+	}
+	// Deleted locally but edited on Drive: restore instead of deleting.
+	if ops["edited.txt"] != driveSyncOpDownload {
+		t.Fatalf("expected edited file restored, got %v", ops)
+	}
+
+	keep := syncPlanOps(planDriveSync(local, remote, state, driveSyncOptions{Direction: driveSyncBoth, TrashPolicy: driveSyncKeep}))
+	if keep["gone-local.txt"] != driveSyncOpSkip || keep["gone-remote.txt"] != driveSyncOpSkip {
+		t.Fatalf("expected keep policy to skip deletions: %v", keep)
+	}
+}
+
+func TestPlanDriveSync_DetectsRenames(t *testing.T) {
+	state := map[string]*driveSyncFileState{
+		"old-remote.txt": {FileID: "f1", RemoteMD5: "11", LocalMD5: "11"},
+		"old-local.txt":  {FileID: "f2", RemoteMD5: "22", LocalMD5: "22"},
+	}
+	local := map[string]*driveLocalFile{
+		"old-remote.txt":   {Path: "old-remote.txt", MD5: "11"},
+		"moved/local.txt":  {Path: "moved/local.txt", MD5: "22"},
+		"untouched-new.md": {Path: "untouched-new.md", MD5: "99"},
+	}
+	remote := &driveRemoteTree{Files: map[string]*driveRemoteFile{
+		"docs/new-remote.txt": {Path: "docs/new-remote.txt", ID: "f1", MD5: "11"},
+		"old-local.txt":       {Path: "old-local.txt", ID: "f2", MD5: "22"},
+	}}
+
+	actions := planDriveSync(local, remote, state, driveSyncOptions{Direction: driveSyncBoth})
+	var renames []driveSyncAction
+	for _, a := range actions {
+		if a.Op == driveSyncOpRenameLocal || a.Op == driveSyncOpRenameRemote {
+			renames = append(renames, a)
+		}
+	}
+	if len(renames) != 2 {
+		t.Fatalf("expected two renames, got %#v", actions)
+	}
+	if renames[0].Op != driveSyncOpRenameLocal || renames[0].From != "old-remote.txt" || renames[0].Path != "docs/new-remote.txt" {
+		t.Fatalf("unexpected remote rename: %#v", renames[0])
+	}
+	if renames[1].Op != driveSyncOpRenameRemote || renames[1].From != "old-local.txt" || renames[1].Path != "moved/local.txt" {
+		t.Fatalf("unexpected local rename: %#v", renames[1])
+	}
+	if ops := syncPlanOps(actions); ops["untouched-new.md"] != driveSyncOpUpload {
+		t.Fatalf("expected new file upload: %v", ops)
+	}
+}
+
+func TestPlanDriveSync_ConflictPolicies(t *testing.T) {
+	state := map[string]*driveSyncFileState{"c.txt": {FileID: "f1", RemoteMD5: "00", LocalMD5: "00"}}
+	local := map[string]*driveLocalFile{"c.txt": {Path: "c.txt", MD5: "11", ModTime: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)}}
+	remote := &driveRemoteTree{Files: map[string]*driveRemoteFile{
+		"c.txt": {Path: "c.txt", ID: "f1", MD5: "22", ModifiedTime: "2025-01-01T00:00:00Z"},
+	}}
+
+	cases := map[string]string{
+		driveSyncConflictNewer:  driveSyncOpUpdate,
+		driveSyncConflictRemote: driveSyncOpDownload,
+		driveSyncConflictLocal:  driveSyncOpUpdate,
+		driveSyncConflictSkip:   driveSyncOpSkip,
+	}
+	for policy, want := range cases {
+		ops := syncPlanOps(planDriveSync(local, remote, state, driveSyncOptions{Direction: driveSyncBoth, Conflict: policy}))
+		if ops["c.txt"] != want {
+			t.Fatalf("conflict=%s: got %s, want %s", policy, ops["c.txt"], want)
+		}
+	}
+}
+
+func TestPlanDriveSync_NativeLocalEdits(t *testing.T) {
+	state := map[string]*driveSyncFileState{
+		"plan.docx":  {FileID: "d1", RemoteModified: "2025-01-01T00:00:00Z", LocalMD5: "00", Native: true},
+		"notes.docx": {FileID: "d2", RemoteModified: "2025-01-01T00:00:00Z", LocalMD5: "00", Native: true},
+	}
+	local := map[string]*driveLocalFile{
+		"plan.docx":  {Path: "plan.docx", MD5: "11", ModTime: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+		"notes.docx": {Path: "notes.docx", MD5: "11", ModTime: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}
+	remote := &driveRemoteTree{Files: map[string]*driveRemoteFile{
+		"plan.docx":  {Path: "plan.docx", ID: "d1", ModifiedTime: "2025-01-01T00:00:00Z", Native: true},
+		"notes.docx": {Path: "notes.docx", ID: "d2", ModifiedTime: "2025-01-03T00:00:00Z", Native: true},
+	}}
+
+	byPath := func(actions []driveSyncAction) map[string]driveSyncAction {
+		out := map[string]driveSyncAction{}
+		for _, a := range actions {
+			out[a.Path] = a
+		}
+		return out
+	}
+
+	// Edited locally only: never recorded as unchanged, never uploaded.
+	got := byPath(planDriveSync(local, remote, state, driveSyncOptions{Direction: driveSyncBoth, Conflict: driveSyncConflictNewer}))
+	if a := got["plan.docx"]; a.Op != driveSyncOpSkip || !strings.Contains(a.Reason, "edited locally") {
+		t.Fatalf("expected local edit to be skipped, got %#v", a)
+	}
+	// Edited on both sides: the newer Drive copy wins under --conflict newer.
+	if a := got["notes.docx"]; a.Op != driveSyncOpDownload || !strings.Contains(a.Reason, "conflict") {
+		t.Fatalf("expected newer Drive copy to win, got %#v", a)
+	}
+
+	got = byPath(planDriveSync(local, remote, state, driveSyncOptions{Direction: driveSyncBoth, Conflict: driveSyncConflictLocal}))
+	if a := got["notes.docx"]; a.Op != driveSyncOpSkip || !strings.Contains(a.Reason, "conflict") {
+		t.Fatalf("expected conflict to keep the local copy, got %#v", a)
+	}
+}
+
+func TestDriveSyncer_DeleteLocalTrashesNextToRoot(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "work")
+	if err := os.MkdirAll(filepath.Join(root, "sub"), 0o750); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "sub", "a.txt"), []byte("a"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	s := &driveSyncer{
+		localDir: root,
+		local:    map[string]*driveLocalFile{"sub/a.txt": {Path: "sub/a.txt", MD5: "aa"}},
+		state:    map[string]*driveSyncFileState{"sub/a.txt": {FileID: "f1", LocalMD5: "aa"}},
+		policy:   driveSyncTrash,
+		trashDir: root + driveSyncTrashSuffix,
+	}
+	if err := s.deleteLocal(driveSyncAction{Op: driveSyncOpDeleteLocal, Path: "sub/a.txt"}); err != nil {
+		t.Fatalf("deleteLocal: %v", err)
+	}
+	matches, err := filepath.Glob(filepath.Join(root+driveSyncTrashSuffix, "*", "sub", "a.txt"))
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("expected trashed file next to the sync root, got %v (%v)", matches, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "sub", "a.txt")); !os.IsNotExist(err) {
+		t.Fatalf("expected file removed from sync root, got %v", err)
+	}
+}
+
+func TestDriveSyncCmd_BothDirectionsThenUnchanged(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+
+	localDir := filepath.Join(home, "work")
+	if err := os.MkdirAll(localDir, 0o750); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(localDir, "local.txt"), []byte("from disk"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	var uploads, downloads int
+	listing := []map[string]any{
+		{"id": "r1", "name": "remote.txt", "mimeType": "text/plain", "md5Checksum": "d3c844c1568f3f145c27483093828b7b", "modifiedTime": "2025-01-01T00:00:00Z"},
+	}
+	srv, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files" && strings.Contains(r.URL.Query().Get("q"), "'F1' in parents"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": listing})
+		case r.Method == http.MethodGet && r.URL.Path == "/files/r1" && r.URL.Query().Get("alt") == "media":
+			downloads++
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("from drive"))
+		case r.Method == http.MethodPost && r.URL.Path == "/upload/drive/v3/files":
+			uploads++
+			created := map[string]any{"id": "r2", "name": "local.txt", "mimeType": "text/plain", "md5Checksum": "a7974a806f69abbefd10af6fedcef207", "modifiedTime": "2025-01-02T00:00:00Z"}
+			listing = append(listing, created)
+			_ = json.NewEncoder(w).Encode(created)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			http.NotFound(w, r)
+		}
+	}))
+	defer closeSrv()
+
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = stubDriveService(srv)
+
+	run := func() map[string]any {
+		t.Helper()
+		var parsed map[string]any
+		out := captureStdout(t, func() {
+			_ = captureStderr(t, func() {
+				if err := Execute([]string{"--json", "--account", "a@example.com", "drive", "sync", localDir, "F1"}); err != nil {
+					t.Fatalf("Execute: %v", err)
+				}
+			})
+		})
+		if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+			t.Fatalf("json parse: %v\nout=%q", err, out)
+		}
+		return parsed
+	}
+
+	first := run()
+	if first["uploaded"] != float64(1) || first["downloaded"] != float64(1) {
+		t.Fatalf("unexpected first run: %#v", first)
+	}
+	got, err := os.ReadFile(filepath.Join(localDir, "remote.txt"))
+	if err != nil || string(got) != "from drive" {
+		t.Fatalf("expected downloaded file, got %q err=%v", got, err)
+	}
+	if st, err := os.Stat(filepath.Join(localDir, "remote.txt")); err != nil || !st.ModTime().Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected remote mtime applied, got %v err=%v", st.ModTime(), err)
+	}
+
+	second := run()
+	if second["unchanged"] != float64(2) || downloads != 1 || uploads != 1 {
+		t.Fatalf("unexpected second run: %#v downloads=%d uploads=%d", second, downloads, uploads)
+	}
+}
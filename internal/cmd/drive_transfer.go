@@ -0,0 +1,399 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	gapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+var (
+	newDriveHTTPClient = googleapi.NewDriveHTTPClient
+	// newDriveTransferService builds the Drive service used for media
+	// downloads; unlike newDriveService it has no whole-request timeout.
+	newDriveTransferService = googleapi.NewDriveTransfer
+)
+
+const (
+	// Drive requires resumable chunks to be multiples of 256 KiB.
+	driveChunkQuantum    = 256 * 1024
+	driveDefaultChunkMiB = 16
+	driveUploadFields    = "id, name, mimeType, size, webViewLink"
+	// drivePartialSuffix marks an interrupted download that the next attempt resumes.
+	drivePartialSuffix = ".partial"
+)
+
+var errDriveUploadSessionExpired = errors.New("upload session expired or was cancelled; rerun without --resume to start over")
+
+// driveUploadSession is persisted while a resumable upload is in flight so
+// `drive upload --resume` can continue it after a crash.
+type driveUploadSession struct {
+	SessionURI  string `json:"sessionUri"`
+	LocalPath   string `json:"localPath"`
+	Size        int64  `json:"size"`
+	ModTimeNano int64  `json:"modTimeNano"`
+	FileID      string `json:"fileId,omitempty"`
+	Parent      string `json:"parent,omitempty"`
+	Name        string `json:"name,omitempty"`
+	CreatedAtMs int64  `json:"createdAtMs"`
+}
+
+type driveUploadRequest struct {
+	Account     string
+	LocalPath   string
+	File        *os.File
+	Info        os.FileInfo
+	Meta        *drive.File
+	ReplaceID   string
+	MimeType    string
+	ChunkSize   int64
+	Resume      bool
+	KeepForever bool
+}
+
+func driveUploadSessionPath(req driveUploadRequest) (string, error) {
+	dir, err := config.EnsureDriveUploadsDir()
+	if err != nil {
+		return "", err
+	}
+	abs, err := filepath.Abs(req.LocalPath)
+	if err != nil {
+		return "", err
+	}
+	key := strings.Join([]string{req.Account, abs, req.ReplaceID, strings.Join(req.Meta.Parents, ","), req.Meta.Name}, "\x00")
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(dir, sanitizeAccountForPath(req.Account)+"_"+hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+func loadDriveUploadSession(p string) (*driveUploadSession, error) {
+	data, err := os.ReadFile(p) //nolint:gosec // state file under config dir
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var s driveUploadSession
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("read upload session %s: %w", p, err)
+	}
+	return &s, nil
+}
+
+func saveDriveUploadSession(p string, s *driveUploadSession) error {
+	payload, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, append(payload, '\n'), 0o600)
+}
+
+// driveChunkSize converts a MiB flag value into a valid chunk size in bytes
+// (0 selects the default).
+func driveChunkSize(mib int64) (int64, error) {
+	if mib == 0 {
+		mib = driveDefaultChunkMiB
+	}
+	if mib < 0 {
+		return 0, usage("--chunk-size must be at least 1 (MiB)")
+	}
+	return mib * 4 * driveChunkQuantum, nil
+}
+
+// uploadDriveResumable uploads req.File through a resumable session, sending
+// one chunk per request and persisting the session URI until Drive confirms
+// the file.
+func uploadDriveResumable(ctx context.Context, u *ui.UI, svc *drive.Service, req driveUploadRequest) (*drive.File, error) {
+	client, err := newDriveHTTPClient(ctx, req.Account)
+	if err != nil {
+		return nil, err
+	}
+	statePath, err := driveUploadSessionPath(req)
+	if err != nil {
+		return nil, err
+	}
+	size := req.Info.Size()
+	modTime := req.Info.ModTime().UnixNano()
+
+	var (
+		session *driveUploadSession
+		offset  int64
+	)
+	if req.Resume {
+		session, err = loadDriveUploadSession(statePath)
+		if err != nil {
+			return nil, err
+		}
+		if session == nil {
+			return nil, usagef("no interrupted upload to resume for %s", req.LocalPath)
+		}
+		if session.Size != size || session.ModTimeNano != modTime {
+			_ = os.Remove(statePath)
+			return nil, fmt.Errorf("%s changed since the upload started; rerun without --resume", req.LocalPath)
+		}
+		var done *drive.File
+		offset, done, err = queryDriveUploadOffset(ctx, client, session.SessionURI, size)
+		if errors.Is(err, errDriveUploadSessionExpired) {
+			_ = os.Remove(statePath)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if done != nil {
+			_ = os.Remove(statePath)
+			return done, nil
+		}
+		if u != nil {
+			u.Err().Printf("resuming upload at %s of %s", formatDriveSize(offset), formatDriveSize(size))
+		}
+	} else {
+		uri, startErr := startDriveResumableUpload(ctx, client, svc.BasePath, req, size)
+		if startErr != nil {
+			return nil, startErr
+		}
+		session = &driveUploadSession{
+			SessionURI:  uri,
+			LocalPath:   req.LocalPath,
+			Size:        size,
+			ModTimeNano: modTime,
+			FileID:      req.ReplaceID,
+			Parent:      strings.Join(req.Meta.Parents, ","),
+			Name:        req.Meta.Name,
+			CreatedAtMs: time.Now().UnixMilli(),
+		}
+		if err := saveDriveUploadSession(statePath, session); err != nil {
+			return nil, err
+		}
+	}
+
+	buf := make([]byte, req.ChunkSize)
+	for {
+		n, readErr := req.File.ReadAt(buf, offset)
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return nil, readErr
+		}
+		next, done, putErr := putDriveUploadChunk(ctx, client, session.SessionURI, buf[:n], offset, size)
+		if errors.Is(putErr, errDriveUploadSessionExpired) {
+			_ = os.Remove(statePath)
+			return nil, putErr
+		}
+		if putErr != nil {
+			return nil, fmt.Errorf("upload interrupted at %s (rerun with --resume): %w", formatDriveSize(offset), putErr)
+		}
+		if done != nil {
+			_ = os.Remove(statePath)
+			if u != nil {
+				u.Err().Printf("uploaded %s", formatDriveSize(size))
+			}
+			return done, nil
+		}
+		if next <= offset && n > 0 {
+			return nil, fmt.Errorf("upload made no progress at %s (rerun with --resume)", formatDriveSize(offset))
+		}
+		offset = next
+		if u != nil {
+			u.Err().Printf("uploaded %s / %s (%d%%)", formatDriveSize(offset), formatDriveSize(size), offset*100/max(size, 1))
+		}
+	}
+}
+
+func driveUploadSessionURL(basePath string, req driveUploadRequest) string {
+	target := "/upload/drive/v3/files"
+	if req.ReplaceID != "" {
+		target += "/" + url.PathEscape(req.ReplaceID)
+	}
+	q := url.Values{}
+	q.Set("uploadType", "resumable")
+	q.Set("supportsAllDrives", "true")
+	q.Set("fields", driveUploadFields)
+	if req.KeepForever {
+		q.Set("keepRevisionForever", "true")
+	}
+	return gapi.ResolveRelative(basePath, target) + "?" + q.Encode()
+}
+
+func startDriveResumableUpload(ctx context.Context, client *http.Client, basePath string, req driveUploadRequest, size int64) (string, error) {
+	body, err := json.Marshal(req.Meta)
+	if err != nil {
+		return "", err
+	}
+	method := http.MethodPost
+	if req.ReplaceID != "" {
+		method = http.MethodPatch
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, driveUploadSessionURL(basePath, req), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	httpReq.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	httpReq.Header.Set("X-Upload-Content-Type", req.MimeType)
+	httpReq.Header.Set("X-Upload-Content-Length", strconv.FormatInt(size, 10))
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if err := gapi.CheckResponse(resp); err != nil {
+		return "", err
+	}
+	loc := resp.Header.Get("Location")
+	if loc == "" {
+		return "", errors.New("drive did not return an upload session URL")
+	}
+	return loc, nil
+}
+
+// putDriveUploadChunk sends chunk at offset. It returns the next offset the
+// server expects, or the created file once the upload is complete.
+func putDriveUploadChunk(ctx context.Context, client *http.Client, sessionURI string, chunk []byte, offset, size int64) (int64, *drive.File, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPut, sessionURI, bytes.NewReader(chunk))
+	if err != nil {
+		return 0, nil, err
+	}
+	httpReq.ContentLength = int64(len(chunk))
+	if len(chunk) == 0 {
+		httpReq.Header.Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+	} else {
+		httpReq.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+int64(len(chunk))-1, size))
+	}
+	return driveUploadResponse(client, httpReq)
+}
+
+// queryDriveUploadOffset asks the session how many bytes it already has.
+func queryDriveUploadOffset(ctx context.Context, client *http.Client, sessionURI string, size int64) (int64, *drive.File, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPut, sessionURI, http.NoBody)
+	if err != nil {
+		return 0, nil, err
+	}
+	httpReq.Header.Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+	return driveUploadResponse(client, httpReq)
+}
+
+func driveUploadResponse(client *http.Client, httpReq *http.Request) (int64, *drive.File, error) {
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		var f drive.File
+		if err := json.NewDecoder(resp.Body).Decode(&f); err != nil {
+			return 0, nil, fmt.Errorf("decode uploaded file: %w", err)
+		}
+		return 0, &f, nil
+	case http.StatusPermanentRedirect:
+		return parseDriveUploadRange(resp.Header.Get("Range")), nil, nil
+	case http.StatusNotFound, http.StatusGone:
+		return 0, nil, errDriveUploadSessionExpired
+	}
+	if err := gapi.CheckResponse(resp); err != nil {
+		return 0, nil, err
+	}
+	return 0, nil, fmt.Errorf("unexpected upload response: %s", resp.Status)
+}
+
+// parseDriveUploadRange turns a "bytes=0-N" Range header into the next offset
+// (N+1). A missing header means the server has nothing yet.
+func parseDriveUploadRange(h string) int64 {
+	_, last, ok := strings.Cut(strings.TrimPrefix(strings.TrimSpace(h), "bytes="), "-")
+	if !ok {
+		return 0
+	}
+	n, err := strconv.ParseInt(last, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n + 1
+}
+
+var driveDownloadFrom = func(ctx context.Context, svc *drive.Service, fileID string, offset int64) (*http.Response, error) {
+	if offset <= 0 {
+		return driveDownload(ctx, svc, fileID)
+	}
+	call := svc.Files.Get(fileID).SupportsAllDrives(true).Context(ctx)
+	call.Header().Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	return call.Download()
+}
+
+// downloadDriveMedia streams a binary file into destPath+".partial", resuming
+// from an existing partial file with an HTTP Range request, and renames it to
+// destPath once complete.
+func downloadDriveMedia(ctx context.Context, svc *drive.Service, meta *drive.File, destPath string) (int64, error) {
+	partial := destPath + drivePartialSuffix
+	var offset int64
+	if st, err := os.Stat(partial); err == nil && st.Mode().IsRegular() {
+		offset = st.Size()
+	}
+
+	resp, err := driveDownloadFrom(ctx, svc, meta.Id, offset)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	switch {
+	case offset > 0 && resp.StatusCode == http.StatusPartialContent:
+		flags = os.O_WRONLY | os.O_APPEND
+	case offset > 0 && resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// The partial file already holds everything; fall through to finalize.
+		return finishDriveMedia(meta, partial, destPath, offset, true)
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		body, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("download failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	default:
+		offset = 0
+	}
+
+	f, err := os.OpenFile(partial, flags, 0o600) //nolint:gosec // user-provided path
+	if err != nil {
+		return 0, err
+	}
+	n, copyErr := io.Copy(f, resp.Body)
+	closeErr := f.Close()
+	if copyErr != nil {
+		return 0, fmt.Errorf("download interrupted after %s (rerun to resume): %w", formatDriveSize(offset+n), copyErr)
+	}
+	if closeErr != nil {
+		return 0, closeErr
+	}
+	return finishDriveMedia(meta, partial, destPath, offset+n, offset > 0)
+}
+
+func finishDriveMedia(meta *drive.File, partial, destPath string, size int64, resumed bool) (int64, error) {
+	if resumed && meta.Md5Checksum != "" {
+		sum, err := fileMD5(partial)
+		if err != nil {
+			return 0, err
+		}
+		if sum != meta.Md5Checksum {
+			_ = os.Remove(partial)
+			return 0, errors.New("resumed download does not match the Drive checksum (file changed?); partial data removed, rerun to download again")
+		}
+	}
+	if err := os.Rename(partial, destPath); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
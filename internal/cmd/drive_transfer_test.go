@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint:gosec // mirrors Drive's md5Checksum in fixtures
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+)
+
+func TestParseDriveUploadRange(t *testing.T) {
+	cases := map[string]int64{
+		"":                0,
+		"bytes=0-1048575": 1048576,
+		"bytes=0-0":       1,
+		"garbage":         0,
+	}
+	for in, want := range cases {
+		if got := parseDriveUploadRange(in); got != want {
+			t.Fatalf("parseDriveUploadRange(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestDriveUploadCmd_ResumableResumesAfterFailure(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+
+	content := bytes.Repeat([]byte("0123456789abcdef"), 160*1024) // 2.5 MiB
+	localPath := filepath.Join(home, "recording.bin")
+	if err := os.WriteFile(localPath, content, 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	var (
+		mu       sync.Mutex
+		received []byte
+		puts     int
+		failNext = true
+		srvURL   string
+	)
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/upload/drive/v3/files":
+			if r.URL.Query().Get("uploadType") != "resumable" || r.Header.Get("X-Upload-Content-Length") != fmt.Sprint(len(content)) {
+				t.Errorf("unexpected session start: %s headers=%v", r.URL.RawQuery, r.Header)
+			}
+			w.Header().Set("Location", srvURL+"/session/1")
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodPut && r.URL.Path == "/session/1":
+			puts++
+			body, _ := io.ReadAll(r.Body)
+			if len(body) == 0 {
+				// Status query from --resume.
+				w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(received)-1))
+				w.WriteHeader(http.StatusPermanentRedirect)
+				return
+			}
+			if len(received) > 0 && failNext {
+				failNext = false
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = io.WriteString(w, `{"error":{"code":400,"message":"connection reset"}}`)
+				return
+			}
+			received = append(received, body...)
+			if len(received) < len(content) {
+				w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(received)-1))
+				w.WriteHeader(http.StatusPermanentRedirect)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "big1", "name": "recording.bin", "size": fmt.Sprint(len(received))})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			http.NotFound(w, r)
+		}
+	}))
+	defer closeSrv()
+	srvURL = strings.TrimSuffix(svc.BasePath, "/")
+
+	origNew, origHTTP := newDriveService, newDriveHTTPClient
+	t.Cleanup(func() { newDriveService, newDriveHTTPClient = origNew, origHTTP })
+	newDriveService = stubDriveService(svc)
+	newDriveHTTPClient = func(context.Context, string) (*http.Client, error) { return http.DefaultClient, nil }
+
+	run := func(extra ...string) (string, error) {
+		var runErr error
+		out := captureStdout(t, func() {
+			_ = captureStderr(t, func() {
+				runErr = Execute(append([]string{"--json", "--account", "a@example.com", "drive", "upload", localPath, "--chunk-size", "1"}, extra...))
+			})
+		})
+		return out, runErr
+	}
+
+	if _, err := run(); err == nil || !strings.Contains(err.Error(), "--resume") {
+		t.Fatalf("expected interrupted upload error, got %v", err)
+	}
+	out, err := run("--resume")
+	if err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if !bytes.Equal(received, content) {
+		t.Fatalf("uploaded bytes differ: got %d want %d", len(received), len(content))
+	}
+	if !strings.Contains(out, `"big1"`) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+	// chunk 1, failed chunk 2, status query, chunk 2, chunk 3
+	if puts != 5 {
+		t.Fatalf("unexpected PUT count: %d", puts)
+	}
+	dir := filepath.Join(home, "xdg-config", "gogcli", "state", "drive-uploads")
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("expected session state removed, found %d file(s) err=%v", len(entries), err)
+	}
+}
+
+func TestDownloadDriveFile_ResumesPartial(t *testing.T) {
+	full := "hello resumable world"
+	sum := md5.Sum([]byte(full)) //nolint:gosec // fixture checksum
+	dest := filepath.Join(t.TempDir(), "file.bin")
+	if err := os.WriteFile(dest+drivePartialSuffix, []byte(full[:6]), 0o600); err != nil {
+		t.Fatalf("write partial: %v", err)
+	}
+
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Range"); got != "bytes=6-" {
+			t.Errorf("unexpected Range %q", got)
+		}
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = io.WriteString(w, full[6:])
+	}))
+	defer closeSrv()
+
+	meta := &drive.File{Id: "id1", MimeType: "application/octet-stream", Md5Checksum: hex.EncodeToString(sum[:])}
+	outPath, n, err := downloadDriveFile(context.Background(), svc, meta, dest, "")
+	if err != nil {
+		t.Fatalf("downloadDriveFile: %v", err)
+	}
+	if outPath != dest || n != int64(len(full)) {
+		t.Fatalf("unexpected result: %q %d", outPath, n)
+	}
+	if b, _ := os.ReadFile(dest); string(b) != full {
+		t.Fatalf("unexpected content: %q", b)
+	}
+	if _, err := os.Stat(dest + drivePartialSuffix); !os.IsNotExist(err) {
+		t.Fatalf("expected partial removed, stat=%v", err)
+	}
+}
+
+func TestDownloadDriveFile_ResumeChecksumMismatch(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "file.bin")
+	if err := os.WriteFile(dest+drivePartialSuffix, []byte("stale"), 0o600); err != nil {
+		t.Fatalf("write partial: %v", err)
+	}
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = io.WriteString(w, "-tail")
+	}))
+	defer closeSrv()
+
+	meta := &drive.File{Id: "id1", MimeType: "application/octet-stream", Md5Checksum: "00000000000000000000000000000000"}
+	if _, _, err := downloadDriveFile(context.Background(), svc, meta, dest, ""); err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Fatalf("expected checksum error, got %v", err)
+	}
+	if _, err := os.Stat(dest + drivePartialSuffix); !os.IsNotExist(err) {
+		t.Fatalf("expected stale partial removed, stat=%v", err)
+	}
+}
@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec // Drive reports md5Checksum; used for change detection only
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	gapi "google.golang.org/api/googleapi"
+)
+
+const driveTreeFields = "nextPageToken, files(id, name, mimeType, md5Checksum, modifiedTime, size, parents)"
+
+// driveRemoteFile is a file found while walking a Drive folder tree. Path is
+// slash-separated and relative to the walked root; Google-native files carry
+// the extension of their default export format.
+type driveRemoteFile struct {
+	Path         string `json:"path"`
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	MimeType     string `json:"mimeType"`
+	MD5          string `json:"md5,omitempty"`
+	ModifiedTime string `json:"modifiedTime,omitempty"`
+	Size         int64  `json:"size,omitempty"`
+	ParentID     string `json:"parentId,omitempty"`
+	Native       bool   `json:"native,omitempty"`
+}
+
+type driveRemoteTree struct {
+	Files map[string]*driveRemoteFile
+	// Folders maps relative folder paths ("" is the root) to folder IDs.
+	Folders map[string]string
+	// Skipped lists paths that were ignored (duplicates, non-exportable types).
+	Skipped []string
+}
+
+func (t *driveRemoteTree) byID() map[string]*driveRemoteFile {
+	out := make(map[string]*driveRemoteFile, len(t.Files))
+	for _, f := range t.Files {
+		out[f.ID] = f
+	}
+	return out
+}
+
+// driveNativeExportable reports whether a Google-native file can be exported
+// with the default format mapping.
+func driveNativeExportable(mimeType string) bool {
+	switch mimeType {
+	case driveMimeGoogleDoc, driveMimeGoogleSheet, driveMimeGoogleSlides, driveMimeGoogleDrawing:
+		return true
+	default:
+		return false
+	}
+}
+
+// driveTreeFileName returns the local name for a Drive file, appending the
+// export extension for Google-native files.
+func driveTreeFileName(f *drive.File) string {
+	name := sanitizeDriveTreeName(f.Name)
+	if !strings.HasPrefix(f.MimeType, driveMimeGooglePrefix) {
+		return name
+	}
+	ext := driveExportExtension(driveExportMimeType(f.MimeType))
+	if strings.EqualFold(filepath.Ext(name), ext) {
+		return name
+	}
+	return name + ext
+}
+
+// sanitizeDriveTreeName keeps Drive names usable as a single path element.
+func sanitizeDriveTreeName(name string) string {
+	name = strings.TrimSpace(strings.NewReplacer("/", "_", "\\", "_").Replace(name))
+	if name == "" || name == "." || name == ".." {
+		return "_"
+	}
+	return name
+}
+
+func listDriveFolderChildren(ctx context.Context, svc *drive.Service, folderID string) ([]*drive.File, error) {
+	return listDriveFolderChildrenFields(ctx, svc, folderID, driveTreeFields)
+}
+
+// listDriveFolderChildrenFields lists the non-trashed children of folderID,
+// requesting the given list fields (must include nextPageToken).
+func listDriveFolderChildrenFields(ctx context.Context, svc *drive.Service, folderID string, fields string) ([]*drive.File, error) {
+	fetch := func(pageToken string) ([]*drive.File, string, error) {
+		call := svc.Files.List().
+			Q(fmt.Sprintf("'%s' in parents and trashed = false", escapeDriveQueryString(folderID))).
+			PageSize(1000).
+			OrderBy("name").
+			Fields(gapi.Field(fields)).
+			Context(ctx)
+		call = driveFilesListCallWithDriveSupport(call, true)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Files, resp.NextPageToken, nil
+	}
+	return collectAllPages("", fetch)
+}
+
+// walkDriveTree lists every file below folderID, recursing into subfolders.
+func walkDriveTree(ctx context.Context, svc *drive.Service, folderID string) (*driveRemoteTree, error) {
+	tree := &driveRemoteTree{
+		Files:   map[string]*driveRemoteFile{},
+		Folders: map[string]string{"": folderID},
+	}
+	type pending struct{ id, rel string }
+	queue := []pending{{id: folderID}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		children, err := listDriveFolderChildren(ctx, svc, cur.id)
+		if err != nil {
+			return nil, fmt.Errorf("list folder %s: %w", orEmpty(cur.rel, "/"), err)
+		}
+		for _, child := range children {
+			if child == nil {
+				continue
+			}
+			if child.MimeType == driveMimeFolder {
+				rel := path.Join(cur.rel, sanitizeDriveTreeName(child.Name))
+				if _, exists := tree.Folders[rel]; exists {
+					tree.Skipped = append(tree.Skipped, rel+"/")
+					continue
+				}
+				tree.Folders[rel] = child.Id
+				queue = append(queue, pending{id: child.Id, rel: rel})
+				continue
+			}
+			native := strings.HasPrefix(child.MimeType, driveMimeGooglePrefix)
+			rel := path.Join(cur.rel, driveTreeFileName(child))
+			if native && !driveNativeExportable(child.MimeType) {
+				tree.Skipped = append(tree.Skipped, rel)
+				continue
+			}
+			if _, exists := tree.Files[rel]; exists {
+				tree.Skipped = append(tree.Skipped, rel)
+				continue
+			}
+			tree.Files[rel] = &driveRemoteFile{
+				Path:         rel,
+				ID:           child.Id,
+				Name:         child.Name,
+				MimeType:     child.MimeType,
+				MD5:          child.Md5Checksum,
+				ModifiedTime: child.ModifiedTime,
+				Size:         child.Size,
+				ParentID:     cur.id,
+				Native:       native,
+			}
+		}
+	}
+	sort.Strings(tree.Skipped)
+	return tree, nil
+}
+
+// driveLocalFile is a regular file found while walking a local directory.
+type driveLocalFile struct {
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+	MD5     string    `json:"md5"`
+}
+
+// walkLocalTree lists regular files below root (symlinks are skipped) and
+// hashes them. exclude holds glob patterns matched against the relative path
+// and the base name.
+func walkLocalTree(root string, exclude []string) (map[string]*driveLocalFile, error) {
+	files := map[string]*driveLocalFile{}
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if p == root {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if driveTreeExcluded(rel, exclude) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		sum, err := fileMD5(p)
+		if err != nil {
+			return err
+		}
+		files[rel] = &driveLocalFile{Path: rel, Size: info.Size(), ModTime: info.ModTime(), MD5: sum}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func driveTreeExcluded(rel string, patterns []string) bool {
+	base := path.Base(rel)
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, base); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func fileMD5(p string) (string, error) {
+	f, err := os.Open(p) //nolint:gosec // walked path under user-provided root
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := md5.New() //nolint:gosec // matches Drive's md5Checksum
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ensureDriveFolderPath returns the ID of the folder at rel (creating missing
+// folders below the tree root) and records new folders in tree.Folders.
+func ensureDriveFolderPath(ctx context.Context, svc *drive.Service, tree *driveRemoteTree, rel string) (string, error) {
+	rel = strings.Trim(path.Clean("/"+rel), "/")
+	if id, ok := tree.Folders[rel]; ok {
+		return id, nil
+	}
+	parentID, err := ensureDriveFolderPath(ctx, svc, tree, path.Dir(rel))
+	if err != nil {
+		return "", err
+	}
+	created, err := createDriveFolder(ctx, svc, path.Base(rel), parentID, "id")
+	if err != nil {
+		return "", fmt.Errorf("create folder %s: %w", rel, err)
+	}
+	tree.Folders[rel] = created.Id
+	return created.Id, nil
+}
@@ -0,0 +1,491 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	driveUsageFields = "nextPageToken, files(id, name, mimeType, size, quotaBytesUsed, md5Checksum, createdTime, modifiedTime, owners(emailAddress), capabilities(canTrash))"
+
+	driveUsageByOwner = "owner"
+	driveUsageByMime  = "mime"
+	driveUsageByFiles = "files"
+
+	driveDupesKeepNewest = "newest"
+
+	// Files in shared drives have no individual owner.
+	driveUsageSharedDriveOwner = "(shared drive)"
+)
+
+// driveUsageFile is a non-folder file found while scanning a tree. Path is
+// slash-separated and relative to the scanned root.
+type driveUsageFile struct {
+	ID       string `json:"id"`
+	Path     string `json:"path"`
+	MimeType string `json:"mimeType"`
+	Size     int64  `json:"size"`
+	Quota    int64  `json:"quotaBytesUsed"`
+	MD5      string `json:"md5,omitempty"`
+	Owner    string `json:"owner"`
+	Created  string `json:"createdTime,omitempty"`
+	Modified string `json:"modifiedTime,omitempty"`
+	CanTrash bool   `json:"-"`
+}
+
+type driveUsageBucket struct {
+	Key   string `json:"key"`
+	ID    string `json:"id,omitempty"`
+	Files int    `json:"files"`
+	Size  int64  `json:"size"`
+	Quota int64  `json:"quotaBytesUsed"`
+}
+
+type driveUsageReport struct {
+	Root    string             `json:"root"`
+	Files   int                `json:"files"`
+	Folders int                `json:"folders"`
+	Size    int64              `json:"size"`
+	Quota   int64              `json:"quotaBytesUsed"`
+	ByDir   []driveUsageBucket `json:"byFolder"`
+	ByOwner []driveUsageBucket `json:"byOwner"`
+	ByMime  []driveUsageBucket `json:"byMimeType"`
+	Largest []driveUsageFile   `json:"largest"`
+}
+
+// scanDriveUsage walks folderID (a folder or shared drive ID) and returns
+// every file below it plus folder IDs keyed by relative path ("" is the root).
+func scanDriveUsage(ctx context.Context, svc *drive.Service, folderID string) ([]*driveUsageFile, map[string]string, error) {
+	folders := map[string]string{"": folderID}
+	var files []*driveUsageFile
+	type pending struct{ id, rel string }
+	queue := []pending{{id: folderID}}
+	seen := map[string]bool{folderID: true}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		children, err := listDriveFolderChildrenFields(ctx, svc, cur.id, driveUsageFields)
+		if err != nil {
+			return nil, nil, fmt.Errorf("list folder %s: %w", orEmpty(cur.rel, "/"), err)
+		}
+		for _, child := range children {
+			if child == nil {
+				continue
+			}
+			rel := path.Join(cur.rel, sanitizeDriveTreeName(child.Name))
+			if child.MimeType == driveMimeFolder {
+				// A folder can have several parents; count its contents once.
+				if seen[child.Id] {
+					continue
+				}
+				seen[child.Id] = true
+				folders[rel] = child.Id
+				queue = append(queue, pending{id: child.Id, rel: rel})
+				continue
+			}
+			owner := driveUsageSharedDriveOwner
+			if len(child.Owners) > 0 && child.Owners[0] != nil {
+				owner = strings.ToLower(child.Owners[0].EmailAddress)
+			}
+			files = append(files, &driveUsageFile{
+				ID:       child.Id,
+				Path:     rel,
+				MimeType: child.MimeType,
+				Size:     child.Size,
+				Quota:    child.QuotaBytesUsed,
+				MD5:      child.Md5Checksum,
+				Owner:    owner,
+				Created:  child.CreatedTime,
+				Modified: child.ModifiedTime,
+				CanTrash: child.Capabilities == nil || child.Capabilities.CanTrash,
+			})
+		}
+	}
+	return files, folders, nil
+}
+
+type DriveDuCmd struct {
+	FolderID string `arg:"" name:"folderId" optional:"" help:"Folder or shared drive ID or path (default: My Drive root)"`
+	By       string `name:"by" help:"Table grouping: folder|owner|mime|files" default:"folder" enum:"folder,owner,mime,files"`
+	Depth    int    `name:"depth" help:"Folder depth to show with --by folder (0 = root only)" default:"1"`
+	Top      int    `name:"top" help:"Rows to show per grouping" default:"20"`
+}
+
+func (c *DriveDuCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if c.Top <= 0 {
+		return usage("--top must be > 0")
+	}
+	if c.Depth < 0 {
+		return usage("--depth must be >= 0")
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	folderID := orEmpty(strings.TrimSpace(c.FolderID), "root")
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	folderID, err = resolveDriveID(ctx, svc, folderID)
+	if err != nil {
+		return err
+	}
+	files, folders, err := scanDriveUsage(ctx, svc, folderID)
+	if err != nil {
+		return err
+	}
+	report := buildDriveUsageReport(folderID, files, folders, c.Depth, c.Top)
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, report)
+	}
+
+	u.Err().Printf("%d files in %d folders: %s (quota %s)", report.Files, report.Folders, formatDriveSize(report.Size), formatDriveSize(report.Quota))
+	w, flush := tableWriter(ctx)
+	defer flush()
+	if c.By == driveUsageByFiles {
+		fmt.Fprintln(w, "SIZE\tQUOTA\tOWNER\tPATH\tID")
+		for _, f := range report.Largest {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", formatDriveSize(f.Size), formatDriveSize(f.Quota), f.Owner, sanitizeTab(f.Path), f.ID)
+		}
+		return nil
+	}
+
+	buckets, label := report.ByDir, "FOLDER"
+	switch c.By {
+	case driveUsageByOwner:
+		buckets, label = report.ByOwner, "OWNER"
+	case driveUsageByMime:
+		buckets, label = report.ByMime, "MIME_TYPE"
+	}
+	fmt.Fprintf(w, "SIZE\tQUOTA\tFILES\t%s\n", label)
+	for _, b := range buckets {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", formatDriveSize(b.Size), formatDriveSize(b.Quota), b.Files, sanitizeTab(b.Key))
+	}
+	return nil
+}
+
+// buildDriveUsageReport rolls file sizes up into every ancestor folder and
+// groups them by owner and MIME type; each list is sorted largest first and
+// cut to top entries.
+func buildDriveUsageReport(root string, files []*driveUsageFile, folders map[string]string, depth, top int) *driveUsageReport {
+	report := &driveUsageReport{Root: root, Files: len(files), Folders: len(folders) - 1}
+	dirs := map[string]*driveUsageBucket{}
+	owners := map[string]*driveUsageBucket{}
+	mimes := map[string]*driveUsageBucket{}
+	add := func(m map[string]*driveUsageBucket, key string, f *driveUsageFile) {
+		b := m[key]
+		if b == nil {
+			b = &driveUsageBucket{Key: key}
+			m[key] = b
+		}
+		b.Files++
+		b.Size += f.Size
+		b.Quota += f.Quota
+	}
+	for rel, id := range folders {
+		if driveUsageDepth(rel) <= depth {
+			dirs[rel] = &driveUsageBucket{Key: orEmpty(rel, "/"), ID: id}
+		}
+	}
+	for _, f := range files {
+		report.Size += f.Size
+		report.Quota += f.Quota
+		add(owners, f.Owner, f)
+		add(mimes, f.MimeType, f)
+		for dir := path.Dir(f.Path); ; dir = path.Dir(dir) {
+			if dir == "." {
+				dir = ""
+			}
+			if b := dirs[dir]; b != nil {
+				b.Files++
+				b.Size += f.Size
+				b.Quota += f.Quota
+			}
+			if dir == "" {
+				break
+			}
+		}
+	}
+	report.ByDir = sortDriveUsageBuckets(dirs, top)
+	report.ByOwner = sortDriveUsageBuckets(owners, top)
+	report.ByMime = sortDriveUsageBuckets(mimes, top)
+
+	largest := make([]driveUsageFile, 0, len(files))
+	for _, f := range files {
+		largest = append(largest, *f)
+	}
+	sort.SliceStable(largest, func(i, j int) bool {
+		if driveUsageWeight(largest[i].Size, largest[i].Quota) != driveUsageWeight(largest[j].Size, largest[j].Quota) {
+			return driveUsageWeight(largest[i].Size, largest[i].Quota) > driveUsageWeight(largest[j].Size, largest[j].Quota)
+		}
+		return largest[i].Path < largest[j].Path
+	})
+	if len(largest) > top {
+		largest = largest[:top]
+	}
+	report.Largest = largest
+	return report
+}
+
+func driveUsageDepth(rel string) int {
+	if rel == "" {
+		return 0
+	}
+	return strings.Count(rel, "/") + 1
+}
+
+// driveUsageWeight ranks by quota when known since it includes stored
+// revisions, falling back to content size.
+func driveUsageWeight(size, quota int64) int64 {
+	if quota > size {
+		return quota
+	}
+	return size
+}
+
+func sortDriveUsageBuckets(m map[string]*driveUsageBucket, top int) []driveUsageBucket {
+	out := make([]driveUsageBucket, 0, len(m))
+	for _, b := range m {
+		out = append(out, *b)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		wi, wj := driveUsageWeight(out[i].Size, out[i].Quota), driveUsageWeight(out[j].Size, out[j].Quota)
+		if wi != wj {
+			return wi > wj
+		}
+		return out[i].Key < out[j].Key
+	})
+	if len(out) > top {
+		out = out[:top]
+	}
+	return out
+}
+
+type DriveDupesCmd struct {
+	FolderID        string `arg:"" name:"folderId" optional:"" help:"Folder or shared drive ID or path (default: My Drive root)"`
+	MinSize         string `name:"min-size" help:"Ignore files smaller than this (bytes, or with KB/MB/GB suffix)"`
+	TrashDuplicates bool   `name:"trash-duplicates" help:"Move every copy except the kept one to trash (confirms first)"`
+	Keep            string `name:"keep" help:"Which copy to keep with --trash-duplicates: oldest|newest" default:"oldest" enum:"oldest,newest"`
+}
+
+type driveDupeGroup struct {
+	MD5    string           `json:"md5"`
+	Size   int64            `json:"size"`
+	Count  int              `json:"count"`
+	Wasted int64            `json:"wasted"`
+	Files  []driveUsageFile `json:"files"`
+	Kept   string           `json:"kept,omitempty"`
+}
+
+type driveDupeTrashResult struct {
+	ID    string `json:"id"`
+	Path  string `json:"path"`
+	Error string `json:"error,omitempty"`
+}
+
+func (c *DriveDupesCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	minSize, err := parseDriveSize(c.MinSize)
+	if err != nil {
+		return usagef("invalid --min-size: %v", err)
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	folderID := orEmpty(strings.TrimSpace(c.FolderID), "root")
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	folderID, err = resolveDriveID(ctx, svc, folderID)
+	if err != nil {
+		return err
+	}
+	files, _, err := scanDriveUsage(ctx, svc, folderID)
+	if err != nil {
+		return err
+	}
+	groups := findDriveDuplicates(files, minSize)
+	var wasted int64
+	for i := range groups {
+		wasted += groups[i].Wasted
+		if c.TrashDuplicates {
+			groups[i].Kept = driveDupeKeeper(groups[i].Files, c.Keep).ID
+		}
+	}
+
+	var trashed []driveDupeTrashResult
+	if c.TrashDuplicates && len(groups) > 0 {
+		var victims []driveUsageFile
+		for _, g := range groups {
+			for _, f := range g.Files {
+				if f.ID != g.Kept {
+					victims = append(victims, f)
+				}
+			}
+		}
+		if err := confirmDestructive(ctx, flags, fmt.Sprintf("trash %d duplicate drive file(s) (%s), keeping the %s copy of each", len(victims), formatDriveSize(wasted), c.Keep)); err != nil {
+			return err
+		}
+		for _, f := range victims {
+			res := driveDupeTrashResult{ID: f.ID, Path: f.Path}
+			if !f.CanTrash {
+				res.Error = "no permission to trash"
+			} else if _, err := svc.Files.Update(f.ID, &drive.File{Trashed: true}).
+				SupportsAllDrives(true).
+				Fields("id, trashed").
+				Context(ctx).
+				Do(); err != nil {
+				res.Error = err.Error()
+			}
+			trashed = append(trashed, res)
+		}
+	}
+
+	failed := 0
+	for _, r := range trashed {
+		if r.Error != "" {
+			failed++
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		payload := map[string]any{
+			"root":   folderID,
+			"files":  len(files),
+			"groups": groups,
+			"wasted": wasted,
+		}
+		if c.TrashDuplicates {
+			payload["trashed"] = trashed
+		}
+		if err := outfmt.WriteJSON(ctx, os.Stdout, payload); err != nil {
+			return err
+		}
+		return driveDupesTrashError(failed)
+	}
+
+	if len(groups) == 0 {
+		u.Err().Println("No duplicates")
+		return nil
+	}
+	u.Err().Printf("%d duplicate group(s) among %d files; %s reclaimable", len(groups), len(files), formatDriveSize(wasted))
+	w, flush := tableWriter(ctx)
+	fmt.Fprintln(w, "MD5\tSIZE\tCREATED\tOWNER\tPATH\tID")
+	for _, g := range groups {
+		for _, f := range g.Files {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", g.MD5, formatDriveSize(f.Size), formatDateTime(f.Created), f.Owner, sanitizeTab(f.Path), f.ID)
+		}
+	}
+	flush()
+
+	for _, r := range trashed {
+		if r.Error != "" {
+			u.Err().Printf("trash %s: %s", r.Path, r.Error)
+		}
+	}
+	if len(trashed) > 0 {
+		u.Err().Printf("Trashed %d file(s), %d failed", len(trashed)-failed, failed)
+	}
+	return driveDupesTrashError(failed)
+}
+
+func driveDupesTrashError(failed int) error {
+	if failed > 0 {
+		return fmt.Errorf("%d duplicate(s) could not be trashed", failed)
+	}
+	return nil
+}
+
+// findDriveDuplicates groups binary files by md5Checksum+size. Google-native
+// files have no checksum and are never reported. Groups are sorted by wasted
+// bytes (size times extra copies), largest first.
+func findDriveDuplicates(files []*driveUsageFile, minSize int64) []driveDupeGroup {
+	byKey := map[string]*driveDupeGroup{}
+	seenID := map[string]bool{}
+	for _, f := range files {
+		if f.MD5 == "" || f.Size < minSize || seenID[f.ID] {
+			continue
+		}
+		// Multi-parent files show up once per parent; they are not copies.
+		seenID[f.ID] = true
+		key := f.MD5 + ":" + strconv.FormatInt(f.Size, 10)
+		g := byKey[key]
+		if g == nil {
+			g = &driveDupeGroup{MD5: f.MD5, Size: f.Size}
+			byKey[key] = g
+		}
+		g.Files = append(g.Files, *f)
+	}
+	out := make([]driveDupeGroup, 0, len(byKey))
+	for _, g := range byKey {
+		if len(g.Files) < 2 {
+			continue
+		}
+		sort.Slice(g.Files, func(i, j int) bool {
+			if g.Files[i].Created != g.Files[j].Created {
+				return g.Files[i].Created < g.Files[j].Created
+			}
+			return g.Files[i].Path < g.Files[j].Path
+		})
+		g.Count = len(g.Files)
+		g.Wasted = g.Size * int64(g.Count-1)
+		out = append(out, *g)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Wasted != out[j].Wasted {
+			return out[i].Wasted > out[j].Wasted
+		}
+		return out[i].MD5 < out[j].MD5
+	})
+	return out
+}
+
+// driveDupeKeeper picks the copy to keep from files sorted oldest first.
+func driveDupeKeeper(files []driveUsageFile, keep string) driveUsageFile {
+	if keep == driveDupesKeepNewest {
+		return files[len(files)-1]
+	}
+	return files[0]
+}
+
+// parseDriveSize parses a byte count with an optional binary unit suffix
+// (B, KB, MB, GB, TB); empty means 0.
+func parseDriveSize(raw string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(raw))
+	if s == "" {
+		return 0, nil
+	}
+	mult := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		mult   int64
+	}{{"TB", 1 << 40}, {"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"T", 1 << 40}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(s, unit.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix))
+			mult = unit.mult
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid size %q", raw)
+	}
+	return int64(v * float64(mult)), nil
+}
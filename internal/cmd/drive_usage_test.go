@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseDriveSize(t *testing.T) {
+	cases := map[string]int64{"": 0, "512": 512, "1KB": 1024, "1.5 mb": 1572864, "2G": 2 << 30}
+	for in, want := range cases {
+		got, err := parseDriveSize(in)
+		if err != nil || got != want {
+			t.Fatalf("parseDriveSize(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	if _, err := parseDriveSize("lots"); err == nil {
+		t.Fatalf("expected error for invalid size")
+	}
+}
+
+func TestBuildDriveUsageReport(t *testing.T) {
+	files := []*driveUsageFile{
+		{ID: "1", Path: "video/a.mp4", MimeType: "video/mp4", Size: 900, Quota: 900, Owner: "a@example.com"},
+		{ID: "2", Path: "video/raw/b.mov", MimeType: "video/quicktime", Size: 500, Quota: 1500, Owner: "b@example.com"},
+		{ID: "3", Path: "notes.txt", MimeType: "text/plain", Size: 10, Quota: 10, Owner: "a@example.com"},
+	}
+	folders := map[string]string{"": "root", "video": "v", "video/raw": "r"}
+	report := buildDriveUsageReport("root", files, folders, 1, 2)
+
+	if report.Size != 1410 || report.Quota != 2410 || report.Folders != 2 {
+		t.Fatalf("unexpected totals: %#v", report)
+	}
+	if len(report.ByDir) != 2 || report.ByDir[0].Key != "/" || report.ByDir[1].Key != "video" || report.ByDir[1].Quota != 2400 {
+		t.Fatalf("unexpected folders: %#v", report.ByDir)
+	}
+	if report.ByOwner[0].Key != "b@example.com" {
+		t.Fatalf("expected quota-heavy owner first: %#v", report.ByOwner)
+	}
+	if len(report.Largest) != 2 || report.Largest[0].ID != "2" {
+		t.Fatalf("unexpected largest: %#v", report.Largest)
+	}
+}
+
+func TestFindDriveDuplicates(t *testing.T) {
+	files := []*driveUsageFile{
+		{ID: "a", Path: "x/report.pdf", MD5: "m1", Size: 100, Created: "2024-01-02T00:00:00Z"},
+		{ID: "b", Path: "y/report.pdf", MD5: "m1", Size: 100, Created: "2024-01-01T00:00:00Z"},
+		{ID: "b", Path: "z/report.pdf", MD5: "m1", Size: 100, Created: "2024-01-01T00:00:00Z"},
+		{ID: "c", Path: "doc", MD5: "", Size: 0},
+		{ID: "d", Path: "tiny1", MD5: "m2", Size: 1},
+		{ID: "e", Path: "tiny2", MD5: "m2", Size: 1},
+	}
+	groups := findDriveDuplicates(files, 10)
+	if len(groups) != 1 || groups[0].Count != 2 || groups[0].Wasted != 100 {
+		t.Fatalf("unexpected groups: %#v", groups)
+	}
+	if driveDupeKeeper(groups[0].Files, "oldest").ID != "b" || driveDupeKeeper(groups[0].Files, driveDupesKeepNewest).ID != "a" {
+		t.Fatalf("unexpected keeper order: %#v", groups[0].Files)
+	}
+}
+
+func TestDriveDupesCmd_TrashDuplicatesKeepsOldest(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		trashed []string
+	)
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query().Get("q")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files" && strings.Contains(q, "'F1' in parents"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{
+				{"id": "sub", "name": "copies", "mimeType": driveMimeFolder},
+				{"id": "orig", "name": "deck.pdf", "mimeType": "application/pdf", "md5Checksum": "abc", "size": "2048", "createdTime": "2024-01-01T00:00:00Z", "capabilities": map[string]any{"canTrash": true}},
+			}})
+		case r.Method == http.MethodGet && r.URL.Path == "/files" && strings.Contains(q, "'sub' in parents"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{
+				{"id": "copy", "name": "deck (1).pdf", "mimeType": "application/pdf", "md5Checksum": "abc", "size": "2048", "createdTime": "2024-03-01T00:00:00Z", "capabilities": map[string]any{"canTrash": true}},
+			}})
+		case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/files/"):
+			mu.Lock()
+			trashed = append(trashed, strings.TrimPrefix(r.URL.Path, "/files/"))
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "x", "trashed": true})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			http.NotFound(w, r)
+		}
+	}))
+	defer closeSrv()
+
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = stubDriveService(svc)
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = Execute([]string{"--json", "--force", "--account", "a@example.com", "drive", "dupes", "F1", "--trash-duplicates"})
+	})
+	if runErr != nil {
+		t.Fatalf("Execute: %v", runErr)
+	}
+	if len(trashed) != 1 || trashed[0] != "copy" {
+		t.Fatalf("unexpected trashed: %v", trashed)
+	}
+	var parsed struct {
+		Wasted int64            `json:"wasted"`
+		Groups []driveDupeGroup `json:"groups"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json parse: %v\nout=%q", err, out)
+	}
+	if parsed.Wasted != 2048 || len(parsed.Groups) != 1 || parsed.Groups[0].Kept != "orig" {
+		t.Fatalf("unexpected output: %s", out)
+	}
+}
+
+func TestDriveDupesCmd_TrashFailureExitsNonZero(t *testing.T) {
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPatch && r.URL.Path == "/files/copy" {
+			http.Error(w, `{"error":{"code":403,"message":"forbidden"}}`, http.StatusForbidden)
+			return
+		}
+		if r.Method != http.MethodGet || r.URL.Path != "/files" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{
+			{"id": "orig", "name": "deck.pdf", "mimeType": "application/pdf", "md5Checksum": "abc", "size": "2048", "createdTime": "2024-01-01T00:00:00Z"},
+			{"id": "copy", "name": "deck (1).pdf", "mimeType": "application/pdf", "md5Checksum": "abc", "size": "2048", "createdTime": "2024-03-01T00:00:00Z"},
+		}})
+	}))
+	defer closeSrv()
+
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+	newDriveService = stubDriveService(svc)
+
+	for _, args := range [][]string{
+		{"--json", "--force", "--account", "a@example.com", "drive", "dupes", "F1", "--trash-duplicates"},
+		{"--force", "--account", "a@example.com", "drive", "dupes", "F1", "--trash-duplicates"},
+	} {
+		var runErr error
+		out := captureStdout(t, func() {
+			_ = captureStderr(t, func() {
+				runErr = Execute(args)
+			})
+		})
+		if runErr == nil || !strings.Contains(runErr.Error(), "could not be trashed") {
+			t.Fatalf("%v: expected trash failure error, got %v", args, runErr)
+		}
+		if !strings.Contains(out, "copy") {
+			t.Fatalf("%v: expected report before failing, got %q", args, out)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type DriveWatchCmd struct {
+	Serve  DriveWatchServeCmd  `cmd:"" name:"serve" help:"Register a changes.watch channel and handle push notifications"`
+	Status DriveWatchStatusCmd `cmd:"" name:"status" aliases:"ls" help:"Show stored watch channels and page tokens"`
+	Stop   DriveWatchStopCmd   `cmd:"" name:"stop" aliases:"rm,delete" help:"Stop a watch channel"`
+}
+
+type DriveWatchServeCmd struct {
+	Drive     string   `name:"drive" help:"Shared drive ID (default: your My Drive change feed)"`
+	Folder    string   `name:"folder" help:"Only forward changes inside this folder ID or path (any depth)"`
+	Mime      []string `name:"mime" help:"Only forward these MIME types (repeatable; 'image/*' matches a prefix)"`
+	Bind      string   `name:"bind" help:"Bind address" default:"127.0.0.1"`
+	Port      int      `name:"port" help:"Listen port" default:"8790"`
+	Path      string   `name:"path" help:"Push handler path" default:"/drive-push"`
+	Address   string   `name:"address" help:"Public HTTPS URL Google should deliver notifications to (registers a channel; omit to reuse the stored one)"`
+	Token     string   `name:"token" help:"Channel token expected in X-Goog-Channel-Token (default: random)"`
+	TTL       string   `name:"ttl" help:"Requested channel lifetime (seconds or Go duration)"`
+	HookURL   string   `name:"hook-url" help:"Webhook URL to forward changes to (default: print JSONL to stdout)"`
+	HookToken string   `name:"hook-token" help:"Webhook bearer token"`
+	SaveHook  bool     `name:"save-hook" help:"Persist hook settings to watch state"`
+}
+
+func (c *DriveWatchServeCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	address, ttl, err := parseWatchServeFlags(c.Path, c.Port, c.Address, c.TTL, c.HookURL, c.HookToken)
+	if err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	driveID := strings.TrimSpace(c.Drive)
+	filter, err := resolveDriveChangeFilter(ctx, svc, c.Folder, c.Mime)
+	if err != nil {
+		return err
+	}
+
+	store, err := openDriveWatchStore(account)
+	if err != nil {
+		return err
+	}
+
+	hook := resolveWatchHook(store.Get().Hook, c.HookURL, c.HookToken)
+	if c.SaveHook && hook != nil {
+		if err := store.Update(func(s *driveWatchState) error {
+			s.Hook = hook
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Establish a baseline so the first notification only reports new changes.
+	if store.DriveState(driveID).PageToken == "" {
+		if _, err := collectDriveChanges(ctx, svc, store, driveID, filter); err != nil {
+			return err
+		}
+	}
+
+	channel := store.DriveState(driveID).Channel
+	if address != "" && !watchChannelReusable(channel, address, time.Now()) {
+		channel, err = registerDriveWatchChannel(ctx, svc, store, driveID, address, c.Token, ttl)
+		if err != nil {
+			return err
+		}
+		u.Err().Printf("watch: registered channel %s (expires %s)", channel.ID, formatUnixMillis(channel.ExpirationMs))
+	}
+	if channel == nil {
+		return usage("no stored channel for this drive; pass --address to register one")
+	}
+
+	server := &driveWatchServer{
+		watchPushServer: newWatchPushServer(ctx, c.Path, *channel, hook),
+		account:         account,
+		driveID:         driveID,
+		filter:          filter,
+		store:           store,
+		svc:             svc,
+	}
+	return serveWatch(ctx, c.Bind, c.Port, c.Path, server)
+}
+
+func registerDriveWatchChannel(ctx context.Context, svc *drive.Service, store *driveWatchStore, driveID, address, token string, ttl time.Duration) (*watchChannel, error) {
+	id, token, err := newWatchChannelIDs(token)
+	if err != nil {
+		return nil, err
+	}
+	req := &drive.Channel{
+		Id:      id,
+		Type:    "web_hook",
+		Address: address,
+		Token:   token,
+	}
+	if ttl > 0 {
+		req.Expiration = time.Now().Add(ttl).UnixMilli()
+	}
+	call := svc.Changes.Watch(store.DriveState(driveID).PageToken, req).
+		IncludeRemoved(true).
+		SupportsAllDrives(true).
+		Context(ctx)
+	if driveID != "" {
+		call = call.DriveId(driveID).IncludeItemsFromAllDrives(true)
+	}
+	resp, err := call.Do()
+	if err != nil {
+		return nil, err
+	}
+
+	previous := store.DriveState(driveID).Channel
+	channel := &watchChannel{
+		ID:           resp.Id,
+		ResourceID:   resp.ResourceId,
+		Address:      address,
+		Token:        token,
+		ExpirationMs: resp.Expiration,
+	}
+	if err := store.Update(func(s *driveWatchState) error {
+		s.drive(driveID).Channel = channel
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	// Best-effort: the old channel would otherwise keep firing until it expires.
+	if previous != nil && previous.ID != "" && previous.ID != channel.ID {
+		_ = stopDriveWatchChannel(ctx, svc, previous)
+	}
+	return channel, nil
+}
+
+func stopDriveWatchChannel(ctx context.Context, svc *drive.Service, ch *watchChannel) error {
+	return svc.Channels.Stop(&drive.Channel{Id: ch.ID, ResourceId: ch.ResourceID}).Context(ctx).Do()
+}
+
+type driveHookPayload struct {
+	Account string        `json:"account"`
+	DriveID string        `json:"driveId"`
+	Changes []driveChange `json:"changes"`
+}
+
+type driveWatchServer struct {
+	watchPushServer
+	account string
+	driveID string
+	filter  driveChangeFilter
+	store   *driveWatchStore
+	svc     *drive.Service
+}
+
+func (s *driveWatchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.accept(w, r, "change") {
+		return
+	}
+
+	s.mu.Lock()
+	result, err := collectDriveChanges(r.Context(), s.svc, s.store, s.driveID, s.filter)
+	s.mu.Unlock()
+	if err != nil {
+		s.warnf("watch: fetch changes failed: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	payload := &driveHookPayload{
+		Account: s.account,
+		DriveID: driveWatchKey(s.driveID),
+		Changes: result.Changes,
+	}
+	deliverWatchChanges(r.Context(), &s.watchPushServer, w, s.store, payload, result.Changes)
+}
+
+type DriveWatchStatusCmd struct{}
+
+func (c *DriveWatchStatusCmd) Run(ctx context.Context, flags *RootFlags) error {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	store, err := openDriveWatchStore(account)
+	if err != nil {
+		return err
+	}
+	state := store.Get()
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"watch": state})
+	}
+
+	u := ui.FromContext(ctx)
+	if len(state.Drives) == 0 {
+		u.Err().Println("No drive watch state")
+		return nil
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "DRIVE\tPAGE_TOKEN\tCHANNEL\tEXPIRES\tUPDATED")
+	ids := make([]string, 0, len(state.Drives))
+	for id := range state.Drives {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		st := state.Drives[id]
+		channelID, expires := "", ""
+		if st.Channel != nil {
+			channelID = st.Channel.ID
+			expires = formatUnixMillis(st.Channel.ExpirationMs)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", id, st.PageToken, channelID, expires, formatUnixMillis(st.UpdatedAtMs))
+	}
+	return nil
+}
+
+type DriveWatchStopCmd struct {
+	Drive string `name:"drive" help:"Shared drive ID (default: your My Drive change feed)"`
+}
+
+func (c *DriveWatchStopCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	driveID := strings.TrimSpace(c.Drive)
+
+	if confirmErr := confirmDestructive(ctx, flags, "stop drive watch channel for "+driveWatchKey(driveID)); confirmErr != nil {
+		return confirmErr
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	store, err := openDriveWatchStore(account)
+	if err != nil {
+		return err
+	}
+	channel := store.DriveState(driveID).Channel
+	if channel == nil {
+		return usagef("no stored channel for %s", driveWatchKey(driveID))
+	}
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	if err := stopDriveWatchChannel(ctx, svc, channel); err != nil && !isNotFoundAPIError(err) {
+		return err
+	}
+	if err := store.Update(func(s *driveWatchState) error {
+		s.drive(driveID).Channel = nil
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"stopped": true, "channelId": channel.ID})
+	}
+	u.Out().Printf("stopped\ttrue")
+	u.Out().Printf("channel_id\t%s", channel.ID)
+	return nil
+}
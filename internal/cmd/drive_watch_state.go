@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/steipete/gogcli/internal/config"
+)
+
+const (
+	// driveWatchMyDriveKey keys the user's own change feed (no --drive).
+	driveWatchMyDriveKey = "my-drive"
+
+	// Fingerprints are only needed to classify the next change to a file, so
+	// files that have not changed for a while are forgotten.
+	driveWatchFileTTL      = 30 * 24 * time.Hour
+	driveWatchMaxFileCount = 5000
+)
+
+// driveWatchFile is the last seen fingerprint of a changed file, used to tell
+// sharing-only changes from content edits and to filter removals by folder.
+type driveWatchFile struct {
+	ModifiedTime string   `json:"modifiedTime,omitempty"`
+	Permissions  string   `json:"permissions,omitempty"`
+	Parents      []string `json:"parents,omitempty"`
+	Trashed      bool     `json:"trashed,omitempty"`
+	SeenAtMs     int64    `json:"seenAtMs,omitempty"`
+}
+
+type driveWatchDriveState struct {
+	PageToken   string                     `json:"pageToken,omitempty"`
+	UpdatedAtMs int64                      `json:"updatedAtMs,omitempty"`
+	Channel     *watchChannel              `json:"channel,omitempty"`
+	Files       map[string]*driveWatchFile `json:"files,omitempty"`
+}
+
+// driveWatchState is persisted per account and keyed by shared drive ID (or
+// driveWatchMyDriveKey), so `drive changes` and `drive watch serve` share
+// page tokens.
+type driveWatchState struct {
+	Account string                           `json:"account"`
+	Drives  map[string]*driveWatchDriveState `json:"drives,omitempty"`
+	Hook    *watchHook                       `json:"hook,omitempty"`
+	watchDelivery
+}
+
+func driveWatchKey(driveID string) string {
+	if driveID == "" {
+		return driveWatchMyDriveKey
+	}
+	return driveID
+}
+
+func (s *driveWatchState) drive(driveID string) *driveWatchDriveState {
+	if s.Drives == nil {
+		s.Drives = map[string]*driveWatchDriveState{}
+	}
+	key := driveWatchKey(driveID)
+	st := s.Drives[key]
+	if st == nil {
+		st = &driveWatchDriveState{}
+		s.Drives[key] = st
+	}
+	return st
+}
+
+// pruneFiles drops fingerprints older than driveWatchFileTTL and keeps at
+// most driveWatchMaxFileCount of the most recently seen ones. Entries written
+// before SeenAtMs existed count as seen now.
+func (st *driveWatchDriveState) pruneFiles(now time.Time) {
+	cutoff := now.Add(-driveWatchFileTTL).UnixMilli()
+	ids := make([]string, 0, len(st.Files))
+	for id, fp := range st.Files {
+		if fp == nil {
+			delete(st.Files, id)
+			continue
+		}
+		if fp.SeenAtMs == 0 {
+			fp.SeenAtMs = now.UnixMilli()
+		}
+		if fp.SeenAtMs < cutoff {
+			delete(st.Files, id)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) <= driveWatchMaxFileCount {
+		return
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := st.Files[ids[i]].SeenAtMs, st.Files[ids[j]].SeenAtMs
+		if a != b {
+			return a > b
+		}
+		return ids[i] < ids[j]
+	})
+	for _, id := range ids[driveWatchMaxFileCount:] {
+		delete(st.Files, id)
+	}
+}
+
+type driveWatchStore struct {
+	*watchStore[driveWatchState]
+}
+
+func driveWatchStatePath(account string) (string, error) {
+	dir, err := config.EnsureDriveWatchDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, sanitizeAccountForPath(account)+".json"), nil
+}
+
+// openDriveWatchStore loads stored state for account, starting empty when
+// nothing has been recorded yet.
+func openDriveWatchStore(account string) (*driveWatchStore, error) {
+	path, err := driveWatchStatePath(account)
+	if err != nil {
+		return nil, err
+	}
+	store, err := openWatchStore(path, driveWatchState{Account: account})
+	if err != nil {
+		return nil, err
+	}
+	return &driveWatchStore{store}, nil
+}
+
+// DriveState returns a copy of the stored state for driveID ("" = My Drive).
+// The Files map is shared with the store and must not be modified.
+func (s *driveWatchStore) DriveState(driveID string) driveWatchDriveState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if st := s.state.Drives[driveWatchKey(driveID)]; st != nil {
+		return *st
+	}
+	return driveWatchDriveState{}
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDriveWatchServer_PrintsChanges(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/changes" || r.URL.Query().Get("pageToken") != "t1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"newStartPageToken": "t2",
+			"changes": []map[string]any{
+				{"changeType": "file", "fileId": "img", "file": map[string]any{"id": "img", "name": "a.png", "mimeType": "image/png"}},
+				{"changeType": "file", "fileId": "doc", "file": map[string]any{"id": "doc", "name": "notes.txt", "mimeType": "text/plain"}},
+			},
+		})
+	}))
+	defer closeSrv()
+
+	store, err := openDriveWatchStore("watch-user@example.com")
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	if err := store.Update(func(s *driveWatchState) error {
+		s.drive("").PageToken = "t1"
+		return nil
+	}); err != nil {
+		t.Fatalf("seed store: %v", err)
+	}
+
+	var out bytes.Buffer
+	server := &driveWatchServer{
+		watchPushServer: watchPushServer{
+			path:    "/drive-push",
+			channel: watchChannel{ID: "chan-1", Token: "secret"},
+			out:     json.NewEncoder(&out),
+			logf:    func(string, ...any) {},
+			warnf:   func(string, ...any) {},
+		},
+		account: "watch-user@example.com",
+		filter:  driveChangeFilter{Mimes: []string{"image/*"}},
+		store:   store,
+		svc:     svc,
+	}
+
+	notify := func(token, state string) int {
+		req := httptest.NewRequest(http.MethodPost, "/drive-push", nil)
+		req.Header.Set("X-Goog-Channel-ID", "chan-1")
+		req.Header.Set("X-Goog-Channel-Token", token)
+		req.Header.Set("X-Goog-Resource-State", state)
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := notify("wrong", "change"); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for bad token, got %d", code)
+	}
+	if code := notify("secret", "sync"); code != http.StatusOK || out.Len() != 0 {
+		t.Fatalf("sync handshake should not fetch changes (code %d, out %q)", code, out.String())
+	}
+	if code := notify("secret", "change"); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	var ch driveChange
+	if err := json.Unmarshal(out.Bytes(), &ch); err != nil {
+		t.Fatalf("parse output: %v (%q)", err, out.String())
+	}
+	if ch.FileID != "img" {
+		t.Fatalf("unexpected change: %#v", ch)
+	}
+	if got := store.DriveState("").PageToken; got != "t2" {
+		t.Fatalf("expected page token to advance, got %q", got)
+	}
+}
+
+func TestDriveWatchDriveState_PruneFiles(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	st := &driveWatchDriveState{Files: map[string]*driveWatchFile{
+		"fresh":  {SeenAtMs: now.Add(-time.Hour).UnixMilli()},
+		"stale":  {SeenAtMs: now.Add(-driveWatchFileTTL - time.Hour).UnixMilli()},
+		"legacy": {},
+	}}
+	st.pruneFiles(now)
+	if len(st.Files) != 2 || st.Files["fresh"] == nil || st.Files["legacy"] == nil {
+		t.Fatalf("unexpected files after prune: %#v", st.Files)
+	}
+	if st.Files["legacy"].SeenAtMs != now.UnixMilli() {
+		t.Fatalf("expected legacy entry to be stamped, got %d", st.Files["legacy"].SeenAtMs)
+	}
+
+	st.Files = map[string]*driveWatchFile{}
+	for i := 0; i < driveWatchMaxFileCount+10; i++ {
+		st.Files[fmt.Sprintf("f%05d", i)] = &driveWatchFile{SeenAtMs: now.Add(-time.Duration(i) * time.Minute).UnixMilli()}
+	}
+	st.pruneFiles(now)
+	if len(st.Files) != driveWatchMaxFileCount {
+		t.Fatalf("expected %d files, got %d", driveWatchMaxFileCount, len(st.Files))
+	}
+	if st.Files["f00000"] == nil || st.Files[fmt.Sprintf("f%05d", driveWatchMaxFileCount)] != nil {
+		t.Fatalf("expected the oldest entries to be dropped")
+	}
+}
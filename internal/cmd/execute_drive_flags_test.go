@@ -2,9 +2,9 @@ package cmd
 
 import "testing"
 
-func TestExecute_DriveLs_PositionalFolderRejected(t *testing.T) {
+func TestExecute_DriveLs_PositionalFolderWithParentRejected(t *testing.T) {
 	_ = captureStderr(t, func() {
-		err := Execute([]string{"--account", "a@b.com", "drive", "ls", "root"})
+		err := Execute([]string{"--account", "a@b.com", "drive", "ls", "root", "--parent", "p1"})
 		if err == nil {
 			t.Fatalf("expected error")
 		}
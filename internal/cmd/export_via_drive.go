@@ -7,6 +7,8 @@ import (
 	"os"
 	"strings"
 
+	"google.golang.org/api/drive/v3"
+
 	"github.com/steipete/gogcli/internal/config"
 	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
@@ -79,44 +81,51 @@ func exportViaDrive(ctx context.Context, flags *RootFlags, opts exportViaDriveOp
 		return err
 	}
 
-	svc, err := newDriveService(ctx, account)
+	svc, err := newDriveTransferService(ctx, account)
 	if err != nil {
 		return err
 	}
 
+	downloadedPath, size, err := exportDriveFile(ctx, svc, opts, id, outPathFlag, format)
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"path": downloadedPath, "size": size})
+	}
+	u.Out().Printf("path\t%s", downloadedPath)
+	u.Out().Printf("size\t%s", formatDriveSize(size))
+	return nil
+}
+
+// exportDriveFile checks the type of id and downloads it in format without
+// printing. svc should come from newDriveTransferService so large exports are
+// not cut off by the request timeout.
+func exportDriveFile(ctx context.Context, svc *drive.Service, opts exportViaDriveOptions, id string, outPath string, format string) (string, int64, error) {
 	meta, err := svc.Files.Get(id).
 		SupportsAllDrives(true).
 		Fields("id, name, mimeType").
 		Context(ctx).
 		Do()
 	if err != nil {
-		return err
+		return "", 0, err
 	}
 	if meta == nil {
-		return errors.New("file not found")
+		return "", 0, errors.New("file not found")
 	}
 	if opts.ExpectedMime != "" && meta.MimeType != opts.ExpectedMime {
 		label := strings.TrimSpace(opts.KindLabel)
 		if label == "" {
 			label = "expected type"
 		}
-		return fmt.Errorf("file is not a %s (mimeType=%q)", label, meta.MimeType)
-	}
-
-	destPath, err := resolveDriveDownloadDestPath(meta, outPathFlag)
-	if err != nil {
-		return err
+		return "", 0, fmt.Errorf("file is not a %s (mimeType=%q)", label, meta.MimeType)
 	}
 
-	downloadedPath, size, err := downloadDriveFile(ctx, svc, meta, destPath, format)
+	destPath, err := resolveDriveDownloadDestPath(meta, outPath)
 	if err != nil {
-		return err
+		return "", 0, err
 	}
 
-	if outfmt.IsJSON(ctx) {
-		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"path": downloadedPath, "size": size})
-	}
-	u.Out().Printf("path\t%s", downloadedPath)
-	u.Out().Printf("size\t%s", formatDriveSize(size))
-	return nil
+	return downloadDriveFile(ctx, svc, meta, destPath, format)
 }
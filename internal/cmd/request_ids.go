@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// randomRequestID returns a random hex identifier for API calls that need a
+// caller-chosen ID, such as watch channel IDs and Drives.Create request IDs.
+func randomRequestID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
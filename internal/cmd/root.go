@@ -88,7 +88,13 @@ type CLI struct {
 
 type exitPanic struct{ code int }
 
-func Execute(args []string) (err error) {
+func Execute(args []string) error {
+	return executeContext(context.Background(), args)
+}
+
+// executeContext runs the CLI with base as the parent of the command context,
+// letting in-process callers such as `drive shell` pass values through.
+func executeContext(base context.Context, args []string) (err error) {
 	args = rewriteDesirePathArgs(args)
 
 	parser, cli, err := newParser(helpDescription())
@@ -141,8 +147,7 @@ func Execute(args []string) (err error) {
 		return newUsageError(err)
 	}
 
-	ctx := context.Background()
-	ctx = outfmt.WithMode(ctx, mode)
+	ctx := outfmt.WithMode(base, mode)
 	ctx = outfmt.WithJSONTransform(ctx, outfmt.JSONTransform{
 		ResultsOnly: cli.ResultsOnly,
 		Select:      splitCommaList(cli.Select),
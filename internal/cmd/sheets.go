@@ -29,6 +29,7 @@ type SheetsCmd struct {
 	Append   SheetsAppendCmd   `cmd:"" name:"append" aliases:"add" help:"Append values to a range"`
 	Insert   SheetsInsertCmd   `cmd:"" name:"insert" help:"Insert empty rows or columns into a sheet"`
 	Clear    SheetsClearCmd    `cmd:"" name:"clear" help:"Clear values in a range"`
+	Import   SheetsImportCmd   `cmd:"" name:"import" help:"Import CSV, TSV, JSON, or JSONL rows into a sheet"`
 	Format   SheetsFormatCmd   `cmd:"" name:"format" help:"Apply cell formatting to a range"`
 	Notes    SheetsNotesCmd    `cmd:"" name:"notes" help:"Get cell notes from a range"`
 	Metadata SheetsMetadataCmd `cmd:"" name:"metadata" aliases:"info" help:"Get spreadsheet metadata"`
@@ -73,6 +74,7 @@ type SheetsGetCmd struct {
 	Range             string `arg:"" name:"range" help:"Range (eg. Sheet1!A1:B10)"`
 	MajorDimension    string `name:"dimension" help:"Major dimension: ROWS or COLUMNS"`
 	ValueRenderOption string `name:"render" help:"Value render option: FORMATTED_VALUE, UNFORMATTED_VALUE, or FORMULA"`
+	Format            string `name:"format" help:"Output format: csv|jsonl|json-objects (jsonl and json-objects key rows by the header row)" enum:",csv,jsonl,json-objects" default:""`
 }
 
 func (c *SheetsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -102,6 +104,10 @@ func (c *SheetsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 	if strings.TrimSpace(c.ValueRenderOption) != "" {
 		call = call.ValueRenderOption(c.ValueRenderOption)
+	} else if c.Format == "jsonl" || c.Format == "json-objects" {
+		// Keep numbers and booleans typed in header-keyed output; dates stay
+		// readable strings instead of serial numbers.
+		call = call.ValueRenderOption("UNFORMATTED_VALUE").DateTimeRenderOption("FORMATTED_STRING")
 	}
 
 	resp, err := call.Do()
@@ -109,6 +115,10 @@ func (c *SheetsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
+	if c.Format != "" {
+		return writeSheetsValues(os.Stdout, c.Format, resp.Values)
+	}
+
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
 			"range":  resp.Range,
@@ -0,0 +1,545 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// sheetsImportChunkBytes caps the estimated payload of one write request so
+// wide rows are split well below the API's request size limit.
+const sheetsImportChunkBytes = 2 << 20
+
+var sheetsAnchorCellRe = regexp.MustCompile(`^([A-Za-z]{1,3})([0-9]+)$`)
+
+type SheetsImportCmd struct {
+	SpreadsheetID string   `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Range         string   `arg:"" name:"range" help:"Top-left cell of the table (eg. Sheet1, Sheet1!B2, or A1 on the first sheet)"`
+	File          string   `arg:"" name:"file" help:"CSV, TSV, JSON array, or JSONL file (- for stdin)"`
+	Format        string   `name:"format" help:"Input format: csv|tsv|json|jsonl (default: from the file extension)" enum:",csv,tsv,json,jsonl" default:""`
+	Mode          string   `name:"mode" help:"replace: clear the table and write it; append: add rows below; upsert: update rows matching --key and append the rest" enum:"replace,append,upsert" default:"replace"`
+	Key           string   `name:"key" help:"Header of the column that identifies rows (--mode upsert)"`
+	Map           []string `name:"map" help:"Rename input columns to sheet headers: source=Header (comma-separated or repeatable)"`
+	Types         []string `name:"types" help:"Column types: Header=auto|string|number|bool|date|formula (comma-separated or repeatable)"`
+	ChunkRows     int      `name:"chunk-rows" help:"Rows per write request" default:"1000"`
+}
+
+func (c *SheetsImportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+
+	spreadsheetID := normalizeGoogleID(strings.TrimSpace(c.SpreadsheetID))
+	if spreadsheetID == "" {
+		return usage("empty spreadsheetId")
+	}
+	sheetName, anchorCol, anchorRow, err := parseSheetsAnchor(cleanRange(c.Range))
+	if err != nil {
+		return err
+	}
+	key := strings.TrimSpace(c.Key)
+	if c.Mode == "upsert" && key == "" {
+		return usage("--mode upsert requires --key")
+	}
+	if c.Mode != "upsert" && key != "" {
+		return usage("--key is only used with --mode upsert")
+	}
+	if c.ChunkRows <= 0 {
+		return usage("--chunk-rows must be positive")
+	}
+	rename := map[string]string{}
+	for _, e := range c.Map {
+		src, dest, ok := strings.Cut(e, "=")
+		src, dest = strings.TrimSpace(src), strings.TrimSpace(dest)
+		if !ok || src == "" || dest == "" {
+			return usagef("invalid --map entry %q (want source=Header)", e)
+		}
+		rename[src] = dest
+	}
+	types, err := parseSheetsTypes(c.Types)
+	if err != nil {
+		return err
+	}
+
+	path := strings.TrimSpace(c.File)
+	if path == "" {
+		return usage("empty file")
+	}
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		expanded, expandErr := config.ExpandPath(path)
+		if expandErr != nil {
+			return expandErr
+		}
+		f, openErr := os.Open(expanded) //nolint:gosec // user-provided path
+		if openErr != nil {
+			return openErr
+		}
+		defer f.Close()
+		in = f
+	}
+	br := bufio.NewReaderSize(in, 1<<16)
+	format := sheetsInputFormat(c.Format, path, br)
+
+	if err = dryRunExit(ctx, flags, "sheets.import", map[string]any{
+		"spreadsheet_id": spreadsheetID,
+		"range":          c.Range,
+		"file":           path,
+		"format":         format,
+		"mode":           c.Mode,
+		"key":            key,
+	}); err != nil {
+		return err
+	}
+
+	records, err := newSheetsRecordReader(br, format)
+	if err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newSheetsService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	ss, err := svc.Spreadsheets.Get(spreadsheetID).Fields("sheets.properties").Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	var sheet *sheets.SheetProperties
+	for _, s := range ss.Sheets {
+		if s.Properties == nil {
+			continue
+		}
+		if sheetName == "" || s.Properties.Title == sheetName {
+			sheet = s.Properties
+			break
+		}
+	}
+	if sheet == nil {
+		if sheetName == "" {
+			return fmt.Errorf("spreadsheet has no sheets")
+		}
+		return usagef("unknown sheet %q", sheetName)
+	}
+
+	imp := &sheetsImporter{
+		ctx:       ctx,
+		svc:       svc,
+		id:        spreadsheetID,
+		sheetID:   sheet.SheetId,
+		prefix:    formatSheetPrefix(sheet.Title),
+		col:       anchorCol,
+		row:       anchorRow,
+		key:       key,
+		rename:    rename,
+		types:     types,
+		chunkRows: c.ChunkRows,
+		index:     map[string]int{},
+	}
+	if g := sheet.GridProperties; g != nil {
+		imp.gridRows, imp.gridCols = g.RowCount, g.ColumnCount
+	}
+
+	switch c.Mode {
+	case "append":
+		err = imp.append(records)
+	case "upsert":
+		err = imp.upsert(records)
+	default:
+		err = imp.replace(records)
+	}
+	if err != nil {
+		return err
+	}
+
+	table := imp.prefix + imp.cell(imp.row, 0)
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"range":         table,
+			"mode":          c.Mode,
+			"format":        format,
+			"rows":          imp.rows,
+			"updated":       imp.updated,
+			"added":         imp.added,
+			"columns":       imp.header,
+			"requests":      imp.requests,
+		})
+	}
+	u.Out().Printf("Imported %d rows into %s (%s)", imp.rows, table, c.Mode)
+	if c.Mode == "upsert" {
+		u.Out().Printf("updated\t%d", imp.updated)
+		u.Out().Printf("added\t%d", imp.added)
+	}
+	u.Out().Printf("columns\t%d", len(imp.header))
+	u.Out().Printf("requests\t%d", imp.requests)
+	return nil
+}
+
+// parseSheetsAnchor reads "Sheet", "Sheet!B2", "B2", or a range whose first
+// cell is used. A bare name that is not a cell reference is a sheet title.
+func parseSheetsAnchor(spec string) (string, int, int, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return "", 0, 0, usage("empty range")
+	}
+	sheetName, cellPart, err := splitA1Sheet(spec)
+	if err != nil {
+		return "", 0, 0, usage(err.Error())
+	}
+	if before, _, ok := strings.Cut(cellPart, ":"); ok {
+		cellPart = before
+	}
+	cellPart = strings.TrimSpace(cellPart)
+	if m := sheetsAnchorCellRe.FindStringSubmatch(cellPart); m != nil {
+		col, colErr := colLettersToIndex(m[1])
+		row, rowErr := strconv.Atoi(m[2])
+		if colErr == nil && rowErr == nil && row > 0 {
+			return sheetName, col, row, nil
+		}
+	}
+	if sheetName == "" {
+		name, nameErr := unquoteSheetName(cellPart)
+		if nameErr != nil {
+			return "", 0, 0, usage(nameErr.Error())
+		}
+		return name, 1, 1, nil
+	}
+	return "", 0, 0, usagef("invalid start cell %q", cellPart)
+}
+
+// sheetsImporter writes records below a header row at (row, col). Header
+// columns are matched by name; input columns the sheet lacks are added on
+// the right and the header row is rewritten with the next write.
+type sheetsImporter struct {
+	ctx       context.Context
+	svc       *sheets.Service
+	id        string
+	sheetID   int64
+	prefix    string
+	col, row  int
+	key       string
+	rename    map[string]string
+	types     map[string]string
+	chunkRows int
+
+	gridRows, gridCols int64
+	header             []string
+	index              map[string]int
+	headerDirty        bool
+
+	pending      []*sheets.ValueRange
+	pendingAt    []sheetsPendingRange
+	pendingRows  int
+	pendingBytes int
+
+	rows, updated, added, requests int
+}
+
+type sheetsPendingRange struct{ row, col int }
+
+func (imp *sheetsImporter) cell(row, col int) string {
+	letters, _ := colIndexToLetters(imp.col + col)
+	return letters + strconv.Itoa(row)
+}
+
+// lastCol is the open-ended column bound of the table: the grid's last
+// column, or the anchor column on an empty grid.
+func (imp *sheetsImporter) lastCol() string {
+	last := max(int(imp.gridCols), imp.col+len(imp.header)-1, imp.col)
+	letters, _ := colIndexToLetters(last)
+	return letters
+}
+
+func (imp *sheetsImporter) setHeader(values []any) {
+	for _, v := range values {
+		name := strings.TrimSpace(fmt.Sprintf("%v", v))
+		if _, dup := imp.index[name]; name != "" && !dup {
+			imp.index[name] = len(imp.header)
+		}
+		imp.header = append(imp.header, name)
+	}
+	for len(imp.header) > 0 && imp.header[len(imp.header)-1] == "" {
+		imp.header = imp.header[:len(imp.header)-1]
+	}
+}
+
+// cells maps a record onto the header, adding unknown columns. present
+// marks the columns the record has a value for.
+func (imp *sheetsImporter) cells(rec sheetsRecord) ([]any, []bool, error) {
+	for _, k := range rec.keys {
+		name := k
+		if dest, ok := imp.rename[k]; ok {
+			name = dest
+		}
+		if _, ok := imp.index[name]; !ok {
+			imp.index[name] = len(imp.header)
+			imp.header = append(imp.header, name)
+			imp.headerDirty = true
+		}
+	}
+	row := make([]any, len(imp.header))
+	present := make([]bool, len(imp.header))
+	for i := range row {
+		row[i] = ""
+	}
+	for _, k := range rec.keys {
+		name := k
+		if dest, ok := imp.rename[k]; ok {
+			name = dest
+		}
+		typ := imp.types[name]
+		if typ == "" {
+			typ = sheetsTypeAuto
+		}
+		v, err := sheetsCellValue(rec.values[k], typ)
+		if err != nil {
+			return nil, nil, usagef("record %d, column %s: %v", imp.rows+1, name, err)
+		}
+		i := imp.index[name]
+		row[i], present[i] = v, true
+	}
+	return row, present, nil
+}
+
+func (imp *sheetsImporter) headerRow() []any {
+	row := make([]any, len(imp.header))
+	for i, h := range imp.header {
+		row[i] = sheetsTextValue(h)
+	}
+	return row
+}
+
+// add queues values for (row, col), extending the previous range when it
+// ends right above at the same column.
+func (imp *sheetsImporter) add(row, col int, values []any) error {
+	if n := len(imp.pending); n > 0 {
+		last, at := imp.pending[n-1], imp.pendingAt[n-1]
+		if at.col == col && at.row+len(last.Values) == row {
+			last.Values = append(last.Values, values)
+			return imp.queued(values)
+		}
+	}
+	imp.pending = append(imp.pending, &sheets.ValueRange{
+		Range:  imp.prefix + imp.cell(row, col),
+		Values: [][]any{values},
+	})
+	imp.pendingAt = append(imp.pendingAt, sheetsPendingRange{row: row, col: col})
+	return imp.queued(values)
+}
+
+func (imp *sheetsImporter) queued(values []any) error {
+	imp.pendingRows++
+	for _, v := range values {
+		imp.pendingBytes += len(fmt.Sprintf("%v", v)) + 4
+	}
+	if imp.pendingRows >= imp.chunkRows || imp.pendingBytes >= sheetsImportChunkBytes {
+		return imp.flush()
+	}
+	return nil
+}
+
+// flush writes the queued ranges (and the header row, if it changed) in one
+// values.batchUpdate, growing the grid first when they reach past it.
+func (imp *sheetsImporter) flush() error {
+	data := imp.pending
+	if imp.headerDirty {
+		data = append(data, &sheets.ValueRange{
+			Range:  imp.prefix + imp.cell(imp.row, 0),
+			Values: [][]any{imp.headerRow()},
+		})
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	lastRow := imp.row
+	for i, vr := range imp.pending {
+		lastRow = max(lastRow, imp.pendingAt[i].row+len(vr.Values)-1)
+	}
+	if err := imp.ensureGrid(lastRow, imp.col+len(imp.header)-1); err != nil {
+		return err
+	}
+	_, err := imp.svc.Spreadsheets.Values.BatchUpdate(imp.id, &sheets.BatchUpdateValuesRequest{
+		ValueInputOption: "USER_ENTERED",
+		Data:             data,
+	}).Context(imp.ctx).Do()
+	if err != nil {
+		return fmt.Errorf("write rows: %w", err)
+	}
+	imp.requests++
+	imp.headerDirty = false
+	imp.pending, imp.pendingAt = nil, nil
+	imp.pendingRows, imp.pendingBytes = 0, 0
+	return nil
+}
+
+// ensureGrid appends rows and columns so (lastRow, lastCol) is on the sheet;
+// values writes outside the grid are rejected.
+func (imp *sheetsImporter) ensureGrid(lastRow, lastCol int) error {
+	var reqs []*sheets.Request
+	if extra := int64(lastRow) - imp.gridRows; imp.gridRows > 0 && extra > 0 {
+		reqs = append(reqs, &sheets.Request{AppendDimension: &sheets.AppendDimensionRequest{SheetId: imp.sheetID, Dimension: "ROWS", Length: extra}})
+	}
+	if extra := int64(lastCol) - imp.gridCols; imp.gridCols > 0 && extra > 0 {
+		reqs = append(reqs, &sheets.Request{AppendDimension: &sheets.AppendDimensionRequest{SheetId: imp.sheetID, Dimension: "COLUMNS", Length: extra}})
+	}
+	if len(reqs) == 0 {
+		return nil
+	}
+	_, err := imp.svc.Spreadsheets.BatchUpdate(imp.id, &sheets.BatchUpdateSpreadsheetRequest{Requests: reqs}).Context(imp.ctx).Do()
+	if err != nil {
+		return fmt.Errorf("grow sheet: %w", err)
+	}
+	imp.requests++
+	imp.gridRows = max(imp.gridRows, int64(lastRow))
+	imp.gridCols = max(imp.gridCols, int64(lastCol))
+	return nil
+}
+
+// readTable returns the header row and the rows below it, unformatted so
+// numeric keys compare as numbers.
+func (imp *sheetsImporter) readTable() ([]any, [][]any, error) {
+	rng := imp.prefix + imp.cell(imp.row, 0) + ":" + imp.lastCol()
+	resp, err := imp.svc.Spreadsheets.Values.Get(imp.id, rng).ValueRenderOption("UNFORMATTED_VALUE").DateTimeRenderOption("FORMATTED_STRING").Context(imp.ctx).Do()
+	if err != nil {
+		return nil, nil, fmt.Errorf("read table: %w", err)
+	}
+	imp.requests++
+	if len(resp.Values) == 0 {
+		return nil, nil, nil
+	}
+	return resp.Values[0], resp.Values[1:], nil
+}
+
+func (imp *sheetsImporter) replace(records sheetsRecordReader) error {
+	if imp.gridCols >= int64(imp.col) {
+		rng := imp.prefix + imp.cell(imp.row, 0) + ":" + imp.lastCol()
+		if _, err := imp.svc.Spreadsheets.Values.Clear(imp.id, rng, &sheets.ClearValuesRequest{}).Context(imp.ctx).Do(); err != nil {
+			return fmt.Errorf("clear table: %w", err)
+		}
+		imp.requests++
+	}
+	return imp.each(records, func(row []any, _ []bool, _ sheetsRecord) error {
+		return imp.add(imp.row+imp.rows, 0, row)
+	})
+}
+
+func (imp *sheetsImporter) append(records sheetsRecordReader) error {
+	header, rows, err := imp.readTable()
+	if err != nil {
+		return err
+	}
+	imp.setHeader(header)
+	next := imp.row + 1 + len(rows)
+	return imp.each(records, func(row []any, _ []bool, _ sheetsRecord) error {
+		next++
+		return imp.add(next-1, 0, row)
+	})
+}
+
+func (imp *sheetsImporter) upsert(records sheetsRecordReader) error {
+	header, rows, err := imp.readTable()
+	if err != nil {
+		return err
+	}
+	imp.setHeader(header)
+
+	existing := map[string]int{}
+	if keyCol, ok := imp.index[imp.key]; ok {
+		for i, r := range rows {
+			if keyCol >= len(r) {
+				continue
+			}
+			if k := sheetsKeyString(r[keyCol]); k != "" {
+				if _, dup := existing[k]; !dup {
+					existing[k] = imp.row + 1 + i
+				}
+			}
+		}
+	}
+	next := imp.row + 1 + len(rows)
+
+	return imp.each(records, func(row []any, present []bool, rec sheetsRecord) error {
+		_, ok := imp.index[imp.key]
+		var raw any
+		for _, k := range rec.keys {
+			if k == imp.key || imp.rename[k] == imp.key {
+				raw = rec.values[k]
+			}
+		}
+		k := sheetsKeyString(raw)
+		if !ok || k == "" {
+			return usagef("record %d has no %s value", imp.rows, imp.key)
+		}
+		target, found := existing[k]
+		if found {
+			imp.updated++
+		} else {
+			target = next
+			next++
+			existing[k] = target
+			imp.added++
+		}
+		// Write only the columns the record has, so other cells (formulas,
+		// notes columns) in existing rows are left alone.
+		for start := 0; start < len(row); {
+			if !present[start] {
+				start++
+				continue
+			}
+			end := start
+			for end < len(row) && present[end] {
+				end++
+			}
+			if err := imp.add(target, start, row[start:end]); err != nil {
+				return err
+			}
+			start = end
+		}
+		return nil
+	})
+}
+
+// each maps every record onto the header and hands it to write, flushing
+// whatever is queued at the end.
+func (imp *sheetsImporter) each(records sheetsRecordReader, write func([]any, []bool, sheetsRecord) error) error {
+	for {
+		rec, err := records.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		row, present, err := imp.cells(rec)
+		if err != nil {
+			return err
+		}
+		imp.rows++
+		if err := write(row, present, rec); err != nil {
+			return err
+		}
+	}
+	// A CSV with only a header row still sets up the table's columns.
+	if csvRecords, ok := records.(*sheetsCSVReader); ok && imp.rows == 0 {
+		if _, _, err := imp.cells(sheetsRecord{keys: csvRecords.header}); err != nil {
+			return err
+		}
+	}
+	return imp.flush()
+}
@@ -0,0 +1,314 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestSheetsCellValue_Types(t *testing.T) {
+	cases := []struct {
+		in   any
+		typ  string
+		want any
+	}{
+		{"42", sheetsTypeAuto, json.Number("42")},
+		{"-1.5e3", sheetsTypeAuto, json.Number("-1.5e3")},
+		{"00123", sheetsTypeAuto, "'00123"},
+		{"TRUE", sheetsTypeAuto, true},
+		{"2026-01-05", sheetsTypeAuto, "2026-01-05"},
+		{"=A1*2", sheetsTypeAuto, "'=A1*2"},
+		{"1/2", sheetsTypeAuto, "'1/2"},
+		{"123456789012345", sheetsTypeAuto, json.Number("123456789012345")},
+		{"1234567890123456", sheetsTypeAuto, "'1234567890123456"},
+		{"-98765432109876543", sheetsTypeAuto, "'-98765432109876543"},
+		{json.Number("12345678901234567890"), sheetsTypeAuto, "'12345678901234567890"},
+		{json.Number("12345678901234567890"), sheetsTypeNumber, json.Number("12345678901234567890")},
+		{"", sheetsTypeAuto, ""},
+		{nil, sheetsTypeAuto, ""},
+		{json.Number("7"), sheetsTypeString, "'7"},
+		{map[string]any{"a": json.Number("1")}, sheetsTypeAuto, `'{"a":1}`},
+		{" +3.50 ", sheetsTypeNumber, 3.5},
+		{"yes", sheetsTypeBool, true},
+		{"A1+B1", sheetsTypeFormula, "=A1+B1"},
+		{"=A1*2", sheetsTypeFormula, "=A1*2"},
+		{"Jan 5 2026", sheetsTypeDate, "Jan 5 2026"},
+	}
+	for _, tc := range cases {
+		got, err := sheetsCellValue(tc.in, tc.typ)
+		if err != nil {
+			t.Fatalf("%v as %s: %v", tc.in, tc.typ, err)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("%v as %s: got %#v, want %#v", tc.in, tc.typ, got, tc.want)
+		}
+	}
+	if _, err := sheetsCellValue("abc", sheetsTypeNumber); err == nil {
+		t.Fatalf("expected number error")
+	}
+	if _, err := parseSheetsTypes([]string{"amount=money"}); err == nil || ExitCode(err) != 2 {
+		t.Fatalf("expected usage error for unknown type, got %v", err)
+	}
+}
+
+func TestSheetsKeyString_LongIntegersStayExact(t *testing.T) {
+	// A long ID stored as text reads back as a string; a JSON input number
+	// must produce the same key.
+	if got := sheetsKeyString(json.Number("12345678901234567890")); got != "12345678901234567890" {
+		t.Fatalf("unexpected key: %q", got)
+	}
+	if got := sheetsKeyString(json.Number("2.50")); got != "2.5" {
+		t.Fatalf("unexpected key: %q", got)
+	}
+}
+
+func TestParseSheetsAnchor(t *testing.T) {
+	cases := map[string][3]any{
+		"Sheet1":         {"Sheet1", 1, 1},
+		"B3":             {"", 2, 3},
+		"'My Data'!C2":   {"My Data", 3, 2},
+		"Sheet1!B2:D9":   {"Sheet1", 2, 2},
+		"Budget 2026":    {"Budget 2026", 1, 1},
+		"'Q1''s plan'":   {"Q1's plan", 1, 1},
+		"Sheet1\\!A5":    {"Sheet1", 1, 5},
+		"Sheet1!A10:B12": {"Sheet1", 1, 10},
+	}
+	for in, want := range cases {
+		sheet, col, row, err := parseSheetsAnchor(cleanRange(in))
+		if err != nil {
+			t.Fatalf("%s: %v", in, err)
+		}
+		if sheet != want[0] || col != want[1] || row != want[2] {
+			t.Fatalf("%s: got %q %d %d, want %v", in, sheet, col, row, want)
+		}
+	}
+	if _, _, _, err := parseSheetsAnchor("Sheet1!nope"); err == nil || ExitCode(err) != 2 {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
+
+// importTestServer serves a one-sheet spreadsheet (3 rows x 2 columns by
+// default) whose values reads return table, and records every write.
+type importTestServer struct {
+	mu      sync.Mutex
+	table   [][]any
+	clears  []string
+	reads   []string
+	dates   []string
+	writes  []sheets.BatchUpdateValuesRequest
+	resizes []*sheets.AppendDimensionRequest
+}
+
+func (s *importTestServer) start(t *testing.T) {
+	t.Helper()
+	orig := newSheetsService
+	t.Cleanup(func() { newSheetsService = orig })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		path := strings.TrimPrefix(r.URL.Path, "/sheets/v4")
+		path = strings.TrimPrefix(path, "/v4")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case path == "/spreadsheets/s1/values:batchUpdate" && r.Method == http.MethodPost:
+			var req sheets.BatchUpdateValuesRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode values batchUpdate: %v", err)
+			}
+			s.writes = append(s.writes, req)
+			_ = json.NewEncoder(w).Encode(map[string]any{})
+		case path == "/spreadsheets/s1:batchUpdate" && r.Method == http.MethodPost:
+			var req sheets.BatchUpdateSpreadsheetRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode batchUpdate: %v", err)
+			}
+			for _, q := range req.Requests {
+				s.resizes = append(s.resizes, q.AppendDimension)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{})
+		case strings.HasPrefix(path, "/spreadsheets/s1/values/") && strings.HasSuffix(path, ":clear"):
+			rng, _ := url.PathUnescape(strings.TrimSuffix(strings.TrimPrefix(path, "/spreadsheets/s1/values/"), ":clear"))
+			s.clears = append(s.clears, rng)
+			_ = json.NewEncoder(w).Encode(map[string]any{})
+		case strings.HasPrefix(path, "/spreadsheets/s1/values/") && r.Method == http.MethodGet:
+			rng, _ := url.PathUnescape(strings.TrimPrefix(path, "/spreadsheets/s1/values/"))
+			s.reads = append(s.reads, rng+" "+r.URL.Query().Get("valueRenderOption"))
+			s.dates = append(s.dates, r.URL.Query().Get("dateTimeRenderOption"))
+			_ = json.NewEncoder(w).Encode(map[string]any{"range": rng, "values": s.table})
+		case path == "/spreadsheets/s1" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"sheets": []map[string]any{
+					{"properties": map[string]any{"sheetId": 7, "title": "Sheet1", "gridProperties": map[string]any{"rowCount": 3, "columnCount": 2}}},
+				},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	svc, err := sheets.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newSheetsService = func(context.Context, string) (*sheets.Service, error) { return svc, nil }
+}
+
+func writeImportFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestSheetsImport_ReplaceWritesTypedChunks(t *testing.T) {
+	s := &importTestServer{}
+	s.start(t)
+
+	csvPath := writeImportFile(t, "people.csv", "id,name,joined\n1,00123,2026-01-05\n2,=1+1,TRUE\n3,hello,\n4,x,y\n")
+	flags := &RootFlags{Account: "a@b.com"}
+	if err := runKong(t, &SheetsImportCmd{}, []string{"s1", "Sheet1", csvPath, "--chunk-rows", "2"}, newDocsCmdContext(t), flags); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+
+	if strings.Join(s.clears, "|") != "Sheet1!A1:B" {
+		t.Fatalf("unexpected clears: %v", s.clears)
+	}
+	if len(s.writes) != 2 {
+		t.Fatalf("expected 2 chunked writes, got %d", len(s.writes))
+	}
+	first := s.writes[0]
+	if first.ValueInputOption != "USER_ENTERED" || len(first.Data) != 2 {
+		t.Fatalf("unexpected first write: %#v", first)
+	}
+	if first.Data[0].Range != "Sheet1!A2" || !reflect.DeepEqual(first.Data[0].Values, [][]any{
+		{float64(1), "'00123", "2026-01-05"},
+		{float64(2), "'=1+1", true},
+	}) {
+		t.Fatalf("unexpected first rows: %s %#v", first.Data[0].Range, first.Data[0].Values)
+	}
+	if first.Data[1].Range != "Sheet1!A1" || !reflect.DeepEqual(first.Data[1].Values, [][]any{{"'id", "'name", "'joined"}}) {
+		t.Fatalf("unexpected header write: %#v", first.Data[1])
+	}
+	second := s.writes[1]
+	if len(second.Data) != 1 || second.Data[0].Range != "Sheet1!A4" || !reflect.DeepEqual(second.Data[0].Values, [][]any{
+		{float64(3), "'hello", ""},
+		{float64(4), "'x", "'y"},
+	}) {
+		t.Fatalf("unexpected second write: %#v", second.Data)
+	}
+
+	if len(s.resizes) != 2 || s.resizes[0].Dimension != "COLUMNS" || s.resizes[0].Length != 1 ||
+		s.resizes[1].Dimension != "ROWS" || s.resizes[1].Length != 2 || s.resizes[1].SheetId != 7 {
+		t.Fatalf("unexpected grid growth: %#v", s.resizes)
+	}
+}
+
+func TestSheetsImport_UpsertByKey(t *testing.T) {
+	s := &importTestServer{table: [][]any{
+		{"id", "name", "amount"},
+		{1, "a", 5},
+		{2, "b", 6},
+	}}
+	s.start(t)
+
+	jsonl := writeImportFile(t, "rows.jsonl", `{"id":2,"total":7}`+"\n"+`{"id":3,"name":"c","vip":true}`+"\n")
+	flags := &RootFlags{Account: "a@b.com"}
+	if err := runKong(t, &SheetsImportCmd{}, []string{"s1", "A1", jsonl, "--mode", "upsert", "--key", "id", "--map", "total=amount"}, newDocsCmdContext(t), flags); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+
+	if strings.Join(s.reads, "|") != "Sheet1!A1:B UNFORMATTED_VALUE" || strings.Join(s.dates, "|") != "FORMATTED_STRING" {
+		t.Fatalf("unexpected reads: %v %v", s.reads, s.dates)
+	}
+	if len(s.writes) != 1 {
+		t.Fatalf("expected 1 write, got %d", len(s.writes))
+	}
+	got := map[string][][]any{}
+	for _, vr := range s.writes[0].Data {
+		got[vr.Range] = vr.Values
+	}
+	want := map[string][][]any{
+		"Sheet1!A3": {{float64(2)}},
+		"Sheet1!C3": {{float64(7)}},
+		"Sheet1!A4": {{float64(3), "'c"}},
+		"Sheet1!D4": {{true}},
+		"Sheet1!A1": {{"'id", "'name", "'amount", "'vip"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected upsert writes:\n got %#v\nwant %#v", got, want)
+	}
+
+	err := runKong(t, &SheetsImportCmd{}, []string{"s1", "A1", jsonl, "--mode", "upsert"}, newDocsCmdContext(t), flags)
+	if err == nil || ExitCode(err) != 2 {
+		t.Fatalf("expected usage error without --key, got %v", err)
+	}
+}
+
+func TestWriteSheetsValues_Formats(t *testing.T) {
+	values := [][]any{
+		{"name", "", "name"},
+		{"Ada, L.", float64(36), true},
+		{"Bob"},
+	}
+	var csvOut strings.Builder
+	if err := writeSheetsValues(&csvOut, "csv", values); err != nil {
+		t.Fatalf("csv: %v", err)
+	}
+	if csvOut.String() != "name,,name\n\"Ada, L.\",36,true\nBob\n" {
+		t.Fatalf("unexpected csv: %q", csvOut.String())
+	}
+
+	var jsonl strings.Builder
+	if err := writeSheetsValues(&jsonl, "jsonl", values); err != nil {
+		t.Fatalf("jsonl: %v", err)
+	}
+	want := `{"name":"Ada, L.","column2":36,"name_2":true}` + "\n" + `{"name":"Bob","column2":null,"name_2":null}` + "\n"
+	if jsonl.String() != want {
+		t.Fatalf("unexpected jsonl: %q", jsonl.String())
+	}
+
+	var objects strings.Builder
+	if err := writeSheetsValues(&objects, "json-objects", values[:1]); err != nil {
+		t.Fatalf("json-objects: %v", err)
+	}
+	if objects.String() != "[]\n" {
+		t.Fatalf("unexpected empty objects output: %q", objects.String())
+	}
+}
+
+func TestSheetsGet_JSONLKeepsDatesReadable(t *testing.T) {
+	s := &importTestServer{table: [][]any{{"name", "joined"}, {"Ada", "2026-01-05"}}}
+	s.start(t)
+
+	flags := &RootFlags{Account: "a@b.com"}
+	out := captureStdout(t, func() {
+		if err := runKong(t, &SheetsGetCmd{}, []string{"s1", "Sheet1!A1:B2", "--format", "jsonl"}, newDocsCmdContext(t), flags); err != nil {
+			t.Fatalf("get: %v", err)
+		}
+	})
+	if strings.Join(s.reads, "|") != "Sheet1!A1:B2 UNFORMATTED_VALUE" || strings.Join(s.dates, "|") != "FORMATTED_STRING" {
+		t.Fatalf("unexpected read options: %v %v", s.reads, s.dates)
+	}
+	if out != `{"name":"Ada","joined":"2026-01-05"}`+"\n" {
+		t.Fatalf("unexpected jsonl: %q", out)
+	}
+}
@@ -0,0 +1,455 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// sheetsRecord is one input row: its column names in input order and the
+// value of each (strings for CSV, decoded JSON values otherwise).
+type sheetsRecord struct {
+	keys   []string
+	values map[string]any
+}
+
+// sheetsRecordReader streams records from CSV/TSV with a header row, a JSON
+// array of objects, or JSON objects one after another (JSONL).
+type sheetsRecordReader interface {
+	Next() (sheetsRecord, error) // io.EOF after the last record
+}
+
+// sheetsInputFormat picks the input format from --format, the file
+// extension, or (for stdin) the first non-blank byte.
+func sheetsInputFormat(format, path string, br *bufio.Reader) string {
+	if format != "" {
+		return format
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return "csv"
+	case ".tsv", ".tab":
+		return "tsv"
+	case ".json":
+		return "json"
+	case ".jsonl", ".ndjson":
+		return "jsonl"
+	}
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return "csv"
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			_, _ = br.ReadByte()
+			continue
+		case '[', '{':
+			return "json"
+		}
+		return "csv"
+	}
+}
+
+func newSheetsRecordReader(r *bufio.Reader, format string) (sheetsRecordReader, error) {
+	switch format {
+	case "csv", "tsv":
+		cr := csv.NewReader(r)
+		if format == "tsv" {
+			cr.Comma = '\t'
+			cr.LazyQuotes = true
+		}
+		cr.FieldsPerRecord = -1
+		header, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return &sheetsCSVReader{r: cr}, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read header: %w", err)
+		}
+		if len(header) > 0 {
+			header[0] = strings.TrimPrefix(header[0], "\ufeff")
+		}
+		for i := range header {
+			header[i] = strings.TrimSpace(header[i])
+			if header[i] == "" {
+				return nil, usagef("header column %d is empty", i+1)
+			}
+		}
+		return &sheetsCSVReader{r: cr, header: header}, nil
+	case "json", "jsonl":
+		dec := json.NewDecoder(r)
+		dec.UseNumber()
+		jr := &sheetsJSONReader{dec: dec}
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			jr.done = true
+			return jr, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		switch tok {
+		case json.Delim('['):
+			jr.array = true
+		case json.Delim('{'):
+			jr.open = true
+		default:
+			return nil, usage("JSON input must be an array of objects or one object per line")
+		}
+		return jr, nil
+	}
+	return nil, usagef("unsupported input format %q", format)
+}
+
+type sheetsCSVReader struct {
+	r      *csv.Reader
+	header []string
+	line   int
+}
+
+func (c *sheetsCSVReader) Next() (sheetsRecord, error) {
+	for {
+		row, err := c.r.Read()
+		if err != nil {
+			return sheetsRecord{}, err
+		}
+		c.line++
+		if len(row) == 1 && strings.TrimSpace(row[0]) == "" {
+			continue
+		}
+		if len(row) > len(c.header) {
+			return sheetsRecord{}, usagef("row %d has %d columns, header has %d", c.line+1, len(row), len(c.header))
+		}
+		rec := sheetsRecord{keys: c.header[:len(row)], values: make(map[string]any, len(row))}
+		for i, v := range row {
+			rec.values[c.header[i]] = v
+		}
+		return rec, nil
+	}
+}
+
+type sheetsJSONReader struct {
+	dec   *json.Decoder
+	array bool
+	open  bool // the first object's '{' was already consumed
+	done  bool
+	n     int
+}
+
+func (j *sheetsJSONReader) Next() (sheetsRecord, error) {
+	if j.done {
+		return sheetsRecord{}, io.EOF
+	}
+	if j.array && !j.dec.More() {
+		j.done = true
+		return sheetsRecord{}, io.EOF
+	}
+	j.n++
+	if !j.open {
+		tok, err := j.dec.Token()
+		if errors.Is(err, io.EOF) && !j.array {
+			j.done = true
+			return sheetsRecord{}, io.EOF
+		}
+		if err != nil {
+			return sheetsRecord{}, fmt.Errorf("record %d: invalid JSON: %w", j.n, err)
+		}
+		if tok != json.Delim('{') {
+			return sheetsRecord{}, usagef("record %d: expected a JSON object", j.n)
+		}
+	}
+	j.open = false
+
+	rec := sheetsRecord{values: map[string]any{}}
+	for j.dec.More() {
+		tok, err := j.dec.Token()
+		if err != nil {
+			return sheetsRecord{}, fmt.Errorf("record %d: invalid JSON: %w", j.n, err)
+		}
+		key, _ := tok.(string)
+		var v any
+		if err := j.dec.Decode(&v); err != nil {
+			return sheetsRecord{}, fmt.Errorf("record %d: invalid JSON: %w", j.n, err)
+		}
+		if _, dup := rec.values[key]; !dup {
+			rec.keys = append(rec.keys, key)
+		}
+		rec.values[key] = v
+	}
+	if _, err := j.dec.Token(); err != nil {
+		return sheetsRecord{}, fmt.Errorf("record %d: invalid JSON: %w", j.n, err)
+	}
+	return rec, nil
+}
+
+// Column types for sheets import. Values are written USER_ENTERED, so
+// numbers and booleans are sent as JSON values, dates and formulas as
+// strings for Sheets to parse, and text with a leading apostrophe so Sheets
+// never reinterprets it ("00123", "1/2", "TRUE").
+const (
+	sheetsTypeAuto    = "auto"
+	sheetsTypeString  = "string"
+	sheetsTypeNumber  = "number"
+	sheetsTypeBool    = "bool"
+	sheetsTypeDate    = "date"
+	sheetsTypeFormula = "formula"
+)
+
+var (
+	sheetsAutoNumberRe = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+	sheetsNumberRe     = regexp.MustCompile(`^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+	sheetsDateRe       = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}([ T][0-9]{2}:[0-9]{2}(:[0-9]{2}(\.[0-9]+)?)?)?$`)
+)
+
+// parseSheetsTypes parses --types entries of the form column=type.
+func parseSheetsTypes(entries []string) (map[string]string, error) {
+	types := map[string]string{}
+	for _, e := range entries {
+		col, typ, ok := strings.Cut(e, "=")
+		col, typ = strings.TrimSpace(col), strings.ToLower(strings.TrimSpace(typ))
+		if !ok || col == "" {
+			return nil, usagef("invalid --types entry %q (want column=type)", e)
+		}
+		switch typ {
+		case sheetsTypeAuto, sheetsTypeString, sheetsTypeNumber, sheetsTypeBool, sheetsTypeDate, sheetsTypeFormula:
+		default:
+			return nil, usagef("unknown type %q for %s (use auto|string|number|bool|date|formula)", typ, col)
+		}
+		types[col] = typ
+	}
+	return types, nil
+}
+
+// sheetsCellValue converts an input value to what is sent for typ. Auto mode
+// stores a leading "=" as text; formulas need an explicit formula column type.
+func sheetsCellValue(v any, typ string) (any, error) {
+	switch t := v.(type) {
+	case nil:
+		return "", nil
+	case json.Number:
+		if typ == sheetsTypeNumber || (typ == sheetsTypeAuto && !sheetsLongInteger(t.String())) {
+			return t, nil
+		}
+		v = t.String()
+	case bool:
+		if typ == sheetsTypeAuto || typ == sheetsTypeBool {
+			return t, nil
+		}
+		v = strconv.FormatBool(t)
+	case string:
+	default:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return nil, err
+		}
+		v = string(b)
+	}
+
+	s := v.(string)
+	if s == "" {
+		return "", nil
+	}
+	switch typ {
+	case sheetsTypeString:
+		return sheetsTextValue(s), nil
+	case sheetsTypeNumber:
+		trimmed := strings.TrimSpace(s)
+		if !sheetsNumberRe.MatchString(trimmed) {
+			return nil, fmt.Errorf("%q is not a number", s)
+		}
+		f, err := strconv.ParseFloat(trimmed, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", s)
+		}
+		return f, nil
+	case sheetsTypeBool:
+		switch strings.ToLower(strings.TrimSpace(s)) {
+		case "true", "yes", "y", "1":
+			return true, nil
+		case "false", "no", "n", "0":
+			return false, nil
+		}
+		return nil, fmt.Errorf("%q is not a boolean", s)
+	case sheetsTypeDate:
+		return strings.TrimSpace(s), nil
+	case sheetsTypeFormula:
+		if !strings.HasPrefix(s, "=") {
+			s = "=" + s
+		}
+		return s, nil
+	}
+
+	// auto
+	switch {
+	case strings.EqualFold(s, "true"):
+		return true, nil
+	case strings.EqualFold(s, "false"):
+		return false, nil
+	case sheetsAutoNumberRe.MatchString(s) && !sheetsLongInteger(s):
+		return json.Number(s), nil
+	case sheetsDateRe.MatchString(s):
+		return s, nil
+	}
+	return sheetsTextValue(s), nil
+}
+
+// sheetsLongInteger reports whether s is an integer with more digits than a
+// Sheets number (a double) holds exactly, such as an order or account ID.
+// Auto mode keeps these as text so they do not silently lose precision.
+func sheetsLongInteger(s string) bool {
+	digits := strings.TrimPrefix(s, "-")
+	if digits == "" {
+		return false
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return len(strings.TrimLeft(digits, "0")) > 15
+}
+
+func sheetsTextValue(s string) string {
+	return "'" + s
+}
+
+// sheetsKeyString renders a cell or input value for matching --key values.
+func sheetsKeyString(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(t)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case json.Number:
+		if sheetsLongInteger(t.String()) {
+			return t.String()
+		}
+		if f, err := t.Float64(); err == nil {
+			return strconv.FormatFloat(f, 'f', -1, 64)
+		}
+		return t.String()
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// sheetsHeaderKeys names the columns of a header row for header-keyed
+// output: blank headers become columnN, repeats get a _N suffix.
+func sheetsHeaderKeys(header []any, width int) []string {
+	keys := make([]string, width)
+	seen := map[string]int{}
+	for i := range keys {
+		name := ""
+		if i < len(header) {
+			name = strings.TrimSpace(fmt.Sprintf("%v", header[i]))
+		}
+		if name == "" {
+			name = fmt.Sprintf("column%d", i+1)
+		}
+		seen[name]++
+		if n := seen[name]; n > 1 {
+			name = fmt.Sprintf("%s_%d", name, n)
+		}
+		keys[i] = name
+	}
+	return keys
+}
+
+// writeSheetsRowObject writes row as a JSON object keyed by keys, in column
+// order; missing trailing cells are null.
+func writeSheetsRowObject(w io.Writer, keys []string, row []any) error {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		var v any
+		if i < len(row) {
+			v = row[i]
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+	}
+	buf.WriteByte('}')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// writeSheetsValues writes rows for sheets get --format: csv as-is, or jsonl
+// and json-objects with the first row as the keys of every following row.
+func writeSheetsValues(w io.Writer, format string, values [][]any) error {
+	bw := bufio.NewWriter(w)
+	if format == "csv" {
+		cw := csv.NewWriter(bw)
+		for _, row := range values {
+			cells := make([]string, len(row))
+			for i, cell := range row {
+				cells[i] = fmt.Sprintf("%v", cell)
+			}
+			if err := cw.Write(cells); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+		return bw.Flush()
+	}
+
+	var keys []string
+	var rows [][]any
+	if len(values) > 0 {
+		width := 0
+		for _, row := range values {
+			width = max(width, len(row))
+		}
+		keys = sheetsHeaderKeys(values[0], width)
+		rows = values[1:]
+	}
+	if format == "json-objects" {
+		_, _ = bw.WriteString("[")
+	}
+	for i, row := range rows {
+		if format == "json-objects" {
+			sep := ",\n  "
+			if i == 0 {
+				sep = "\n  "
+			}
+			_, _ = bw.WriteString(sep)
+		}
+		if err := writeSheetsRowObject(bw, keys, row); err != nil {
+			return err
+		}
+		if format == "jsonl" {
+			_, _ = bw.WriteString("\n")
+		}
+	}
+	if format == "json-objects" {
+		if len(rows) > 0 {
+			_, _ = bw.WriteString("\n")
+		}
+		_, _ = bw.WriteString("]\n")
+	}
+	return bw.Flush()
+}
@@ -24,6 +24,8 @@ type SlidesCmd struct {
 	Info               SlidesInfoCmd               `cmd:"" name:"info" aliases:"get,show" help:"Get Google Slides presentation metadata"`
 	Create             SlidesCreateCmd             `cmd:"" name:"create" aliases:"add,new" help:"Create a Google Slides presentation"`
 	CreateFromMarkdown SlidesCreateFromMarkdownCmd `cmd:"" name:"create-from-markdown" help:"Create a Google Slides presentation from markdown"`
+	Outline            SlidesOutlineCmd            `cmd:"" name:"outline" help:"Dump all slides' titles, text, tables, and speaker notes in order"`
+	Render             SlidesRenderCmd             `cmd:"" name:"render" help:"Generate decks from a template: fill {{placeholders}}, swap images, repeat and drop tagged slides"`
 	Copy               SlidesCopyCmd               `cmd:"" name:"copy" aliases:"cp,duplicate" help:"Copy a Google Slides presentation"`
 	AddSlide           SlidesAddSlideCmd           `cmd:"" name:"add-slide" help:"Add a slide with a full-bleed image and optional speaker notes"`
 	ListSlides         SlidesListSlidesCmd         `cmd:"" name:"list-slides" help:"List all slides with their object IDs"`
@@ -36,10 +38,17 @@ type SlidesCmd struct {
 type SlidesExportCmd struct {
 	PresentationID string         `arg:"" name:"presentationId" help:"Presentation ID"`
 	Output         OutputPathFlag `embed:""`
-	Format         string         `name:"format" help:"Export format: pdf|pptx" default:"pptx"`
+	Format         string         `name:"format" help:"Export format: pdf|pptx|png (png writes one image per slide into the --out directory)" default:"pptx"`
+	Size           string         `name:"size" help:"PNG size: small|medium|large (default large)" enum:",small,medium,large" default:""`
 }
 
 func (c *SlidesExportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	if strings.EqualFold(strings.TrimSpace(c.Format), "png") {
+		return exportSlidesThumbnails(ctx, flags, c.PresentationID, c.Output.Path, c.Size)
+	}
+	if c.Size != "" {
+		return usage("--size requires --format png")
+	}
 	return exportViaDrive(ctx, flags, exportViaDriveOptions{
 		ArgName:       "presentationId",
 		ExpectedMime:  "application/vnd.google-apps.presentation",
@@ -146,6 +155,7 @@ type SlidesCreateFromMarkdownCmd struct {
 	Content     string `name:"content" help:"Markdown content (inline)"`
 	ContentFile string `name:"content-file" help:"Read markdown content from file"`
 	Parent      string `name:"parent" help:"Destination folder ID"`
+	Template    string `name:"template" help:"Presentation ID whose masters and layouts to use (the deck is copied and its slides replaced)"`
 	Debug       bool   `name:"debug" help:"Show debug output"`
 }
 
@@ -161,8 +171,10 @@ func (c *SlidesCreateFromMarkdownCmd) Run(ctx context.Context, flags *RootFlags)
 		return usage("empty title")
 	}
 
-	// Get markdown content
+	// Get markdown content; local images are relative to the content file,
+	// or to the working directory for inline content
 	var markdown string
+	imageBase := "content.md"
 	switch {
 	case c.ContentFile != "":
 		var data []byte
@@ -171,6 +183,7 @@ func (c *SlidesCreateFromMarkdownCmd) Run(ctx context.Context, flags *RootFlags)
 			return fmt.Errorf("failed to read content file: %w", err)
 		}
 		markdown = string(data)
+		imageBase = c.ContentFile
 	case c.Content != "":
 		markdown = c.Content
 	default:
@@ -181,28 +194,34 @@ func (c *SlidesCreateFromMarkdownCmd) Run(ctx context.Context, flags *RootFlags)
 		debugSlides = true
 	}
 
-	// Create Slides service
+	// Create Slides and Drive services
 	slidesSvc, err := newSlidesService(ctx, account)
 	if err != nil {
 		return err
 	}
+	driveSvc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	template := normalizeGoogleID(strings.TrimSpace(c.Template))
+	parent := strings.TrimSpace(c.Parent)
 
 	// Create presentation from markdown
-	presentation, err := CreatePresentationFromMarkdown(title, markdown, slidesSvc)
+	presentation, err := CreatePresentationFromMarkdown(ctx, slidesSvc, driveSvc, markdown, slidesMarkdownOptions{
+		Title:      title,
+		Parent:     parent,
+		TemplateID: template,
+		ImageBase:  imageBase,
+	})
 	if err != nil {
 		return err
 	}
 
-	// Move to parent folder if specified
-	if c.Parent != "" {
-		var parentDriveSvc *drive.Service
-		parentDriveSvc, err = newDriveService(ctx, account)
-		if err != nil {
-			return err
-		}
-
-		_, err = parentDriveSvc.Files.Update(presentation.PresentationId, &drive.File{}).
-			AddParents(c.Parent).
+	// Move to parent folder if specified (template copies are created there)
+	if parent != "" && template == "" {
+		_, err = driveSvc.Files.Update(presentation.PresentationId, &drive.File{}).
+			AddParents(parent).
 			SupportsAllDrives(true).
 			Context(ctx).
 			Do()
@@ -212,12 +231,6 @@ func (c *SlidesCreateFromMarkdownCmd) Run(ctx context.Context, flags *RootFlags)
 	}
 
 	// Get presentation link
-	var driveSvc *drive.Service
-	driveSvc, err = newDriveService(ctx, account)
-	if err != nil {
-		return err
-	}
-
 	file, err := driveSvc.Files.Get(presentation.PresentationId).
 		Fields("id, name, webViewLink").
 		SupportsAllDrives(true).
@@ -234,7 +247,7 @@ func (c *SlidesCreateFromMarkdownCmd) Run(ctx context.Context, flags *RootFlags)
 		})
 	}
 
-	u.Out().Printf("Created presentation with %d slides", len(ParseMarkdownToSlides(markdown)))
+	u.Out().Printf("Created presentation with %d slides", len(presentation.Slides))
 	u.Out().Printf("id\t%s", presentation.PresentationId)
 	u.Out().Printf("name\t%s", file.Name)
 	if file.WebViewLink != "" {
@@ -1,147 +1,524 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strings"
 
+	"google.golang.org/api/drive/v3"
 	"google.golang.org/api/slides/v1"
 )
 
-// SlidesToAPIRequests converts slide structures to Google Slides API batch update requests
-func SlidesToAPIRequests(slideData []Slide) ([]*slides.Request, map[int]string) {
-	var requests []*slides.Request
-	slideIDs := make(map[int]string)
+const (
+	slidesPlaceholderTitle         = "TITLE"
+	slidesPlaceholderCenteredTitle = "CENTERED_TITLE"
+	slidesPlaceholderSubtitle      = "SUBTITLE"
 
-	for i, slide := range slideData {
-		slideID := fmt.Sprintf("slide_%d", i+1)
-		slideIDs[i] = slideID
+	emuPerPT = 12700
+)
 
-		// Create blank slide
-		requests = append(requests, &slides.Request{
-			CreateSlide: &slides.CreateSlideRequest{
-				ObjectId: slideID,
-				SlideLayoutReference: &slides.LayoutReference{
-					PredefinedLayout: "BLANK",
-				},
+// slidesBox is a rectangle on a slide, in points.
+type slidesBox struct {
+	x, y, w, h float64
+}
+
+func (b slidesBox) properties(pageID string) *slides.PageElementProperties {
+	return &slides.PageElementProperties{
+		PageObjectId: pageID,
+		Size: &slides.Size{
+			Width:  &slides.Dimension{Magnitude: b.w, Unit: "PT"},
+			Height: &slides.Dimension{Magnitude: b.h, Unit: "PT"},
+		},
+		Transform: &slides.AffineTransform{
+			ScaleX:     1,
+			ScaleY:     1,
+			TranslateX: b.x,
+			TranslateY: b.y,
+			Unit:       "PT",
+		},
+	}
+}
+
+// slidesPlaceholder is a layout placeholder content can be mapped to.
+type slidesPlaceholder struct {
+	layoutObjectID string
+	box            slidesBox
+}
+
+// slidesLayout is a layout of the target deck with the placeholders used for
+// the title and the body (one per column).
+type slidesLayout struct {
+	objectID string
+	title    *slidesPlaceholder
+	bodies   []slidesPlaceholder
+}
+
+// slidesLayouts indexes a deck's layouts by name. Layouts of the default
+// theme are named after their predefined layout (TITLE_AND_BODY, ...), and
+// so are those of most themes built from it.
+func slidesLayouts(pres *slides.Presentation) map[string]*slidesLayout {
+	layouts := make(map[string]*slidesLayout)
+	if pres == nil {
+		return layouts
+	}
+	for _, page := range pres.Layouts {
+		if page == nil || page.LayoutProperties == nil || page.LayoutProperties.Name == "" {
+			continue
+		}
+		name := page.LayoutProperties.Name
+		if _, ok := layouts[name]; ok {
+			continue
+		}
+		layout := &slidesLayout{objectID: page.ObjectId}
+		type body struct {
+			index int64
+			ph    slidesPlaceholder
+		}
+		var bodies, subtitles []body
+		for _, el := range page.PageElements {
+			if el.Shape == nil || el.Shape.Placeholder == nil {
+				continue
+			}
+			ph := slidesPlaceholder{layoutObjectID: el.ObjectId, box: slidesElementBox(el)}
+			switch el.Shape.Placeholder.Type {
+			case slidesPlaceholderTitle, slidesPlaceholderCenteredTitle:
+				if layout.title == nil {
+					layout.title = &ph
+				}
+			case placeholderTypeBody:
+				bodies = append(bodies, body{el.Shape.Placeholder.Index, ph})
+			case slidesPlaceholderSubtitle:
+				subtitles = append(subtitles, body{el.Shape.Placeholder.Index, ph})
+			}
+		}
+		if len(bodies) == 0 {
+			bodies = subtitles
+		}
+		sort.SliceStable(bodies, func(i, j int) bool { return bodies[i].index < bodies[j].index })
+		for _, b := range bodies {
+			layout.bodies = append(layout.bodies, b.ph)
+		}
+		layouts[name] = layout
+	}
+	return layouts
+}
+
+// slidesElementBox returns the bounds of a page element in points.
+func slidesElementBox(el *slides.PageElement) slidesBox {
+	var box slidesBox
+	scaleX, scaleY := 1.0, 1.0
+	if t := el.Transform; t != nil {
+		if t.ScaleX != 0 {
+			scaleX = t.ScaleX
+		}
+		if t.ScaleY != 0 {
+			scaleY = t.ScaleY
+		}
+		box.x = slidesPT(t.TranslateX, t.Unit)
+		box.y = slidesPT(t.TranslateY, t.Unit)
+	}
+	if s := el.Size; s != nil {
+		if s.Width != nil {
+			box.w = slidesPT(s.Width.Magnitude, s.Width.Unit) * scaleX
+		}
+		if s.Height != nil {
+			box.h = slidesPT(s.Height.Magnitude, s.Height.Unit) * scaleY
+		}
+	}
+	return box
+}
+
+func slidesPT(v float64, unit string) float64 {
+	if unit == "PT" {
+		return v
+	}
+	return v / emuPerPT
+}
+
+// resolveSlidesLayout picks the deck layout for want, falling back to
+// TITLE_AND_BODY; nil means the deck has neither and the slide is built on
+// a blank page with text boxes.
+func resolveSlidesLayout(layouts map[string]*slidesLayout, want SlideLayout) *slidesLayout {
+	if l, ok := layouts[string(want)]; ok {
+		return l
+	}
+	if l, ok := layouts[string(LayoutTitleAndBody)]; ok && want != LayoutBlank {
+		return l
+	}
+	return nil
+}
+
+// slidesText accumulates the text of one text box with its inline styles
+// and list ranges (UTF-16 offsets).
+type slidesText struct {
+	text    strings.Builder
+	length  int64
+	styles  []TextStyle
+	bullets []slidesBulletRange
+}
+
+type slidesBulletRange struct {
+	start, end int64
+	preset     string
+}
+
+func (t *slidesText) paragraph(text string, styles []TextStyle) {
+	for _, st := range styles {
+		st.Start += t.length
+		st.End += t.length
+		t.styles = append(t.styles, st)
+	}
+	t.text.WriteString(text)
+	t.text.WriteString("\n")
+	t.length += utf16Len(text) + 1
+}
+
+func (t *slidesText) add(elem SlideElement) {
+	switch elem.Type {
+	case "body":
+		t.paragraph(elem.Content, elem.Styles)
+	case "bullets", "numbered":
+		preset := "BULLET_DISC_CIRCLE_SQUARE"
+		if elem.Type == "numbered" {
+			preset = "NUMBERED_DIGIT_ALPHA_ROMAN"
+		}
+		start := t.length
+		for i, item := range elem.Items {
+			var styles []TextStyle
+			if i < len(elem.ItemStyles) {
+				styles = elem.ItemStyles[i]
+			}
+			t.paragraph(item, styles)
+		}
+		t.bullets = append(t.bullets, slidesBulletRange{start: start, end: t.length, preset: preset})
+	case "code":
+		start := t.length
+		for _, line := range strings.Split(elem.Content, "\n") {
+			t.paragraph(line, nil)
+		}
+		t.styles = append(t.styles, TextStyle{Code: true, Start: start, End: t.length - 1})
+	}
+}
+
+// requests inserts the text into objectID (dropping the final newline),
+// clears any bullets the placeholder inherits, and applies lists and
+// inline styles.
+func (t *slidesText) requests(objectID string, cell *slides.TableCellLocation) []*slides.Request {
+	text := strings.TrimSuffix(t.text.String(), "\n")
+	if text == "" {
+		return nil
+	}
+	reqs := []*slides.Request{{
+		InsertText: &slides.InsertTextRequest{ObjectId: objectID, CellLocation: cell, Text: text},
+	}}
+	if cell == nil {
+		reqs = append(reqs, &slides.Request{
+			DeleteParagraphBullets: &slides.DeleteParagraphBulletsRequest{
+				ObjectId:  objectID,
+				TextRange: &slides.Range{Type: "ALL"},
 			},
 		})
-
-		// Add title box
-		titleID := fmt.Sprintf("title_%d", i+1)
-		requests = append(requests, &slides.Request{
-			CreateShape: &slides.CreateShapeRequest{
-				ObjectId:  titleID,
-				ShapeType: "TEXT_BOX",
-				ElementProperties: &slides.PageElementProperties{
-					PageObjectId: slideID,
-					Transform: &slides.AffineTransform{
-						ScaleX:     1,
-						ScaleY:     1,
-						TranslateX: 72 * 0.5, // 0.5 inches from left
-						TranslateY: 72 * 0.5, // 0.5 inches from top
-						Unit:       "PT",
-					},
-					Size: &slides.Size{
-						Width:  &slides.Dimension{Magnitude: 612 - 72, Unit: "PT"},
-						Height: &slides.Dimension{Magnitude: 100, Unit: "PT"},
-					},
-				},
+	}
+	end := utf16Len(text)
+	for _, b := range t.bullets {
+		reqs = append(reqs, &slides.Request{
+			CreateParagraphBullets: &slides.CreateParagraphBulletsRequest{
+				ObjectId:     objectID,
+				CellLocation: cell,
+				TextRange:    slidesFixedRange(b.start, min(b.end, end)),
+				BulletPreset: b.preset,
 			},
 		})
+	}
+	for _, st := range t.styles {
+		if r := slidesTextStyleRequest(objectID, cell, st, end); r != nil {
+			reqs = append(reqs, r)
+		}
+	}
+	return reqs
+}
+
+func slidesFixedRange(start, end int64) *slides.Range {
+	return &slides.Range{
+		Type:       "FIXED_RANGE",
+		StartIndex: &start,
+		EndIndex:   &end,
+	}
+}
+
+// slidesTextStyleRequest mirrors buildTextStyleRequest for the Slides API.
+func slidesTextStyleRequest(objectID string, cell *slides.TableCellLocation, style TextStyle, limit int64) *slides.Request {
+	end := min(style.End, limit)
+	if style.Start < 0 || end <= style.Start {
+		return nil
+	}
+	textStyle := &slides.TextStyle{}
+	var fields []string
+	if style.Bold {
+		textStyle.Bold = true
+		fields = append(fields, "bold")
+	}
+	if style.Italic {
+		textStyle.Italic = true
+		fields = append(fields, "italic")
+	}
+	if style.Strikethrough {
+		textStyle.Strikethrough = true
+		fields = append(fields, "strikethrough")
+	}
+	if style.Superscript {
+		textStyle.BaselineOffset = "SUPERSCRIPT"
+		fields = append(fields, "baselineOffset")
+	}
+	if style.Code {
+		textStyle.FontFamily = "Courier New"
+		fields = append(fields, "fontFamily")
+	}
+	if style.Link != "" {
+		textStyle.Link = &slides.Link{Url: style.Link}
+		fields = append(fields, "link")
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return &slides.Request{
+		UpdateTextStyle: &slides.UpdateTextStyleRequest{
+			ObjectId:     objectID,
+			CellLocation: cell,
+			TextRange:    slidesFixedRange(style.Start, end),
+			Style:        textStyle,
+			Fields:       strings.Join(fields, ","),
+		},
+	}
+}
+
+// Text box geometry used when the deck has no matching layout (points, for
+// the default 720x405 page).
+var (
+	slidesDefaultTitleBox = slidesBox{x: 36, y: 36, w: 648, h: 60}
+	slidesDefaultBodyBox  = slidesBox{x: 36, y: 108, w: 648, h: 261}
+)
+
+// SlidesToAPIRequests converts slide structures to Google Slides API batch
+// update requests. Slides use the deck's layouts (see slidesLayouts) with
+// the title and body placeholders mapped to known object IDs; images and
+// tables take the bounds of their body placeholder or column. imageURLs maps
+// local image references to uploaded URLs; remote URLs are used as-is.
+func SlidesToAPIRequests(slideData []Slide, layouts map[string]*slidesLayout, imageURLs map[string]string) ([]*slides.Request, map[int]string) {
+	var requests []*slides.Request
+	slideIDs := make(map[int]string)
+
+	for i, slide := range slideData {
+		n := i + 1
+		slideID := fmt.Sprintf("slide_%d", n)
+		slideIDs[i] = slideID
+		titleID := fmt.Sprintf("title_%d", n)
+		layout := resolveSlidesLayout(layouts, slide.Layout)
 
-		// Add title text
+		// Split content into body regions: one, or two for column slides
+		// (unless the layout only has a single body placeholder)
+		regions := 1
 		for _, elem := range slide.Elements {
-			if elem.Type == "title" {
-				requests = append(requests, &slides.Request{
-					InsertText: &slides.InsertTextRequest{
-						ObjectId:       titleID,
-						Text:           elem.Content,
-						InsertionIndex: 0,
-					},
-				})
+			if elem.Column > 0 {
+				regions = 2
+			}
+		}
+		if layout != nil && len(layout.bodies) > 0 {
+			regions = min(regions, len(layout.bodies))
+		}
+		bodyIDs := make([]string, regions)
+		boxes := make([]slidesBox, regions)
+		mapped := make([]bool, regions)
+		for r := range bodyIDs {
+			bodyIDs[r] = fmt.Sprintf("body_%d", n)
+			if r > 0 {
+				bodyIDs[r] = fmt.Sprintf("body_%d_%d", n, r+1)
+			}
+			boxes[r] = slidesDefaultBodyBox
+			if regions > 1 {
+				boxes[r].w = (slidesDefaultBodyBox.w - 18) / 2
+				boxes[r].x += float64(r) * (boxes[r].w + 18)
+			}
+		}
 
-				// Make title bold
-				requests = append(requests, &slides.Request{
-					UpdateTextStyle: &slides.UpdateTextStyleRequest{
-						ObjectId: titleID,
-						TextRange: &slides.Range{
-							Type: "ALL",
-						},
-						Style: &slides.TextStyle{
-							Bold: true,
-							FontSize: &slides.Dimension{
-								Magnitude: 36,
-								Unit:      "PT",
-							},
-						},
-						Fields: "bold,fontSize",
-					},
+		create := &slides.CreateSlideRequest{ObjectId: slideID}
+		titleMapped := false
+		if layout == nil {
+			create.SlideLayoutReference = &slides.LayoutReference{PredefinedLayout: string(LayoutBlank)}
+		} else {
+			create.SlideLayoutReference = &slides.LayoutReference{LayoutId: layout.objectID}
+			if layout.title != nil {
+				titleMapped = true
+				create.PlaceholderIdMappings = append(create.PlaceholderIdMappings, &slides.LayoutPlaceholderIdMapping{
+					LayoutPlaceholderObjectId: layout.title.layoutObjectID,
+					ObjectId:                  titleID,
+				})
+			}
+			for r, ph := range layout.bodies {
+				id := fmt.Sprintf("body_%d_%d", n, r+1)
+				if r < regions {
+					id = bodyIDs[r]
+					boxes[r] = ph.box
+					mapped[r] = true
+				}
+				create.PlaceholderIdMappings = append(create.PlaceholderIdMappings, &slides.LayoutPlaceholderIdMapping{
+					LayoutPlaceholderObjectId: ph.layoutObjectID,
+					ObjectId:                  id,
 				})
 			}
 		}
+		requests = append(requests, &slides.Request{CreateSlide: create})
 
-		// Add body box
-		bodyID := fmt.Sprintf("body_%d", i+1)
-		requests = append(requests, &slides.Request{
-			CreateShape: &slides.CreateShapeRequest{
-				ObjectId:  bodyID,
-				ShapeType: "TEXT_BOX",
-				ElementProperties: &slides.PageElementProperties{
-					PageObjectId: slideID,
-					Transform: &slides.AffineTransform{
-						ScaleX:     1,
-						ScaleY:     1,
-						TranslateX: 72 * 0.5,
-						TranslateY: 72 * 1.5, // Below title
-						Unit:       "PT",
-					},
-					Size: &slides.Size{
-						Width:  &slides.Dimension{Magnitude: 612 - 72, Unit: "PT"},
-						Height: &slides.Dimension{Magnitude: 300, Unit: "PT"},
+		// Title
+		var titleStyles []TextStyle
+		for _, elem := range slide.Elements {
+			if elem.Type == "title" {
+				titleStyles = elem.Styles
+			}
+		}
+		if !titleMapped {
+			requests = append(requests, slidesTextBoxRequest(titleID, slideID, slidesDefaultTitleBox))
+		}
+		title := &slidesText{}
+		title.paragraph(slide.Title, titleStyles)
+		requests = append(requests, title.requests(titleID, nil)...)
+		if !titleMapped {
+			requests = append(requests, &slides.Request{
+				UpdateTextStyle: &slides.UpdateTextStyleRequest{
+					ObjectId:  titleID,
+					TextRange: &slides.Range{Type: "ALL"},
+					Style: &slides.TextStyle{
+						Bold:     true,
+						FontSize: &slides.Dimension{Magnitude: 36, Unit: "PT"},
 					},
+					Fields: "bold,fontSize",
 				},
-			},
-		})
+			})
+		}
 
-		// Build body content
-		var bodyContent strings.Builder
-		for _, elem := range slide.Elements {
-			if elem.Type != "title" {
-				switch elem.Type {
-				case "body":
-					bodyContent.WriteString(elem.Content)
-					bodyContent.WriteString("\n")
-				case "bullets":
-					for _, item := range elem.Items {
-						bodyContent.WriteString("• ")
-						bodyContent.WriteString(item)
-						bodyContent.WriteString("\n")
-					}
-				case "code":
-					bodyContent.WriteString("```\n")
-					bodyContent.WriteString(elem.Content)
-					bodyContent.WriteString("\n```\n")
+		// Body regions; a region whose content is only visuals drops its
+		// placeholder, and layout placeholders beyond the used regions are
+		// removed too.
+		for r := 0; r < regions; r++ {
+			text := &slidesText{}
+			var visuals []SlideElement
+			for _, elem := range slide.Elements {
+				if elem.Type == "title" || min(elem.Column, regions-1) != r {
+					continue
+				}
+				if elem.Type == "image" || elem.Type == "table" {
+					visuals = append(visuals, elem)
+					continue
 				}
+				text.add(elem)
+			}
+
+			box := boxes[r]
+			if text.length > 0 {
+				if !mapped[r] {
+					requests = append(requests, slidesTextBoxRequest(bodyIDs[r], slideID, box))
+				}
+				requests = append(requests, text.requests(bodyIDs[r], nil)...)
+				if len(visuals) > 0 {
+					// Visuals go below the text
+					box.y += box.h / 2
+					box.h /= 2
+				}
+			} else if mapped[r] {
+				requests = append(requests, &slides.Request{DeleteObject: &slides.DeleteObjectRequest{ObjectId: bodyIDs[r]}})
+			}
+			requests = append(requests, slidesVisualRequests(slideID, fmt.Sprintf("%d_%d", n, r+1), box, visuals, imageURLs)...)
+		}
+		if layout != nil {
+			for r := regions; r < len(layout.bodies); r++ {
+				requests = append(requests, &slides.Request{
+					DeleteObject: &slides.DeleteObjectRequest{ObjectId: fmt.Sprintf("body_%d_%d", n, r+1)},
+				})
 			}
 		}
+	}
 
-		// Add body text if there's content
-		if bodyContent.Len() > 0 {
-			requests = append(requests, &slides.Request{
-				InsertText: &slides.InsertTextRequest{
-					ObjectId:       bodyID,
-					Text:           bodyContent.String(),
-					InsertionIndex: 0,
+	return requests, slideIDs
+}
+
+func slidesTextBoxRequest(objectID, slideID string, box slidesBox) *slides.Request {
+	return &slides.Request{
+		CreateShape: &slides.CreateShapeRequest{
+			ObjectId:          objectID,
+			ShapeType:         "TEXT_BOX",
+			ElementProperties: box.properties(slideID),
+		},
+	}
+}
+
+// slidesVisualRequests stacks images and tables vertically inside box.
+func slidesVisualRequests(slideID, suffix string, box slidesBox, visuals []SlideElement, imageURLs map[string]string) []*slides.Request {
+	var reqs []*slides.Request
+	if len(visuals) == 0 {
+		return nil
+	}
+	h := box.h / float64(len(visuals))
+	for k, v := range visuals {
+		b := box
+		b.y += float64(k) * h
+		b.h = h
+		switch v.Type {
+		case "image":
+			url := v.Content
+			if u, ok := imageURLs[v.Content]; ok {
+				url = u
+			}
+			reqs = append(reqs, &slides.Request{
+				CreateImage: &slides.CreateImageRequest{
+					ObjectId:          fmt.Sprintf("image_%s_%d", suffix, k+1),
+					Url:               url,
+					ElementProperties: b.properties(slideID),
+				},
+			})
+		case "table":
+			tableID := fmt.Sprintf("table_%s_%d", suffix, k+1)
+			reqs = append(reqs, &slides.Request{
+				CreateTable: &slides.CreateTableRequest{
+					ObjectId:          tableID,
+					Rows:              int64(len(v.Rows)),
+					Columns:           int64(len(v.Rows[0])),
+					ElementProperties: b.properties(slideID),
 				},
 			})
+			for row, cells := range v.Rows {
+				for col, cell := range cells {
+					styles, plain := ParseInlineFormatting(cell)
+					if row == 0 {
+						styles = append([]TextStyle{{Bold: true, Start: 0, End: utf16Len(plain)}}, styles...)
+					}
+					text := &slidesText{}
+					text.paragraph(plain, styles)
+					reqs = append(reqs, text.requests(tableID, &slides.TableCellLocation{
+						RowIndex:        int64(row),
+						ColumnIndex:     int64(col),
+						ForceSendFields: []string{"RowIndex", "ColumnIndex"},
+					})...)
+				}
+			}
 		}
 	}
+	return reqs
+}
 
-	return requests, slideIDs
+// slidesMarkdownOptions configures CreatePresentationFromMarkdown.
+type slidesMarkdownOptions struct {
+	Title      string
+	Parent     string // destination folder (used when copying a template)
+	TemplateID string // copy this deck and build on its masters and layouts
+	ImageBase  string // markdown file that local image paths are relative to
 }
 
-// CreatePresentationFromMarkdown creates a Google Slides presentation from markdown
-func CreatePresentationFromMarkdown(title string, markdown string, service *slides.Service) (*slides.Presentation, error) {
+// CreatePresentationFromMarkdown creates a Google Slides presentation from
+// markdown: a new deck, or a copy of opts.TemplateID with its slides
+// removed. Local images are uploaded to Drive for the duration of the call,
+// and speaker notes are written once the slides exist.
+func CreatePresentationFromMarkdown(ctx context.Context, service *slides.Service, driveSvc *drive.Service, markdown string, opts slidesMarkdownOptions) (*slides.Presentation, error) {
 	// Parse markdown to slides
 	slidesData := ParseMarkdownToSlides(markdown)
 
@@ -149,34 +526,139 @@ func CreatePresentationFromMarkdown(title string, markdown string, service *slid
 		return nil, fmt.Errorf("no slides found in markdown")
 	}
 
+	// Resolve local images before creating anything
+	localImages := make(map[string]string)
+	for _, slide := range slidesData {
+		for _, elem := range slide.Elements {
+			if elem.Type != "image" || (markdownImage{originalRef: elem.Content}).isRemote() {
+				continue
+			}
+			if _, ok := localImages[elem.Content]; ok {
+				continue
+			}
+			realPath, err := resolveMarkdownImagePath(opts.ImageBase, elem.Content)
+			if err != nil {
+				return nil, err
+			}
+			localImages[elem.Content] = realPath
+		}
+	}
+
 	// Create presentation
-	presentation, err := service.Presentations.Create(&slides.Presentation{
-		Title: title,
-	}).Do()
+	var presentationID string
+	if opts.TemplateID != "" {
+		created, err := copyDriveFile(ctx, driveSvc, copyViaDriveOptions{
+			ExpectedMime: "application/vnd.google-apps.presentation",
+			KindLabel:    "Google Slides presentation",
+		}, opts.TemplateID, opts.Title, opts.Parent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy template: %w", err)
+		}
+		presentationID = created.Id
+	} else {
+		created, err := service.Presentations.Create(&slides.Presentation{
+			Title: opts.Title,
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create presentation: %w", err)
+		}
+		presentationID = created.PresentationId
+	}
+
+	presentation, err := service.Presentations.Get(presentationID).Context(ctx).Do()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create presentation: %w", err)
+		return nil, fmt.Errorf("get presentation: %w", err)
 	}
 
-	// Convert to API requests
-	requests, slideIDs := SlidesToAPIRequests(slidesData)
+	// Upload local images temporarily
+	imageURLs := make(map[string]string)
+	var tempFileIDs []string
+	defer func() { cleanupDriveFileIDsBestEffort(ctx, driveSvc, tempFileIDs) }()
+	for _, ref := range sortedKeys(localImages) {
+		url, fileID, uploadErr := uploadLocalImage(ctx, driveSvc, localImages[ref])
+		if uploadErr != nil {
+			return nil, uploadErr
+		}
+		tempFileIDs = append(tempFileIDs, fileID)
+		imageURLs[ref] = url
+	}
+
+	// Replace the existing slides (the new deck's empty title slide, or the
+	// template's content) with the generated ones
+	var requests []*slides.Request
+	for _, s := range presentation.Slides {
+		requests = append(requests, &slides.Request{DeleteObject: &slides.DeleteObjectRequest{ObjectId: s.ObjectId}})
+	}
+	slideRequests, slideIDs := SlidesToAPIRequests(slidesData, slidesLayouts(presentation), imageURLs)
+	requests = append(requests, slideRequests...)
 
-	// Execute batch update
-	if len(requests) > 0 {
-		_, err = service.Presentations.BatchUpdate(presentation.PresentationId, &slides.BatchUpdatePresentationRequest{
-			Requests: requests,
-		}).Do()
+	_, err = service.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+		Requests: requests,
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to populate slides: %w", err)
+	}
+
+	// Speaker notes live on each slide's notes page, which only exists now
+	hasNotes := false
+	for _, slide := range slidesData {
+		hasNotes = hasNotes || slide.Notes != ""
+	}
+	presentation, err = service.Presentations.Get(presentationID).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("read back presentation: %w", err)
+	}
+	if hasNotes {
+		notesIDs := make(map[string]string)
+		for _, s := range presentation.Slides {
+			notesIDs[s.ObjectId] = slideSpeakerNotesID(s)
+		}
+		var notesRequests []*slides.Request
+		for i, slide := range slidesData {
+			if slide.Notes == "" {
+				continue
+			}
+			notesID := notesIDs[slideIDs[i]]
+			if notesID == "" {
+				return nil, fmt.Errorf("could not find speaker notes placeholder on slide %s", slideIDs[i])
+			}
+			notesRequests = append(notesRequests, &slides.Request{
+				InsertText: &slides.InsertTextRequest{ObjectId: notesID, Text: slide.Notes},
+			})
+		}
+		_, err = service.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+			Requests: notesRequests,
+		}).Context(ctx).Do()
 		if err != nil {
-			return nil, fmt.Errorf("failed to populate slides: %w", err)
+			return nil, fmt.Errorf("insert speaker notes: %w", err)
 		}
 	}
 
 	// Debug output
 	if debugSlides {
 		fmt.Printf("[DEBUG] Created presentation with %d slides\n", len(slidesData))
-		for i, slideID := range slideIDs {
-			fmt.Printf("  Slide %d: %s - %s\n", i+1, slideID, slidesData[i].Title)
+		for i := range slidesData {
+			fmt.Printf("  Slide %d: %s - %s (%s)\n", i+1, slideIDs[i], slidesData[i].Title, slidesData[i].Layout)
 		}
 	}
 
 	return presentation, nil
 }
+
+// slideSpeakerNotesID returns the object ID of a slide's speaker notes
+// shape, falling back to the notes page's BODY placeholder.
+func slideSpeakerNotesID(s *slides.Page) string {
+	if s == nil || s.SlideProperties == nil || s.SlideProperties.NotesPage == nil {
+		return ""
+	}
+	np := s.SlideProperties.NotesPage
+	if np.NotesProperties != nil && np.NotesProperties.SpeakerNotesObjectId != "" {
+		return np.NotesProperties.SpeakerNotesObjectId
+	}
+	for _, el := range np.PageElements {
+		if el.Shape != nil && el.Shape.Placeholder != nil && el.Shape.Placeholder.Type == placeholderTypeBody {
+			return el.ObjectId
+		}
+	}
+	return ""
+}
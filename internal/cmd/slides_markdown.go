@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"regexp"
 	"strings"
 )
 
@@ -17,11 +18,17 @@ const (
 
 // SlideElement represents an element on a slide
 type SlideElement struct {
-	Type     string // "title", "body", "bullets", "code"
+	Type     string // "title", "body", "bullets", "numbered", "code", "image", "table"
 	Content  string
 	Items    []string // for bullet lists
 	IsBold   bool
 	IsItalic bool
+
+	Styles     []TextStyle   // inline formatting of Content (UTF-16 offsets)
+	ItemStyles [][]TextStyle // inline formatting of each item
+	Alt        string        // images: alt text (Content holds the path or URL)
+	Rows       [][]string    // tables: rows of cell markdown, header first
+	Column     int           // 0 = body or left column, 1 = right column
 }
 
 // Slide represents a single slide
@@ -29,8 +36,19 @@ type Slide struct {
 	Title    string
 	Layout   SlideLayout
 	Elements []SlideElement
+	Notes    string // speaker notes
 }
 
+// slidesColumnBreak on its own line moves the rest of a slide's body into
+// the right column.
+const slidesColumnBreak = "|||"
+
+// slidesNotesDelimiter on its own line, like "Note:" alone, moves the rest
+// of a slide into its speaker notes.
+const slidesNotesDelimiter = "???"
+
+var slidesNumberedItemRe = regexp.MustCompile(`^\d{1,9}[.)] `)
+
 // ParseMarkdownToSlides parses markdown into slide structures
 func ParseMarkdownToSlides(markdown string) []Slide {
 	var slides []Slide
@@ -39,9 +57,13 @@ func ParseMarkdownToSlides(markdown string) []Slide {
 	lines := strings.Split(markdown, "\n")
 	var currentSlide strings.Builder
 	inSlide := false
+	inCode := false
 
 	for _, line := range lines {
-		if strings.TrimSpace(line) == "---" {
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+		}
+		if !inCode && strings.TrimSpace(line) == "---" {
 			if currentSlide.Len() > 0 {
 				slide := parseSlide(currentSlide.String())
 				if slide.Title != "" {
@@ -82,8 +104,21 @@ func parseSlide(text string) Slide {
 	var currentElement *SlideElement
 	var inCodeBlock bool
 	var codeContent strings.Builder
+	var notes []string
+	inNotes := false
+	section := false
+	column := 0
+	list := -1 // index of the list element consecutive items are added to
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		// Everything after the notes marker is speaker notes
+		if inNotes {
+			notes = append(notes, line)
+			continue
+		}
 
-	for _, line := range lines {
 		// Handle code blocks
 		if strings.HasPrefix(line, "```") {
 			if inCodeBlock {
@@ -98,8 +133,10 @@ func parseSlide(text string) Slide {
 			} else {
 				// Start code block
 				inCodeBlock = true
+				list = -1
 				currentElement = &SlideElement{
-					Type: "code",
+					Type:   "code",
+					Column: column,
 				}
 			}
 			continue
@@ -113,78 +150,124 @@ func parseSlide(text string) Slide {
 			continue
 		}
 
+		trimmed := strings.TrimSpace(line)
+
 		// Skip empty lines
-		if strings.TrimSpace(line) == "" {
+		if trimmed == "" {
 			continue
 		}
 
-		// Title (## heading for slides)
-		if strings.HasPrefix(line, "## ") {
-			title := strings.TrimPrefix(line, "## ")
-			// Remove formatting markers
-			title = stripInlineFormatting(title)
+		if slidesNotesLine(trimmed) {
+			inNotes = true
+			continue
+		}
+
+		if trimmed == slidesColumnBreak {
+			column = 1
+			list = -1
+			continue
+		}
+
+		// Title (## heading for slides, # for section headers)
+		if strings.HasPrefix(line, "## ") || strings.HasPrefix(line, "# ") {
+			section = strings.HasPrefix(line, "# ")
+			styles, title := ParseInlineFormatting(strings.TrimSpace(strings.TrimLeft(line, "#")))
 			slide.Title = title
 			slide.Elements = append(slide.Elements, SlideElement{
 				Type:    "title",
 				Content: title,
+				Styles:  styles,
 			})
+			list = -1
 			continue
 		}
 
-		// Bullet points
-		if strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") {
-			item := strings.TrimPrefix(strings.TrimPrefix(line, "- "), "* ")
-			item = stripInlineFormatting(item)
+		// Image on its own line
+		if m := mdImageRe.FindStringSubmatch(trimmed); m != nil && m[0] == trimmed {
+			ref := m[2]
+			if ref == "" {
+				ref = m[3]
+			}
+			slide.Elements = append(slide.Elements, SlideElement{
+				Type:    "image",
+				Content: ref,
+				Alt:     m[1],
+				Column:  column,
+			})
+			list = -1
+			continue
+		}
 
-			// Find or create bullets element
-			var bulletsElement *SlideElement
-			for i := range slide.Elements {
-				if slide.Elements[i].Type == "bullets" {
-					bulletsElement = &slide.Elements[i]
-					break
+		// Tables
+		if isTableStart(lines, i) {
+			rows := [][]string{splitTableRow(line)}
+			i += 2
+			for ; i < len(lines) && strings.Contains(lines[i], "|") && strings.TrimSpace(lines[i]) != slidesColumnBreak; i++ {
+				row := splitTableRow(lines[i])
+				for len(row) < len(rows[0]) {
+					row = append(row, "")
 				}
+				rows = append(rows, row[:len(rows[0])])
 			}
+			i--
+			slide.Elements = append(slide.Elements, SlideElement{
+				Type:   "table",
+				Rows:   rows,
+				Column: column,
+			})
+			list = -1
+			continue
+		}
 
-			if bulletsElement == nil {
+		// Bullet points and numbered items
+		kind, item := "", ""
+		switch {
+		case strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") || strings.HasPrefix(line, "+ "):
+			kind, item = "bullets", line[2:]
+		case slidesNumberedItemRe.MatchString(line):
+			kind, item = "numbered", line[slidesNumberedItemRe.FindStringIndex(line)[1]:]
+		}
+		if kind != "" {
+			styles, plain := ParseInlineFormatting(strings.TrimSpace(item))
+			if list < 0 || slide.Elements[list].Type != kind {
 				slide.Elements = append(slide.Elements, SlideElement{
-					Type:  "bullets",
-					Items: []string{item},
+					Type:   kind,
+					Column: column,
 				})
-			} else {
-				bulletsElement.Items = append(bulletsElement.Items, item)
+				list = len(slide.Elements) - 1
 			}
+			slide.Elements[list].Items = append(slide.Elements[list].Items, plain)
+			slide.Elements[list].ItemStyles = append(slide.Elements[list].ItemStyles, styles)
 			continue
 		}
 
 		// Regular paragraph
-		content := stripInlineFormatting(line)
+		styles, content := ParseInlineFormatting(trimmed)
 		slide.Elements = append(slide.Elements, SlideElement{
 			Type:    "body",
 			Content: content,
+			Styles:  styles,
+			Column:  column,
 		})
+		list = -1
 	}
 
+	slide.Notes = strings.TrimSpace(strings.Join(notes, "\n"))
+
 	// Determine layout based on content
 	slide.Layout = determineLayout(slide)
+	if section && slide.Layout == LayoutTitleOnly {
+		slide.Layout = LayoutSectionHeader
+	}
 
 	return slide
 }
 
-// stripInlineFormatting removes markdown formatting from text
-func stripInlineFormatting(text string) string {
-	// Remove bold/italic markers
-	text = strings.ReplaceAll(text, "**", "")
-	text = strings.ReplaceAll(text, "__", "")
-	text = strings.ReplaceAll(text, "*", "")
-	text = strings.ReplaceAll(text, "_", "")
-
-	// Remove code markers
-	text = strings.ReplaceAll(text, "`", "")
-
-	// Remove links but keep text [text](url) -> text
-	// Simple approach: just remove brackets and parens for now
-
-	return text
+// slidesNotesLine reports whether line starts the speaker notes: "Note:"
+// or "Notes:" on a line of its own, or "???". A sentence that merely begins
+// with "Note:" stays on the slide.
+func slidesNotesLine(line string) bool {
+	return line == slidesNotesDelimiter || strings.EqualFold(line, "Note:") || strings.EqualFold(line, "Notes:")
 }
 
 // determineLayout chooses the best layout for a slide
@@ -193,18 +276,22 @@ func determineLayout(slide Slide) SlideLayout {
 	hasBullets := false
 	hasBody := false
 	hasCode := false
+	hasColumns := false
 
 	for _, elem := range slide.Elements {
 		switch elem.Type {
 		case "title":
 			hasTitle = true
-		case "bullets":
+		case "bullets", "numbered":
 			hasBullets = true
-		case "body":
+		case "body", "image", "table":
 			hasBody = true
 		case "code":
 			hasCode = true
 		}
+		if elem.Column > 0 {
+			hasColumns = true
+		}
 	}
 
 	// No title = blank layout
@@ -212,6 +299,11 @@ func determineLayout(slide Slide) SlideLayout {
 		return LayoutBlank
 	}
 
+	// A column break = two columns
+	if hasColumns {
+		return LayoutTitleAndTwoColumns
+	}
+
 	// Code slides often need more space
 	if hasCode {
 		return LayoutTitleAndBody
@@ -0,0 +1,305 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+	"google.golang.org/api/slides/v1"
+)
+
+const slidesTestMarkdown = "## **Plan** overview\n" +
+	"- ship *fast*\n" +
+	"- test\n" +
+	"![chart](chart.png)\n" +
+	"\n" +
+	"Note:\n" +
+	"Mention the deadline.\n" +
+	"Keep it short.\n" +
+	"---\n" +
+	"## Compare\n" +
+	"Left side\n" +
+	"|||\n" +
+	"| A | B |\n" +
+	"|---|---|\n" +
+	"| `x` | 2 |\n" +
+	"---\n" +
+	"# Part two\n"
+
+func TestParseMarkdownToSlides_NotesImagesColumnsTables(t *testing.T) {
+	got := ParseMarkdownToSlides(slidesTestMarkdown)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 slides, got %d: %+v", len(got), got)
+	}
+
+	first := got[0]
+	if first.Title != "Plan overview" || first.Layout != LayoutTitleAndBody {
+		t.Fatalf("unexpected first slide: %+v", first)
+	}
+	if first.Notes != "Mention the deadline.\nKeep it short." {
+		t.Fatalf("notes = %q", first.Notes)
+	}
+	title := first.Elements[0]
+	if len(title.Styles) != 1 || !title.Styles[0].Bold || title.Styles[0].End != 4 {
+		t.Fatalf("unexpected title styles: %+v", title.Styles)
+	}
+	bullets := first.Elements[1]
+	if bullets.Type != "bullets" || strings.Join(bullets.Items, "|") != "ship fast|test" {
+		t.Fatalf("unexpected bullets: %+v", bullets)
+	}
+	if st := bullets.ItemStyles[0]; len(st) != 1 || !st[0].Italic || st[0].Start != 5 || st[0].End != 9 {
+		t.Fatalf("unexpected item styles: %+v", st)
+	}
+	if img := first.Elements[2]; img.Type != "image" || img.Content != "chart.png" || img.Alt != "chart" {
+		t.Fatalf("unexpected image: %+v", img)
+	}
+
+	second := got[1]
+	if second.Layout != LayoutTitleAndTwoColumns {
+		t.Fatalf("layout = %s", second.Layout)
+	}
+	if body := second.Elements[1]; body.Type != "body" || body.Column != 0 {
+		t.Fatalf("unexpected left column: %+v", body)
+	}
+	table := second.Elements[2]
+	if table.Type != "table" || table.Column != 1 || len(table.Rows) != 2 || table.Rows[1][0] != "`x`" {
+		t.Fatalf("unexpected table: %+v", table)
+	}
+
+	if got[2].Layout != LayoutSectionHeader {
+		t.Fatalf("expected section header, got %s", got[2].Layout)
+	}
+}
+
+// slidesTestLayout returns a layout page with placeholders of the given
+// types (BODY placeholders are numbered in order).
+func slidesTestLayout(id, name string, types ...string) map[string]any {
+	var elements []any
+	bodies := 0
+	for _, typ := range types {
+		ph := map[string]any{"type": typ}
+		if typ == placeholderTypeBody {
+			ph["index"] = bodies
+			bodies++
+		}
+		elements = append(elements, map[string]any{
+			"objectId":  id + "_" + strings.ToLower(typ) + "_" + string(rune('0'+bodies)),
+			"size":      map[string]any{"width": map[string]any{"magnitude": 3048000, "unit": "EMU"}, "height": map[string]any{"magnitude": 1270000, "unit": "EMU"}},
+			"transform": map[string]any{"scaleX": 2, "scaleY": 1, "translateX": 127000, "translateY": 254000, "unit": "EMU"},
+			"shape":     map[string]any{"placeholder": ph},
+		})
+	}
+	return map[string]any{"objectId": id, "layoutProperties": map[string]any{"name": name}, "pageElements": elements}
+}
+
+func TestParseMarkdownToSlides_NotesMarkerOnItsOwnLine(t *testing.T) {
+	got := ParseMarkdownToSlides("## Limits\n" +
+		"Note: the API is rate limited.\n" +
+		"NOTES: keep going\n" +
+		"???\n" +
+		"Say it slowly.\n" +
+		"---\n" +
+		"## Next\n" +
+		"Body\n" +
+		"notes:\n" +
+		"Wrap up.\n")
+	if len(got) != 2 {
+		t.Fatalf("expected 2 slides, got %+v", got)
+	}
+	var body []string
+	for _, el := range got[0].Elements[1:] {
+		body = append(body, el.Content)
+	}
+	if strings.Join(body, "|") != "Note: the API is rate limited.|NOTES: keep going" || got[0].Notes != "Say it slowly." {
+		t.Fatalf("unexpected first slide: body %q, notes %q", body, got[0].Notes)
+	}
+	if got[1].Notes != "Wrap up." {
+		t.Fatalf("notes = %q", got[1].Notes)
+	}
+}
+
+func TestSlidesCreateFromMarkdown_TemplateLayoutsImagesAndNotes(t *testing.T) {
+	origSlides, origDrive := newSlidesService, newDriveService
+	t.Cleanup(func() {
+		newSlidesService = origSlides
+		newDriveService = origDrive
+	})
+
+	var (
+		mu       sync.Mutex
+		batches  [][]*slides.Request
+		copied   string
+		deleted  bool
+		gotPages int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, "/drive/v3")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/presentations/deck1":
+			gotPages++
+			pres := map[string]any{
+				"presentationId": "deck1",
+				"layouts": []any{
+					slidesTestLayout("lay_tb", "TITLE_AND_BODY", "TITLE", placeholderTypeBody),
+					slidesTestLayout("lay_2c", "TITLE_AND_TWO_COLUMNS", "TITLE", placeholderTypeBody, placeholderTypeBody),
+				},
+				"slides": []any{map[string]any{"objectId": "old1"}},
+			}
+			if len(batches) > 0 {
+				var created []any
+				for _, id := range []string{"slide_1", "slide_2", "slide_3"} {
+					created = append(created, map[string]any{
+						"objectId": id,
+						"slideProperties": map[string]any{"notesPage": map[string]any{
+							"notesProperties": map[string]any{"speakerNotesObjectId": "notes_" + id},
+						}},
+					})
+				}
+				pres["slides"] = created
+			}
+			_ = json.NewEncoder(w).Encode(pres)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/presentations/deck1:batchUpdate":
+			var req slides.BatchUpdatePresentationRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode batchUpdate: %v", err)
+			}
+			batches = append(batches, req.Requests)
+			_ = json.NewEncoder(w).Encode(map[string]any{"presentationId": "deck1"})
+		case r.Method == http.MethodGet && path == "/files/tmpl":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "tmpl", "mimeType": "application/vnd.google-apps.presentation"})
+		case r.Method == http.MethodPost && path == "/files/tmpl/copy":
+			var f drive.File
+			_ = json.NewDecoder(r.Body).Decode(&f)
+			copied = f.Name + "@" + strings.Join(f.Parents, ",")
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "deck1", "name": f.Name})
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/upload/drive/v3/files"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "img1", "webContentLink": "https://example.com/img1"})
+		case r.Method == http.MethodPost && path == "/files/img1/permissions":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "perm1"})
+		case r.Method == http.MethodDelete && path == "/files/img1":
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && path == "/files/deck1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "deck1", "name": "Deck"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	slidesSvc, err := slides.NewService(context.Background(), option.WithoutAuthentication(), option.WithHTTPClient(srv.Client()), option.WithEndpoint(srv.URL+"/"))
+	if err != nil {
+		t.Fatalf("slides.NewService: %v", err)
+	}
+	driveSvc, err := drive.NewService(context.Background(), option.WithoutAuthentication(), option.WithHTTPClient(srv.Client()), option.WithEndpoint(srv.URL+"/"))
+	if err != nil {
+		t.Fatalf("drive.NewService: %v", err)
+	}
+	newSlidesService = func(context.Context, string) (*slides.Service, error) { return slidesSvc, nil }
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return driveSvc, nil }
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "chart.png"), []byte("fake-image-data"), 0o600); err != nil {
+		t.Fatalf("write image: %v", err)
+	}
+	mdPath := filepath.Join(dir, "deck.md")
+	if err := os.WriteFile(mdPath, []byte(slidesTestMarkdown), 0o600); err != nil {
+		t.Fatalf("write markdown: %v", err)
+	}
+
+	flags := &RootFlags{Account: "a@b.com"}
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &SlidesCreateFromMarkdownCmd{}, []string{"Deck", "--content-file", mdPath, "--template", "tmpl", "--parent", "folder1"}, newDocsCmdContext(t), flags); err != nil {
+			t.Fatalf("create-from-markdown: %v", err)
+		}
+	})
+
+	if copied != "Deck@folder1" {
+		t.Fatalf("unexpected template copy: %q", copied)
+	}
+	if !deleted {
+		t.Fatalf("expected uploaded image cleanup")
+	}
+	if len(batches) != 2 || gotPages != 2 {
+		t.Fatalf("expected 2 batches and 2 reads, got %d and %d", len(batches), gotPages)
+	}
+
+	reqs := batches[0]
+	if reqs[0].DeleteObject == nil || reqs[0].DeleteObject.ObjectId != "old1" {
+		t.Fatalf("expected template slide removal first, got %#v", reqs[0])
+	}
+	var creates []*slides.CreateSlideRequest
+	var image *slides.CreateImageRequest
+	var table *slides.CreateTableRequest
+	var bullets []*slides.CreateParagraphBulletsRequest
+	var bold, italic, code int
+	for _, r := range reqs {
+		switch {
+		case r.CreateSlide != nil:
+			creates = append(creates, r.CreateSlide)
+		case r.CreateImage != nil:
+			image = r.CreateImage
+		case r.CreateTable != nil:
+			table = r.CreateTable
+		case r.CreateParagraphBullets != nil:
+			bullets = append(bullets, r.CreateParagraphBullets)
+		case r.UpdateTextStyle != nil:
+			st := r.UpdateTextStyle.Style
+			switch {
+			case st.Bold:
+				bold++
+			case st.Italic:
+				italic++
+			case st.FontFamily == "Courier New":
+				code++
+			}
+		}
+	}
+	if len(creates) != 3 {
+		t.Fatalf("expected 3 slides, got %d", len(creates))
+	}
+	if creates[0].SlideLayoutReference.LayoutId != "lay_tb" || creates[1].SlideLayoutReference.LayoutId != "lay_2c" {
+		t.Fatalf("unexpected layouts: %#v %#v", creates[0].SlideLayoutReference, creates[1].SlideLayoutReference)
+	}
+	// The section header falls back to TITLE_AND_BODY in this template.
+	if creates[2].SlideLayoutReference.LayoutId != "lay_tb" {
+		t.Fatalf("unexpected fallback layout: %#v", creates[2].SlideLayoutReference)
+	}
+	if m := creates[1].PlaceholderIdMappings; len(m) != 3 || m[0].ObjectId != "title_2" || m[1].ObjectId != "body_2" || m[2].ObjectId != "body_2_2" {
+		t.Fatalf("unexpected placeholder mappings: %#v", m)
+	}
+	if image == nil || image.Url != "https://example.com/img1" || image.ElementProperties.PageObjectId != "slide_1" {
+		t.Fatalf("unexpected image: %#v", image)
+	}
+	// The image shares the body with the bullets, so it takes the lower half
+	// of the placeholder (10pt, 20pt, 480x100pt).
+	if tr := image.ElementProperties.Transform; tr.TranslateX != 10 || tr.TranslateY != 70 || image.ElementProperties.Size.Height.Magnitude != 50 {
+		t.Fatalf("unexpected image bounds: %#v %#v", tr, image.ElementProperties.Size)
+	}
+	if table == nil || table.Rows != 2 || table.Columns != 2 || table.ElementProperties.Size.Width.Magnitude != 480 {
+		t.Fatalf("unexpected table: %#v", table)
+	}
+	if len(bullets) != 1 || *bullets[0].TextRange.StartIndex != 0 || *bullets[0].TextRange.EndIndex != 14 {
+		t.Fatalf("unexpected bullets: %#v", bullets)
+	}
+	// Title "Plan", table header cells A and B; "fast"; `x`.
+	if bold != 3 || italic != 1 || code != 1 {
+		t.Fatalf("unexpected style counts: bold=%d italic=%d code=%d", bold, italic, code)
+	}
+
+	notes := batches[1]
+	if len(notes) != 1 || notes[0].InsertText.ObjectId != "notes_slide_1" || notes[0].InsertText.Text != "Mention the deadline.\nKeep it short." {
+		t.Fatalf("unexpected notes requests: %#v", notes)
+	}
+}
@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"google.golang.org/api/slides/v1"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+)
+
+type SlidesOutlineCmd struct {
+	PresentationID string `arg:"" name:"presentationId" help:"Presentation ID"`
+	Format         string `name:"format" help:"Output format: markdown|text" default:"markdown" enum:"markdown,md,text"`
+}
+
+// slidesOutlineSlide is one slide of an outline; Text and Tables are the
+// slide content in reading order (top to bottom, then left to right).
+type slidesOutlineSlide struct {
+	Number   int          `json:"number"`
+	ObjectID string       `json:"objectId"`
+	Title    string       `json:"title,omitempty"`
+	Text     []string     `json:"text,omitempty"`
+	Tables   [][][]string `json:"tables,omitempty"`
+	Notes    string       `json:"notes,omitempty"`
+
+	blocks []slidesOutlineBlock
+}
+
+// slidesOutlineBlock is a text box (paragraphs), a table, or an image.
+type slidesOutlineBlock struct {
+	paragraphs []slidesOutlineParagraph
+	rows       [][]string
+	image      string // source URL, or "" for an image without one
+	isImage    bool
+}
+
+type slidesOutlineParagraph struct {
+	text   string
+	bullet string // "", "-" or "1."
+	level  int64
+}
+
+func (c *SlidesOutlineCmd) Run(ctx context.Context, flags *RootFlags) error {
+	presentationID := normalizeGoogleID(strings.TrimSpace(c.PresentationID))
+	if presentationID == "" {
+		return usage("empty presentationId")
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newSlidesService(ctx, account)
+	if err != nil {
+		return err
+	}
+	pres, err := svc.Presentations.Get(presentationID).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("get presentation: %w", err)
+	}
+
+	outline := slidesOutline(pres)
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"presentationId": pres.PresentationId,
+			"title":          pres.Title,
+			"slides":         outline,
+		})
+	}
+	if c.Format == "text" {
+		_, err = os.Stdout.WriteString(slidesOutlineText(outline))
+		return err
+	}
+	_, err = os.Stdout.WriteString(slidesOutlineMarkdown(outline))
+	return err
+}
+
+// slidesOutline extracts every slide's title, text, tables, images, and
+// speaker notes.
+func slidesOutline(pres *slides.Presentation) []slidesOutlineSlide {
+	out := make([]slidesOutlineSlide, 0, len(pres.Slides))
+	for i, page := range pres.Slides {
+		s := slidesOutlineSlide{Number: i + 1, ObjectID: page.ObjectId}
+		slidesOutlineElements(&s, page.PageElements)
+		for _, b := range s.blocks {
+			for _, p := range b.paragraphs {
+				s.Text = append(s.Text, p.text)
+			}
+			if b.rows != nil {
+				s.Tables = append(s.Tables, b.rows)
+			}
+		}
+		if notesID := slideSpeakerNotesID(page); notesID != "" {
+			for _, el := range page.SlideProperties.NotesPage.PageElements {
+				if el.ObjectId == notesID && el.Shape != nil {
+					s.Notes = strings.TrimSpace(slidesPlainText(el.Shape.Text))
+				}
+			}
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func slidesOutlineElements(s *slidesOutlineSlide, elements []*slides.PageElement) {
+	sorted := append([]*slides.PageElement(nil), elements...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := slidesElementBox(sorted[i]), slidesElementBox(sorted[j])
+		if a.y != b.y {
+			return a.y < b.y
+		}
+		return a.x < b.x
+	})
+	for _, el := range sorted {
+		switch {
+		case el.Shape != nil && el.Shape.Text != nil:
+			paras := slidesOutlineParagraphs(el.Shape.Text)
+			if len(paras) == 0 {
+				continue
+			}
+			if ph := el.Shape.Placeholder; ph != nil && s.Title == "" &&
+				(ph.Type == slidesPlaceholderTitle || ph.Type == slidesPlaceholderCenteredTitle) {
+				var parts []string
+				for _, p := range paras {
+					parts = append(parts, p.text)
+				}
+				s.Title = strings.Join(parts, " ")
+				continue
+			}
+			s.blocks = append(s.blocks, slidesOutlineBlock{paragraphs: paras})
+		case el.Table != nil:
+			var rows [][]string
+			for _, row := range el.Table.TableRows {
+				var cells []string
+				for _, cell := range row.TableCells {
+					var parts []string
+					for _, p := range slidesOutlineParagraphs(cell.Text) {
+						parts = append(parts, p.text)
+					}
+					cells = append(cells, strings.Join(parts, " "))
+				}
+				rows = append(rows, cells)
+			}
+			if len(rows) > 0 {
+				s.blocks = append(s.blocks, slidesOutlineBlock{rows: rows})
+			}
+		case el.Image != nil:
+			s.blocks = append(s.blocks, slidesOutlineBlock{isImage: true, image: el.Image.SourceUrl})
+		case el.ElementGroup != nil:
+			slidesOutlineElements(s, el.ElementGroup.Children)
+		}
+	}
+}
+
+// slidesOutlineParagraphs splits text into non-empty paragraphs with their
+// list markers.
+func slidesOutlineParagraphs(text *slides.TextContent) []slidesOutlineParagraph {
+	if text == nil {
+		return nil
+	}
+	var out []slidesOutlineParagraph
+	var cur *slidesOutlineParagraph
+	flush := func() {
+		if cur != nil {
+			cur.text = strings.TrimSpace(strings.ReplaceAll(cur.text, "\v", " "))
+			if cur.text != "" {
+				out = append(out, *cur)
+			}
+		}
+		cur = nil
+	}
+	for _, te := range text.TextElements {
+		switch {
+		case te.ParagraphMarker != nil:
+			flush()
+			cur = &slidesOutlineParagraph{}
+			if b := te.ParagraphMarker.Bullet; b != nil {
+				cur.bullet = "-"
+				cur.level = b.NestingLevel
+				if glyph := strings.TrimSpace(b.Glyph); glyph != "" && unicode.IsDigit(rune(glyph[0])) {
+					cur.bullet = "1."
+				}
+			}
+		case te.TextRun != nil || te.AutoText != nil:
+			if cur == nil {
+				cur = &slidesOutlineParagraph{}
+			}
+			if te.TextRun != nil {
+				cur.text += te.TextRun.Content
+			} else {
+				cur.text += te.AutoText.Content
+			}
+		}
+	}
+	flush()
+	return out
+}
+
+// slidesOutlineMarkdown renders the outline in the syntax create-from-markdown
+// reads: slides separated by ---, ## titles, lists, tables, and Note: blocks.
+func slidesOutlineMarkdown(outline []slidesOutlineSlide) string {
+	var sb strings.Builder
+	for i, s := range outline {
+		if i > 0 {
+			sb.WriteString("\n---\n\n")
+		}
+		title := s.Title
+		if title == "" {
+			title = fmt.Sprintf("Slide %d", s.Number)
+		}
+		sb.WriteString("## " + title + "\n")
+		for _, b := range s.blocks {
+			sb.WriteString("\n")
+			switch {
+			case b.isImage:
+				sb.WriteString("![image](" + b.image + ")\n")
+			case b.rows != nil:
+				for r, row := range b.rows {
+					cells := make([]string, len(row))
+					for k, cell := range row {
+						cells[k] = strings.ReplaceAll(cell, "|", `\|`)
+					}
+					sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+					if r == 0 {
+						sb.WriteString("|" + strings.Repeat(" --- |", len(row)) + "\n")
+					}
+				}
+			default:
+				for _, p := range b.paragraphs {
+					if p.bullet != "" {
+						sb.WriteString(strings.Repeat("  ", int(p.level)) + p.bullet + " " + p.text + "\n")
+						continue
+					}
+					sb.WriteString(p.text + "\n")
+				}
+			}
+		}
+		if s.Notes != "" {
+			sb.WriteString("\nNote:\n" + s.Notes + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// slidesOutlineText renders the outline as plain text, one block per slide.
+func slidesOutlineText(outline []slidesOutlineSlide) string {
+	var sb strings.Builder
+	for i, s := range outline {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "Slide %d: %s\n", s.Number, s.Title)
+		for _, b := range s.blocks {
+			switch {
+			case b.isImage:
+				sb.WriteString("[image]\n")
+			case b.rows != nil:
+				for _, row := range b.rows {
+					sb.WriteString(strings.Join(row, "\t") + "\n")
+				}
+			default:
+				for _, p := range b.paragraphs {
+					sb.WriteString(strings.Repeat("  ", int(p.level)) + p.text + "\n")
+				}
+			}
+		}
+		if s.Notes != "" {
+			sb.WriteString("Notes: " + strings.ReplaceAll(s.Notes, "\n", "\n  ") + "\n")
+		}
+	}
+	return sb.String()
+}
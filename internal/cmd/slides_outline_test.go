@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/api/slides/v1"
+)
+
+func TestSlidesOutline_MarkdownInReadingOrder(t *testing.T) {
+	at := func(y float64) *slides.AffineTransform {
+		return &slides.AffineTransform{ScaleX: 1, ScaleY: 1, TranslateY: y, Unit: "PT"}
+	}
+	para := func(bullet *slides.Bullet, text string) []*slides.TextElement {
+		return []*slides.TextElement{
+			{ParagraphMarker: &slides.ParagraphMarker{Bullet: bullet}},
+			{TextRun: &slides.TextRun{Content: text + "\n"}},
+		}
+	}
+	var body []*slides.TextElement
+	body = append(body, para(&slides.Bullet{Glyph: "●"}, "Ship it")...)
+	body = append(body, para(&slides.Bullet{Glyph: "1.", NestingLevel: 1}, "Test it")...)
+	body = append(body, para(nil, "")...)
+
+	pres := &slides.Presentation{Slides: []*slides.Page{
+		{
+			ObjectId: "s1",
+			PageElements: []*slides.PageElement{
+				// Listed before the title but placed below it.
+				{ObjectId: "tbl", Transform: at(300), Table: &slides.Table{TableRows: []*slides.TableRow{
+					{TableCells: []*slides.TableCell{
+						{Text: &slides.TextContent{TextElements: para(nil, "Name")}},
+						{Text: &slides.TextContent{TextElements: para(nil, "A|B")}},
+					}},
+				}}},
+				{ObjectId: "body", Transform: at(100), Shape: &slides.Shape{Text: &slides.TextContent{TextElements: body}}},
+				{ObjectId: "title", Transform: at(10), Shape: &slides.Shape{
+					Placeholder: &slides.Placeholder{Type: "TITLE"},
+					Text:        &slides.TextContent{TextElements: para(nil, "Plan")},
+				}},
+			},
+			SlideProperties: &slides.SlideProperties{NotesPage: &slides.Page{
+				NotesProperties: &slides.NotesProperties{SpeakerNotesObjectId: "n1"},
+				PageElements: []*slides.PageElement{{ObjectId: "n1", Shape: &slides.Shape{
+					Text: &slides.TextContent{TextElements: para(nil, "Mention dates")},
+				}}},
+			}},
+		},
+		{ObjectId: "s2", PageElements: []*slides.PageElement{
+			{ObjectId: "img", Image: &slides.Image{SourceUrl: "https://example.com/a.png"}},
+		}},
+	}}
+
+	outline := slidesOutline(pres)
+	if len(outline) != 2 || outline[0].Title != "Plan" || strings.Join(outline[0].Text, "|") != "Ship it|Test it" || outline[0].Notes != "Mention dates" {
+		t.Fatalf("unexpected outline: %+v", outline)
+	}
+	want := "## Plan\n\n- Ship it\n  1. Test it\n\n| Name | A\\|B |\n| --- | --- |\n\nNote:\nMention dates\n" +
+		"\n---\n\n## Slide 2\n\n![image](https://example.com/a.png)\n"
+	if got := slidesOutlineMarkdown(outline); got != want {
+		t.Fatalf("markdown mismatch:\n%s\nwant:\n%s", got, want)
+	}
+
+	// The Markdown round-trips through create-from-markdown's parser.
+	parsed := ParseMarkdownToSlides(slidesOutlineMarkdown(outline))
+	if len(parsed) != 2 || parsed[0].Notes != "Mention dates" || parsed[1].Elements[1].Type != "image" {
+		t.Fatalf("unexpected round trip: %+v", parsed)
+	}
+
+	if got := slidesOutlineText(outline); !strings.HasPrefix(got, "Slide 1: Plan\nShip it\n  Test it\nName\tA|B\nNotes: Mention dates\n") {
+		t.Fatalf("unexpected text outline:\n%s", got)
+	}
+}
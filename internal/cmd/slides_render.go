@@ -0,0 +1,353 @@
+package cmd
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/slides/v1"
+)
+
+// SlidesRenderCmd copies a template deck once per data record and fills its
+// placeholders, using the same data format and {{key}} syntax as docs render:
+//
+//	{{key}}, {{client.name}}  text in shapes and table cells
+//	{{image:logo}}            shape replaced by an image (URL or local file)
+//
+// Slides are tagged in their speaker notes; tags are removed from the notes:
+//
+//	{{#if key}}, {{#unless key}}  slide kept only when key is truthy/falsy
+//	{{#each projects}}            slide repeated once per element of projects,
+//	                              with {{projects.name}} filled per copy
+type SlidesRenderCmd struct {
+	TemplateID    string `arg:"" name:"templateId" help:"Template presentation ID"`
+	Data          string `name:"data" required:"" help:"Values: JSON object, JSON array of objects, or CSV with a header row (one deck per record; - for stdin)"`
+	OutFolder     string `name:"out-folder" help:"Destination folder ID or path (default: next to the template)"`
+	Name          string `name:"name" help:"Name for each deck, may use {{placeholders}} (default: template title, numbered for several records)"`
+	RefreshCharts bool   `name:"refresh-charts" help:"Refresh linked Sheets charts after filling placeholders"`
+	PDF           bool   `name:"pdf" help:"Also export each generated deck as PDF"`
+	PDFDir        string `name:"pdf-dir" help:"Directory for --pdf files (default: Drive downloads dir)"`
+	Strict        bool   `name:"strict" help:"Fail before copying when a placeholder has no value"`
+}
+
+var slidesRenderTagRe = regexp.MustCompile(`\{\{\s*#(if|unless|each)\s+([^{}]+?)\s*\}\}`)
+
+func (c *SlidesRenderCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return runTemplateRender(ctx, flags, templateRenderOptions{
+		Op:          "slides.render",
+		TemplateID:  c.TemplateID,
+		TemplateArg: "templateId",
+		Data:        c.Data,
+		OutFolder:   c.OutFolder,
+		Name:        c.Name,
+		PDF:         c.PDF,
+		PDFDir:      c.PDFDir,
+		Strict:      c.Strict,
+		DryRun:      map[string]any{"refresh_charts": c.RefreshCharts},
+		Export:      exportViaDriveOptions{ExpectedMime: driveMimeGoogleSlides, KindLabel: "Google Slides presentation"},
+		ResultsKey:  "presentations",
+	}, c.openTemplate)
+}
+
+func (c *SlidesRenderCmd) openTemplate(ctx context.Context, account string, driveSvc *drive.Service, templateID, dataDir string) (*templateRender, error) {
+	slidesSvc, err := newSlidesService(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	template, err := slidesSvc.Presentations.Get(templateID).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return &templateRender{
+		title: template.Title,
+		keys:  slidesRenderPlaceholders(template),
+		render: func(ctx context.Context, rec docsRenderRecord, name, parent string) (*drive.File, error) {
+			return copyTemplateAndFill(ctx, driveSvc, copyViaDriveOptions{
+				ExpectedMime: driveMimeGoogleSlides,
+				KindLabel:    "Google Slides presentation",
+			}, templateID, name, parent, func(id string) error {
+				return renderSlidesTemplate(ctx, slidesSvc, driveSvc, id, rec, dataDir, c.RefreshCharts)
+			})
+		},
+	}, nil
+}
+
+// renderSlidesTemplate fills the copied deck presID from rec in one batch,
+// then optionally refreshes its Sheets charts.
+func renderSlidesTemplate(ctx context.Context, slidesSvc *slides.Service, driveSvc *drive.Service, presID string, rec docsRenderRecord, dataDir string, refreshCharts bool) error {
+	pres, err := slidesSvc.Presentations.Get(presID).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	var uploaded []string
+	defer func() { cleanupDriveFileIDsBestEffort(ctx, driveSvc, uploaded) }()
+	urls := map[string]string{}
+	reqs, err := slidesRenderRequests(pres, rec, func(src string) (string, error) {
+		if url, ok := urls[src]; ok {
+			return url, nil
+		}
+		url, err := docsRenderImageURL(ctx, driveSvc, src, dataDir, &uploaded)
+		if err != nil {
+			return "", err
+		}
+		urls[src] = url
+		return url, nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(reqs) > 0 {
+		if _, err = slidesSvc.Presentations.BatchUpdate(presID, &slides.BatchUpdatePresentationRequest{
+			Requests:     reqs,
+			WriteControl: &slides.WriteControl{RequiredRevisionId: pres.RevisionId},
+		}).Context(ctx).Do(); err != nil {
+			return err
+		}
+	}
+
+	if !refreshCharts {
+		return nil
+	}
+	pres, err = slidesSvc.Presentations.Get(presID).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	var refresh []*slides.Request
+	for _, id := range slidesSheetsCharts(pres) {
+		refresh = append(refresh, &slides.Request{RefreshSheetsChart: &slides.RefreshSheetsChartRequest{ObjectId: id}})
+	}
+	if len(refresh) == 0 {
+		return nil
+	}
+	_, err = slidesSvc.Presentations.BatchUpdate(presID, &slides.BatchUpdatePresentationRequest{
+		Requests: refresh,
+	}).Context(ctx).Do()
+	return err
+}
+
+// slidesRenderRequests builds the batch for one record: tagged slides are
+// deleted or duplicated per list element (with that element's values filled
+// on each copy), tags are removed from the notes, and the remaining
+// placeholders are replaced deck-wide. imageURL turns an image value into a
+// URL the Slides API can fetch.
+func slidesRenderRequests(pres *slides.Presentation, rec docsRenderRecord, imageURL func(string) (string, error)) ([]*slides.Request, error) {
+	var reqs []*slides.Request
+	for _, slide := range pres.Slides {
+		tags, untag := slidesRenderTags(slide)
+		keep := true
+		var list string
+		var items []any
+		for _, tag := range tags {
+			switch tag[0] {
+			case "if":
+				keep = keep && rec.truthy(tag[1])
+			case "unless":
+				keep = keep && !rec.truthy(tag[1])
+			case "each":
+				if list != "" {
+					return nil, usagef("slide %s: more than one {{#each}} tag", slide.ObjectId)
+				}
+				list = tag[1]
+				v, _ := rec.lookup(list)
+				var ok bool
+				if items, ok = v.([]any); !ok && v != nil {
+					return nil, usagef("slide %s: %s is not a list", slide.ObjectId, list)
+				}
+				keep = keep && len(items) > 0
+			}
+		}
+		if !keep {
+			reqs = append(reqs, &slides.Request{DeleteObject: &slides.DeleteObjectRequest{ObjectId: slide.ObjectId}})
+			continue
+		}
+		reqs = append(reqs, untag...)
+		if list == "" {
+			continue
+		}
+
+		// Each duplicate lands right after the original, so create them
+		// last to first to keep the list order.
+		ids := []string{slide.ObjectId}
+		for k := 1; k < len(items); k++ {
+			ids = append(ids, templateRenderCopyID(slide.ObjectId, k))
+		}
+		for k := len(items) - 1; k >= 1; k-- {
+			reqs = append(reqs, &slides.Request{DuplicateObject: &slides.DuplicateObjectRequest{
+				ObjectId:  slide.ObjectId,
+				ObjectIds: map[string]string{slide.ObjectId: ids[k]},
+			}})
+		}
+		var spellings [][2]string
+		for _, s := range slidesRenderSpellings([]*slides.Page{slide}) {
+			if strings.HasPrefix(strings.TrimPrefix(s[1], docsRenderImagePrefix), list+".") {
+				spellings = append(spellings, s)
+			}
+		}
+		for k, id := range ids {
+			itemReqs, err := slidesRenderReplacements(spellings, docsRenderItem(rec, list, items[k]), []string{id}, imageURL)
+			if err != nil {
+				return nil, err
+			}
+			reqs = append(reqs, itemReqs...)
+		}
+	}
+
+	deck, err := slidesRenderReplacements(slidesRenderSpellings(pres.Slides), rec, nil, imageURL)
+	if err != nil {
+		return nil, err
+	}
+	return append(reqs, deck...), nil
+}
+
+// slidesRenderReplacements replaces each placeholder spelling on pageIDs
+// (all slides when empty): images via replaceAllShapesWithImage, everything
+// else (and images without a value) via replaceAllText.
+func slidesRenderReplacements(spellings [][2]string, rec docsRenderRecord, pageIDs []string, imageURL func(string) (string, error)) ([]*slides.Request, error) {
+	var reqs []*slides.Request
+	for _, s := range spellings {
+		match := &slides.SubstringMatchCriteria{Text: s[0], MatchCase: true}
+		if key, ok := strings.CutPrefix(s[1], docsRenderImagePrefix); ok {
+			v, _ := rec.lookup(key)
+			if src := strings.TrimSpace(docsRenderText(v)); src != "" {
+				url, err := imageURL(src)
+				if err != nil {
+					return nil, err
+				}
+				reqs = append(reqs, &slides.Request{ReplaceAllShapesWithImage: &slides.ReplaceAllShapesWithImageRequest{
+					ContainsText:       match,
+					ImageUrl:           url,
+					ImageReplaceMethod: "CENTER_INSIDE",
+					PageObjectIds:      pageIDs,
+				}})
+				continue
+			}
+			reqs = append(reqs, &slides.Request{ReplaceAllText: &slides.ReplaceAllTextRequest{
+				ContainsText:  match,
+				PageObjectIds: pageIDs,
+			}})
+			continue
+		}
+		v, _ := rec.lookup(s[1])
+		reqs = append(reqs, &slides.Request{ReplaceAllText: &slides.ReplaceAllTextRequest{
+			ContainsText:  match,
+			ReplaceText:   docsRenderText(v),
+			PageObjectIds: pageIDs,
+		}})
+	}
+	return reqs, nil
+}
+
+// slidesRenderTags returns the {{#if}}/{{#unless}}/{{#each}} tags in a
+// slide's speaker notes with the requests deleting them (last first).
+func slidesRenderTags(slide *slides.Page) ([][2]string, []*slides.Request) {
+	notesID := slideSpeakerNotesID(slide)
+	if notesID == "" {
+		return nil, nil
+	}
+	var text string
+	for _, el := range slide.SlideProperties.NotesPage.PageElements {
+		if el.ObjectId == notesID && el.Shape != nil {
+			text = slidesPlainText(el.Shape.Text)
+		}
+	}
+	var tags [][2]string
+	var untag []*slides.Request
+	for _, loc := range slidesRenderTagRe.FindAllStringSubmatchIndex(text, -1) {
+		tags = append(tags, [2]string{text[loc[2]:loc[3]], text[loc[4]:loc[5]]})
+		untag = append([]*slides.Request{{DeleteText: &slides.DeleteTextRequest{
+			ObjectId:  notesID,
+			TextRange: slidesFixedRange(utf16Len(text[:loc[0]]), utf16Len(text[:loc[1]])),
+		}}}, untag...)
+	}
+	return tags, untag
+}
+
+// slidesRenderSpellings returns each distinct placeholder spelling on pages
+// with its key (control tags excluded), in order of first appearance.
+func slidesRenderSpellings(pages []*slides.Page) [][2]string {
+	seen := map[string]bool{}
+	var out [][2]string
+	for _, page := range pages {
+		slidesEachText(page.PageElements, func(text string) {
+			for _, m := range docsRenderPlaceholderRe.FindAllStringSubmatch(text, -1) {
+				if m[1] == "" || strings.HasPrefix(m[1], "#") || strings.HasPrefix(m[1], "/") || seen[m[0]] {
+					continue
+				}
+				seen[m[0]] = true
+				out = append(out, [2]string{m[0], m[1]})
+			}
+		})
+	}
+	return out
+}
+
+// slidesRenderPlaceholders returns the distinct placeholder keys of a deck.
+func slidesRenderPlaceholders(pres *slides.Presentation) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, s := range slidesRenderSpellings(pres.Slides) {
+		if !seen[s[1]] {
+			seen[s[1]] = true
+			keys = append(keys, s[1])
+		}
+	}
+	return keys
+}
+
+// slidesEachText calls fn with the text of every shape and table cell in
+// elements, descending into groups.
+func slidesEachText(elements []*slides.PageElement, fn func(string)) {
+	for _, el := range elements {
+		switch {
+		case el.Shape != nil && el.Shape.Text != nil:
+			fn(slidesPlainText(el.Shape.Text))
+		case el.Table != nil:
+			for _, row := range el.Table.TableRows {
+				for _, cell := range row.TableCells {
+					if cell.Text != nil {
+						fn(slidesPlainText(cell.Text))
+					}
+				}
+			}
+		case el.ElementGroup != nil:
+			slidesEachText(el.ElementGroup.Children, fn)
+		}
+	}
+}
+
+func slidesPlainText(text *slides.TextContent) string {
+	if text == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, te := range text.TextElements {
+		if te.TextRun != nil {
+			sb.WriteString(te.TextRun.Content)
+		}
+	}
+	return sb.String()
+}
+
+// slidesSheetsCharts returns the object IDs of linked Sheets charts.
+func slidesSheetsCharts(pres *slides.Presentation) []string {
+	var ids []string
+	var walk func([]*slides.PageElement)
+	walk = func(elements []*slides.PageElement) {
+		for _, el := range elements {
+			if el.SheetsChart != nil {
+				ids = append(ids, el.ObjectId)
+			}
+			if el.ElementGroup != nil {
+				walk(el.ElementGroup.Children)
+			}
+		}
+	}
+	for _, s := range pres.Slides {
+		walk(s.PageElements)
+	}
+	sort.Strings(ids)
+	return ids
+}
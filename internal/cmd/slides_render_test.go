@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+	"google.golang.org/api/slides/v1"
+)
+
+// renderTestDeck: an intro slide with text and an image placeholder, a
+// slide tagged {{#if vip}}, and a slide repeated per project that also holds
+// a table and a linked chart.
+func renderTestDeck() *slides.Presentation {
+	shape := func(id, text string) *slides.PageElement {
+		return &slides.PageElement{ObjectId: id, Shape: &slides.Shape{Text: &slides.TextContent{TextElements: []*slides.TextElement{
+			{TextRun: &slides.TextRun{Content: text + "\n"}},
+		}}}}
+	}
+	slide := func(id, notes string, elements ...*slides.PageElement) *slides.Page {
+		return &slides.Page{ObjectId: id, PageElements: elements, SlideProperties: &slides.SlideProperties{NotesPage: &slides.Page{
+			NotesProperties: &slides.NotesProperties{SpeakerNotesObjectId: id + "_notes"},
+			PageElements:    []*slides.PageElement{shape(id+"_notes", notes)},
+		}}}
+	}
+	table := &slides.PageElement{ObjectId: "tbl", Table: &slides.Table{TableRows: []*slides.TableRow{{TableCells: []*slides.TableCell{
+		{Text: &slides.TextContent{TextElements: []*slides.TextElement{{TextRun: &slides.TextRun{Content: "Week {{week}}\n"}}}}},
+	}}}}}
+	return &slides.Presentation{
+		PresentationId: "deck1",
+		RevisionId:     "rev-7",
+		Title:          "Weekly",
+		Slides: []*slides.Page{
+			slide("s_intro", "", shape("t1", "Week {{ week }}"), shape("logo", "{{image:logo}}")),
+			slide("s_vip", "{{#if vip}}", shape("t2", "VIP corner")),
+			slide("s_proj", "Talk {{#each projects}} track",
+				shape("t3", "{{projects.name}}: {{projects.status}}"),
+				table,
+				&slides.PageElement{ObjectId: "chart1", SheetsChart: &slides.SheetsChart{SpreadsheetId: "sheet1"}},
+			),
+		},
+	}
+}
+
+func TestSlidesRender_PlansTaggedSlidesAndReplacements(t *testing.T) {
+	records, err := parseDocsRenderJSON(`{"week":12,"logo":"logo.png","vip":false,"projects":[{"name":"Atlas","status":"ok"},{"name":"Borealis","status":"late"}]}`)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	deck := renderTestDeck()
+	if keys := strings.Join(slidesRenderPlaceholders(deck), ","); keys != "week,image:logo,projects.name,projects.status" {
+		t.Fatalf("unexpected placeholders: %s", keys)
+	}
+
+	reqs, err := slidesRenderRequests(deck, records[0], func(src string) (string, error) { return "https://img/" + src, nil })
+	if err != nil {
+		t.Fatalf("requests: %v", err)
+	}
+	if len(reqs) != 12 {
+		t.Fatalf("expected 12 requests, got %d: %#v", len(reqs), reqs)
+	}
+	if d := reqs[0].DeleteObject; d == nil || d.ObjectId != "s_vip" {
+		t.Fatalf("expected the vip slide to be dropped, got %#v", reqs[0])
+	}
+	if d := reqs[1].DeleteText; d == nil || d.ObjectId != "s_proj_notes" || *d.TextRange.StartIndex != 5 || *d.TextRange.EndIndex != 23 {
+		t.Fatalf("expected the each tag to be removed from the notes, got %#v", reqs[1])
+	}
+	if d := reqs[2].DuplicateObject; d == nil || d.ObjectId != "s_proj" || d.ObjectIds["s_proj"] != "s_proj_copy1" {
+		t.Fatalf("unexpected duplicate: %#v", reqs[2])
+	}
+	perPage := map[string]string{}
+	for _, r := range reqs[3:7] {
+		rt := r.ReplaceAllText
+		if rt == nil || len(rt.PageObjectIds) != 1 {
+			t.Fatalf("expected page-scoped replacement, got %#v", r)
+		}
+		perPage[rt.PageObjectIds[0]+" "+rt.ContainsText.Text] = rt.ReplaceText
+	}
+	if perPage["s_proj {{projects.name}}"] != "Atlas" || perPage["s_proj_copy1 {{projects.name}}"] != "Borealis" || perPage["s_proj_copy1 {{projects.status}}"] != "late" {
+		t.Fatalf("unexpected per-project values: %v", perPage)
+	}
+	if rt := reqs[7].ReplaceAllText; rt == nil || rt.ContainsText.Text != "{{ week }}" || rt.ReplaceText != "12" || len(rt.PageObjectIds) != 0 {
+		t.Fatalf("unexpected deck replacement: %#v", reqs[7])
+	}
+	if img := reqs[8].ReplaceAllShapesWithImage; img == nil || img.ImageUrl != "https://img/logo.png" || img.ContainsText.Text != "{{image:logo}}" {
+		t.Fatalf("unexpected image replacement: %#v", reqs[8])
+	}
+
+	if _, err := slidesRenderRequests(deck, docsRenderRecord{"projects": "nope"}, nil); err == nil || ExitCode(err) != 2 {
+		t.Fatalf("expected usage error for a non-list, got %v", err)
+	}
+}
+
+func TestSlidesRender_CopiesRendersAndRefreshesCharts(t *testing.T) {
+	origDrive, origSlides := newDriveService, newSlidesService
+	t.Cleanup(func() {
+		newDriveService = origDrive
+		newSlidesService = origSlides
+	})
+
+	var (
+		mu      sync.Mutex
+		names   []string
+		batches []slides.BatchUpdatePresentationRequest
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, "/drive/v3")
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/presentations/"):
+			deck := renderTestDeck()
+			deck.PresentationId = strings.TrimPrefix(r.URL.Path, "/v1/presentations/")
+			_ = json.NewEncoder(w).Encode(deck)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":batchUpdate"):
+			var req slides.BatchUpdatePresentationRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode batchUpdate: %v", err)
+			}
+			batches = append(batches, req)
+			_ = json.NewEncoder(w).Encode(map[string]any{})
+		case r.Method == http.MethodGet && path == "/files/tmpl":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "tmpl", "mimeType": driveMimeGoogleSlides})
+		case r.Method == http.MethodPost && path == "/files/tmpl/copy":
+			var f drive.File
+			_ = json.NewDecoder(r.Body).Decode(&f)
+			names = append(names, f.Name)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "copy1", "name": f.Name, "mimeType": driveMimeGoogleSlides})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	driveSvc, err := drive.NewService(context.Background(), option.WithoutAuthentication(), option.WithHTTPClient(srv.Client()), option.WithEndpoint(srv.URL+"/"))
+	if err != nil {
+		t.Fatalf("drive.NewService: %v", err)
+	}
+	slidesSvc, err := slides.NewService(context.Background(), option.WithoutAuthentication(), option.WithHTTPClient(srv.Client()), option.WithEndpoint(srv.URL+"/"))
+	if err != nil {
+		t.Fatalf("slides.NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return driveSvc, nil }
+	newSlidesService = func(context.Context, string) (*slides.Service, error) { return slidesSvc, nil }
+
+	data := filepath.Join(t.TempDir(), "week.json")
+	if err := os.WriteFile(data, []byte(`{"week":12,"logo":"https://example.com/logo.png","vip":true,"projects":[{"name":"Atlas","status":"ok"}]}`), 0o600); err != nil {
+		t.Fatalf("write data: %v", err)
+	}
+	flags := &RootFlags{Account: "a@b.com"}
+	if err := runKong(t, &SlidesRenderCmd{}, []string{"tmpl", "--data", data, "--name", "Week {{week}}", "--refresh-charts"}, newDocsCmdContext(t), flags); err != nil {
+		t.Fatalf("slides render: %v", err)
+	}
+
+	if strings.Join(names, "|") != "Week 12" {
+		t.Fatalf("unexpected copy names: %v", names)
+	}
+	if len(batches) != 2 {
+		t.Fatalf("expected render and refresh batches, got %d", len(batches))
+	}
+	if wc := batches[0].WriteControl; wc == nil || wc.RequiredRevisionId != "rev-7" {
+		t.Fatalf("expected revision guard, got %#v", wc)
+	}
+	for _, r := range batches[0].Requests {
+		if r.DeleteObject != nil || r.DuplicateObject != nil {
+			t.Fatalf("vip slide and single project should be kept as-is, got %#v", r)
+		}
+	}
+	if reqs := batches[1].Requests; len(reqs) != 1 || reqs[0].RefreshSheetsChart == nil || reqs[0].RefreshSheetsChart.ObjectId != "chart1" {
+		t.Fatalf("unexpected refresh batch: %#v", batches[1].Requests)
+	}
+
+	if err := os.WriteFile(data, []byte(`{"week":12}`), 0o600); err != nil {
+		t.Fatalf("write data: %v", err)
+	}
+	err = runKong(t, &SlidesRenderCmd{}, []string{"tmpl", "--data", data, "--strict"}, newDocsCmdContext(t), flags)
+	if err == nil || ExitCode(err) != 2 || !strings.Contains(err.Error(), "logo") {
+		t.Fatalf("expected strict usage error, got %v", err)
+	}
+}
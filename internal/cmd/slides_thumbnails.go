@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+)
+
+// slidesThumbnailTimeout bounds each thumbnail download; the PNGs are small,
+// so a stalled content URL should fail rather than hang the export.
+const slidesThumbnailTimeout = 60 * time.Second
+
+type slidesThumbnailFile struct {
+	Number   int    `json:"number"`
+	ObjectID string `json:"objectId"`
+	Path     string `json:"path"`
+	Width    int64  `json:"width"`
+	Height   int64  `json:"height"`
+}
+
+// exportSlidesThumbnails renders every slide with pages.getThumbnail and
+// downloads the PNGs into outDir (default: <drive downloads>/<id>) as
+// slide-01.png, slide-02.png, ...
+func exportSlidesThumbnails(ctx context.Context, flags *RootFlags, presentationID, outDir, size string) error {
+	presentationID = normalizeGoogleID(strings.TrimSpace(presentationID))
+	if presentationID == "" {
+		return usage("empty presentationId")
+	}
+	if size == "" {
+		size = "large"
+	}
+
+	outDir = strings.TrimSpace(outDir)
+	if outDir == "" {
+		dir, err := config.DriveDownloadsDir()
+		if err != nil {
+			return err
+		}
+		outDir = filepath.Join(dir, presentationID)
+	} else {
+		expanded, err := config.ExpandPath(outDir)
+		if err != nil {
+			return err
+		}
+		outDir = expanded
+	}
+
+	if err := dryRunExit(ctx, flags, "slides.export", map[string]any{
+		"id":     presentationID,
+		"out":    outDir,
+		"format": "png",
+		"size":   size,
+	}); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newSlidesService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	pres, err := svc.Presentations.Get(presentationID).Fields("slides.objectId").Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("get presentation: %w", err)
+	}
+	if err = os.MkdirAll(outDir, 0o755); err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: slidesThumbnailTimeout}
+	width := max(2, len(strconv.Itoa(len(pres.Slides))))
+	files := make([]slidesThumbnailFile, 0, len(pres.Slides))
+	for i, slide := range pres.Slides {
+		thumb, err := svc.Presentations.Pages.GetThumbnail(presentationID, slide.ObjectId).
+			ThumbnailPropertiesMimeType("PNG").
+			ThumbnailPropertiesThumbnailSize(strings.ToUpper(size)).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return fmt.Errorf("slide %d: get thumbnail: %w", i+1, err)
+		}
+		path := filepath.Join(outDir, fmt.Sprintf("slide-%0*d.png", width, i+1))
+		if err := downloadSlidesThumbnail(ctx, client, thumb.ContentUrl, path); err != nil {
+			return fmt.Errorf("slide %d: %w", i+1, err)
+		}
+		files = append(files, slidesThumbnailFile{
+			Number:   i + 1,
+			ObjectID: slide.ObjectId,
+			Path:     path,
+			Width:    thumb.Width,
+			Height:   thumb.Height,
+		})
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"dir": outDir, "slides": files})
+	}
+	w, done := tableWriter(ctx)
+	defer done()
+	_, _ = fmt.Fprintln(w, "SLIDE\tOBJECT_ID\tPATH")
+	for _, f := range files {
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\n", f.Number, f.ObjectID, f.Path)
+	}
+	return nil
+}
+
+// downloadSlidesThumbnail fetches a thumbnail content URL; these URLs are
+// pre-authorized for the requesting account and expire after 30 minutes.
+func downloadSlidesThumbnail(ctx context.Context, client *http.Client, url, path string) error {
+	if url == "" {
+		return fmt.Errorf("thumbnail has no content URL")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("download thumbnail: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download thumbnail: HTTP %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read thumbnail: %w", err)
+	}
+	return writeFileAtomic(path, data)
+}
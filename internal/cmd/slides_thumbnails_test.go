@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/slides/v1"
+)
+
+func TestSlidesExport_PNGThumbnails(t *testing.T) {
+	origSlides := newSlidesService
+	t.Cleanup(func() { newSlidesService = origSlides })
+
+	var sizes []string
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/presentations/pres1":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"slides": []any{
+				map[string]any{"objectId": "s1"},
+				map[string]any{"objectId": "s2"},
+			}})
+		case strings.HasSuffix(r.URL.Path, "/thumbnail"):
+			sizes = append(sizes, r.URL.Query().Get("thumbnailProperties.thumbnailSize")+"/"+r.URL.Query().Get("thumbnailProperties.mimeType"))
+			page := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/presentations/pres1/pages/"), "/thumbnail")
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"contentUrl": srv.URL + "/img/" + page, "width": 800, "height": 450})
+		case strings.HasPrefix(r.URL.Path, "/img/"):
+			_, _ = w.Write([]byte("png-" + strings.TrimPrefix(r.URL.Path, "/img/")))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	svc, err := slides.NewService(context.Background(), option.WithoutAuthentication(), option.WithHTTPClient(srv.Client()), option.WithEndpoint(srv.URL+"/"))
+	if err != nil {
+		t.Fatalf("slides.NewService: %v", err)
+	}
+	newSlidesService = func(context.Context, string) (*slides.Service, error) { return svc, nil }
+
+	dir := filepath.Join(t.TempDir(), "thumbs")
+	flags := &RootFlags{Account: "a@b.com"}
+	out := captureStdout(t, func() {
+		if err := runKong(t, &SlidesExportCmd{}, []string{"pres1", "--format", "png", "--size", "medium", "--out", dir}, newDocsCmdContext(t), flags); err != nil {
+			t.Fatalf("export png: %v", err)
+		}
+	})
+	if strings.Join(sizes, ",") != "MEDIUM/PNG,MEDIUM/PNG" {
+		t.Fatalf("unexpected thumbnail requests: %v", sizes)
+	}
+	for name, want := range map[string]string{"slide-01.png": "png-s1", "slide-02.png": "png-s2"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || string(data) != want {
+			t.Fatalf("%s: got %q, %v", name, data, err)
+		}
+	}
+	if !strings.Contains(out, "s2") {
+		t.Fatalf("expected slide listing, got %q", out)
+	}
+
+	err = runKong(t, &SlidesExportCmd{}, []string{"pres1", "--format", "pdf", "--size", "small"}, newDocsCmdContext(t), flags)
+	if err == nil || ExitCode(err) != 2 {
+		t.Fatalf("expected usage error for --size without png, got %v", err)
+	}
+}
+
+func TestDownloadSlidesThumbnail_ClientTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	path := filepath.Join(t.TempDir(), "slide-01.png")
+	client := &http.Client{Timeout: 50 * time.Millisecond}
+	if err := downloadSlidesThumbnail(context.Background(), client, srv.URL+"/img/s1", path); err == nil {
+		t.Fatalf("expected timeout error")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected no file after timeout, got %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// templateRenderOptions are the flags docs render and slides render share,
+// plus what differs per backend in the dry run, the PDF export, and the
+// output.
+type templateRenderOptions struct {
+	Op          string // dry-run operation
+	TemplateID  string
+	TemplateArg string // argument name for usage errors
+	Data        string
+	OutFolder   string
+	Name        string
+	PDF         bool
+	PDFDir      string
+	Strict      bool
+	DryRun      map[string]any // backend flags added to the dry-run payload
+	Export      exportViaDriveOptions
+	ResultsKey  string // JSON key of the results list
+}
+
+// templateRender is an opened template: its title, its placeholder keys, and
+// a callback that copies the template for one record and fills the copy.
+type templateRender struct {
+	title  string
+	keys   []string
+	render func(ctx context.Context, rec docsRenderRecord, name, parent string) (*drive.File, error)
+}
+
+// templateRenderOpener opens the template for a backend once its services
+// can be built for account.
+type templateRenderOpener func(ctx context.Context, account string, driveSvc *drive.Service, templateID, dataDir string) (*templateRender, error)
+
+type templateRenderResult struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Link    string   `json:"link,omitempty"`
+	PDF     string   `json:"pdf,omitempty"`
+	Missing []string `json:"missing,omitempty"`
+}
+
+// runTemplateRender loads the records, copies and fills the template once per
+// record through open, optionally exports each copy as PDF, and prints the
+// results.
+func runTemplateRender(ctx context.Context, flags *RootFlags, opts templateRenderOptions, open templateRenderOpener) error {
+	u := ui.FromContext(ctx)
+
+	templateID := normalizeGoogleID(strings.TrimSpace(opts.TemplateID))
+	if templateID == "" {
+		return usagef("empty %s", opts.TemplateArg)
+	}
+	records, err := loadDocsRenderData(opts.Data)
+	if err != nil {
+		return err
+	}
+	dataDir := "."
+	if opts.Data != "-" {
+		dataDir = filepath.Dir(opts.Data)
+	}
+	pdfDir := strings.TrimSpace(opts.PDFDir)
+	if pdfDir != "" {
+		if !opts.PDF {
+			return usage("--pdf-dir requires --pdf")
+		}
+		if pdfDir, err = config.ExpandPath(pdfDir); err != nil {
+			return err
+		}
+	}
+
+	payload := map[string]any{
+		"template_id": templateID,
+		"records":     len(records),
+		"out_folder":  strings.TrimSpace(opts.OutFolder),
+		"name":        opts.Name,
+		"pdf":         opts.PDF,
+		"pdf_dir":     pdfDir,
+	}
+	for k, v := range opts.DryRun {
+		payload[k] = v
+	}
+	if err = dryRunExit(ctx, flags, opts.Op, payload); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	driveSvc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	var exportSvc *drive.Service
+	if opts.PDF {
+		if exportSvc, err = newDriveTransferService(ctx, account); err != nil {
+			return err
+		}
+	}
+	parent, err := resolveDriveID(ctx, driveSvc, strings.TrimSpace(opts.OutFolder))
+	if err != nil {
+		return err
+	}
+	tmpl, err := open(ctx, account, driveSvc, templateID, dataDir)
+	if err != nil {
+		return err
+	}
+
+	missing := make([][]string, len(records))
+	for i, rec := range records {
+		missing[i] = rec.missing(tmpl.keys)
+		if opts.Strict && len(missing[i]) > 0 {
+			return usagef("record %d: no value for %s", i+1, strings.Join(missing[i], ", "))
+		}
+	}
+	if pdfDir != "" {
+		if err = os.MkdirAll(pdfDir, 0o755); err != nil {
+			return err
+		}
+	}
+
+	results := make([]templateRenderResult, 0, len(records))
+	for i, rec := range records {
+		name := tmpl.title
+		if opts.Name != "" {
+			name = rec.render(opts.Name)
+		} else if len(records) > 1 {
+			name = fmt.Sprintf("%s %d", tmpl.title, i+1)
+		}
+
+		created, err := tmpl.render(ctx, rec, name, parent)
+		if err != nil {
+			return fmt.Errorf("record %d: %w", i+1, err)
+		}
+
+		result := templateRenderResult{ID: created.Id, Name: created.Name, Link: created.WebViewLink, Missing: missing[i]}
+		if opts.PDF {
+			result.PDF, _, err = exportDriveFile(ctx, exportSvc, opts.Export, created.Id, pdfDir, defaultExportFormat)
+			if err != nil {
+				return fmt.Errorf("record %d: export %s: %w", i+1, created.Id, err)
+			}
+		}
+		if len(result.Missing) > 0 && !outfmt.IsJSON(ctx) {
+			u.Err().Printf("%s: no value for %s", result.Name, strings.Join(result.Missing, ", "))
+		}
+		results = append(results, result)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{opts.ResultsKey: results})
+	}
+	w, done := tableWriter(ctx)
+	defer done()
+	if opts.PDF {
+		_, _ = fmt.Fprintln(w, "ID\tNAME\tPDF")
+		for _, r := range results {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", r.ID, r.Name, r.PDF)
+		}
+		return nil
+	}
+	_, _ = fmt.Fprintln(w, "ID\tNAME\tLINK")
+	for _, r := range results {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", r.ID, r.Name, r.Link)
+	}
+	return nil
+}
+
+// copyTemplateAndFill copies the template as name into parent and fills the
+// copy with fill; both steps are named in the error.
+func copyTemplateAndFill(ctx context.Context, driveSvc *drive.Service, opts copyViaDriveOptions, templateID, name, parent string, fill func(id string) error) (*drive.File, error) {
+	created, err := copyDriveFile(ctx, driveSvc, opts, templateID, name, parent)
+	if err != nil {
+		return nil, fmt.Errorf("copy template: %w", err)
+	}
+	if err = fill(created.Id); err != nil {
+		return nil, fmt.Errorf("render %s: %w", created.Id, err)
+	}
+	return created, nil
+}
+
+// templateRenderCopyID names the n-th copy of a template object that is
+// repeated per list element.
+func templateRenderCopyID(id string, n int) string {
+	return fmt.Sprintf("%s_copy%d", id, n)
+}
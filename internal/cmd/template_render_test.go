@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+)
+
+func TestRunTemplateRender_NamesCopiesAndReportsMissingValues(t *testing.T) {
+	origDrive := newDriveService
+	t.Cleanup(func() { newDriveService = origDrive })
+	newDriveService = stubDriveService(&drive.Service{})
+
+	data := filepath.Join(t.TempDir(), "rows.json")
+	if err := os.WriteFile(data, []byte(`[{"client":"Acme"},{"client":"Beta","fail":true}]`), 0o600); err != nil {
+		t.Fatalf("write data: %v", err)
+	}
+	var (
+		dataDir string
+		names   []string
+	)
+	open := func(_ context.Context, account string, _ *drive.Service, templateID, dir string) (*templateRender, error) {
+		if account != "a@b.com" || templateID != "tmpl" {
+			t.Fatalf("open(%q, %q)", account, templateID)
+		}
+		dataDir = dir
+		return &templateRender{
+			title: "Quote",
+			keys:  []string{"client", "total"},
+			render: func(_ context.Context, rec docsRenderRecord, name, _ string) (*drive.File, error) {
+				// Only the second run fails, on its second record.
+				if rec.truthy("fail") && len(names) > 2 {
+					return nil, errors.New("boom")
+				}
+				names = append(names, name)
+				return &drive.File{Id: "id" + name, Name: name}, nil
+			},
+		}, nil
+	}
+	opts := templateRenderOptions{Op: "test.render", TemplateID: "tmpl", TemplateArg: "templateId", Data: data, ResultsKey: "files"}
+	flags := &RootFlags{Account: "a@b.com"}
+
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+	out := captureStdout(t, func() {
+		if err := runTemplateRender(ctx, flags, opts, open); err != nil {
+			t.Fatalf("render: %v", err)
+		}
+	})
+	if dataDir != filepath.Dir(data) {
+		t.Fatalf("data dir = %q", dataDir)
+	}
+	if strings.Join(names, "|") != "Quote 1|Quote 2" {
+		t.Fatalf("unexpected default names: %v", names)
+	}
+	var parsed struct {
+		Files []templateRenderResult `json:"files"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v (%s)", err, out)
+	}
+	if len(parsed.Files) != 2 || strings.Join(parsed.Files[0].Missing, ",") != "total" {
+		t.Fatalf("unexpected results: %#v", parsed.Files)
+	}
+
+	opts.Name = "{{client}} quote"
+	if err := runTemplateRender(ctx, flags, opts, open); err == nil || !strings.Contains(err.Error(), "record 2: boom") {
+		t.Fatalf("expected the record number on a render error, got %v", err)
+	}
+	if names[2] != "Acme quote" {
+		t.Fatalf("unexpected --name rendering: %v", names)
+	}
+
+	opts.Strict = true
+	if err := runTemplateRender(ctx, flags, opts, open); err == nil || ExitCode(err) != 2 || !strings.Contains(err.Error(), "record 1: no value for total") {
+		t.Fatalf("expected strict usage error, got %v", err)
+	}
+	opts.Strict, opts.PDFDir = false, t.TempDir()
+	if err := runTemplateRender(ctx, flags, opts, open); err == nil || ExitCode(err) != 2 {
+		t.Fatalf("expected usage error for --pdf-dir without --pdf, got %v", err)
+	}
+}
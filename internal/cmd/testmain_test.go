@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"google.golang.org/api/drive/v3"
 )
 
 func TestMain(m *testing.M) {
@@ -22,6 +25,11 @@ func TestMain(m *testing.M) {
 	_ = os.Setenv("HOME", home)
 	_ = os.Setenv("XDG_CONFIG_HOME", xdg)
 
+	// Tests stub newDriveService; route media transfers through the same stub.
+	newDriveTransferService = func(ctx context.Context, email string) (*drive.Service, error) {
+		return newDriveService(ctx, email)
+	}
+
 	code := m.Run()
 
 	if oldHome == "" {
@@ -0,0 +1,309 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// Re-register channels that expire within this window instead of reusing them.
+const watchChannelRenewWindow = time.Hour
+
+// Push watchers share the channel, hook and state plumbing below; only the
+// watch and changes calls differ per service.
+
+type watchHook struct {
+	URL   string `json:"url"`
+	Token string `json:"token,omitempty"`
+}
+
+type watchChannel struct {
+	ID           string `json:"id"`
+	ResourceID   string `json:"resourceId"`
+	Address      string `json:"address"`
+	Token        string `json:"token,omitempty"`
+	ExpirationMs int64  `json:"expirationMs,omitempty"`
+}
+
+// watchDelivery is embedded in each watch state to record the last hook call.
+type watchDelivery struct {
+	LastDeliveryStatus     string `json:"lastDeliveryStatus,omitempty"`
+	LastDeliveryAtMs       int64  `json:"lastDeliveryAtMs,omitempty"`
+	LastDeliveryStatusNote string `json:"lastDeliveryStatusNote,omitempty"`
+}
+
+func (d *watchDelivery) delivery() *watchDelivery { return d }
+
+// watchStore persists one account's watch state as JSON.
+type watchStore[S any] struct {
+	path  string
+	mu    sync.Mutex
+	state S
+}
+
+// openWatchStore loads the state at path, starting from empty when nothing
+// has been recorded yet.
+func openWatchStore[S any](path string, empty S) (*watchStore[S], error) {
+	store := &watchStore[S]{path: path, state: empty}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return store, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &store.state); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func (s *watchStore[S]) Get() S {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+func (s *watchStore[S]) Update(fn func(*S) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := fn(&s.state); err != nil {
+		return err
+	}
+	return s.Save()
+}
+
+func (s *watchStore[S]) Save() error {
+	if s.path == "" {
+		return errors.New("missing watch state path")
+	}
+	payload, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, append(payload, '\n'), 0o600)
+}
+
+// recordDelivery stores the outcome of the latest hook call. States that do
+// not embed watchDelivery are left untouched.
+func (s *watchStore[S]) recordDelivery(status, note string) {
+	_ = s.Update(func(state *S) error {
+		if d, ok := any(state).(interface{ delivery() *watchDelivery }); ok {
+			rec := d.delivery()
+			rec.LastDeliveryStatus = status
+			rec.LastDeliveryAtMs = time.Now().UnixMilli()
+			rec.LastDeliveryStatusNote = note
+		}
+		return nil
+	})
+}
+
+type watchDeliveryRecorder interface {
+	recordDelivery(status, note string)
+}
+
+// parseWatchServeFlags validates the listener, channel and hook flags shared
+// by the watch serve commands and returns the trimmed address and TTL.
+func parseWatchServeFlags(path string, port int, address, ttl, hookURL, hookToken string) (string, time.Duration, error) {
+	if !strings.HasPrefix(path, "/") {
+		return "", 0, usage("--path must start with '/'")
+	}
+	if port <= 0 {
+		return "", 0, usage("--port must be > 0")
+	}
+	address = strings.TrimSpace(address)
+	if address != "" {
+		parsed, err := url.Parse(address)
+		if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			return "", 0, usage("--address must be an https:// URL reachable by Google")
+		}
+	}
+	d, err := parseDurationSeconds(ttl)
+	if err != nil {
+		return "", 0, err
+	}
+	if strings.TrimSpace(hookToken) != "" && strings.TrimSpace(hookURL) == "" {
+		return "", 0, usage("--hook-url required when using --hook-token")
+	}
+	return address, d, nil
+}
+
+// resolveWatchHook prefers the hook given by flags over the stored one.
+func resolveWatchHook(stored *watchHook, hookURL, hookToken string) *watchHook {
+	if strings.TrimSpace(hookURL) != "" {
+		return &watchHook{URL: strings.TrimSpace(hookURL), Token: hookToken}
+	}
+	return stored
+}
+
+func watchChannelReusable(ch *watchChannel, address string, now time.Time) bool {
+	if ch == nil || ch.ID == "" || ch.Address != address {
+		return false
+	}
+	if ch.ExpirationMs == 0 {
+		return true
+	}
+	return time.UnixMilli(ch.ExpirationMs).After(now.Add(watchChannelRenewWindow))
+}
+
+// newWatchChannelIDs returns a fresh channel ID and the channel token,
+// generating one when token is empty.
+func newWatchChannelIDs(token string) (string, string, error) {
+	id, err := randomRequestID()
+	if err != nil {
+		return "", "", err
+	}
+	if strings.TrimSpace(token) == "" {
+		token, err = randomRequestID()
+		if err != nil {
+			return "", "", err
+		}
+	}
+	return id, token, nil
+}
+
+// watchPushServer holds the request checks and hook delivery shared by the
+// push handlers.
+type watchPushServer struct {
+	path       string
+	channel    watchChannel
+	hook       *watchHook
+	hookClient *http.Client
+	out        *json.Encoder
+	logf       func(string, ...any)
+	warnf      func(string, ...any)
+
+	// mu serializes incremental fetches; notifications can arrive concurrently.
+	mu sync.Mutex
+}
+
+// accept answers every request except an authorized change notification
+// (one of changeStates) and reports whether the caller should fetch changes.
+func (s *watchPushServer) accept(w http.ResponseWriter, r *http.Request, changeStates ...string) bool {
+	if !pathMatches(s.path, r.URL.Path) {
+		w.WriteHeader(http.StatusNotFound)
+		return false
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return false
+	}
+	if !s.authorize(r) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return false
+	}
+
+	state := r.Header.Get("X-Goog-Resource-State")
+	if state == "sync" {
+		s.logf("watch: channel %s confirmed", s.channel.ID)
+		w.WriteHeader(http.StatusOK)
+		return false
+	}
+	for _, want := range changeStates {
+		if state == want {
+			return true
+		}
+	}
+	s.warnf("watch: ignoring notification with resource state %q", state)
+	w.WriteHeader(http.StatusAccepted)
+	return false
+}
+
+func (s *watchPushServer) authorize(r *http.Request) bool {
+	if r.Header.Get("X-Goog-Channel-ID") != s.channel.ID {
+		return false
+	}
+	if s.channel.Token == "" {
+		return true
+	}
+	token := r.Header.Get("X-Goog-Channel-Token")
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.channel.Token)) == 1
+}
+
+// deliverWatchChanges forwards payload to the hook, or prints changes as
+// JSONL when no hook is configured, and answers the notification.
+func deliverWatchChanges[C any](ctx context.Context, s *watchPushServer, w http.ResponseWriter, rec watchDeliveryRecorder, payload any, changes []C) {
+	if len(changes) == 0 {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if s.hook == nil || s.hook.URL == "" {
+		s.mu.Lock()
+		for _, ch := range changes {
+			_ = s.out.Encode(ch)
+		}
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if err := s.sendHook(ctx, rec, payload); err != nil {
+		s.warnf("watch: hook failed: %v", err)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *watchPushServer) sendHook(ctx context.Context, rec watchDeliveryRecorder, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.hook.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.hook.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.hook.Token)
+	}
+	resp, err := s.hookClient.Do(req)
+	if err != nil {
+		rec.recordDelivery("error", err.Error())
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		rec.recordDelivery(gmailWatchStatusHTTPError, fmt.Sprintf("status %d", resp.StatusCode))
+		return fmt.Errorf("hook status %d", resp.StatusCode)
+	}
+	rec.recordDelivery("ok", "")
+	return nil
+}
+
+func newWatchPushServer(ctx context.Context, path string, channel watchChannel, hook *watchHook) watchPushServer {
+	u := ui.FromContext(ctx)
+	return watchPushServer{
+		path:       path,
+		channel:    channel,
+		hook:       hook,
+		hookClient: &http.Client{Timeout: defaultHookRequestTimeoutSec * time.Second},
+		out:        json.NewEncoder(os.Stdout),
+		logf:       u.Err().Printf,
+		warnf:      u.Err().Printf,
+	}
+}
+
+func serveWatch(ctx context.Context, bind string, port int, path string, handler http.Handler) error {
+	addr := net.JoinHostPort(bind, strconv.Itoa(port))
+	ui.FromContext(ctx).Err().Printf("watch: listening on %s%s", addr, path)
+
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return listenAndServe(httpServer)
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWatchChannelReusable(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ch := &watchChannel{ID: "c1", Address: "https://example.com/hook", ExpirationMs: now.Add(48 * time.Hour).UnixMilli()}
+	if !watchChannelReusable(ch, "https://example.com/hook", now) {
+		t.Fatalf("expected channel to be reusable")
+	}
+	if watchChannelReusable(ch, "https://other.example.com/hook", now) {
+		t.Fatalf("expected address change to force re-registration")
+	}
+	ch.ExpirationMs = now.Add(10 * time.Minute).UnixMilli()
+	if watchChannelReusable(ch, "https://example.com/hook", now) {
+		t.Fatalf("expected expiring channel to be renewed")
+	}
+	if watchChannelReusable(nil, "https://example.com/hook", now) {
+		t.Fatalf("expected nil channel to be unusable")
+	}
+}
+
+func TestWatchStore_RecordDeliveryKeepsStateShape(t *testing.T) {
+	store, err := openWatchStore(t.TempDir()+"/state.json", calendarWatchState{Account: "a@example.com"})
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	store.recordDelivery(gmailWatchStatusHTTPError, "status 500")
+
+	reopened, err := openWatchStore(store.path, calendarWatchState{})
+	if err != nil {
+		t.Fatalf("reopen store: %v", err)
+	}
+	state := reopened.Get()
+	if state.Account != "a@example.com" || state.LastDeliveryStatus != gmailWatchStatusHTTPError || state.LastDeliveryStatusNote != "status 500" || state.LastDeliveryAtMs == 0 {
+		t.Fatalf("unexpected state: %#v", state)
+	}
+}
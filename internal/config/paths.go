@@ -241,6 +241,116 @@ func EnsureGmailWatchDir() (string, error) {
 	return dir, nil
 }
 
+func CalendarSyncDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "state", "calendar-sync"), nil
+}
+
+func EnsureCalendarSyncDir() (string, error) {
+	dir, err := CalendarSyncDir()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("ensure calendar sync dir: %w", err)
+	}
+
+	return dir, nil
+}
+
+func CalendarWatchDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "state", "calendar-watch"), nil
+}
+
+func EnsureCalendarWatchDir() (string, error) {
+	dir, err := CalendarWatchDir()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("ensure calendar watch dir: %w", err)
+	}
+
+	return dir, nil
+}
+
+func DriveSyncDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "state", "drive-sync"), nil
+}
+
+func EnsureDriveSyncDir() (string, error) {
+	dir, err := DriveSyncDir()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("ensure drive sync dir: %w", err)
+	}
+
+	return dir, nil
+}
+
+func DriveUploadsDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "state", "drive-uploads"), nil
+}
+
+func EnsureDriveUploadsDir() (string, error) {
+	dir, err := DriveUploadsDir()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("ensure drive uploads dir: %w", err)
+	}
+
+	return dir, nil
+}
+
+func DriveWatchDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "state", "drive-watch"), nil
+}
+
+func EnsureDriveWatchDir() (string, error) {
+	dir, err := DriveWatchDir()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("ensure drive watch dir: %w", err)
+	}
+
+	return dir, nil
+}
+
 // ExpandPath expands ~ at the beginning of a path to the user's home directory.
 // This is needed because ~ is a shell feature and is not expanded when paths
 // are quoted (e.g., --out "~/Downloads/file.pdf").
@@ -61,6 +61,51 @@ func TestPaths_CreateDirs(t *testing.T) {
 		t.Fatalf("expected watch dir: %v", statErr)
 	}
 
+	calendarSyncDir, err := EnsureCalendarSyncDir()
+	if err != nil {
+		t.Fatalf("EnsureCalendarSyncDir: %v", err)
+	}
+
+	if _, statErr := os.Stat(calendarSyncDir); statErr != nil {
+		t.Fatalf("expected calendar sync dir: %v", statErr)
+	}
+
+	calendarWatchDir, err := EnsureCalendarWatchDir()
+	if err != nil {
+		t.Fatalf("EnsureCalendarWatchDir: %v", err)
+	}
+
+	if _, statErr := os.Stat(calendarWatchDir); statErr != nil {
+		t.Fatalf("expected calendar watch dir: %v", statErr)
+	}
+
+	driveSyncDir, err := EnsureDriveSyncDir()
+	if err != nil {
+		t.Fatalf("EnsureDriveSyncDir: %v", err)
+	}
+
+	if _, statErr := os.Stat(driveSyncDir); statErr != nil {
+		t.Fatalf("expected drive sync dir: %v", statErr)
+	}
+
+	driveUploadsDir, err := EnsureDriveUploadsDir()
+	if err != nil {
+		t.Fatalf("EnsureDriveUploadsDir: %v", err)
+	}
+
+	if _, statErr := os.Stat(driveUploadsDir); statErr != nil {
+		t.Fatalf("expected drive uploads dir: %v", statErr)
+	}
+
+	driveWatchDir, err := EnsureDriveWatchDir()
+	if err != nil {
+		t.Fatalf("EnsureDriveWatchDir: %v", err)
+	}
+
+	if _, statErr := os.Stat(driveWatchDir); statErr != nil {
+		t.Fatalf("expected drive watch dir: %v", statErr)
+	}
+
 	credsPath, err := ClientCredentialsPath()
 	if err != nil {
 		t.Fatalf("ClientCredentialsPath: %v", err)
@@ -20,7 +20,12 @@ import (
 	"github.com/steipete/gogcli/internal/secrets"
 )
 
-const defaultHTTPTimeout = 30 * time.Second
+const (
+	defaultHTTPTimeout = 30 * time.Second
+	// transferResponseHeaderTimeout bounds how long media transfers wait for
+	// the server to start responding (finalizing a large upload can be slow).
+	transferResponseHeaderTimeout = 2 * time.Minute
+)
 
 var (
 	readClientCredentials = config.ReadClientCredentialsFor
@@ -95,6 +100,21 @@ func optionsForAccount(ctx context.Context, service googleauth.Service, email st
 func optionsForAccountScopes(ctx context.Context, serviceLabel string, email string, scopes []string) ([]option.ClientOption, error) {
 	slog.Debug("creating client options with custom scopes", "serviceLabel", serviceLabel, "email", email)
 
+	c, err := httpClientForAccountScopes(ctx, serviceLabel, email, scopes, defaultHTTPTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("client options with custom scopes created successfully", "serviceLabel", serviceLabel, "email", email)
+
+	return []option.ClientOption{option.WithHTTPClient(c)}, nil
+}
+
+// httpClientForAccountScopes builds an authenticated, retrying HTTP client.
+// A zero timeout leaves the exchange unbounded (for large media transfers)
+// and only bounds the wait for response headers.
+func httpClientForAccountScopes(ctx context.Context, serviceLabel string, email string, scopes []string, timeout time.Duration) (*http.Client, error) {
+
 	var creds config.ClientCredentials
 
 	var ts oauth2.TokenSource
@@ -123,19 +143,19 @@ func optionsForAccountScopes(ctx context.Context, serviceLabel string, email str
 		}
 	}
 	baseTransport := newBaseTransport()
+	if timeout <= 0 {
+		baseTransport.ResponseHeaderTimeout = transferResponseHeaderTimeout
+	}
 	// Wrap with retry logic for 429 and 5xx errors
 	retryTransport := NewRetryTransport(&oauth2.Transport{
 		Source: ts,
 		Base:   baseTransport,
 	})
-	c := &http.Client{
-		Transport: retryTransport,
-		Timeout:   defaultHTTPTimeout,
-	}
 
-	slog.Debug("client options with custom scopes created successfully", "serviceLabel", serviceLabel, "email", email)
-
-	return []option.ClientOption{option.WithHTTPClient(c)}, nil
+	return &http.Client{
+		Transport: retryTransport,
+		Timeout:   timeout,
+	}, nil
 }
 
 func newBaseTransport() *http.Transport {
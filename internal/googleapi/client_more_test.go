@@ -295,3 +295,44 @@ func TestNewBaseTransport_RespectsProxyAndTLSMinimum(t *testing.T) {
 		t.Fatalf("expected HTTPS proxy to be honored, got: %v", proxyURL)
 	}
 }
+
+func TestHTTPClientForAccountScopes_TransferHasNoOverallTimeout(t *testing.T) {
+	origRead := readClientCredentials
+	origOpen := openSecretsStore
+
+	t.Cleanup(func() {
+		readClientCredentials = origRead
+		openSecretsStore = origOpen
+	})
+
+	readClientCredentials = func(string) (config.ClientCredentials, error) {
+		return config.ClientCredentials{ClientID: "id", ClientSecret: "secret"}, nil
+	}
+	openSecretsStore = func() (secrets.Store, error) {
+		return &stubStore{tok: secrets.Token{Email: "a@b.com", RefreshToken: "rt"}}, nil
+	}
+
+	c, err := httpClientForAccountScopes(context.Background(), "drive", "a@b.com", []string{"s1"}, 0)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if c.Timeout != 0 {
+		t.Fatalf("expected no overall timeout, got %v", c.Timeout)
+	}
+
+	retry, ok := c.Transport.(*RetryTransport)
+	if !ok {
+		t.Fatalf("expected retry transport, got %T", c.Transport)
+	}
+
+	oauthTransport, ok := retry.Base.(*oauth2.Transport)
+	if !ok {
+		t.Fatalf("expected oauth2 transport, got %T", retry.Base)
+	}
+
+	base, ok := oauthTransport.Base.(*http.Transport)
+	if !ok || base.ResponseHeaderTimeout != transferResponseHeaderTimeout {
+		t.Fatalf("expected response header timeout on base transport, got %#v", oauthTransport.Base)
+	}
+}
@@ -3,8 +3,10 @@ package googleapi
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
 
 	"github.com/steipete/gogcli/internal/googleauth"
 )
@@ -18,3 +20,31 @@ func NewDrive(ctx context.Context, email string) (*drive.Service, error) {
 		return svc, nil
 	}
 }
+
+// NewDriveTransfer returns a Drive service for media downloads whose
+// requests are not cut off by a whole-request timeout, so multi-GB files can
+// stream to completion. Metadata calls should use NewDrive.
+func NewDriveTransfer(ctx context.Context, email string) (*drive.Service, error) {
+	if client, err := NewDriveHTTPClient(ctx, email); err != nil {
+		return nil, err
+	} else if svc, err := drive.NewService(ctx, option.WithHTTPClient(client)); err != nil {
+		return nil, fmt.Errorf("create drive service: %w", err)
+	} else {
+		return svc, nil
+	}
+}
+
+// NewDriveHTTPClient returns an authenticated client for raw Drive media
+// requests (resumable upload sessions). Only the wait for response headers is
+// bounded; callers control the overall duration through the context.
+func NewDriveHTTPClient(ctx context.Context, email string) (*http.Client, error) {
+	scopes, err := googleauth.Scopes(googleauth.ServiceDrive)
+	if err != nil {
+		return nil, fmt.Errorf("resolve scopes: %w", err)
+	}
+	client, err := httpClientForAccountScopes(ctx, string(googleauth.ServiceDrive), email, scopes, 0)
+	if err != nil {
+		return nil, fmt.Errorf("drive options: %w", err)
+	}
+	return client, nil
+}
@@ -24,6 +24,7 @@ const (
 	ServiceForms     Service = "forms"
 	ServiceAppScript Service = "appscript"
 	ServiceGroups    Service = "groups"
+	ServiceResources Service = "resources"
 	ServiceKeep      Service = "keep"
 )
 
@@ -83,6 +84,7 @@ var serviceOrder = []Service{
 	ServiceForms,
 	ServiceAppScript,
 	ServiceGroups,
+	ServiceResources,
 	ServiceKeep,
 }
 
@@ -207,6 +209,12 @@ var serviceInfoByService = map[Service]serviceInfo{
 		apis:   []string{"Cloud Identity API"},
 		note:   "Workspace only",
 	},
+	ServiceResources: {
+		scopes: []string{"https://www.googleapis.com/auth/admin.directory.resource.calendar.readonly"},
+		user:   false,
+		apis:   []string{"Admin SDK API"},
+		note:   "Workspace only; rooms/resources for calendar booking",
+	},
 	ServiceKeep: {
 		scopes: []string{"https://www.googleapis.com/auth/keep.readonly"},
 		user:   false,
@@ -543,6 +551,8 @@ func scopesForServiceWithOptions(service Service, opts ScopeOptions) ([]string,
 		return Scopes(service)
 	case ServiceGroups:
 		return Scopes(service)
+	case ServiceResources:
+		return Scopes(service)
 	case ServiceKeep:
 		return Scopes(service)
 	default:
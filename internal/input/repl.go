@@ -0,0 +1,108 @@
+package input
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// Completer returns candidate replacements for head, the part of the line
+// before the cursor. Each candidate is a full replacement for head.
+type Completer func(head string) []string
+
+// LineReader reads successive lines for an interactive session. On a terminal
+// it offers line editing, history, and tab completion; otherwise it reads plain
+// lines so scripts can be piped in.
+type LineReader struct {
+	out      io.Writer
+	br       *bufio.Reader
+	fd       int
+	terminal *term.Terminal
+	complete Completer
+}
+
+// NewLineReader creates a LineReader on in, writing prompts to out.
+func NewLineReader(in io.Reader, out io.Writer) *LineReader {
+	r := &LineReader{out: out, br: bufio.NewReader(in), fd: -1}
+	if f, ok := in.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		r.fd = int(f.Fd())
+		r.terminal = term.NewTerminal(struct {
+			io.Reader
+			io.Writer
+		}{f, out}, "")
+		r.terminal.AutoCompleteCallback = r.autoComplete
+	}
+	return r
+}
+
+// SetCompleter installs fn for tab completion (terminal only).
+func (r *LineReader) SetCompleter(fn Completer) {
+	r.complete = fn
+}
+
+// ReadLine prints prompt and returns the next line without its line ending.
+// It returns io.EOF at end of input (or Ctrl-D on an empty terminal line).
+func (r *LineReader) ReadLine(prompt string) (string, error) {
+	if r.terminal == nil {
+		_, _ = fmt.Fprint(r.out, prompt)
+		return ReadLine(r.br)
+	}
+
+	// Raw mode only while editing so command output keeps normal newlines.
+	state, err := term.MakeRaw(r.fd)
+	if err != nil {
+		return "", fmt.Errorf("terminal raw mode: %w", err)
+	}
+	defer func() { _ = term.Restore(r.fd, state) }()
+
+	r.terminal.SetPrompt(prompt)
+	line, err := r.terminal.ReadLine()
+	if err != nil {
+		return "", err
+	}
+	return line, nil
+}
+
+func (r *LineReader) autoComplete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' || r.complete == nil {
+		return "", 0, false
+	}
+	head := line[:pos]
+	next, ok := CompleteHead(head, r.complete(head))
+	if !ok {
+		return "", 0, false
+	}
+	return next + line[pos:], len(next), true
+}
+
+// CompleteHead picks the completion for head from candidates: the only
+// candidate, or the longest common prefix when it extends head.
+func CompleteHead(head string, candidates []string) (string, bool) {
+	matches := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		if strings.HasPrefix(c, head) {
+			matches = append(matches, c)
+		}
+	}
+	if len(matches) == 0 {
+		return "", false
+	}
+	prefix := matches[0]
+	for _, m := range matches[1:] {
+		for !strings.HasPrefix(m, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	for !utf8.ValidString(prefix) {
+		prefix = prefix[:len(prefix)-1]
+	}
+	if len(prefix) <= len(head) {
+		return "", false
+	}
+	return prefix, true
+}
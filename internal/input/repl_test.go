@@ -0,0 +1,53 @@
+package input
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLineReader_Piped(t *testing.T) {
+	var out bytes.Buffer
+	r := NewLineReader(strings.NewReader("ls\r\ncd Projects\npwd"), &out)
+
+	var lines []string
+	for {
+		line, err := r.ReadLine("> ")
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("ReadLine: %v", err)
+		}
+		lines = append(lines, line)
+	}
+	if strings.Join(lines, "|") != "ls|cd Projects|pwd" {
+		t.Fatalf("unexpected lines: %q", lines)
+	}
+	if out.String() != "> > > > " {
+		t.Fatalf("unexpected prompts: %q", out.String())
+	}
+}
+
+func TestCompleteHead(t *testing.T) {
+	tests := []struct {
+		head       string
+		candidates []string
+		want       string
+		ok         bool
+	}{
+		{"cd Pro", []string{"cd Projects/"}, "cd Projects/", true},
+		{"ls Re", []string{"ls Report 2025.pdf", "ls Report 2026.pdf", "ls Notes"}, "ls Report 202", true},
+		{"ls Report 202", []string{"ls Report 2025.pdf", "ls Report 2026.pdf"}, "", false},
+		{"ls x", []string{"ls y"}, "", false},
+		{"get ä", []string{"get äpfel", "get äö"}, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := CompleteHead(tt.head, tt.candidates)
+		if got != tt.want || ok != tt.ok {
+			t.Fatalf("CompleteHead(%q) = %q, %v; want %q, %v", tt.head, got, ok, tt.want, tt.ok)
+		}
+	}
+}